@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/goccy/go-json"
 )
@@ -123,7 +125,8 @@ func (s *TestNewOrder) Do(ctx context.Context, opts ...RequestOption) (res *Acco
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: "/api/v3/order/test",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "TestNewOrder",
 	}
 	r.setParam("symbol", s.symbol)
 	r.setParam("side", s.side)
@@ -132,7 +135,7 @@ func (s *TestNewOrder) Do(ctx context.Context, opts ...RequestOption) (res *Acco
 		r.setParam("timeInForce", *s.timeInForce)
 	}
 	if s.quantity != nil {
-		r.setParam("quantity", strconv.FormatFloat(*s.quantity, 'f', -1, 64))
+		r.setParam("quantity", *s.quantity)
 	}
 	if s.quoteOrderQty != nil {
 		r.setParam("quoteOrderQty", *s.quoteOrderQty)
@@ -169,7 +172,7 @@ func (s *TestNewOrder) Do(ctx context.Context, opts ...RequestOption) (res *Acco
 		return nil, err
 	}
 	res = new(AccountOrderBookResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -200,6 +203,8 @@ type CreateOrderService struct {
 	newOrderRespType        *string
 	selfTradePreventionMode *string
 	pricePrecision          *int
+	idempotent              bool
+	checkSymbolStatus       *bool
 }
 
 // Symbol set symbol
@@ -279,7 +284,7 @@ func (s *CreateOrderService) TrailingDelta(trailingDelta int) *CreateOrderServic
 	return s
 }
 
-// IcebergQuantity set icebergQuantity
+// IcebergQuantity set icebergQty, like IcebergQty
 func (s *CreateOrderService) IcebergQuantity(icebergQty float64) *CreateOrderService {
 	s.icebergQty = &icebergQty
 	return s
@@ -303,13 +308,17 @@ const (
 	FULL   = 3
 )
 
-// Do send request
-func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res interface{}, err error) {
-	respType := ACK
-	r := &request{
+// orderRequest build the request of Do and DoNormalized and return the response type Binance answers with:
+// the requested newOrderRespType, else FULL for MARKET and LIMIT orders and ACK for the other types
+func (s *CreateOrderService) orderRequest() (r *request, respType int) {
+	respType = ACK
+	r = &request{
 		method:   http.MethodPost,
 		endpoint: "/api/v3/order",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "CreateOrderService",
+		// a duplicate of an order sent with an idempotency key returns the existing order
+		idempotent: s.idempotent,
 	}
 	r.setParam("symbol", s.symbol)
 	r.setParam("side", s.side)
@@ -324,7 +333,7 @@ func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 		r.setParam("timeInForce", *s.timeInForce)
 	}
 	if s.quantity != nil {
-		r.setParam("quantity", strconv.FormatFloat(*s.quantity, 'f', -1, 64))
+		r.setParam("quantity", *s.quantity)
 	}
 	if s.quoteOrderQty != nil {
 		r.setParam("quoteOrderQty", *s.quoteOrderQty)
@@ -370,9 +379,35 @@ func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 	if s.selfTradePreventionMode != nil {
 		r.setParam("selfTradePreventionMode", *s.selfTradePreventionMode)
 	}
+	return r, respType
+}
+
+// preflight run the checks enabled before the order is sent
+func (s *CreateOrderService) preflight(ctx context.Context) error {
+	if err := s.validateIceberg(ctx); err != nil {
+		return err
+	}
+	if s.c.CheckMaintenanceBeforeOrders {
+		if err := s.c.CheckMaintenance(ctx); err != nil {
+			return err
+		}
+	}
+	return s.validateSymbolStatus(ctx)
+}
+
+// Do send request
+func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res interface{}, err error) {
+	if err := s.preflight(ctx); err != nil {
+		return nil, err
+	}
+	r, respType := s.orderRequest()
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
-		return nil, err
+		existing, err := s.existingOrder(ctx, err, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return existing.asRespType(respType), nil
 	}
 	switch respType {
 	case ACK:
@@ -382,7 +417,29 @@ func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 	case FULL:
 		res = new(CreateOrderResponseFULL)
 	}
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DoNormalized send request and decode any newOrderRespType into a CreateOrderResponse
+func (s *CreateOrderService) DoNormalized(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
+	if err := s.preflight(ctx); err != nil {
+		return nil, err
+	}
+	r, _ := s.orderRequest()
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		existing, err := s.existingOrder(ctx, err, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &CreateOrderResponse{CreateOrderResponseFULL: *existing, respType: RESULT, duplicate: true}, nil
+	}
+	res = new(CreateOrderResponse)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -390,11 +447,15 @@ func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 }
 
 func (s *CreateOrderService) DoSmall(ctx context.Context, opts ...RequestOption) (res interface{}, err error) {
+	if err := s.preflight(ctx); err != nil {
+		return nil, err
+	}
 	respType := ACK
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: "/api/v3/order",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "CreateOrderService",
 	}
 	r.setParam("symbol", s.symbol)
 	r.setParam("side", s.side)
@@ -409,7 +470,7 @@ func (s *CreateOrderService) DoSmall(ctx context.Context, opts ...RequestOption)
 		r.setParam("timeInForce", *s.timeInForce)
 	}
 	if s.quantity != nil {
-		r.setParam("quantity", strconv.FormatFloat(*s.quantity, 'f', -1, 64))
+		r.setParam("quantity", *s.quantity)
 	}
 	if s.quoteOrderQty != nil {
 		r.setParam("quoteOrderQty", *s.quoteOrderQty)
@@ -464,7 +525,7 @@ func (s *CreateOrderService) DoSmall(ctx context.Context, opts ...RequestOption)
 	case FULL:
 		res = new(CreateOrderResponseFULL)
 	}
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -472,11 +533,15 @@ func (s *CreateOrderService) DoSmall(ctx context.Context, opts ...RequestOption)
 }
 
 func (s *CreateOrderService) DoHigh(ctx context.Context, opts ...RequestOption) (res interface{}, err error) {
+	if err := s.preflight(ctx); err != nil {
+		return nil, err
+	}
 	respType := ACK
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: "/api/v3/order",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "CreateOrderService",
 	}
 	r.setParam("symbol", s.symbol)
 	r.setParam("side", s.side)
@@ -491,7 +556,7 @@ func (s *CreateOrderService) DoHigh(ctx context.Context, opts ...RequestOption)
 		r.setParam("timeInForce", *s.timeInForce)
 	}
 	if s.quantity != nil {
-		r.setParam("quantity", strconv.FormatFloat(*s.quantity, 'f', -1, 64))
+		r.setParam("quantity", *s.quantity)
 	}
 	if s.quoteOrderQty != nil {
 		r.setParam("quoteOrderQty", *s.quoteOrderQty)
@@ -546,7 +611,7 @@ func (s *CreateOrderService) DoHigh(ctx context.Context, opts ...RequestOption)
 	case FULL:
 		res = new(CreateOrderResponseFULL)
 	}
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -573,11 +638,12 @@ type CreateOrderResponseRESULT struct {
 	OrigQty                 string `json:"origQty"`
 	ExecutedQty             string `json:"executedQty"`
 	CumulativeQuoteQty      string `json:"cummulativeQuoteQty"`
+	OrigQuoteOrderQty       string `json:"origQuoteOrderQty"`
 	Status                  string `json:"status"`
 	TimeInForce             string `json:"timeInForce"`
 	Type                    string `json:"type"`
 	Side                    string `json:"side"`
-	WorkingTime             uint64 `json:"workingTime"`
+	WorkingTime             int64  `json:"workingTime"`
 	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
 	IcebergQty              string `json:"icebergQty,omitempty"`
 	PreventedMatchId        int64  `json:"preventedMatchId,omitempty"`
@@ -600,11 +666,12 @@ type CreateOrderResponseFULL struct {
 	OrigQty                 string `json:"origQty"`
 	ExecutedQty             string `json:"executedQty"`
 	CumulativeQuoteQty      string `json:"cummulativeQuoteQty"`
+	OrigQuoteOrderQty       string `json:"origQuoteOrderQty"`
 	Status                  string `json:"status"`
 	TimeInForce             string `json:"timeInForce"`
 	Type                    string `json:"type"`
 	Side                    string `json:"side"`
-	WorkingTime             uint64 `json:"workingTime"`
+	WorkingTime             int64  `json:"workingTime"`
 	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
 	IcebergQty              string `json:"icebergQty,omitempty"`
 	PreventedMatchId        int64  `json:"preventedMatchId,omitempty"`
@@ -623,6 +690,48 @@ type CreateOrderResponseFULL struct {
 	} `json:"fills"`
 }
 
+// CreateOrderResponse define a new order response of any newOrderRespType,
+// the fields an ACK or RESULT response does not carry are zero
+type CreateOrderResponse struct {
+	CreateOrderResponseFULL
+	respType  int
+	duplicate bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler and records the response type received
+func (r *CreateOrderResponse) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		Status *string          `json:"status"`
+		Fills  *json.RawMessage `json:"fills"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &r.CreateOrderResponseFULL); err != nil {
+		return err
+	}
+	switch {
+	case probe.Fills != nil:
+		r.respType = FULL
+	case probe.Status != nil:
+		r.respType = RESULT
+	default:
+		r.respType = ACK
+	}
+	return nil
+}
+
+// RespType return the type of the response received: ACK, RESULT or FULL
+func (r *CreateOrderResponse) RespType() int {
+	return r.respType
+}
+
+// Duplicate return true when the order was already sent with the same idempotency key,
+// the response is then the existing order as returned by GetOrderService
+func (r *CreateOrderResponse) Duplicate() bool {
+	return r.duplicate
+}
+
 // Binance Cancel Order endpoint (DELETE /api/v3/order)
 // CancelOrderService cancel order
 type CancelOrderService struct {
@@ -666,10 +775,14 @@ func (s *CancelOrderService) CancelRestrictions(cancelRestrictions string) *Canc
 
 // Do send request
 func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CancelOrderResponse, err error) {
+	if err := requireOrderId("CancelOrderService", orderIdParam{"orderId", s.orderId != nil}, orderIdParam{"origClientOrderId", isSet(s.origClientOrderId)}); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: "/api/v3/order",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "CancelOrderService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -692,7 +805,7 @@ func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, err
 	}
 	res = new(CancelOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -717,7 +830,8 @@ func (s *CancelOpenOrdersService) Do(ctx context.Context, opts ...RequestOption)
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: "/api/v3/openOrders",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "CancelOpenOrdersService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -728,7 +842,7 @@ func (s *CancelOpenOrdersService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = make([]*CancelOrderResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -761,6 +875,64 @@ type CancelOrderResponse struct {
 	TrailingTime        int64  `json:"trailingTime,omitempty"`
 }
 
+var (
+	// CancelOrdersConcurrency is the maximum number of cancels CancelOrders keeps in flight
+	CancelOrdersConcurrency = 5
+	// CancelOrdersInterval is the minimum delay between two cancels sent by CancelOrders,
+	// the default keeps a batch within the 50 orders per 10 seconds limit
+	CancelOrdersInterval = time.Millisecond * 200
+)
+
+// CancelOrderResult define the outcome of one order cancelled by CancelOrders
+type CancelOrderResult struct {
+	OrderId  int64
+	Response *CancelOrderResponse
+	Err      error
+}
+
+// CancelOrders cancel the given orders of symbol concurrently and return one result per order,
+// in the order of orderIds. A failed cancel (for example an order already filled) does not stop the others.
+func (c *Client) CancelOrders(ctx context.Context, symbol string, orderIds []int64, opts ...RequestOption) []CancelOrderResult {
+	results := make([]CancelOrderResult, len(orderIds))
+	concurrency := CancelOrdersConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var pace <-chan time.Time
+	if CancelOrdersInterval > 0 {
+		ticker := time.NewTicker(CancelOrdersInterval)
+		defer ticker.Stop()
+		pace = ticker.C
+	}
+	var wg sync.WaitGroup
+	for i, orderId := range orderIds {
+		results[i].OrderId = orderId
+		if i > 0 && pace != nil {
+			select {
+			case <-pace:
+			case <-ctx.Done():
+			}
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		wg.Add(1)
+		go func(i int, orderId int64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].Response, results[i].Err = c.NewCancelOrderService().Symbol(symbol).OrderId(orderId).Do(ctx, opts...)
+		}(i, orderId)
+	}
+	wg.Wait()
+	return results
+}
+
 // Query Order (USER_DATA)
 // Binance Query Order (USER_DATA) (GET /api/v3/order)
 // GetOrderService get order
@@ -791,10 +963,14 @@ func (s *GetOrderService) OrigClientOrderId(origClientOrderId string) *GetOrderS
 
 // Do send request
 func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *GetOrderResponse, err error) {
+	if err := requireOrderId("GetOrderService", orderIdParam{"orderId", s.orderId != nil}, orderIdParam{"origClientOrderId", isSet(s.origClientOrderId)}); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/order",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetOrderService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -811,7 +987,7 @@ func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *G
 		return nil, err
 	}
 	res = new(GetOrderResponse)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -837,7 +1013,7 @@ type GetOrderResponse struct {
 	Time                    uint64 `json:"time"`
 	UpdateTime              uint64 `json:"updateTime"`
 	IsWorking               bool   `json:"isWorking"`
-	WorkingTime             uint64 `json:"workingTime"`
+	WorkingTime             int64  `json:"workingTime"`
 	OrigQuoteOrderQty       string `json:"origQuoteOrderQty"`
 	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
 	PreventedMatchId        int64  `json:"preventedMatchId,omitempty"`
@@ -995,10 +1171,14 @@ func (s *CancelReplaceService) CancelRestrictions(cancelRestrictions string) *Ca
 
 // Do send request
 func (s *CancelReplaceService) Do(ctx context.Context, opts ...RequestOption) (res *CancelReplaceResponse, err error) {
+	if err := requireOrderId("CancelReplaceService", orderIdParam{"cancelOrderId", s.cancelOrderId != nil}, orderIdParam{"cancelOrigClientOrderId", isSet(s.cancelOrigClientOrderId)}); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: "/api/v3/order/cancelReplace",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "CancelReplaceService",
 	}
 	m := params{
 		"symbol":            s.symbol,
@@ -1055,9 +1235,13 @@ func (s *CancelReplaceService) Do(ctx context.Context, opts ...RequestOption) (r
 		m["cancelRestrictions"] = *s.cancelRestrictions
 	}
 	r.setParams(m)
-	data, _ := s.c.callAPI(ctx, r, opts...)
+	// a rejected cancel or new order is answered with the error body, returned as the response
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
 	res = new(CancelReplaceResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1167,7 +1351,8 @@ func (s *GetOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (r
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/openOrders",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetOpenOrdersService",
 	}
 	if s.symbol != nil {
 		r.setParam("symbol", *s.symbol)
@@ -1177,7 +1362,7 @@ func (s *GetOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (r
 		return nil, err
 	}
 	res = make([]*NewOpenOrdersResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -1203,7 +1388,7 @@ type NewOpenOrdersResponse struct {
 	Time                    uint64 `json:"time"`
 	UpdateTime              uint64 `json:"updateTime"`
 	IsWorking               bool   `json:"isWorking"`
-	WorkingTime             uint64 `json:"workingTime"`
+	WorkingTime             int64  `json:"workingTime"`
 	OrigQuoteOrderQty       string `json:"origQuoteOrderQty"`
 	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
 	PreventedMatchId        int64  `json:"preventedMatchId,omitempty"`
@@ -1260,7 +1445,8 @@ func (s *GetAllOrdersService) Do(ctx context.Context, opts ...RequestOption) (re
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/allOrders",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetAllOrdersService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -1283,7 +1469,7 @@ func (s *GetAllOrdersService) Do(ctx context.Context, opts ...RequestOption) (re
 		return nil, err
 	}
 	res = make([]*NewAllOrdersResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -1311,7 +1497,7 @@ type NewAllOrdersResponse struct {
 	UpdateTime              uint64 `json:"updateTime"`
 	IsWorking               bool   `json:"isWorking"`
 	OrigQuoteOrderQty       string `json:"origQuoteOrderQty"`
-	WorkingTime             uint64 `json:"workingTime"`
+	WorkingTime             int64  `json:"workingTime"`
 	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
 	PreventedMatchId        int64  `json:"preventedMatchId,omitempty"`
 	PreventedQuantity       string `json:"preventedQuantity,omitempty"`
@@ -1448,7 +1634,7 @@ func (s *NewOCOService) StopLimitTimeInForce(stopLimitTimeInForce string) *NewOC
 	return s
 }
 
-// NewOrderRespType set newOrderRespType
+// NewOrderRespType set newOrderRespType, ACK, RESULT or FULL (default)
 func (s *NewOCOService) NewOrderRespType(newOrderRespType string) *NewOCOService {
 	s.newOrderRespType = &newOrderRespType
 	return s
@@ -1465,7 +1651,8 @@ func (s *NewOCOService) Do(ctx context.Context, opts ...RequestOption) (res *Ord
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: "/api/v3/order/oco",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "NewOCOService",
 	}
 	m := params{
 		"symbol":    s.symbol,
@@ -1510,9 +1697,11 @@ func (s *NewOCOService) Do(ctx context.Context, opts ...RequestOption) (res *Ord
 	if s.stopLimitTimeInForce != nil {
 		m["stopLimitTimeInForce"] = *s.stopLimitTimeInForce
 	}
+	respType := "FULL"
 	if s.newOrderRespType != nil {
-		m["newOrderRespType"] = *s.newOrderRespType
+		respType = *s.newOrderRespType
 	}
+	m["newOrderRespType"] = respType
 	if s.selfTradePreventionMode != nil {
 		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
 	}
@@ -1522,52 +1711,16 @@ func (s *NewOCOService) Do(ctx context.Context, opts ...RequestOption) (res *Ord
 		return nil, err
 	}
 	res = new(OrderOCOResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
+	res.setRespType(respType)
 	return res, nil
 }
 
-type OrderOCOResponse struct {
-	OrderListId       int64  `json:"orderListId"`
-	ContingencyType   string `json:"contingencyType"`
-	ListStatusType    string `json:"listStatusType"`
-	ListOrderStatus   string `json:"listOrderStatus"`
-	ListClientOrderId string `json:"listClientOrderId"`
-	TransactionTime   uint64 `json:"transactionTime"`
-	Symbol            string `json:"symbol"`
-	Orders            []struct {
-		Symbol        string `json:"symbol"`
-		OrderId       int64  `json:"orderId"`
-		ClientOrderId string `json:"clientOrderId"`
-	} `json:"orders"`
-	OrderReports []struct {
-		Symbol                  string  `json:"symbol"`
-		OrderId                 int64   `json:"orderId"`
-		OrderListId             int64   `json:"orderListId"`
-		ClientOrderId           string  `json:"clientOrderId"`
-		TransactTime            uint64  `json:"transactTime"`
-		Price                   float64 `json:"price"`
-		OrigQty                 float64 `json:"origQty"`
-		ExecutedQty             float64 `json:"executedQty"`
-		CummulativeQuoteQty     float64 `json:"cummulativeQuoteQty"`
-		Status                  string  `json:"status"`
-		TimeInForce             string  `json:"timeInForce"`
-		Type                    string  `json:"type"`
-		Side                    string  `json:"side"`
-		StopPrice               string  `json:"stopPrice"`
-		WorkingTime             uint64  `json:"workingTime"`
-		SelfTradePreventionMode string  `json:"selfTradePreventionMode"`
-		IcebergQty              string  `json:"icebergQty,omitempty"`
-		PreventedMatchId        int64   `json:"preventedMatchId,omitempty"`
-		PreventedQuantity       string  `json:"preventedQuantity,omitempty"`
-		StrategyId              int64   `json:"strategyId,omitempty"`
-		StrategyType            int64   `json:"strategyType,omitempty"`
-		TrailingDelta           string  `json:"trailingDelta,omitempty"`
-		TrailingTime            int64   `json:"trailingTime,omitempty"`
-	} `json:"orderReports"`
-}
+// OrderOCOResponse define the response of NewOCOService and CancelOCOService
+type OrderOCOResponse = OrderListResponse
 
 // Binance Cancel OCO (TRADE) (DELETE /api/v3/orderList)
 // CancelOCOService cancel OCO order
@@ -1605,10 +1758,14 @@ func (s *CancelOCOService) NewClientOrderId(newClientOrderId string) *CancelOCOS
 
 // Do send request
 func (s *CancelOCOService) Do(ctx context.Context, opts ...RequestOption) (res *OrderOCOResponse, err error) {
+	if err := requireOrderId("CancelOCOService", orderIdParam{"orderListId", s.orderListId != nil}, orderIdParam{"listClientOrderId", isSet(s.listClientOrderId)}); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: "/api/v3/orderList",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "CancelOCOService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -1628,7 +1785,7 @@ func (s *CancelOCOService) Do(ctx context.Context, opts ...RequestOption) (res *
 		return nil, err
 	}
 	res = new(OrderOCOResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1657,10 +1814,14 @@ func (s *QueryOCOService) OrigClientOrderId(origClientOrderId string) *QueryOCOS
 
 // Do send request
 func (s *QueryOCOService) Do(ctx context.Context, opts ...RequestOption) (res *OCOResponse, err error) {
+	if err := requireOrderId("QueryOCOService", orderIdParam{"orderListId", s.orderListId != nil}, orderIdParam{"origClientOrderId", isSet(s.origClientOrderId)}); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/orderList",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QueryOCOService",
 	}
 	if s.orderListId != nil {
 		r.setParam("orderListId", *s.orderListId)
@@ -1673,7 +1834,7 @@ func (s *QueryOCOService) Do(ctx context.Context, opts ...RequestOption) (res *O
 		return nil, err
 	}
 	res = new(OCOResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1719,7 +1880,8 @@ func (s *QueryAllOCOService) Do(ctx context.Context, opts ...RequestOption) (res
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/allOrderList",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QueryAllOCOService",
 	}
 	if s.fromId != nil {
 		r.setParam("fromId", *s.fromId)
@@ -1738,7 +1900,7 @@ func (s *QueryAllOCOService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*OCOResponse{}, err
 	}
 	res = make([]*OCOResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*OCOResponse{}, err
 	}
@@ -1772,14 +1934,15 @@ func (s *QueryOpenOCOService) Do(ctx context.Context, opts ...RequestOption) (re
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/openOrderList",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QueryOpenOCOService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(OCOResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1797,14 +1960,15 @@ func (s *GetAccountService) Do(ctx context.Context, opts ...RequestOption) (res
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/account",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetAccountService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(AccountResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1886,7 +2050,8 @@ func (s *GetMyTradesService) Do(ctx context.Context, opts ...RequestOption) (res
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/myTrades",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetMyTradesService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -1912,7 +2077,7 @@ func (s *GetMyTradesService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*AccountTradeListResponse{}, err
 	}
 	res = make([]*AccountTradeListResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*AccountTradeListResponse{}, err
 	}
@@ -1948,14 +2113,15 @@ func (s *GetQueryCurrentOrderCountUsageService) Do(ctx context.Context, opts ...
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/rateLimit/order",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetQueryCurrentOrderCountUsageService",
 	}
 	res = make([]*QueryCurrentOrderCountUsageResponse, 0)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return res, err
 	}
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return res, err
 	}
@@ -2017,7 +2183,8 @@ func (s *GetQueryPreventedMatchesService) Do(ctx context.Context, opts ...Reques
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/myPreventedMatches",
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetQueryPreventedMatchesService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -2040,7 +2207,7 @@ func (s *GetQueryPreventedMatchesService) Do(ctx context.Context, opts ...Reques
 		return nil, err
 	}
 	res = new(QueryPreventedMatchesResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
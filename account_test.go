@@ -2,9 +2,13 @@ package binance_connector
 
 import (
 	"context"
+	"net/http"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -249,6 +253,98 @@ func (s *accountTestSuite) TestNewOrder() {
 	s.assertCreateOrderResponseEqual(expectedResp, orderResp.(*CreateOrderResponseRESULT))
 }
 
+func (s *accountTestSuite) TestNewOrderNormalized() {
+	responses := map[string][]byte{
+		"ACK": []byte(`{
+			"symbol": "BTCUSDT",
+			"orderId": 28,
+			"orderListId": -1,
+			"clientOrderId": "6gCrw2kRUAF9CvJDGP16IP",
+			"transactTime": 1507725176595
+		}`),
+		"RESULT": []byte(`{
+			"symbol": "BTCUSDT",
+			"orderId": 28,
+			"orderListId": -1,
+			"clientOrderId": "6gCrw2kRUAF9CvJDGP16IP",
+			"transactTime": 1507725176595,
+			"price": "0.00000000",
+			"origQty": "10.00000000",
+			"executedQty": "10.00000000",
+			"cummulativeQuoteQty": "10.00000000",
+			"status": "FILLED",
+			"timeInForce": "GTC",
+			"type": "MARKET",
+			"side": "SELL",
+			"workingTime": 1507725176595,
+			"selfTradePreventionMode": "NONE"
+		}`),
+		"FULL": []byte(`{
+			"symbol": "BTCUSDT",
+			"orderId": 28,
+			"orderListId": -1,
+			"clientOrderId": "6gCrw2kRUAF9CvJDGP16IP",
+			"transactTime": 1507725176595,
+			"price": "0.00000000",
+			"origQty": "10.00000000",
+			"executedQty": "10.00000000",
+			"cummulativeQuoteQty": "10.00000000",
+			"status": "FILLED",
+			"timeInForce": "GTC",
+			"type": "MARKET",
+			"side": "SELL",
+			"workingTime": 1507725176595,
+			"selfTradePreventionMode": "NONE",
+			"fills": [
+				{
+					"price": "4000.00000000",
+					"qty": "1.00000000",
+					"commission": "4.00000000",
+					"commissionAsset": "USDT",
+					"tradeId": 56
+				}
+			]
+		}`),
+	}
+	expectedTypes := map[string]int{"ACK": ACK, "RESULT": RESULT, "FULL": FULL}
+
+	for respType, data := range responses {
+		s.SetupTest()
+		s.mockDo(data, nil)
+		s.assertReq(func(r *request) {
+			s.assertRequestEqual(newSignedRequest().setParams(params{
+				"symbol":           "BTCUSDT",
+				"side":             "SELL",
+				"type":             "MARKET",
+				"quantity":         10,
+				"newOrderRespType": respType,
+			}), r)
+		})
+		res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").
+			Side("SELL").Type("MARKET").Quantity(10).
+			NewOrderRespType(respType).
+			DoNormalized(newContext())
+		s.assertDo()
+		r := s.r()
+		r.NoError(err, respType)
+		r.Equal(expectedTypes[respType], res.RespType(), respType)
+		r.Equal(int64(28), res.OrderId, respType)
+		r.Equal(uint64(1507725176595), res.TransactTime, respType)
+		switch respType {
+		case "ACK":
+			r.Empty(res.Status)
+			r.Empty(res.Fills)
+		case "RESULT":
+			r.Equal("FILLED", res.Status)
+			r.Empty(res.Fills)
+		case "FULL":
+			r.Equal("FILLED", res.Status)
+			r.Len(res.Fills, 1)
+			r.Equal("4000.00000000", res.Fills[0].Price)
+		}
+	}
+}
+
 func (s *baseTestSuite) assertCreateOrderResponseEqual(e, a *CreateOrderResponseRESULT) {
 	r := s.r()
 	r.Equal(e.Symbol, a.Symbol, "Symbol")
@@ -708,7 +804,7 @@ func (s *accountTestSuite) TestGetAllOrders() {
 	s.Equal(uint64(1617167610255), resp[0].UpdateTime)
 	s.True(resp[0].IsWorking)
 	s.Equal("25000.00000000", resp[0].OrigQuoteOrderQty)
-	s.Equal(uint64(0), resp[0].WorkingTime)
+	s.Equal(int64(0), resp[0].WorkingTime)
 	s.Equal("DECREMENT_AND_CANCEL", resp[0].SelfTradePreventionMode)
 	s.Equal("0.00000000", resp[0].PreventedQuantity)
 	s.Equal(int64(0), resp[0].PreventedMatchId)
@@ -878,3 +974,107 @@ func (s *accountTestSuite) TestGetQueryPreventedMatches() {
 	s.Equal("0.00005", resp.PreventedMatches[0].MakerPreventedQuantity)
 	s.Equal(uint64(1613450271000), resp.PreventedMatches[0].TransactTime)
 }
+
+func (s *accountTestSuite) TestCancelOrders() {
+	origInterval := CancelOrdersInterval
+	defer func() { CancelOrdersInterval = origInterval }()
+	CancelOrdersInterval = time.Millisecond
+
+	s.client.Client.do = s.client.do
+	for _, orderId := range []string{"1", "3"} {
+		orderId := orderId
+		s.client.On("do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.Query().Get("orderId") == orderId
+		})).Return(newHTTPResponse([]byte(`{"symbol":"BTCUSDT","orderId":`+orderId+`,"status":"CANCELED"}`), http.StatusOK), nil).Once()
+	}
+	s.client.On("do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.URL.Query().Get("orderId") == "2"
+	})).Return(newHTTPResponse([]byte(`{"code":-2011,"msg":"Unknown order sent."}`), http.StatusBadRequest), nil).Once()
+
+	results := s.client.CancelOrders(newContext(), "BTCUSDT", []int64{1, 2, 3})
+	s.r().Len(results, 3)
+	s.Equal(int64(1), results[0].OrderId)
+	s.r().NoError(results[0].Err)
+	s.Equal("CANCELED", results[0].Response.Status)
+
+	s.Equal(int64(2), results[1].OrderId)
+	s.Nil(results[1].Response)
+	s.Equal(&handlers.APIError{Code: -2011, Message: "Unknown order sent.", Status: http.StatusBadRequest}, results[1].Err)
+
+	s.Equal(int64(3), results[2].OrderId)
+	s.r().NoError(results[2].Err)
+	s.Equal(int64(3), results[2].Response.OrderId)
+}
+
+func (s *accountTestSuite) TestCancelOrdersContextDone() {
+	ctx, cancel := context.WithCancel(newContext())
+	cancel()
+
+	results := s.client.CancelOrders(ctx, "BTCUSDT", []int64{1, 2})
+	s.r().Len(results, 2)
+	s.ErrorIs(results[0].Err, context.Canceled)
+	s.ErrorIs(results[1].Err, context.Canceled)
+}
+
+func (s *accountTestSuite) TestNewOCOMixedOrderReports() {
+	data := []byte(`{
+		"orderListId": 0,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "JYVpp3F0f5CAG15DhtrqLp",
+		"transactionTime": 1563417480525,
+		"symbol": "LTCBTC",
+		"orders": [
+			{"symbol": "LTCBTC", "orderId": 2, "clientOrderId": "Kk7sqHb9J6mJWTMDVW7Vos"},
+			{"symbol": "LTCBTC", "orderId": 3, "clientOrderId": "xTXKaGYd4bluPVp78IVRvl"}
+		],
+		"orderReports": [
+			{
+				"symbol": "LTCBTC",
+				"orderId": 2,
+				"orderListId": 0,
+				"clientOrderId": "Kk7sqHb9J6mJWTMDVW7Vos",
+				"transactTime": 1563417480525,
+				"price": "",
+				"origQty": "0.624363",
+				"executedQty": "0.000000",
+				"cummulativeQuoteQty": "0.000000",
+				"status": "NEW",
+				"timeInForce": "GTC",
+				"type": "STOP_LOSS",
+				"side": "BUY",
+				"stopPrice": "0.960664",
+				"workingTime": -1,
+				"selfTradePreventionMode": "NONE"
+			},
+			{
+				"symbol": "LTCBTC",
+				"orderId": 3,
+				"orderListId": 0,
+				"clientOrderId": "xTXKaGYd4bluPVp78IVRvl",
+				"transactTime": 1563417480525,
+				"price": "0.036435",
+				"origQty": "0.624363",
+				"executedQty": "0.000000",
+				"status": "NEW",
+				"timeInForce": "GTC",
+				"type": "LIMIT_MAKER",
+				"side": "BUY",
+				"workingTime": 1563417480525,
+				"selfTradePreventionMode": "NONE"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	res, err := s.client.NewNewOCOService().Symbol("LTCBTC").Side("BUY").Quantity(0.624363).Price(0.036435).StopPrice(0.960664).Do(newContext())
+	s.r().NoError(err)
+	s.r().Len(res.OrderReports, 2)
+	s.Equal(FlexFloat(0), res.OrderReports[0].Price)
+	s.Equal(0.624363, res.OrderReports[0].OrigQty.Float64())
+	s.Equal("0.960664", res.OrderReports[0].StopPrice)
+	s.Equal(FlexFloat(0.036435), res.OrderReports[1].Price)
+	s.Equal(FlexFloat(0), res.OrderReports[1].CummulativeQuoteQty)
+}
@@ -0,0 +1,104 @@
+package binance_connector
+
+import (
+	"context"
+	"time"
+)
+
+// AggTradesPaginator walk the aggregate trades of a symbol forward through fromId: each page after the first
+// is requested from the id following the last trade received.
+type AggTradesPaginator struct {
+	s         *AggTradesList
+	fromId    int
+	startTime *uint64
+	endTime   *uint64
+	pageSize  int
+	interval  time.Duration
+}
+
+// Paginate return a paginator of the trades from the fromId of the service, or from its startTime when fromId
+// is not set, up to its endTime or the most recent trade. Without fromId nor startTime, the walk starts at the
+// first trade of the symbol.
+func (s *AggTradesList) Paginate() *AggTradesPaginator {
+	p := &AggTradesPaginator{
+		s:         s,
+		startTime: s.startTime,
+		endTime:   s.endTime,
+		pageSize:  DefaultPageSize,
+	}
+	if s.fromId != nil {
+		p.fromId = *s.fromId
+		p.startTime = nil
+	}
+	if s.limit != nil {
+		p.pageSize = *s.limit
+	}
+	return p
+}
+
+// PageSize set the number of trades requested per page, at most 1000
+func (p *AggTradesPaginator) PageSize(pageSize int) *AggTradesPaginator {
+	p.pageSize = pageSize
+	return p
+}
+
+// Interval set the delay between two requests, to spread their weight
+func (p *AggTradesPaginator) Interval(interval time.Duration) *AggTradesPaginator {
+	p.interval = interval
+	return p
+}
+
+// Each call fn with every trade, in id order. It stops at the first error of a request, of fn or of ctx.
+func (p *AggTradesPaginator) Each(ctx context.Context, fn func(trade *AggTradesListResponse) error) error {
+	pageSize := p.pageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	s := p.s
+	s.Limit(pageSize)
+	s.endTime = nil
+	if p.startTime != nil {
+		s.fromId = nil
+		s.StartTime(*p.startTime)
+	} else {
+		s.startTime = nil
+		s.FromId(p.fromId)
+	}
+	for first := true; ; first = false {
+		if !first {
+			if err := sleepContext(ctx, p.interval); err != nil {
+				return err
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		trades, err := s.Do(ctx)
+		if err != nil {
+			return err
+		}
+		for _, trade := range trades {
+			if p.endTime != nil && trade.Time > *p.endTime {
+				return nil
+			}
+			if err := fn(trade); err != nil {
+				return err
+			}
+		}
+		if len(trades) < pageSize {
+			return nil
+		}
+		s.startTime = nil
+		s.FromId(int(trades[len(trades)-1].AggTradeId) + 1)
+	}
+}
+
+// All return every trade of the walk
+func (p *AggTradesPaginator) All(ctx context.Context) ([]*AggTradesListResponse, error) {
+	var trades []*AggTradesListResponse
+	err := p.Each(ctx, func(trade *AggTradesListResponse) error {
+		trades = append(trades, trade)
+		return nil
+	})
+	return trades, err
+}
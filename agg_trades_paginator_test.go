@@ -0,0 +1,100 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type aggTradesPaginatorTestSuite struct {
+	suite.Suite
+	client  *Client
+	queries []string
+	// pages are the trades answered by the next requests, an empty list once they are all sent
+	pages [][]uint64
+}
+
+func TestAggTradesPaginator(t *testing.T) {
+	suite.Run(t, new(aggTradesPaginatorTestSuite))
+}
+
+func (s *aggTradesPaginatorTestSuite) SetupTest() {
+	s.queries = nil
+	s.pages = nil
+	s.client = NewClient("", "", "https://dummyapi.com")
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.queries = append(s.queries, req.URL.RawQuery)
+		var ids []uint64
+		if len(s.pages) > 0 {
+			ids, s.pages = s.pages[0], s.pages[1:]
+		}
+		trades := make([]string, len(ids))
+		for i, id := range ids {
+			trades[i] = fmt.Sprintf(`{"a":%d,"p":"1.0","q":"1.0","f":%d,"l":%d,"T":%d,"m":false,"M":true}`, id, id, id, 1000+id)
+		}
+		return newHTTPResponse([]byte("["+strings.Join(trades, ",")+"]"), http.StatusOK), nil
+	}
+}
+
+func (s *aggTradesPaginatorTestSuite) ids(trades []*AggTradesListResponse) []uint64 {
+	ids := make([]uint64, len(trades))
+	for i, trade := range trades {
+		ids[i] = trade.AggTradeId
+	}
+	return ids
+}
+
+func (s *aggTradesPaginatorTestSuite) TestFromId() {
+	s.pages = [][]uint64{{10, 11}, {12, 13}, {14}}
+	trades, err := s.client.NewAggTradesService().Symbol("BTCUSDT").FromId(10).Paginate().PageSize(2).All(context.Background())
+	s.Require().NoError(err)
+	s.Equal([]uint64{10, 11, 12, 13, 14}, s.ids(trades))
+	s.Equal([]string{
+		"fromId=10&limit=2&symbol=BTCUSDT",
+		"fromId=12&limit=2&symbol=BTCUSDT",
+		"fromId=14&limit=2&symbol=BTCUSDT",
+	}, s.queries)
+}
+
+func (s *aggTradesPaginatorTestSuite) TestStartTimeUntilEndTime() {
+	s.pages = [][]uint64{{10, 11}, {12, 13}}
+	trades, err := s.client.NewAggTradesService().Symbol("BTCUSDT").StartTime(1005).EndTime(1012).Limit(2).
+		Paginate().All(context.Background())
+	s.Require().NoError(err)
+	// the walk stops at the first trade after endTime
+	s.Equal([]uint64{10, 11, 12}, s.ids(trades))
+	s.Equal([]string{
+		"limit=2&startTime=1005&symbol=BTCUSDT",
+		"fromId=12&limit=2&symbol=BTCUSDT",
+	}, s.queries)
+}
+
+func (s *aggTradesPaginatorTestSuite) TestStopOnError() {
+	s.pages = [][]uint64{{10, 11}, {12, 13}}
+	errStop := errors.New("stop")
+	count := 0
+	err := s.client.NewAggTradesService().Symbol("BTCUSDT").Paginate().PageSize(2).
+		Each(context.Background(), func(trade *AggTradesListResponse) error {
+			count++
+			if trade.AggTradeId == 11 {
+				return errStop
+			}
+			return nil
+		})
+	s.ErrorIs(err, errStop)
+	s.Equal(2, count)
+	s.Equal([]string{"fromId=0&limit=2&symbol=BTCUSDT"}, s.queries)
+}
+
+func (s *aggTradesPaginatorTestSuite) TestContextDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.client.NewAggTradesService().Symbol("BTCUSDT").Paginate().All(ctx)
+	s.ErrorIs(err, context.Canceled)
+	s.Empty(s.queries)
+}
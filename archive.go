@@ -0,0 +1,303 @@
+package binance_connector
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultArchiveBaseURL is the site of the Binance public market data archives
+const DefaultArchiveBaseURL = "https://data.binance.vision"
+
+var (
+	// ErrArchiveNotFound is returned when the archives do not cover a range and no client fills it from REST
+	ErrArchiveNotFound = errors.New("archive not found")
+	// ErrArchiveChecksum is returned when an archive does not match its published SHA-256 checksum
+	ErrArchiveChecksum = errors.New("archive checksum mismatch")
+)
+
+// ArchiveClient read the klines and aggregate trades of the spot market from the CSV archives of
+// data.binance.vision: a zip file per month, and per day for the current month, each with a CHECKSUM file.
+// Downloading the archives costs no request weight, which makes multi-year backtests fast. The archives lag
+// behind the market by a day or so; set Client to fetch the recent range they do not cover from REST.
+type ArchiveClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	// Client fetches from the REST endpoints the range following the last archive published, nil to fail with
+	// ErrArchiveNotFound instead
+	Client *Client
+}
+
+// NewArchiveClient create an archive client of DefaultArchiveBaseURL without REST fallback
+func NewArchiveClient() *ArchiveClient {
+	return &ArchiveClient{BaseURL: DefaultArchiveBaseURL, HTTPClient: http.DefaultClient}
+}
+
+// Klines return the klines of symbol and interval opened between start and end included, in time order
+func (a *ArchiveClient) Klines(ctx context.Context, symbol, interval string, start, end time.Time) ([]*KlinesResponse, error) {
+	symbol = strings.ToUpper(symbol)
+	path := func(period, date string) string {
+		return fmt.Sprintf("/data/spot/%s/klines/%s/%s/%s-%s-%s.zip", period, symbol, interval, symbol, interval, date)
+	}
+	klines, gap, err := readArchives(ctx, a, path, parseArchiveKline, start, end)
+	if err != nil || gap == nil {
+		return klines, err
+	}
+	if a.Client == nil {
+		return klines, fmt.Errorf("%w: %s klines from %s", ErrArchiveNotFound, symbol, gap.UTC().Format(time.DateOnly))
+	}
+	from, to := uint64(gap.UnixMilli()), uint64(end.UnixMilli())
+	for from <= to {
+		page, err := a.Client.NewKlinesService().Symbol(symbol).Interval(interval).
+			StartTime(from).EndTime(to).Limit(1000).Do(ctx)
+		if err != nil {
+			return klines, err
+		}
+		klines = append(klines, page...)
+		if len(page) < 1000 {
+			break
+		}
+		from = page[len(page)-1].OpenTime + 1
+	}
+	return klines, nil
+}
+
+// AggTrades return the aggregate trades of symbol between start and end included, in id order. The monthly
+// archives of the most traded symbols weigh hundreds of megabytes once unzipped.
+func (a *ArchiveClient) AggTrades(ctx context.Context, symbol string, start, end time.Time) ([]*AggTradesListResponse, error) {
+	symbol = strings.ToUpper(symbol)
+	path := func(period, date string) string {
+		return fmt.Sprintf("/data/spot/%s/aggTrades/%s/%s-aggTrades-%s.zip", period, symbol, symbol, date)
+	}
+	trades, gap, err := readArchives(ctx, a, path, parseArchiveAggTrade, start, end)
+	if err != nil || gap == nil {
+		return trades, err
+	}
+	if a.Client == nil {
+		return trades, fmt.Errorf("%w: %s aggTrades from %s", ErrArchiveNotFound, symbol, gap.UTC().Format(time.DateOnly))
+	}
+	recent, err := a.Client.NewAggTradesListService().Symbol(symbol).
+		StartTime(uint64(gap.UnixMilli())).EndTime(uint64(end.UnixMilli())).Paginate().PageSize(1000).All(ctx)
+	return append(trades, recent...), err
+}
+
+// readArchives read the records between start and end from the monthly archives, or the daily ones for the
+// current month and a month not archived yet. gap is the start of the range following the last archive
+// published, nil when the archives cover the whole range.
+func readArchives[T any](ctx context.Context, a *ArchiveClient, path func(period, date string) string,
+	parse func(record []string) (T, uint64, error), start, end time.Time) (records []T, gap *time.Time, err error) {
+	start, end = start.UTC(), end.UTC()
+	from, to := uint64(start.UnixMilli()), uint64(end.UnixMilli())
+	read := func(data []byte) error {
+		return parseArchive(data, func(record []string) error {
+			v, t, err := parse(record)
+			if err != nil {
+				return err
+			}
+			if t >= from && t <= to {
+				records = append(records, v)
+			}
+			return nil
+		})
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	currentMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(end); month = month.AddDate(0, 1, 0) {
+		if month.Before(currentMonth) {
+			data, err := a.download(ctx, path("monthly", month.Format("2006-01")))
+			if err == nil {
+				if err := read(data); err != nil {
+					return records, nil, err
+				}
+				continue
+			}
+			if !errors.Is(err, ErrArchiveNotFound) {
+				return records, nil, err
+			}
+		}
+		// the monthly archive of the previous month is published a few days after its end
+		day := month
+		if start.After(day) {
+			day = start.Truncate(24 * time.Hour)
+		}
+		for ; day.Month() == month.Month() && !day.After(end); day = day.AddDate(0, 0, 1) {
+			if !day.Before(today) {
+				return records, &day, nil
+			}
+			data, err := a.download(ctx, path("daily", day.Format(time.DateOnly)))
+			if errors.Is(err, ErrArchiveNotFound) {
+				return records, &day, nil
+			}
+			if err != nil {
+				return records, nil, err
+			}
+			if err := read(data); err != nil {
+				return records, nil, err
+			}
+		}
+	}
+	return records, nil, nil
+}
+
+// download return the archive at path once checked against its CHECKSUM file
+func (a *ArchiveClient) download(ctx context.Context, path string) ([]byte, error) {
+	data, err := a.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	checksum, err := a.get(ctx, path+".CHECKSUM")
+	if err != nil {
+		return nil, err
+	}
+	// the checksum file holds "<sha256 in hex>  <file name>"
+	fields := strings.Fields(string(checksum))
+	sum := sha256.Sum256(data)
+	if len(fields) == 0 || !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+		return nil, fmt.Errorf("%w: %s", ErrArchiveChecksum, path)
+	}
+	return data, nil
+}
+
+func (a *ArchiveClient) get(ctx context.Context, path string) ([]byte, error) {
+	baseURL := a.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultArchiveBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", UserAgent())
+	httpClient := a.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrArchiveNotFound, path)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("archive %s: %s", path, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// parseArchive call fn with each CSV record of the files of the zip archive data, skipping the header line
+// some archives have
+func parseArchive(data []byte, fn func(record []string) error) error {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	for _, file := range archive.File {
+		f, err := file.Open()
+		if err != nil {
+			return err
+		}
+		r := csv.NewReader(f)
+		r.FieldsPerRecord = -1
+		for {
+			record, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				f.Close()
+				return err
+			}
+			if _, err := strconv.ParseUint(record[0], 10, 64); err != nil {
+				continue
+			}
+			if err := fn(record); err != nil {
+				f.Close()
+				return err
+			}
+		}
+		f.Close()
+	}
+	return nil
+}
+
+// archiveTime return the archive timestamp in milliseconds, the spot archives are in microseconds since 2025
+func archiveTime(field string) (uint64, error) {
+	t, err := strconv.ParseUint(field, 10, 64)
+	if t >= 1e15 {
+		t /= 1000
+	}
+	return t, err
+}
+
+// parseArchiveKline parse open time, open, high, low, close, volume, close time, quote asset volume,
+// number of trades, taker buy base and quote asset volumes, ignore
+func parseArchiveKline(record []string) (*KlinesResponse, uint64, error) {
+	if len(record) < 11 {
+		return nil, 0, fmt.Errorf("kline archive: %d fields", len(record))
+	}
+	openTime, err := archiveTime(record[0])
+	if err != nil {
+		return nil, 0, err
+	}
+	closeTime, err := archiveTime(record[6])
+	if err != nil {
+		return nil, 0, err
+	}
+	trades, err := strconv.ParseUint(record[8], 10, 64)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &KlinesResponse{
+		OpenTime:                 openTime,
+		Open:                     record[1],
+		High:                     record[2],
+		Low:                      record[3],
+		Close:                    record[4],
+		Volume:                   record[5],
+		CloseTime:                closeTime,
+		QuoteAssetVolume:         record[7],
+		NumberOfTrades:           trades,
+		TakerBuyBaseAssetVolume:  record[9],
+		TakerBuyQuoteAssetVolume: record[10],
+	}, openTime, nil
+}
+
+// parseArchiveAggTrade parse aggregate trade id, price, quantity, first trade id, last trade id, time,
+// buyer is maker, best price match
+func parseArchiveAggTrade(record []string) (*AggTradesListResponse, uint64, error) {
+	if len(record) < 8 {
+		return nil, 0, fmt.Errorf("aggTrades archive: %d fields", len(record))
+	}
+	trade := &AggTradesListResponse{Price: record[1], Qty: record[2]}
+	var err error
+	for _, field := range []struct {
+		dst   *uint64
+		value string
+	}{{&trade.AggTradeId, record[0]}, {&trade.FirstTradeId, record[3]}, {&trade.LastTradeId, record[4]}} {
+		if *field.dst, err = strconv.ParseUint(field.value, 10, 64); err != nil {
+			return nil, 0, err
+		}
+	}
+	if trade.Time, err = archiveTime(record[5]); err != nil {
+		return nil, 0, err
+	}
+	if trade.IsBuyer, err = strconv.ParseBool(record[6]); err != nil {
+		return nil, 0, err
+	}
+	if trade.IsBest, err = strconv.ParseBool(record[7]); err != nil {
+		return nil, 0, err
+	}
+	return trade, trade.Time, nil
+}
@@ -0,0 +1,148 @@
+package binance_connector
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type archiveTestSuite struct {
+	suite.Suite
+	files    map[string]string
+	requests []string
+}
+
+func TestArchive(t *testing.T) {
+	suite.Run(t, new(archiveTestSuite))
+}
+
+func (s *archiveTestSuite) SetupTest() {
+	s.files = map[string]string{}
+	s.requests = nil
+}
+
+// addArchive publish a zip archive of csv at path with its checksum file
+func (s *archiveTestSuite) addArchive(path, csv string) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	name := path[strings.LastIndex(path, "/")+1:]
+	f, err := w.Create(strings.TrimSuffix(name, ".zip") + ".csv")
+	s.Require().NoError(err)
+	f.Write([]byte(csv))
+	s.Require().NoError(w.Close())
+	sum := sha256.Sum256(buf.Bytes())
+	s.files[path] = buf.String()
+	s.files[path+".CHECKSUM"] = hex.EncodeToString(sum[:]) + "  " + name + "\n"
+}
+
+func (s *archiveTestSuite) newArchiveClient() *ArchiveClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests = append(s.requests, r.URL.Path)
+		if strings.HasPrefix(r.URL.Path, "/api/v3/klines") {
+			w.Write([]byte(`[[1706832000000,"43000.00","43100.00","42900.00","43050.00","10.0",1706835599999,"430500.0",100,"5.0","215250.0","0"]]`))
+			return
+		}
+		data, ok := s.files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(data))
+	}))
+	s.T().Cleanup(server.Close)
+	a := NewArchiveClient()
+	a.BaseURL = server.URL
+	a.HTTPClient = server.Client()
+	return a
+}
+
+func (s *archiveTestSuite) TestKlinesMonthly() {
+	s.addArchive("/data/spot/monthly/klines/BTCUSDT/1h/BTCUSDT-1h-2024-01.zip",
+		"1704067200000,42283.58,42554.57,42261.02,42475.23,1271.68,1704070799999,53957248.97,47134,682.57,28957416.82,0\n"+
+			"1704070800000,42475.23,42775.00,42431.65,42613.56,1196.37,1704074399999,50984893.78,45996,712.23,30355648.30,0\n"+
+			"1704074400000,42613.57,42638.41,42500.00,42581.10,685.21,1704077999999,29168191.42,33120,301.48,12832716.35,0\n")
+
+	klines, err := s.newArchiveClient().Klines(context.Background(), "btcusdt", "1h",
+		time.UnixMilli(1704070800000), time.UnixMilli(1704074400000))
+	s.Require().NoError(err)
+	s.Require().Len(klines, 2)
+	s.Equal(&KlinesResponse{
+		OpenTime:                 1704070800000,
+		Open:                     "42475.23",
+		High:                     "42775.00",
+		Low:                      "42431.65",
+		Close:                    "42613.56",
+		Volume:                   "1196.37",
+		CloseTime:                1704074399999,
+		QuoteAssetVolume:         "50984893.78",
+		NumberOfTrades:           45996,
+		TakerBuyBaseAssetVolume:  "712.23",
+		TakerBuyQuoteAssetVolume: "30355648.30",
+	}, klines[0])
+	s.Equal(uint64(1704074400000), klines[1].OpenTime)
+}
+
+func (s *archiveTestSuite) TestKlinesDailyAndRESTFallback() {
+	// January is only archived per day up to the 2nd, the rest comes from REST
+	s.addArchive("/data/spot/daily/klines/BTCUSDT/1d/BTCUSDT-1d-2024-01-01.zip",
+		"open_time,open,high,low,close,volume,close_time,quote_volume,count,taker_buy_volume,taker_buy_quote_volume,ignore\n"+
+			"1704067200000000,42283.58,44184.10,42180.77,44179.55,27174.29,1704153599999999,1169995006.38,1008914,14238.49,613000185.20,0\n")
+	a := s.newArchiveClient()
+	start, end := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 23, 0, 0, 0, time.UTC)
+
+	klines, err := a.Klines(context.Background(), "BTCUSDT", "1d", start, end)
+	s.ErrorIs(err, ErrArchiveNotFound)
+	s.Require().Len(klines, 1)
+	s.Equal(uint64(1704067200000), klines[0].OpenTime)
+	s.Equal(uint64(1704153599999), klines[0].CloseTime)
+
+	a.Client = NewPublicClient(a.BaseURL)
+	a.Client.HTTPClient = a.HTTPClient
+	s.requests = nil
+	klines, err = a.Klines(context.Background(), "BTCUSDT", "1d", start, end)
+	s.Require().NoError(err)
+	s.Require().Len(klines, 2)
+	s.Equal(uint64(1706832000000), klines[1].OpenTime)
+	s.Equal([]string{
+		"/data/spot/monthly/klines/BTCUSDT/1d/BTCUSDT-1d-2024-01.zip",
+		"/data/spot/daily/klines/BTCUSDT/1d/BTCUSDT-1d-2024-01-01.zip",
+		"/data/spot/daily/klines/BTCUSDT/1d/BTCUSDT-1d-2024-01-01.zip.CHECKSUM",
+		"/data/spot/daily/klines/BTCUSDT/1d/BTCUSDT-1d-2024-01-02.zip",
+		"/api/v3/klines",
+	}, s.requests)
+}
+
+func (s *archiveTestSuite) TestAggTrades() {
+	s.addArchive("/data/spot/monthly/aggTrades/BTCUSDT/BTCUSDT-aggTrades-2024-01.zip",
+		"3359048693,42283.58,0.00120000,3364000001,3364000002,1704067200000,True,True\n"+
+			"3359048694,42283.59,0.01000000,3364000003,3364000003,1704067200103,False,True\n")
+
+	trades, err := s.newArchiveClient().AggTrades(context.Background(), "BTCUSDT",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	s.Equal([]*AggTradesListResponse{
+		{AggTradeId: 3359048693, Price: "42283.58", Qty: "0.00120000", FirstTradeId: 3364000001, LastTradeId: 3364000002,
+			Time: 1704067200000, IsBuyer: true, IsBest: true},
+		{AggTradeId: 3359048694, Price: "42283.59", Qty: "0.01000000", FirstTradeId: 3364000003, LastTradeId: 3364000003,
+			Time: 1704067200103, IsBuyer: false, IsBest: true},
+	}, trades)
+}
+
+func (s *archiveTestSuite) TestChecksumMismatch() {
+	path := "/data/spot/monthly/aggTrades/BTCUSDT/BTCUSDT-aggTrades-2024-01.zip"
+	s.addArchive(path, "3359048693,42283.58,0.00120000,3364000001,3364000002,1704067200000,True,True\n")
+	s.files[path+".CHECKSUM"] = strings.Repeat("0", 64) + "  BTCUSDT-aggTrades-2024-01.zip\n"
+
+	_, err := s.newArchiveClient().AggTrades(context.Background(), "BTCUSDT",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	s.ErrorIs(err, ErrArchiveChecksum)
+}
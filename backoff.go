@@ -0,0 +1,90 @@
+package binance_connector
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// DefaultBackoffMultiplier is the growth of the delays of a Backoff whose Multiplier is not set
+const DefaultBackoffMultiplier = 2
+
+// JitterStrategy define how a Backoff randomizes its delays, so that many clients failing together,
+// e.g. after a Binance-wide disconnection, do not all retry at the same time
+type JitterStrategy int
+
+const (
+	// JitterNone keeps the exponential delays as they are
+	JitterNone JitterStrategy = iota
+	// JitterFull pick a delay between zero and the exponential delay, spreading the attempts the most
+	JitterFull
+	// JitterEqual pick a delay between half the exponential delay and the exponential delay
+	JitterEqual
+)
+
+// String return the name of the strategy
+func (j JitterStrategy) String() string {
+	switch j {
+	case JitterNone:
+		return "NONE"
+	case JitterFull:
+		return "FULL"
+	case JitterEqual:
+		return "EQUAL"
+	}
+	return "UNKNOWN"
+}
+
+// Backoff define the delays between the attempts of a retried operation: attempt n waits
+// Base * Multiplier^n, capped at Max, then randomized by Jitter.
+type Backoff struct {
+	Base time.Duration
+	// Max caps the delays, zero or negative for no cap
+	Max time.Duration
+	// Multiplier is the growth of the delays between two attempts, DefaultBackoffMultiplier when below 1.
+	// A Multiplier of 1 waits Base before every attempt.
+	Multiplier float64
+	Jitter     JitterStrategy
+}
+
+// Delay return the delay before attempt, attempt 0 being the first retry
+func (b Backoff) Delay(attempt int) time.Duration {
+	return b.jitter(b.exponential(attempt))
+}
+
+// exponential return the delay of attempt before jitter
+func (b Backoff) exponential(attempt int) time.Duration {
+	if b.Base <= 0 {
+		return 0
+	}
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = DefaultBackoffMultiplier
+	}
+	if attempt < 0 {
+		attempt = 0
+	}
+	limit := time.Duration(math.MaxInt64)
+	if b.Max > 0 {
+		limit = b.Max
+	}
+	delay := float64(b.Base) * math.Pow(multiplier, float64(attempt))
+	if delay >= float64(limit) {
+		return limit
+	}
+	return time.Duration(delay)
+}
+
+func (b Backoff) jitter(delay time.Duration) time.Duration {
+	if delay <= 0 {
+		return delay
+	}
+	switch b.Jitter {
+	case JitterFull:
+		return time.Duration(rand.Int64N(int64(delay)))
+	case JitterEqual:
+		half := delay / 2
+		return delay - half + time.Duration(rand.Int64N(int64(half)+1))
+	}
+	return delay
+}
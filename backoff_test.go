@@ -0,0 +1,79 @@
+package binance_connector
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type backoffTestSuite struct {
+	suite.Suite
+}
+
+func TestBackoff(t *testing.T) {
+	suite.Run(t, new(backoffTestSuite))
+}
+
+func (s *backoffTestSuite) TestNoJitter() {
+	b := Backoff{Base: 100 * time.Millisecond, Max: time.Second}
+	expected := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for attempt, delay := range expected {
+		s.Equal(delay*time.Millisecond, b.Delay(attempt), "attempt %d", attempt)
+	}
+
+	b.Multiplier = 3
+	s.Equal(900*time.Millisecond, b.Delay(2))
+	b.Multiplier = 1
+	s.Equal(100*time.Millisecond, b.Delay(5))
+	s.Equal(100*time.Millisecond, b.Delay(-1))
+}
+
+func (s *backoffTestSuite) TestUncapped() {
+	b := Backoff{Base: time.Second}
+	s.Equal(1024*time.Second, b.Delay(10))
+	s.Equal(time.Duration(math.MaxInt64), b.Delay(1000))
+	s.Zero(Backoff{}.Delay(3))
+}
+
+func (s *backoffTestSuite) TestFullJitter() {
+	b := Backoff{Base: 100 * time.Millisecond, Max: time.Second, Jitter: JitterFull}
+	for attempt := 0; attempt < 6; attempt++ {
+		ceiling := Backoff{Base: b.Base, Max: b.Max}.Delay(attempt)
+		for i := 0; i < 200; i++ {
+			delay := b.Delay(attempt)
+			s.GreaterOrEqual(delay, time.Duration(0))
+			s.LessOrEqual(delay, ceiling)
+		}
+	}
+	s.Less(Backoff{Base: 1 << 62, Jitter: JitterFull}.Delay(100), time.Duration(math.MaxInt64))
+}
+
+func (s *backoffTestSuite) TestEqualJitter() {
+	b := Backoff{Base: 100 * time.Millisecond, Max: time.Second, Jitter: JitterEqual}
+	for attempt := 0; attempt < 6; attempt++ {
+		ceiling := Backoff{Base: b.Base, Max: b.Max}.Delay(attempt)
+		for i := 0; i < 200; i++ {
+			delay := b.Delay(attempt)
+			s.GreaterOrEqual(delay, ceiling/2)
+			s.LessOrEqual(delay, ceiling)
+		}
+	}
+}
+
+func (s *backoffTestSuite) TestJitterSpread() {
+	b := Backoff{Base: time.Second, Jitter: JitterFull}
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 20; i++ {
+		seen[b.Delay(0)] = true
+	}
+	s.Greater(len(seen), 1, "the delays are randomized")
+}
+
+func (s *backoffTestSuite) TestString() {
+	s.Equal("NONE", JitterNone.String())
+	s.Equal("FULL", JitterFull.String())
+	s.Equal("EQUAL", JitterEqual.String())
+	s.Equal("UNKNOWN", JitterStrategy(9).String())
+}
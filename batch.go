@@ -0,0 +1,65 @@
+package binance_connector
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of calls a batch runs at once when no limit is given
+const DefaultBatchConcurrency = 5
+
+// Result define the outcome of one item of a batch, either a value or the error of that item
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// OK return true when the item succeeded
+func (r Result[T]) OK() bool {
+	return r.Err == nil
+}
+
+// RunBatch call fn for each input, at most concurrency calls at once (DefaultBatchConcurrency when
+// concurrency is not positive), and return the results aligned with inputs. An item failing does not
+// stop the others; once ctx is done the items not started yet fail with the context error.
+func RunBatch[I, T any](ctx context.Context, inputs []I, concurrency int, fn func(ctx context.Context, input I) (T, error)) []Result[T] {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	results := make([]Result[T], len(inputs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, input := range inputs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(inputs); j++ {
+				results[j].Err = ctx.Err()
+			}
+			wg.Wait()
+			return results
+		}
+		wg.Add(1)
+		go func(i int, input I) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].Value, results[i].Err = fn(ctx, input)
+		}(i, input)
+	}
+	wg.Wait()
+	return results
+}
+
+// SplitResults return the values of the items that succeeded, in input order, and the errors of
+// the ones that failed keyed by their index in the inputs
+func SplitResults[T any](results []Result[T]) (values []T, errs map[int]error) {
+	errs = make(map[int]error)
+	for i, result := range results {
+		if result.Err != nil {
+			errs[i] = result.Err
+			continue
+		}
+		values = append(values, result.Value)
+	}
+	return values, errs
+}
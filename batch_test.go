@@ -0,0 +1,85 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type batchTestSuite struct {
+	suite.Suite
+}
+
+func TestBatch(t *testing.T) {
+	suite.Run(t, new(batchTestSuite))
+}
+
+func (s *batchTestSuite) TestRunBatch() {
+	symbols := []string{"BTCUSDT", "UNKNOWN", "ETHUSDT", "BNBUSDT", "INVALID", "XRPUSDT"}
+	var running, peak atomic.Int32
+	results := RunBatch(context.Background(), symbols, 2, func(ctx context.Context, symbol string) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if symbol == "UNKNOWN" || symbol == "INVALID" {
+			return "", fmt.Errorf("invalid symbol %s", symbol)
+		}
+		return symbol + " price", nil
+	})
+
+	s.Require().Len(results, len(symbols))
+	s.Equal(int32(2), peak.Load())
+	s.True(results[0].OK())
+	s.Equal("BTCUSDT price", results[0].Value)
+	s.False(results[1].OK())
+	s.EqualError(results[1].Err, "invalid symbol UNKNOWN")
+	s.Equal("XRPUSDT price", results[5].Value)
+
+	values, errs := SplitResults(results)
+	s.Equal([]string{"BTCUSDT price", "ETHUSDT price", "BNBUSDT price", "XRPUSDT price"}, values)
+	s.Len(errs, 2)
+	s.EqualError(errs[4], "invalid symbol INVALID")
+}
+
+func (s *batchTestSuite) TestRunBatchDefaultsAndCancel() {
+	var peak, running atomic.Int32
+	inputs := make([]int, 20)
+	RunBatch(context.Background(), inputs, 0, func(ctx context.Context, input int) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		if n > peak.Load() {
+			peak.Store(n)
+		}
+		time.Sleep(5 * time.Millisecond)
+		return input, nil
+	})
+	s.LessOrEqual(peak.Load(), int32(DefaultBatchConcurrency))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	results := RunBatch(ctx, []int{1, 2, 3, 4}, 1, func(ctx context.Context, input int) (int, error) {
+		calls.Add(1)
+		if input == 2 {
+			cancel()
+		}
+		return input * 10, nil
+	})
+	s.Equal(int32(2), calls.Load())
+	s.Equal(10, results[0].Value)
+	s.Equal(20, results[1].Value)
+	s.True(errors.Is(results[2].Err, context.Canceled))
+	s.True(errors.Is(results[3].Err, context.Canceled))
+
+	s.Empty(RunBatch(context.Background(), []int{}, 3, func(ctx context.Context, input int) (int, error) { return 0, nil }))
+}
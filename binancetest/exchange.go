@@ -0,0 +1,794 @@
+// Package binancetest provides an in-memory fake of the Binance spot REST API, to test trading code
+// without the network or an account. Orders are placed, cancelled and queried through the services of
+// the client, filled at the prices injected with SetPrice and reflected in the balances of the account.
+//
+// Only the MARKET, LIMIT and LIMIT_MAKER orders are simulated, with an unlimited liquidity at the injected
+// price and no commission. The signatures are not checked.
+package binancetest
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+	"github.com/luciano-personal-org/binance-connector/handlers"
+)
+
+// BaseURL is the base URL of the clients returned by Exchange.Client, the requests never leave the process
+const BaseURL = "https://binancetest.invalid"
+
+// Symbol define a symbol listed by the fake exchange
+type Symbol struct {
+	Symbol     string
+	BaseAsset  string
+	QuoteAsset string
+	// Status is the trading status of the symbol, TRADING when empty
+	Status string
+	// TickSize is the step of the PRICE_FILTER, e.g. "0.01000000", empty disables the filter
+	TickSize string
+	// StepSize, MinQty and MaxQty are the values of the LOT_SIZE filter, empty disables them
+	StepSize string
+	MinQty   string
+	MaxQty   string
+	// MinNotional is the minimum quote amount of the NOTIONAL filter, empty disables it
+	MinNotional string
+}
+
+// Exchange is an in-memory fake of the Binance spot trading endpoints:
+//
+//	POST /api/v3/order, POST /api/v3/order/test, DELETE /api/v3/order, GET /api/v3/order,
+//	GET /api/v3/openOrders, DELETE /api/v3/openOrders, GET /api/v3/account,
+//	GET /api/v3/exchangeInfo, GET /api/v3/ping and GET /api/v3/time
+//
+// An Exchange is an http.Handler, for httptest.NewServer, and an http.RoundTripper, for the HTTPClient of
+// a client. It is safe for concurrent use.
+type Exchange struct {
+	// Now return the time of the orders and of the responses, time.Now when nil
+	Now func() time.Time
+
+	mu          sync.Mutex
+	symbols     map[string]*Symbol
+	prices      map[string]float64
+	balances    map[string]*balance
+	orders      []*order
+	nextOrderID int64
+	nextTradeID int64
+	rejections  []*handlers.APIError
+}
+
+type balance struct {
+	free   float64
+	locked float64
+}
+
+type fill struct {
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	TradeId         int64  `json:"tradeId"`
+}
+
+type order struct {
+	symbol        *Symbol
+	id            int64
+	clientOrderID string
+	side          string
+	orderType     string
+	timeInForce   string
+	price         float64
+	origQty       float64
+	origQuoteQty  float64
+	executedQty   float64
+	cumQuote      float64
+	status        string
+	// locked is the amount of the balance held by the order while it is open
+	locked     float64
+	fills      []fill
+	time       int64
+	updateTime int64
+}
+
+// NewExchange create an exchange without symbols nor balances
+func NewExchange() *Exchange {
+	return &Exchange{
+		symbols:  make(map[string]*Symbol),
+		prices:   make(map[string]float64),
+		balances: make(map[string]*balance),
+	}
+}
+
+// AddSymbol list symbol on the exchange
+func (e *Exchange) AddSymbol(symbol Symbol) *Exchange {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if symbol.Status == "" {
+		symbol.Status = "TRADING"
+	}
+	e.symbols[symbol.Symbol] = &symbol
+	return e
+}
+
+// SetBalance set the free balance of asset, the amount locked by the open orders is kept
+func (e *Exchange) SetBalance(asset string, free float64) *Exchange {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.balance(asset).free = free
+	return e
+}
+
+// Balance return the free and locked balance of asset
+func (e *Exchange) Balance(asset string) (free, locked float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	b := e.balance(asset)
+	return b.free, b.locked
+}
+
+// SetPrice set the price of symbol, the MARKET orders are filled at it and the open LIMIT orders it
+// reaches are filled at their limit price
+func (e *Exchange) SetPrice(symbol string, price float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.prices[symbol] = price
+	for _, o := range e.orders {
+		if o.symbol.Symbol == symbol && o.status == "NEW" && o.crosses(price) {
+			e.fill(o, o.price)
+		}
+	}
+}
+
+// RejectNextOrder make the next order sent fail with the API error code and msg, e.g.
+// -2010 and "Account has insufficient balance for requested action.". The rejections queue up.
+func (e *Exchange) RejectNextOrder(code int64, msg string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rejections = append(e.rejections, &handlers.APIError{Code: code, Message: msg})
+}
+
+// Client return a client whose requests are served by the exchange in memory
+func (e *Exchange) Client() *binance_connector.Client {
+	c := binance_connector.NewClient("binancetest-api-key", "binancetest-secret-key", BaseURL)
+	c.HTTPClient = &http.Client{Transport: e}
+	return c
+}
+
+// RoundTrip implements http.RoundTripper and serve req without the network
+func (e *Exchange) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
+
+// ServeHTTP implements http.Handler
+func (e *Exchange) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, &handlers.APIError{Code: -1100, Message: err.Error()})
+		return
+	}
+	type route struct{ method, path string }
+	handle, ok := map[route]func(*http.Request) (interface{}, *handlers.APIError){
+		{http.MethodGet, "/api/v3/ping"}:          e.ping,
+		{http.MethodGet, "/api/v3/time"}:          e.serverTime,
+		{http.MethodGet, "/api/v3/exchangeInfo"}:  e.exchangeInfo,
+		{http.MethodPost, "/api/v3/order"}:        e.placeOrder,
+		{http.MethodPost, "/api/v3/order/test"}:   e.testOrder,
+		{http.MethodDelete, "/api/v3/order"}:      e.cancelOrder,
+		{http.MethodGet, "/api/v3/order"}:         e.queryOrder,
+		{http.MethodGet, "/api/v3/openOrders"}:    e.openOrders,
+		{http.MethodDelete, "/api/v3/openOrders"}: e.cancelOpenOrders,
+		{http.MethodGet, "/api/v3/account"}:       e.account,
+	}[route{req.Method, req.URL.Path}]
+	if !ok {
+		writeError(w, http.StatusNotFound, &handlers.APIError{Code: -1, Message: fmt.Sprintf("binancetest: %s %s is not simulated", req.Method, req.URL.Path)})
+		return
+	}
+	e.mu.Lock()
+	res, apiErr := handle(req)
+	e.mu.Unlock()
+	if apiErr != nil {
+		writeError(w, http.StatusBadRequest, apiErr)
+		return
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, &handlers.APIError{Code: -1000, Message: err.Error()})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, apiErr *handlers.APIError) {
+	data, _ := json.Marshal(apiErr)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+func (e *Exchange) ping(*http.Request) (interface{}, *handlers.APIError) {
+	return struct{}{}, nil
+}
+
+func (e *Exchange) serverTime(*http.Request) (interface{}, *handlers.APIError) {
+	return &binance_connector.ServerTimeResponse{ServerTime: uint64(e.millis())}, nil
+}
+
+func (e *Exchange) exchangeInfo(req *http.Request) (interface{}, *handlers.APIError) {
+	res := &binance_connector.ExchangeInfoResponse{Timezone: "UTC", ServerTime: uint64(e.millis())}
+	names := make([]string, 0, len(e.symbols))
+	for name := range e.symbols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var wanted []string
+	if symbol := req.Form.Get("symbol"); symbol != "" {
+		wanted = []string{symbol}
+	} else if symbols := req.Form.Get("symbols"); symbols != "" {
+		if err := json.Unmarshal([]byte(symbols), &wanted); err != nil {
+			return nil, &handlers.APIError{Code: -1100, Message: "Illegal characters found in parameter 'symbols'."}
+		}
+	}
+	for _, name := range names {
+		if len(wanted) > 0 && !contains(wanted, name) {
+			continue
+		}
+		res.Symbols = append(res.Symbols, e.symbols[name].info())
+	}
+	return res, nil
+}
+
+// info return the exchange information of s
+func (s *Symbol) info() *binance_connector.SymbolInfo {
+	info := &binance_connector.SymbolInfo{
+		Symbol:                     s.Symbol,
+		Status:                     s.Status,
+		BaseAsset:                  s.BaseAsset,
+		BaseAssetPrecision:         8,
+		QuoteAsset:                 s.QuoteAsset,
+		QuotePrecision:             8,
+		QuoteAssetPrecision:        8,
+		OrderTypes:                 []string{"LIMIT", "LIMIT_MAKER", "MARKET"},
+		QuoteOrderQtyMarketAllowed: true,
+		IsSpotTradingAllowed:       true,
+		Permissions:                []string{"SPOT"},
+	}
+	if s.TickSize != "" {
+		info.Filters = append(info.Filters, &binance_connector.SymbolFilter{FilterType: "PRICE_FILTER", MinPrice: s.TickSize, TickSize: s.TickSize})
+	}
+	if s.StepSize != "" || s.MinQty != "" || s.MaxQty != "" {
+		info.Filters = append(info.Filters, &binance_connector.SymbolFilter{FilterType: "LOT_SIZE", MinQty: s.MinQty, MaxQty: s.MaxQty, StepSize: s.StepSize})
+	}
+	if s.MinNotional != "" {
+		info.Filters = append(info.Filters, &binance_connector.SymbolFilter{FilterType: "NOTIONAL", MinNotional: s.MinNotional, ApplyMinToMarket: true})
+	}
+	return info
+}
+
+func (e *Exchange) testOrder(req *http.Request) (interface{}, *handlers.APIError) {
+	if _, err := e.newOrder(req); err != nil {
+		return nil, err
+	}
+	return struct{}{}, nil
+}
+
+func (e *Exchange) placeOrder(req *http.Request) (interface{}, *handlers.APIError) {
+	if len(e.rejections) > 0 {
+		apiErr := e.rejections[0]
+		e.rejections = e.rejections[1:]
+		return nil, apiErr
+	}
+	o, apiErr := e.newOrder(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr = e.execute(o); apiErr != nil {
+		return nil, apiErr
+	}
+	e.orders = append(e.orders, o)
+
+	respType := req.Form.Get("newOrderRespType")
+	if respType == "" {
+		respType = "ACK"
+		if o.orderType == "MARKET" || o.orderType == "LIMIT" {
+			respType = "FULL"
+		}
+	}
+	switch respType {
+	case "ACK":
+		return &binance_connector.CreateOrderResponseACK{Symbol: o.symbol.Symbol, OrderId: o.id, OrderListId: -1,
+			ClientOrderId: o.clientOrderID, TransactTime: uint64(o.time)}, nil
+	case "RESULT":
+		return o.result(), nil
+	}
+	fills := o.fills
+	if fills == nil {
+		fills = []fill{}
+	}
+	return &struct {
+		*binance_connector.CreateOrderResponseRESULT
+		Fills []fill `json:"fills"`
+	}{o.result(), fills}, nil
+}
+
+// newOrder check the parameters of an order against the filters of its symbol
+func (e *Exchange) newOrder(req *http.Request) (*order, *handlers.APIError) {
+	if apiErr := checkAPIKey(req); apiErr != nil {
+		return nil, apiErr
+	}
+	symbol, apiErr := e.symbol(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	o := &order{
+		symbol:        symbol,
+		clientOrderID: req.Form.Get("newClientOrderId"),
+		side:          req.Form.Get("side"),
+		orderType:     req.Form.Get("type"),
+		timeInForce:   req.Form.Get("timeInForce"),
+		status:        "NEW",
+		time:          e.millis(),
+	}
+	for name, value := range map[string]string{"side": o.side, "type": o.orderType} {
+		if value == "" {
+			return nil, mandatoryParameter(name)
+		}
+	}
+	if o.side != "BUY" && o.side != "SELL" {
+		return nil, &handlers.APIError{Code: -1117, Message: "Invalid side."}
+	}
+	if o.price, apiErr = floatParam(req, "price"); apiErr != nil {
+		return nil, apiErr
+	}
+	if o.origQty, apiErr = floatParam(req, "quantity"); apiErr != nil {
+		return nil, apiErr
+	}
+	if o.origQuoteQty, apiErr = floatParam(req, "quoteOrderQty"); apiErr != nil {
+		return nil, apiErr
+	}
+	switch o.orderType {
+	case "MARKET":
+		if (o.origQty > 0) == (o.origQuoteQty > 0) {
+			return nil, &handlers.APIError{Code: -1102, Message: "Param 'quantity' or 'quoteOrderQty' must be sent, but both were empty/null!"}
+		}
+	case "LIMIT", "LIMIT_MAKER":
+		if o.price <= 0 {
+			return nil, mandatoryParameter("price")
+		}
+		if o.origQty <= 0 {
+			return nil, mandatoryParameter("quantity")
+		}
+		if o.orderType == "LIMIT" && o.timeInForce == "" {
+			return nil, mandatoryParameter("timeInForce")
+		}
+		if !onStep(o.price, symbol.TickSize) {
+			return nil, filterFailure("PRICE_FILTER")
+		}
+	default:
+		return nil, &handlers.APIError{Code: -1116, Message: "Invalid orderType."}
+	}
+	if symbol.Status != "TRADING" {
+		return nil, &handlers.APIError{Code: -2010, Message: "Market is closed."}
+	}
+	if o.origQuoteQty > 0 {
+		price, ok := e.prices[symbol.Symbol]
+		if !ok {
+			return nil, noPrice(symbol)
+		}
+		o.origQty = floorToStep(o.origQuoteQty/price, symbol.StepSize)
+	}
+	if !onStep(o.origQty, symbol.StepSize) || o.origQty < parseStep(symbol.MinQty) ||
+		(parseStep(symbol.MaxQty) > 0 && o.origQty > parseStep(symbol.MaxQty)) {
+		return nil, filterFailure("LOT_SIZE")
+	}
+	if minNotional := parseStep(symbol.MinNotional); minNotional > 0 {
+		price := o.price
+		if o.orderType == "MARKET" {
+			price = e.prices[symbol.Symbol]
+		}
+		if o.origQty*price < minNotional {
+			return nil, filterFailure("NOTIONAL")
+		}
+	}
+	if o.clientOrderID != "" {
+		for _, open := range e.orders {
+			if open.symbol == symbol && open.clientOrderID == o.clientOrderID && open.status == "NEW" {
+				return nil, &handlers.APIError{Code: -2010, Message: "Duplicate order sent."}
+			}
+		}
+	}
+	return o, nil
+}
+
+// execute fill o at the current price when it crosses it, else leave it open with its balance locked
+func (e *Exchange) execute(o *order) *handlers.APIError {
+	price, hasPrice := e.prices[o.symbol.Symbol]
+	if o.orderType == "MARKET" && !hasPrice {
+		return noPrice(o.symbol)
+	}
+	immediate := o.orderType == "MARKET" || (hasPrice && o.crosses(price))
+	if immediate && o.orderType == "LIMIT_MAKER" {
+		return &handlers.APIError{Code: -2010, Message: "Order would immediately match and take."}
+	}
+	// a LIMIT order is checked against its limit price, a MARKET order against the current price
+	amount := o.origQty
+	if o.side == "BUY" {
+		amount = roundAmount(o.origQty * price)
+		if o.orderType != "MARKET" {
+			amount = roundAmount(o.origQty * o.price)
+		}
+	}
+	b := e.balance(o.lockedAsset())
+	if b.free < amount {
+		return &handlers.APIError{Code: -2010, Message: "Account has insufficient balance for requested action."}
+	}
+	e.nextOrderID++
+	o.id = e.nextOrderID
+	if o.clientOrderID == "" {
+		o.clientOrderID = fmt.Sprintf("binancetest-%d", o.id)
+	}
+	o.updateTime = o.time
+	switch {
+	case immediate:
+		e.fill(o, price)
+	case o.timeInForce == "IOC" || o.timeInForce == "FOK":
+		o.status = "EXPIRED"
+	default:
+		b.free = roundAmount(b.free - amount)
+		b.locked = roundAmount(b.locked + amount)
+		o.locked = amount
+	}
+	return nil
+}
+
+// fill execute the whole quantity of o at price and update the balances
+func (e *Exchange) fill(o *order, price float64) {
+	base, quote := e.balance(o.symbol.BaseAsset), e.balance(o.symbol.QuoteAsset)
+	cost := roundAmount(o.origQty * price)
+	if o.side == "BUY" {
+		quote.locked = roundAmount(quote.locked - o.locked)
+		quote.free = roundAmount(quote.free + o.locked - cost)
+		base.free = roundAmount(base.free + o.origQty)
+	} else {
+		base.locked = roundAmount(base.locked - o.locked)
+		base.free = roundAmount(base.free + o.locked - o.origQty)
+		quote.free = roundAmount(quote.free + cost)
+	}
+	e.nextTradeID++
+	o.fills = append(o.fills, fill{
+		Price:           formatAmount(price),
+		Qty:             formatAmount(o.origQty),
+		Commission:      formatAmount(0),
+		CommissionAsset: o.symbol.BaseAsset,
+		TradeId:         e.nextTradeID,
+	})
+	o.locked = 0
+	o.executedQty = o.origQty
+	o.cumQuote = cost
+	o.status = "FILLED"
+	o.updateTime = e.millis()
+}
+
+func (e *Exchange) cancelOrder(req *http.Request) (interface{}, *handlers.APIError) {
+	if apiErr := checkAPIKey(req); apiErr != nil {
+		return nil, apiErr
+	}
+	o, apiErr := e.lookup(req)
+	if apiErr != nil && apiErr.Code != -2013 {
+		return nil, apiErr
+	}
+	if apiErr != nil || o.status != "NEW" {
+		return nil, &handlers.APIError{Code: -2011, Message: "Unknown order sent."}
+	}
+	return e.cancel(o, req.Form.Get("newClientOrderId")), nil
+}
+
+func (e *Exchange) cancelOpenOrders(req *http.Request) (interface{}, *handlers.APIError) {
+	if apiErr := checkAPIKey(req); apiErr != nil {
+		return nil, apiErr
+	}
+	symbol, apiErr := e.symbol(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	res := []*binance_connector.CancelOrderResponse{}
+	for _, o := range e.orders {
+		if o.symbol == symbol && o.status == "NEW" {
+			res = append(res, e.cancel(o, ""))
+		}
+	}
+	if len(res) == 0 {
+		return nil, &handlers.APIError{Code: -2011, Message: "Unknown order sent."}
+	}
+	return res, nil
+}
+
+// cancel cancel the open order o and release its locked balance
+func (e *Exchange) cancel(o *order, clientOrderID string) *binance_connector.CancelOrderResponse {
+	b := e.balance(o.lockedAsset())
+	b.locked = roundAmount(b.locked - o.locked)
+	b.free = roundAmount(b.free + o.locked)
+	o.locked = 0
+	o.status = "CANCELED"
+	o.updateTime = e.millis()
+	if clientOrderID == "" {
+		clientOrderID = fmt.Sprintf("binancetest-cancel-%d", o.id)
+	}
+	r := o.result()
+	return &binance_connector.CancelOrderResponse{
+		Symbol:             r.Symbol,
+		OrigClientOrderId:  r.ClientOrderId,
+		OrderId:            r.OrderId,
+		OrderListId:        r.OrderListId,
+		ClientOrderId:      clientOrderID,
+		Price:              r.Price,
+		OrigQty:            r.OrigQty,
+		ExecutedQty:        r.ExecutedQty,
+		CumulativeQuoteQty: r.CumulativeQuoteQty,
+		Status:             r.Status,
+		TimeInForce:        r.TimeInForce,
+		Type:               r.Type,
+		Side:               r.Side,
+	}
+}
+
+func (e *Exchange) queryOrder(req *http.Request) (interface{}, *handlers.APIError) {
+	if apiErr := checkAPIKey(req); apiErr != nil {
+		return nil, apiErr
+	}
+	o, apiErr := e.lookup(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return o.query(), nil
+}
+
+func (e *Exchange) openOrders(req *http.Request) (interface{}, *handlers.APIError) {
+	if apiErr := checkAPIKey(req); apiErr != nil {
+		return nil, apiErr
+	}
+	var symbol *Symbol
+	if req.Form.Get("symbol") != "" {
+		var apiErr *handlers.APIError
+		if symbol, apiErr = e.symbol(req); apiErr != nil {
+			return nil, apiErr
+		}
+	}
+	res := []*binance_connector.NewOpenOrdersResponse{}
+	for _, o := range e.orders {
+		if o.status == "NEW" && (symbol == nil || o.symbol == symbol) {
+			res = append(res, (*binance_connector.NewOpenOrdersResponse)(o.query()))
+		}
+	}
+	return res, nil
+}
+
+func (e *Exchange) account(req *http.Request) (interface{}, *handlers.APIError) {
+	if apiErr := checkAPIKey(req); apiErr != nil {
+		return nil, apiErr
+	}
+	res := &binance_connector.AccountResponse{
+		CanTrade:    true,
+		CanWithdraw: true,
+		CanDeposit:  true,
+		UpdateTime:  uint64(e.millis()),
+		AccountType: "SPOT",
+		Balances:    []binance_connector.Balance{},
+		Permissions: []string{"SPOT"},
+	}
+	assets := make([]string, 0, len(e.balances))
+	for asset := range e.balances {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+	for _, asset := range assets {
+		b := e.balances[asset]
+		res.Balances = append(res.Balances, binance_connector.Balance{Asset: asset, Free: formatAmount(b.free), Locked: formatAmount(b.locked)})
+	}
+	return res, nil
+}
+
+// lookup return the order of the request by orderId or origClientOrderId
+func (e *Exchange) lookup(req *http.Request) (*order, *handlers.APIError) {
+	symbol, apiErr := e.symbol(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	id, clientOrderID := req.Form.Get("orderId"), req.Form.Get("origClientOrderId")
+	if id == "" && clientOrderID == "" {
+		return nil, &handlers.APIError{Code: -1102, Message: "Param 'origClientOrderId' or 'orderId' must be sent, but both were empty/null!"}
+	}
+	// the latest order of a client order id reused after its first order closed is returned
+	for i := len(e.orders) - 1; i >= 0; i-- {
+		o := e.orders[i]
+		if o.symbol != symbol {
+			continue
+		}
+		if (id != "" && strconv.FormatInt(o.id, 10) == id) || (id == "" && o.clientOrderID == clientOrderID) {
+			return o, nil
+		}
+	}
+	return nil, &handlers.APIError{Code: -2013, Message: "Order does not exist."}
+}
+
+// symbol return the listed symbol of the request
+func (e *Exchange) symbol(req *http.Request) (*Symbol, *handlers.APIError) {
+	name := req.Form.Get("symbol")
+	if name == "" {
+		return nil, mandatoryParameter("symbol")
+	}
+	symbol, ok := e.symbols[name]
+	if !ok {
+		return nil, &handlers.APIError{Code: -1121, Message: "Invalid symbol."}
+	}
+	return symbol, nil
+}
+
+func (e *Exchange) balance(asset string) *balance {
+	b, ok := e.balances[asset]
+	if !ok {
+		b = &balance{}
+		e.balances[asset] = b
+	}
+	return b
+}
+
+func (e *Exchange) millis() int64 {
+	now := time.Now
+	if e.Now != nil {
+		now = e.Now
+	}
+	return now().UnixMilli()
+}
+
+// crosses return true when the limit price of o is reached at price
+func (o *order) crosses(price float64) bool {
+	if o.side == "BUY" {
+		return price <= o.price
+	}
+	return price >= o.price
+}
+
+// lockedAsset return the asset held by o while it is open
+func (o *order) lockedAsset() string {
+	if o.side == "BUY" {
+		return o.symbol.QuoteAsset
+	}
+	return o.symbol.BaseAsset
+}
+
+func (o *order) result() *binance_connector.CreateOrderResponseRESULT {
+	return &binance_connector.CreateOrderResponseRESULT{
+		Symbol:                  o.symbol.Symbol,
+		OrderId:                 o.id,
+		OrderListId:             -1,
+		ClientOrderId:           o.clientOrderID,
+		TransactTime:            uint64(o.time),
+		Price:                   formatAmount(o.price),
+		OrigQty:                 formatAmount(o.origQty),
+		ExecutedQty:             formatAmount(o.executedQty),
+		CumulativeQuoteQty:      formatAmount(o.cumQuote),
+		OrigQuoteOrderQty:       formatAmount(o.origQuoteQty),
+		Status:                  o.status,
+		TimeInForce:             o.timeInForceOrGTC(),
+		Type:                    o.orderType,
+		Side:                    o.side,
+		WorkingTime:             o.time,
+		SelfTradePreventionMode: "NONE",
+	}
+}
+
+func (o *order) query() *binance_connector.GetOrderResponse {
+	r := o.result()
+	return &binance_connector.GetOrderResponse{
+		Symbol:                  r.Symbol,
+		OrderId:                 r.OrderId,
+		OrderListId:             r.OrderListId,
+		ClientOrderId:           r.ClientOrderId,
+		Price:                   r.Price,
+		OrigQty:                 r.OrigQty,
+		ExecutedQty:             r.ExecutedQty,
+		CumulativeQuoteQty:      r.CumulativeQuoteQty,
+		Status:                  r.Status,
+		TimeInForce:             r.TimeInForce,
+		Type:                    r.Type,
+		Side:                    r.Side,
+		StopPrice:               formatAmount(0),
+		Time:                    uint64(o.time),
+		UpdateTime:              uint64(o.updateTime),
+		IsWorking:               true,
+		WorkingTime:             r.WorkingTime,
+		OrigQuoteOrderQty:       r.OrigQuoteOrderQty,
+		SelfTradePreventionMode: r.SelfTradePreventionMode,
+	}
+}
+
+func (o *order) timeInForceOrGTC() string {
+	if o.timeInForce == "" {
+		return "GTC"
+	}
+	return o.timeInForce
+}
+
+// checkAPIKey reject a signed request sent without an API key
+func checkAPIKey(req *http.Request) *handlers.APIError {
+	if req.Header.Get("X-MBX-APIKEY") == "" {
+		return &handlers.APIError{Code: -2014, Message: "API-key format invalid."}
+	}
+	return nil
+}
+
+// floatParam parse the decimal parameter name, zero when it is not sent
+func floatParam(req *http.Request, name string) (float64, *handlers.APIError) {
+	value := req.Form.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0, &handlers.APIError{Code: -1100, Message: fmt.Sprintf("Illegal characters found in parameter '%s'; legal range is '^([0-9]{1,20})(\\.[0-9]{1,20})?$'.", name)}
+	}
+	return f, nil
+}
+
+func mandatoryParameter(name string) *handlers.APIError {
+	return &handlers.APIError{Code: -1102, Message: fmt.Sprintf("Mandatory parameter '%s' was not sent, was empty/null, or malformed.", name)}
+}
+
+func filterFailure(filter string) *handlers.APIError {
+	return &handlers.APIError{Code: -1013, Message: "Filter failure: " + filter}
+}
+
+func noPrice(symbol *Symbol) *handlers.APIError {
+	return &handlers.APIError{Code: -2010, Message: fmt.Sprintf("binancetest: no price for %s, call SetPrice before a MARKET order", symbol.Symbol)}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStep parse a filter value, zero when it is empty or invalid
+func parseStep(step string) float64 {
+	f, _ := strconv.ParseFloat(step, 64)
+	return f
+}
+
+// onStep return true when value is a multiple of step, or step is zero
+func onStep(value float64, step string) bool {
+	s := parseStep(step)
+	if s <= 0 {
+		return true
+	}
+	n := value / s
+	return math.Abs(n-math.Round(n)) < 1e-9
+}
+
+// floorToStep round value down to a multiple of step
+func floorToStep(value float64, step string) float64 {
+	s := parseStep(step)
+	if s <= 0 {
+		return roundAmount(value)
+	}
+	return roundAmount(math.Floor(value/s+1e-9) * s)
+}
+
+// roundAmount round value to the 8 decimals of the amounts of Binance
+func roundAmount(value float64) float64 {
+	return math.Round(value*1e8) / 1e8
+}
+
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 8, 64)
+}
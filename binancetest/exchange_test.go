@@ -0,0 +1,223 @@
+package binancetest
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+type exchangeTestSuite struct {
+	suite.Suite
+	exchange *Exchange
+	client   *binance_connector.Client
+}
+
+func TestExchange(t *testing.T) {
+	suite.Run(t, new(exchangeTestSuite))
+}
+
+func (s *exchangeTestSuite) SetupTest() {
+	s.exchange = NewExchange().
+		AddSymbol(Symbol{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", TickSize: "0.01000000",
+			StepSize: "0.00001000", MinQty: "0.00001000", MaxQty: "9000.00000000", MinNotional: "5.00000000"}).
+		SetBalance("USDT", 1000)
+	s.exchange.SetPrice("BTCUSDT", 30000)
+	s.client = s.exchange.Client()
+}
+
+func (s *exchangeTestSuite) balance(asset string) (free, locked string) {
+	account, err := s.client.NewGetAccountService().Do(context.Background())
+	s.Require().NoError(err)
+	for _, b := range account.Balances {
+		if b.Asset == asset {
+			return b.Free, b.Locked
+		}
+	}
+	return "", ""
+}
+
+func (s *exchangeTestSuite) TestMarketOrder() {
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").
+		Quantity(0.01).Do(context.Background())
+	s.Require().NoError(err)
+	order := res.(*binance_connector.CreateOrderResponseFULL)
+	s.Equal("FILLED", order.Status)
+	s.Equal("0.01000000", order.ExecutedQty)
+	s.Equal("300.00000000", order.CumulativeQuoteQty)
+	s.Require().Len(order.Fills, 1)
+	s.Equal("30000.00000000", order.Fills[0].Price)
+
+	free, locked := s.balance("USDT")
+	s.Equal("700.00000000", free)
+	s.Equal("0.00000000", locked)
+	free, _ = s.balance("BTC")
+	s.Equal("0.01000000", free)
+
+	// a quote amount is converted at the current price and rounded down to the lot step
+	res, err = s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("SELL").Type("MARKET").
+		QuoteOrderQty(100).Do(context.Background())
+	s.Require().NoError(err)
+	order = res.(*binance_connector.CreateOrderResponseFULL)
+	s.Equal("0.00333000", order.ExecutedQty)
+	s.Equal("99.90000000", order.CumulativeQuoteQty)
+	s.Equal("100.00000000", order.OrigQuoteOrderQty)
+	usdt, _ := s.exchange.Balance("USDT")
+	s.Equal(799.9, usdt)
+	btc, _ := s.exchange.Balance("BTC")
+	s.Equal(0.00667, btc)
+}
+
+func (s *exchangeTestSuite) TestLimitOrder() {
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("LIMIT").TimeInForce("GTC").
+		Price(29000).Quantity(0.02).NewClientOrderId("my-order").DoNormalized(context.Background())
+	s.Require().NoError(err)
+	s.Equal("NEW", res.Status)
+	s.Empty(res.Fills)
+	free, locked := s.exchange.Balance("USDT")
+	s.Equal(420.0, free)
+	s.Equal(580.0, locked)
+
+	open, err := s.client.NewGetOpenOrdersService().Symbol("BTCUSDT").Do(context.Background())
+	s.Require().NoError(err)
+	s.Require().Len(open, 1)
+	s.Equal(res.OrderId, open[0].OrderId)
+
+	// a price above the limit leaves the order open, the limit fills it at its price
+	s.exchange.SetPrice("BTCUSDT", 29500)
+	s.exchange.SetPrice("BTCUSDT", 28000)
+	order, err := s.client.NewGetOrderService().Symbol("BTCUSDT").OrigClientOrderId("my-order").Do(context.Background())
+	s.Require().NoError(err)
+	s.Equal("FILLED", order.Status)
+	s.Equal("0.02000000", order.ExecutedQty)
+	free, locked = s.exchange.Balance("USDT")
+	s.Equal(420.0, free)
+	s.Zero(locked)
+	free, _ = s.exchange.Balance("BTC")
+	s.Equal(0.02, free)
+
+	// a limit crossing the current price fills at once at the current price
+	res, err = s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("SELL").Type("LIMIT").TimeInForce("GTC").
+		Price(27000).Quantity(0.01).DoNormalized(context.Background())
+	s.Require().NoError(err)
+	s.Equal("FILLED", res.Status)
+	s.Equal("28000.00000000", res.Fills[0].Price)
+
+	_, err = s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("SELL").Type("LIMIT_MAKER").
+		Price(27000).Quantity(0.01).DoNormalized(context.Background())
+	s.Equal(&handlers.APIError{Code: -2010, Message: "Order would immediately match and take.", Status: 400}, err)
+
+	res, err = s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("SELL").Type("LIMIT").TimeInForce("IOC").
+		Price(29000).Quantity(0.01).DoNormalized(context.Background())
+	s.Require().NoError(err)
+	s.Equal("EXPIRED", res.Status)
+}
+
+func (s *exchangeTestSuite) TestCancelOrder() {
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("LIMIT").TimeInForce("GTC").
+		Price(25000).Quantity(0.01).DoNormalized(context.Background())
+	s.Require().NoError(err)
+	_, locked := s.exchange.Balance("USDT")
+	s.Equal(250.0, locked)
+
+	cancelled, err := s.client.NewCancelOrderService().Symbol("BTCUSDT").OrderId(res.OrderId).Do(context.Background())
+	s.Require().NoError(err)
+	s.Equal("CANCELED", cancelled.Status)
+	s.Equal(res.ClientOrderId, cancelled.OrigClientOrderId)
+	free, locked := s.exchange.Balance("USDT")
+	s.Equal(1000.0, free)
+	s.Zero(locked)
+
+	_, err = s.client.NewCancelOrderService().Symbol("BTCUSDT").OrderId(res.OrderId).Do(context.Background())
+	s.Equal(&handlers.APIError{Code: -2011, Message: "Unknown order sent.", Status: 400}, err)
+	_, err = s.client.NewGetOrderService().Symbol("BTCUSDT").OrderId(42).Do(context.Background())
+	s.Equal(&handlers.APIError{Code: -2013, Message: "Order does not exist.", Status: 400}, err)
+
+	s.exchange.SetPrice("BTCUSDT", 20000)
+	order, err := s.client.NewGetOrderService().Symbol("BTCUSDT").OrderId(res.OrderId).Do(context.Background())
+	s.Require().NoError(err)
+	s.Equal("CANCELED", order.Status, "a cancelled order is not filled")
+}
+
+func (s *exchangeTestSuite) TestRejections() {
+	order := func() *binance_connector.CreateOrderService {
+		return s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("LIMIT").TimeInForce("GTC")
+	}
+	for _, test := range []struct {
+		name string
+		send *binance_connector.CreateOrderService
+		code int64
+		msg  string
+	}{
+		{"insufficient balance", order().Price(25000).Quantity(1), -2010, "Account has insufficient balance for requested action."},
+		{"price off the tick", order().Price(25000.005).Quantity(0.01), -1013, "Filter failure: PRICE_FILTER"},
+		{"quantity off the step", order().Price(25000).Quantity(0.000015), -1013, "Filter failure: LOT_SIZE"},
+		{"notional below the minimum", order().Price(25000).Quantity(0.0001), -1013, "Filter failure: NOTIONAL"},
+		{"unknown symbol", order().Symbol("ETHUSDT").Price(2000).Quantity(0.1), -1121, "Invalid symbol."},
+		{"no time in force", s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("LIMIT").Price(25000).Quantity(0.01),
+			-1102, "Mandatory parameter 'timeInForce' was not sent, was empty/null, or malformed."},
+	} {
+		_, err := test.send.Do(context.Background())
+		s.Equal(&handlers.APIError{Code: test.code, Message: test.msg, Status: 400}, err, test.name)
+	}
+
+	s.exchange.RejectNextOrder(-1015, "Too many new orders.")
+	_, err := order().Price(25000).Quantity(0.01).Do(context.Background())
+	s.Equal(&handlers.APIError{Code: -1015, Message: "Too many new orders.", Status: 400}, err)
+	_, err = order().Price(25000).Quantity(0.01).Do(context.Background())
+	s.NoError(err, "a rejection applies to one order")
+
+	free, locked := s.exchange.Balance("USDT")
+	s.Equal(750.0, free, "the rejected orders lock no balance")
+	s.Equal(250.0, locked)
+}
+
+func (s *exchangeTestSuite) TestIdempotencyKey() {
+	send := func() (*binance_connector.CreateOrderResponse, error) {
+		return s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("LIMIT").TimeInForce("GTC").
+			Price(25000).Quantity(0.01).IdempotencyKey("key-1").DoNormalized(context.Background())
+	}
+	first, err := send()
+	s.Require().NoError(err)
+	second, err := send()
+	s.Require().NoError(err)
+	s.True(second.Duplicate())
+	s.Equal(first.OrderId, second.OrderId)
+
+	open, err := s.client.NewGetOpenOrdersService().Do(context.Background())
+	s.Require().NoError(err)
+	s.Len(open, 1)
+}
+
+func (s *exchangeTestSuite) TestExchangeInfo() {
+	info, err := s.client.NewExchangeInfoService().Do(context.Background())
+	s.Require().NoError(err)
+	s.Require().Len(info.Symbols, 1)
+	s.Equal("BTC", info.Symbols[0].BaseAsset)
+	s.Len(info.Symbols[0].Filters, 3)
+
+	// the client helpers relying on the filters work against the fake
+	size, err := s.client.QuoteToBaseQty(context.Background(), "BTCUSDT", 100, 30000)
+	s.Require().NoError(err)
+	s.Equal(0.00333, size.BaseQty)
+	s.client.CheckSymbolStatus = true
+	_, err = s.client.NewCreateOrderService().Symbol("ETHUSDT").Side("BUY").Type("MARKET").Quantity(1).Do(context.Background())
+	s.ErrorIs(err, binance_connector.ErrSymbolNotFound)
+}
+
+func (s *exchangeTestSuite) TestServer() {
+	server := httptest.NewServer(s.exchange)
+	defer server.Close()
+
+	client := binance_connector.NewClient("api-key", "secret-key", server.URL)
+	res, err := client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").
+		Quantity(0.001).NewOrderRespType("RESULT").Do(context.Background())
+	s.Require().NoError(err)
+	s.Equal("FILLED", res.(*binance_connector.CreateOrderResponseRESULT).Status)
+
+	_, err = binance_connector.NewPublicClient(server.URL).NewGetAccountService().Do(context.Background())
+	s.Error(err, "the signed endpoints require an API key")
+}
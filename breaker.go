@@ -0,0 +1,186 @@
+package binance_connector
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/luciano-personal-org/binance-connector/handlers"
+)
+
+var (
+	// DefaultAuthBreakerThreshold is the number of consecutive authentication failures opening the breaker of NewClient
+	DefaultAuthBreakerThreshold = 5
+	// DefaultAuthBreakerCooldown is how long the breaker of NewClient stays open
+	DefaultAuthBreakerCooldown = time.Minute
+)
+
+// ErrAuthBreakerOpen is matched by errors.Is on an AuthBreakerOpenError
+var ErrAuthBreakerOpen = errors.New("authentication circuit breaker open")
+
+// authFailureCodes are the error codes caused by the clock or the credentials, not by the request
+var authFailureCodes = map[int64]bool{
+	-1021: true, // Timestamp for this request is outside of the recvWindow
+	-1022: true, // Signature for this request is not valid
+	-2014: true, // API-key format invalid
+	-2015: true, // Invalid API-key, IP, or permissions for action
+}
+
+// AuthBreakerState define the state of an AuthBreaker
+type AuthBreakerState int
+
+const (
+	// AuthBreakerClosed lets every request through
+	AuthBreakerClosed AuthBreakerState = iota
+	// AuthBreakerOpen fails authenticated requests without sending them
+	AuthBreakerOpen
+	// AuthBreakerHalfOpen lets requests through after the cooldown, the first success closes the breaker
+	AuthBreakerHalfOpen
+)
+
+// String return the name of the state
+func (s AuthBreakerState) String() string {
+	switch s {
+	case AuthBreakerClosed:
+		return "CLOSED"
+	case AuthBreakerOpen:
+		return "OPEN"
+	case AuthBreakerHalfOpen:
+		return "HALF_OPEN"
+	}
+	return "UNKNOWN"
+}
+
+// AuthBreakerOpenError is returned instead of sending an authenticated request while the breaker is open
+type AuthBreakerOpenError struct {
+	// Until is the end of the cooldown
+	Until time.Time
+	// LastErr is the authentication failure that opened the breaker
+	LastErr error
+}
+
+func (e *AuthBreakerOpenError) Error() string {
+	return fmt.Sprintf("authenticated requests blocked until %s after repeated authentication failures (last: %v), check the system clock and the API credentials",
+		e.Until.Format(time.RFC3339), e.LastErr)
+}
+
+// Is return true for ErrAuthBreakerOpen
+func (e *AuthBreakerOpenError) Is(target error) bool {
+	return target == ErrAuthBreakerOpen
+}
+
+// AuthBreaker stops sending API key and signed requests after Threshold consecutive
+// authentication failures (-1021, -1022, -2014, -2015), for Cooldown. Once the cooldown
+// elapsed requests are sent again, the first success closes the breaker and another
+// authentication failure opens it for a new cooldown.
+//
+// The failures are counted by API key: the requests sent with the keys of another account, through WithCredentials
+// or ContextWithCredentials, are not blocked by a revoked key of the client.
+type AuthBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu sync.Mutex
+	// keys holds the failures of the API keys, a key is dropped on its first success
+	keys map[string]*authBreakerKey
+}
+
+// authBreakerKey holds the failures of one API key
+type authBreakerKey struct {
+	failures int
+	openedAt time.Time
+	lastErr  error
+}
+
+// NewAuthBreaker create a breaker opening after threshold consecutive authentication failures
+func NewAuthBreaker(threshold int, cooldown time.Duration) *AuthBreaker {
+	return &AuthBreaker{
+		Threshold: threshold,
+		Cooldown:  cooldown,
+	}
+}
+
+// authBreakerSeverity orders the states from the least to the most restrictive
+var authBreakerSeverity = map[AuthBreakerState]int{AuthBreakerClosed: 0, AuthBreakerHalfOpen: 1, AuthBreakerOpen: 2}
+
+// State return the state of the breaker for apiKey. Without an API key it is the most restrictive state among
+// the keys, OPEN as soon as the breaker is open for one of them.
+func (b *AuthBreaker) State(apiKey ...string) AuthBreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	if len(apiKey) > 0 {
+		return b.keys[apiKey[0]].state(now, b.Cooldown)
+	}
+	state := AuthBreakerClosed
+	for _, k := range b.keys {
+		if s := k.state(now, b.Cooldown); authBreakerSeverity[s] > authBreakerSeverity[state] {
+			state = s
+		}
+	}
+	return state
+}
+
+// Reset close the breaker for apiKey, or for every API key when none is given, e.g. after fixing the clock or
+// the credentials
+func (b *AuthBreaker) Reset(apiKey ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(apiKey) == 0 {
+		b.keys = nil
+		return
+	}
+	for _, key := range apiKey {
+		delete(b.keys, key)
+	}
+}
+
+func (k *authBreakerKey) state(now time.Time, cooldown time.Duration) AuthBreakerState {
+	if k == nil || k.openedAt.IsZero() {
+		return AuthBreakerClosed
+	}
+	if now.Before(k.openedAt.Add(cooldown)) {
+		return AuthBreakerOpen
+	}
+	return AuthBreakerHalfOpen
+}
+
+// allow return an AuthBreakerOpenError while the breaker is open for apiKey
+func (b *AuthBreaker) allow(apiKey string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	k := b.keys[apiKey]
+	if k.state(time.Now(), b.Cooldown) == AuthBreakerOpen {
+		return &AuthBreakerOpenError{Until: k.openedAt.Add(b.Cooldown), LastErr: k.lastErr}
+	}
+	return nil
+}
+
+// record count err against apiKey when it is an authentication failure, any other API response proves the
+// credentials work. Transport errors say nothing about the credentials and are ignored.
+func (b *AuthBreaker) record(apiKey string, err error) {
+	var apiErr *handlers.APIError
+	if err != nil && !errors.As(err, &apiErr) {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if apiErr == nil || !authFailureCodes[apiErr.Code] {
+		delete(b.keys, apiKey)
+		return
+	}
+	k := b.keys[apiKey]
+	if k == nil {
+		if b.keys == nil {
+			b.keys = make(map[string]*authBreakerKey)
+		}
+		k = &authBreakerKey{}
+		b.keys[apiKey] = k
+	}
+	k.failures++
+	k.lastErr = apiErr
+	if k.failures >= b.Threshold {
+		k.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,163 @@
+package binance_connector
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+type authBreakerTestSuite struct {
+	suite.Suite
+	client *Client
+	sent   int
+	reply  func() (*http.Response, error)
+}
+
+func TestAuthBreaker(t *testing.T) {
+	suite.Run(t, new(authBreakerTestSuite))
+}
+
+func (s *authBreakerTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.client.AuthBreaker = NewAuthBreaker(3, 50*time.Millisecond)
+	s.sent = 0
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.sent++
+		return s.reply()
+	}
+}
+
+func (s *authBreakerTestSuite) replyError(code int64) {
+	s.reply = func() (*http.Response, error) {
+		return newHTTPResponse([]byte(`{"code":`+strconv.FormatInt(code, 10)+`,"msg":"rejected"}`), http.StatusUnauthorized), nil
+	}
+}
+
+func (s *authBreakerTestSuite) replyOK() {
+	s.reply = func() (*http.Response, error) {
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+}
+
+func (s *authBreakerTestSuite) account() error {
+	_, err := s.client.NewGetAccountService().Do(newContext())
+	return err
+}
+
+func (s *authBreakerTestSuite) TestOpensAfterThreshold() {
+	s.replyError(-1022)
+	for i := 0; i < 3; i++ {
+		err := s.account()
+		var apiErr *handlers.APIError
+		s.Require().ErrorAs(err, &apiErr)
+		s.Equal(int64(-1022), apiErr.Code)
+	}
+	s.Equal(AuthBreakerOpen, s.client.AuthBreaker.State())
+
+	err := s.account()
+	s.Require().ErrorIs(err, ErrAuthBreakerOpen)
+	var openErr *AuthBreakerOpenError
+	s.Require().ErrorAs(err, &openErr)
+	s.Contains(openErr.LastErr.Error(), "code=-1022")
+	s.Contains(err.Error(), "check the system clock and the API credentials")
+	s.Equal(3, s.sent, "no request is sent while the breaker is open")
+
+	// public endpoints are not affected
+	s.replyOK()
+	s.Require().NoError(s.client.NewPingService().Do(newContext()))
+	s.Equal(4, s.sent)
+}
+
+func (s *authBreakerTestSuite) TestSuccessResetsCount() {
+	s.replyError(-2015)
+	s.Error(s.account())
+	s.Error(s.account())
+	s.replyOK()
+	s.NoError(s.account())
+	s.replyError(-2015)
+	s.Error(s.account())
+	s.Error(s.account())
+	s.Equal(AuthBreakerClosed, s.client.AuthBreaker.State())
+}
+
+func (s *authBreakerTestSuite) TestOtherErrorsDoNotCount() {
+	s.replyError(-2010)
+	for i := 0; i < 5; i++ {
+		s.Error(s.account())
+	}
+	s.reply = func() (*http.Response, error) {
+		return nil, errors.New("connection reset")
+	}
+	for i := 0; i < 5; i++ {
+		s.Error(s.account())
+	}
+	s.Equal(AuthBreakerClosed, s.client.AuthBreaker.State())
+}
+
+func (s *authBreakerTestSuite) TestHalfOpenAfterCooldown() {
+	s.replyError(-1021)
+	for i := 0; i < 3; i++ {
+		s.Error(s.account())
+	}
+	time.Sleep(60 * time.Millisecond)
+	s.Equal(AuthBreakerHalfOpen, s.client.AuthBreaker.State())
+
+	// a failure after the cooldown opens the breaker again right away
+	s.Error(s.account())
+	s.Equal(AuthBreakerOpen, s.client.AuthBreaker.State())
+	s.ErrorIs(s.account(), ErrAuthBreakerOpen)
+
+	time.Sleep(60 * time.Millisecond)
+	s.replyOK()
+	s.NoError(s.account())
+	s.Equal(AuthBreakerClosed, s.client.AuthBreaker.State())
+}
+
+func (s *authBreakerTestSuite) TestResetAndDisable() {
+	s.replyError(-1022)
+	for i := 0; i < 3; i++ {
+		s.Error(s.account())
+	}
+	s.client.AuthBreaker.Reset()
+	s.Equal(AuthBreakerClosed, s.client.AuthBreaker.State())
+
+	s.client.AuthBreaker = nil
+	for i := 0; i < 5; i++ {
+		s.NotErrorIs(s.account(), ErrAuthBreakerOpen)
+	}
+	s.Equal(8, s.sent)
+}
+
+func (s *authBreakerTestSuite) TestCountedByAPIKey() {
+	s.replyError(-2015)
+	for i := 0; i < 3; i++ {
+		s.Error(s.account())
+	}
+	s.ErrorIs(s.account(), ErrAuthBreakerOpen)
+	s.Equal(AuthBreakerOpen, s.client.AuthBreaker.State("dummyAPIKey"))
+	s.Equal(AuthBreakerOpen, s.client.AuthBreaker.State())
+
+	// the other accounts served by the client are not blocked by a revoked key
+	s.replyOK()
+	_, err := s.client.NewGetAccountService().Do(newContext(), WithCredentials("otherAPIKey", "otherSecretKey"))
+	s.NoError(err)
+	_, err = s.client.NewGetAccountService().Do(ContextWithCredentials(newContext(), "thirdAPIKey", "thirdSecretKey"))
+	s.NoError(err)
+	s.Equal(AuthBreakerClosed, s.client.AuthBreaker.State("otherAPIKey"))
+	s.Equal(5, s.sent)
+
+	s.replyError(-2015)
+	for i := 0; i < 2; i++ {
+		_, err = s.client.NewGetAccountService().Do(newContext(), WithCredentials("otherAPIKey", "otherSecretKey"))
+		s.NotErrorIs(err, ErrAuthBreakerOpen)
+	}
+	s.Equal(AuthBreakerClosed, s.client.AuthBreaker.State("otherAPIKey"), "the failures of the keys are not added up")
+
+	s.client.AuthBreaker.Reset("dummyAPIKey")
+	s.Equal(AuthBreakerClosed, s.client.AuthBreaker.State("dummyAPIKey"))
+}
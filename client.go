@@ -3,8 +3,8 @@ package binance_connector
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
+	"crypto/tls"
+	"errors"
 	"github.com/goccy/go-json"
 	"fmt"
 	"io"
@@ -12,6 +12,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bitly/go-simplejson"
@@ -33,7 +35,79 @@ type Client struct {
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
-	do         doFunc
+	// RecvWindow is the recvWindow of the signed requests sent without WithRecvWindow, in milliseconds.
+	// Zero leaves it to the Binance default of 5000.
+	RecvWindow int64
+	// Clock is the time source used for the timestamp of signed requests, time.Now when nil
+	Clock func() time.Time
+	// AuthBreaker blocks API key and signed requests after repeated authentication failures, nil disables it
+	AuthBreaker *AuthBreaker
+	// ExchangeInfoCache keeps the exchange information used by the symbol helpers, nil fetches it on every call
+	ExchangeInfoCache *ExchangeInfoCache
+	// MaxResponseSize is the largest response body read, in bytes. Zero means DefaultMaxResponseSize,
+	// a negative value disables the limit.
+	MaxResponseSize int64
+	// DebugSignature logs the payload of every signed request before it is signed, to chase -1022 errors.
+	// The signature and API key are redacted, like in the Debug logs.
+	DebugSignature bool
+	// SuppressDeprecationWarnings stops the warning logged the first time a deprecated endpoint is called
+	SuppressDeprecationWarnings bool
+	// StrictDecoding fails the decoding of responses carrying a field the response struct does not define, with a
+	// DecodeError holding the payload, to notice Binance schema changes during development. Off by default since
+	// Binance adds fields without notice.
+	StrictDecoding bool
+	// CheckSymbolStatus refuses orders on a symbol whose cached status is not TRADING, e.g. BREAK, HALT or
+	// AUCTION_MATCH, with a SymbolNotTradingError. CreateOrderService.CheckSymbolStatus overrides it per order.
+	CheckSymbolStatus bool
+	// CheckMaintenanceBeforeOrders queries the system status before every order and refuses it with a
+	// MaintenanceError during a system maintenance, at the cost of one more request per order
+	CheckMaintenanceBeforeOrders bool
+	// RateLimiter holds back the requests exceeding the REQUEST_WEIGHT and ORDERS rate limits, nil disables it
+	RateLimiter *RateLimiter
+	// DisableCompression stops requesting gzip or deflate compressed responses, e.g. behind a proxy
+	// mangling them. Compression is on by default and reduces the transfer of large responses such as
+	// exchangeInfo several times.
+	DisableCompression bool
+	// DisableSymbolNormalization sends the symbol, symbols and isolatedSymbol parameters as given instead of
+	// upper-casing them, for the rare endpoint expecting another casing
+	DisableSymbolNormalization bool
+	// OnRequest is called with the metrics of every REST request sent, nil disables it
+	OnRequest func(metrics *RequestMetrics)
+	// TraceRequests records the DNS, connect, TLS handshake and time to first byte of every request in the
+	// Trace of OnRequest metrics. Off by default to avoid the tracing overhead.
+	TraceRequests bool
+	// SBESchema requests the SBE encoded responses of the endpoints supporting them, only the order book for
+	// now, with this schema; nil, the default, keeps every response in JSON. Use &DefaultSBESchema unless
+	// pinning another version.
+	SBESchema *SBESchema
+	// DryRun builds, validates, signs and logs the signed requests without sending them: they fail with a
+	// DryRunError holding the request instead, so a dry run is never mistaken for an execution. Public
+	// requests are still sent.
+	DryRun bool
+	// Retry retries the failed idempotent requests, GET requests and orders sent with an IdempotencyKey; nil,
+	// the default, retries none. The rate limit errors are never retried, as the penalty box refuses every
+	// request until the end of the ban.
+	Retry *RetryPolicy
+	// FormatFloat renders the float64 parameters of the requests, such as price, quantity or stopPrice, before
+	// they are signed, e.g. to send the decimals logged by a risk system; nil keeps the default rendering,
+	// DefaultFloatFormatter or the fixed decimals of some order prices. A price sent with an explicit
+	// precision, e.g. CreateOrderService.PricePrecision, is not passed to it.
+	FormatFloat FloatFormatter
+	// KeyType is the type of SecretKey, which decides the signature algorithm. KeyTypeAuto, the default, tells an
+	// RSA or Ed25519 PEM private key from an HMAC secret; set it for a raw Ed25519 seed.
+	KeyType KeyType
+	do      doFunc
+	penalty *penaltyBox
+	// signer caches the signer of SecretKey
+	signer *signerCache
+	// usedWeight is the last X-MBX-USED-WEIGHT-1M received, shared with the clones
+	usedWeight *atomic.Int64
+	// deprecationsWarned holds the deprecated endpoints already warned about
+	deprecationsWarned sync.Map
+	// defaultTimeout is the timeout of requests sent without a deadline, see SetDefaultTimeout
+	defaultTimeout time.Duration
+	// endpointTimeouts holds the timeouts overriding defaultTimeout, keyed by "METHOD /path"
+	endpointTimeouts sync.Map
 }
 
 type doFunc func(req *http.Request) (*http.Response, error)
@@ -45,7 +119,10 @@ const (
 	recvWindowKey = "recvWindow"
 )
 
-func currentTimestamp() int64 {
+func (c *Client) currentTimestamp() int64 {
+	if c.Clock != nil {
+		return FormatTimestamp(c.Clock())
+	}
 	return FormatTimestamp(time.Now())
 }
 
@@ -59,27 +136,98 @@ func PrettyPrint(i interface{}) string {
 	return string(s)
 }
 
-func (c *Client) debug(format string, v ...interface{}) {
+// debug log a line about r, prefixed with its request ID, when Debug is set
+func (c *Client) debug(r *request, format string, v ...interface{}) {
 	if c.Debug {
-		c.Logger.Printf(format, v...)
+		c.Logger.Printf(r.logPrefix()+format, v...)
 	}
 }
 
 // Create client function for initialising new Binance client
 func NewClient(apiKey string, secretKey string, baseURL ...string) *Client {
-	url := "https://api.binance.com"
+	url := EnvironmentProd.URLs().REST
 
 	if len(baseURL) > 0 {
 		url = baseURL[0]
 	}
 
-	return &Client{
-		APIKey:     apiKey,
-		SecretKey:  secretKey,
-		BaseURL:    url,
-		HTTPClient: http.DefaultClient,
-		Logger:     log.New(os.Stderr, Name, log.LstdFlags),
+	c := &Client{
+		APIKey:      apiKey,
+		SecretKey:   secretKey,
+		BaseURL:     url,
+		HTTPClient:  http.DefaultClient,
+		Logger:      log.New(os.Stderr, Name, log.LstdFlags),
+		AuthBreaker: NewAuthBreaker(DefaultAuthBreakerThreshold, DefaultAuthBreakerCooldown),
+		penalty:     &penaltyBox{},
+		usedWeight:  new(atomic.Int64),
+		signer:      &signerCache{},
+	}
+	c.ExchangeInfoCache = NewExchangeInfoCache(c, DefaultExchangeInfoTTL)
+	return c
+}
+
+// NewClientWithKey create a client signing its requests with secretKey, an HMAC secret or an RSA or Ed25519
+// private key. KeyTypeAuto detects the type from the key; a raw Ed25519 seed cannot be told apart from an HMAC
+// secret and needs KeyTypeEd25519. An ErrInvalidSecretKey is returned when the key cannot be parsed as keyType.
+func NewClientWithKey(apiKey, secretKey string, keyType KeyType, baseURL ...string) (*Client, error) {
+	c := NewClient(apiKey, secretKey, baseURL...)
+	c.KeyType = keyType
+	if _, err := c.signer.get(secretKey, keyType); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SetTLSConfig replace HTTPClient by a client using tlsConfig, e.g. a custom CA pool behind a TLS
+// inspecting proxy or a minimum TLS version. The timeout of the current HTTPClient is kept, and its
+// transport settings when it is an *http.Transport.
+// Setting InsecureSkipVerify disables the verification of the server certificate and exposes the
+// API key and signed requests to interception, only use it against a local test server.
+func (c *Client) SetTLSConfig(tlsConfig *tls.Config) *Client {
+	httpClient := &http.Client{}
+	if c.HTTPClient != nil {
+		*httpClient = *c.HTTPClient
+	}
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok || transport == nil {
+		transport = http.DefaultTransport.(*http.Transport)
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = tlsConfig
+	httpClient.Transport = transport
+	c.HTTPClient = httpClient
+	return c
+}
+
+// NewPublicClient create a client without credentials, for market data endpoints.
+// Services that require an API key or a signature fail with a CredentialsRequiredError
+// instead of being sent, unless credentials are set with WithCredentials or ContextWithCredentials.
+func NewPublicClient(baseURL ...string) *Client {
+	return NewClient("", "", baseURL...)
+}
+
+// ErrCredentialsRequired is matched by errors.Is on a CredentialsRequiredError
+var ErrCredentialsRequired = errors.New("API credentials required")
+
+// CredentialsRequiredError is returned when a service needing an API key or a signature is called without credentials
+type CredentialsRequiredError struct {
+	// Service is the name of the service, e.g. GetAccountService, empty for a Client.Request
+	Service  string
+	Method   string
+	Endpoint string
+}
+
+func (e *CredentialsRequiredError) Error() string {
+	service := e.Service
+	if service == "" {
+		service = e.Method + " " + e.Endpoint
 	}
+	return fmt.Sprintf("%s requires API credentials (%s %s) but the client has none, create it with NewClient or use WithCredentials", service, e.Method, e.Endpoint)
+}
+
+// Is return true for ErrCredentialsRequired
+func (e *CredentialsRequiredError) Is(target error) bool {
+	return target == ErrCredentialsRequired
 }
 
 func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
@@ -91,13 +239,21 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	if err != nil {
 		return err
 	}
+	if !c.DisableSymbolNormalization {
+		r.normalizeSymbols()
+	}
+	if c.FormatFloat != nil {
+		r.formatFloats(c.FormatFloat)
+	}
 
 	fullURL := fmt.Sprintf("%s%s", c.BaseURL, r.endpoint)
 	if r.recvWindow > 0 {
 		r.setParam(recvWindowKey, r.recvWindow)
+	} else if c.RecvWindow > 0 && r.secType.Signed() {
+		r.setParam(recvWindowKey, c.RecvWindow)
 	}
-	if r.secType == secTypeSigned {
-		r.setParam(timestampKey, currentTimestamp()-c.TimeOffset)
+	if r.secType.Signed() {
+		r.setParam(timestampKey, c.currentTimestamp()-c.TimeOffset)
 	}
 	queryString := r.query.Encode()
 	body := &bytes.Buffer{}
@@ -106,24 +262,47 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	if r.header != nil {
 		header = r.header.Clone()
 	}
-	header.Set("User-Agent", fmt.Sprintf("%s/%s", Name, Version))
+	header.Set("User-Agent", UserAgent())
+	if header.Get("Accept-Encoding") == "" {
+		if c.DisableCompression {
+			header.Set("Accept-Encoding", "identity")
+		} else {
+			header.Set("Accept-Encoding", acceptEncoding)
+		}
+	}
 	if bodyString != "" {
 		header.Set("Content-Type", "application/x-www-form-urlencoded")
 		body = bytes.NewBufferString(bodyString)
 	}
-	if r.secType == secTypeAPIKey || r.secType == secTypeSigned {
-		header.Set("X-MBX-APIKEY", c.APIKey)
+	apiKey, secretKey := c.APIKey, c.SecretKey
+	if r.apiKey != "" {
+		apiKey, secretKey = r.apiKey, r.secretKey
+	}
+	if (r.secType.RequiresAPIKey() && apiKey == "") || (r.secType.Signed() && secretKey == "") {
+		return &CredentialsRequiredError{Service: r.service, Method: r.method, Endpoint: r.endpoint}
+	}
+	if r.secType.RequiresAPIKey() {
+		header.Set("X-MBX-APIKEY", apiKey)
 	}
 
-	if r.secType == secTypeSigned {
+	if r.secType.Signed() {
 		raw := fmt.Sprintf("%s%s", queryString, bodyString)
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
-		_, err = mac.Write([]byte(raw))
+		c.debugSignature(r, raw)
+		// the keys of WithCredentials and ContextWithCredentials may be of another type than the client ones
+		keyType, signers := c.KeyType, c.signer
+		if r.apiKey != "" {
+			keyType, signers = KeyTypeAuto, r.signer
+		}
+		s, err := signers.get(secretKey, keyType)
+		if err != nil {
+			return err
+		}
+		signature, err := s.sign(raw)
 		if err != nil {
 			return err
 		}
 		v := url.Values{}
-		v.Set(signatureKey, fmt.Sprintf("%x", (mac.Sum(nil))))
+		v.Set(signatureKey, signature)
 		if queryString == "" {
 			queryString = v.Encode()
 		} else {
@@ -133,7 +312,7 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	if queryString != "" {
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
+	c.debug(r, "full url: %s, body: %s", redactURL(fullURL), redactQuery(bodyString))
 	r.fullURL = fullURL
 	r.header = header
 	r.body = body
@@ -141,9 +320,60 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 }
 
 func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, err error) {
+	for r.attempt = 1; ; r.attempt++ {
+		r.response = nil
+		data, err = c.send(ctx, r, opts...)
+		delay, retry := c.retryDelay(r, err)
+		if !retry {
+			return data, err
+		}
+		c.debug(r, "retrying attempt %d in %s: %s", r.attempt, delay, err)
+		if sleepContext(ctx, delay) != nil {
+			return data, err
+		}
+	}
+}
+
+// send make one attempt of the request
+func (c *Client) send(ctx context.Context, r *request, opts ...RequestOption) (data []byte, err error) {
+	if r.requestID == "" {
+		r.requestID = requestID(ctx)
+	}
+	if creds, ok := ctx.Value(credentialsContextKey{}).(credentials); ok {
+		opts = append([]RequestOption{creds.option()}, opts...)
+	}
 	err = c.parseRequest(r, opts...)
-	if r.endpoint != "/api/v3/order/cancelReplace" {
-		if err != nil {
+	if err != nil {
+		return []byte{}, err
+	}
+	c.warnDeprecated(r)
+	if err := c.dryRun(r); err != nil {
+		return []byte{}, err
+	}
+	ctx, cancel := c.withRequestTimeout(ctx, r)
+	defer cancel()
+	if err := c.penalty.check(); err != nil {
+		return []byte{}, err
+	}
+	limiter := c.RateLimiter
+	if limiter != nil {
+		if weight, orders := r.rateLimitCost(); weight > 0 || orders > 0 {
+			if err := limiter.wait(ctx, weight, orders); err != nil {
+				return []byte{}, err
+			}
+		}
+	}
+	breaker := c.AuthBreaker
+	if !r.secType.RequiresAPIKey() {
+		breaker = nil
+	}
+	// the failures are counted against the API key the request is sent with
+	apiKey := c.APIKey
+	if r.apiKey != "" {
+		apiKey = r.apiKey
+	}
+	if breaker != nil {
+		if err := breaker.allow(apiKey); err != nil {
 			return []byte{}, err
 		}
 	}
@@ -151,18 +381,34 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	if err != nil {
 		return []byte{}, err
 	}
+	var tracer *requestTracer
+	if c.OnRequest != nil && c.TraceRequests {
+		tracer = &requestTracer{}
+		ctx = tracer.withTrace(ctx)
+	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
-	c.debug("request: %#v", req)
+	c.debug(r, "request: %s %s, header: %v", req.Method, redactURL(r.fullURL), redactHeader(req.Header))
 	f := c.do
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
-	res, err := f(req)
-	if err != nil {
-		return []byte{}, err
+	var transferSize int64
+	if c.OnRequest != nil {
+		start := time.Now()
+		defer func() {
+			metrics := &RequestMetrics{RequestID: r.requestID, Attempt: r.attempt, Method: r.method, Endpoint: r.endpoint, Duration: time.Since(start), Err: err, TransferSize: transferSize}
+			if r.response != nil {
+				metrics.StatusCode = r.response.StatusCode
+				metrics.ResponseSize = int64(len(r.response.Body))
+			}
+			if tracer != nil {
+				metrics.Trace = tracer.result()
+			}
+			c.OnRequest(metrics)
+		}()
 	}
-	data, err = io.ReadAll(res.Body)
+	res, err := f(req)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -174,19 +420,78 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 			err = cerr
 		}
 	}()
-	c.debug("response: %#v", res)
-	c.debug("response body: %s", string(data))
-	c.debug("response status code: %d", res.StatusCode)
-
-	if res.StatusCode >= http.StatusBadRequest {
-		apiErr := new(handlers.APIError)
-		e := json.Unmarshal(data, apiErr)
+	wire := &countingReader{r: res.Body}
+	body, err := decodeBody(wire, res.Header.Get("Content-Encoding"))
+	if err != nil {
+		return []byte{}, err
+	}
+	// the limit applies to the decompressed body
+	data, err = readResponseBody(body, c.MaxResponseSize)
+	transferSize = wire.n
+	if err != nil {
+		return []byte{}, err
+	}
+	r.response = &RawResponse{StatusCode: res.StatusCode, Header: res.Header, Body: data}
+	if limiter != nil {
+		limiter.update(res.Header)
+	}
+	c.recordUsedWeight(res.Header)
+	c.debug(r, "response: %#v", res)
+	c.debug(r, "response body: %s", string(data))
+	c.debug(r, "response status code: %d", res.StatusCode)
+
+	if res.StatusCode >= http.StatusBadRequest || isRateLimitError(r, data) {
+		apiErr := &handlers.APIError{Status: res.StatusCode}
+		var e error
+		if isSBEResponse(r) && c.SBESchema != nil {
+			e = decodeSBEError(data, *c.SBESchema, apiErr)
+		} else {
+			e = json.Unmarshal(data, apiErr)
+		}
 		if e != nil {
-			c.debug("failed to unmarshal json: %s", e)
+			c.debug(r, "failed to unmarshal json: %s", e)
+		}
+		if breaker != nil {
+			breaker.record(apiKey, apiErr)
+		}
+		if until, ok := rateLimitPenalty(res.StatusCode, apiErr, parseRetryAfter(res.Header), time.Now()); ok {
+			c.penalty.penalize(until, apiErr)
+			return nil, apiErr
+		}
+		if err := maintenanceError(res.StatusCode, apiErr); err != nil {
+			return nil, err
 		}
 		if r.endpoint != "/api/v3/order/cancelReplace" {
 			return nil, apiErr
 		}
+		return data, nil
+	}
+	if breaker != nil {
+		breaker.record(apiKey, nil)
+	}
+	return data, nil
+}
+
+// DefaultMaxResponseSize is the largest response body read by default, well above the size of a full exchangeInfo
+const DefaultMaxResponseSize int64 = 32 << 20
+
+// ErrResponseTooLarge is returned when a response body is larger than Client.MaxResponseSize
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// readResponseBody read body up to limit bytes, DefaultMaxResponseSize when limit is zero
+func readResponseBody(body io.Reader, limit int64) ([]byte, error) {
+	if limit < 0 {
+		return io.ReadAll(body)
+	}
+	if limit == 0 {
+		limit = DefaultMaxResponseSize
+	}
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, limit)
 	}
 	return data, nil
 }
@@ -252,6 +557,14 @@ func (c *Client) NewQueryOpenOCOService() *QueryOpenOCOService {
 	return &QueryOpenOCOService{c: c}
 }
 
+func (c *Client) NewQueryOrderListService() *QueryOrderListService {
+	return &QueryOrderListService{c: c}
+}
+
+func (c *Client) NewAllOrderListService() *AllOrderListService {
+	return &AllOrderListService{c: c}
+}
+
 func (c *Client) NewGetAccountService() *GetAccountService {
 	return &GetAccountService{c: c}
 }
@@ -297,6 +610,11 @@ func (c *Client) NewAggTradesListService() *AggTradesList {
 	return &AggTradesList{c: c}
 }
 
+// NewAggTradesService create a service of the aggregate trades of a symbol, same as NewAggTradesListService
+func (c *Client) NewAggTradesService() *AggTradesList {
+	return &AggTradesList{c: c}
+}
+
 func (c *Client) NewKlinesService() *Klines {
 	return &Klines{c: c}
 }
@@ -309,6 +627,8 @@ func (c *Client) NewAvgPriceService() *AvgPrice {
 	return &AvgPrice{c: c}
 }
 
+// NewTicker24hrService create the service of GET /api/v3/ticker/24hr, the statistics of the last 24 hours for
+// one symbol, a list of symbols or all of them. NewTickerService is the rolling window ticker.
 func (c *Client) NewTicker24hrService() *Ticker24hr {
 	return &Ticker24hr{c: c}
 }
@@ -321,6 +641,8 @@ func (c *Client) NewTickerBookTickerService() *TickerBookTicker {
 	return &TickerBookTicker{c: c}
 }
 
+// NewTickerService create the service of GET /api/v3/ticker, the statistics of a rolling window of up to 7 days.
+// NewTicker24hrService is the classic 24 hours ticker.
 func (c *Client) NewTickerService() *Ticker {
 	return &Ticker{c: c}
 }
@@ -353,6 +675,10 @@ func (c *Client) NewCrossMarginTransferHistoryService() *CrossMarginTransferHist
 	return &CrossMarginTransferHistoryService{c: c}
 }
 
+func (c *Client) NewIsolatedMarginTransferHistoryService() *IsolatedMarginTransferHistoryService {
+	return &IsolatedMarginTransferHistoryService{c: c}
+}
+
 func (c *Client) NewInterestHistoryService() *InterestHistoryService {
 	return &InterestHistoryService{c: c}
 }
@@ -453,6 +779,14 @@ func (c *Client) NewMarginIsolatedMarginFeeService() *MarginIsolatedMarginFeeSer
 	return &MarginIsolatedMarginFeeService{c: c}
 }
 
+func (c *Client) NewMarginCrossMarginDataService() *MarginCrossMarginDataService {
+	return &MarginCrossMarginDataService{c: c}
+}
+
+func (c *Client) NewMarginIsolatedMarginDataService() *MarginIsolatedMarginDataService {
+	return &MarginIsolatedMarginDataService{c: c}
+}
+
 func (c *Client) NewMarginIsolatedMarginTierService() *MarginIsolatedMarginTierService {
 	return &MarginIsolatedMarginTierService{c: c}
 }
@@ -755,6 +1089,11 @@ func (c *Client) NewAutoConvertStableCoinService() *AutoConvertStableCoinService
 	return &AutoConvertStableCoinService{c: c}
 }
 
+// Convert Endpoints:
+func (c *Client) NewConvertOrderStatusService() *ConvertOrderStatusService {
+	return &ConvertOrderStatusService{c: c}
+}
+
 // User Data Streams:
 func (c *Client) NewCreateListenKeyService() *CreateListenKey {
 	return &CreateListenKey{c: c}
@@ -768,6 +1107,38 @@ func (c *Client) NewCloseUserStream() *CloseUserStream {
 	return &CloseUserStream{c: c}
 }
 
+func (c *Client) NewCreateUserStreamService() *CreateUserStreamService {
+	return &CreateUserStreamService{c: c}
+}
+
+func (c *Client) NewKeepaliveUserStreamService() *KeepaliveUserStreamService {
+	return &KeepaliveUserStreamService{c: c}
+}
+
+func (c *Client) NewCreateMarginUserStreamService() *CreateMarginUserStreamService {
+	return &CreateMarginUserStreamService{c: c}
+}
+
+func (c *Client) NewKeepaliveMarginUserStreamService() *KeepaliveMarginUserStreamService {
+	return &KeepaliveMarginUserStreamService{c: c}
+}
+
+func (c *Client) NewCloseMarginUserStreamService() *CloseMarginUserStreamService {
+	return &CloseMarginUserStreamService{c: c}
+}
+
+func (c *Client) NewCreateIsolatedMarginUserStreamService() *CreateIsolatedMarginUserStreamService {
+	return &CreateIsolatedMarginUserStreamService{c: c}
+}
+
+func (c *Client) NewKeepaliveIsolatedMarginUserStreamService() *KeepaliveIsolatedMarginUserStreamService {
+	return &KeepaliveIsolatedMarginUserStreamService{c: c}
+}
+
+func (c *Client) NewCloseIsolatedMarginUserStreamService() *CloseIsolatedMarginUserStreamService {
+	return &CloseIsolatedMarginUserStreamService{c: c}
+}
+
 func (c *Client) NewGetFiatDepositWithdrawHistoryService() *GetFiatDepositWithdrawHistoryService {
 	return &GetFiatDepositWithdrawHistoryService{c: c}
 }
@@ -3,8 +3,11 @@ package binance_connector
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
@@ -127,6 +130,9 @@ func newMockedClient(apiKey, secretKey, baseURL string) *mockedClient {
 func (m *mockedClient) do(req *http.Request) (*http.Response, error) {
 	if m.assertReq != nil {
 		r := newRequest()
+		r.method = req.Method
+		r.endpoint = req.URL.Path
+		r.header = req.Header
 		r.query = req.URL.Query()
 		if req.Body != nil {
 			bs := make([]byte, req.ContentLength)
@@ -152,3 +158,224 @@ func TestFormatTimestamp(t *testing.T) {
 	tm, _ := time.Parse("2006-01-02 15:04:05", "2018-06-01 01:01:01")
 	assert.Equal(t, int64(1527814861000), FormatTimestamp(tm))
 }
+
+type clientTestSuite struct {
+	suite.Suite
+	client *Client
+	sent   *http.Request
+	reply  []byte
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(clientTestSuite))
+}
+
+func (s *clientTestSuite) SetupTest() {
+	s.sent = nil
+	s.reply = []byte(`{}`)
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.recordRequests(s.client)
+}
+
+// recordRequests keeps the last request sent by c in s.sent, answered by s.reply
+func (s *clientTestSuite) recordRequests(c *Client) {
+	c.do = func(req *http.Request) (*http.Response, error) {
+		s.sent = req
+		return newHTTPResponse(s.reply, http.StatusOK), nil
+	}
+}
+
+func (s *clientTestSuite) TestSignedRequestWithFrozenClock() {
+	tm, _ := time.Parse("2006-01-02 15:04:05", "2018-06-01 01:01:01")
+	s.client.Clock = func() time.Time { return tm }
+	s.client.TimeOffset = 1000
+
+	r := &request{method: http.MethodGet, endpoint: "/api/v3/order", secType: SecurityTypeUserData}
+	r.setParam("symbol", "BNBUSDT")
+	s.Require().NoError(s.client.parseRequest(r))
+
+	s.Equal("https://dummyapi.com/api/v3/order?symbol=BNBUSDT&timestamp=1527814860000"+
+		"&signature=60b8fb1b051671c50ae7d4d8d496932334d88f6a1ee9c9c086cff72ed0d42554", r.fullURL)
+}
+
+func (s *clientTestSuite) TestRequestCredentialsOverride() {
+	tm, _ := time.Parse("2006-01-02 15:04:05", "2018-06-01 01:01:01")
+	c := s.client
+	c.Clock = func() time.Time { return tm }
+	c.TimeOffset = 1000
+
+	newSignedOrderRequest := func() *request {
+		r := &request{method: http.MethodGet, endpoint: "/api/v3/order", secType: SecurityTypeUserData}
+		return r.setParam("symbol", "BNBUSDT")
+	}
+
+	_, err := c.callAPI(newContext(), newSignedOrderRequest(), WithCredentials("otherAPIKey", "otherSecretKey"))
+	s.Require().NoError(err)
+	s.Equal("otherAPIKey", s.sent.Header.Get("X-MBX-APIKEY"))
+	s.Equal("ea02665237b48c618b6661374110eab22a2d0f5a70f504d2a3f7da4fa684dacf", s.sent.URL.Query().Get(signatureKey))
+
+	ctx := ContextWithCredentials(newContext(), "ctxAPIKey", "ctxSecretKey")
+	_, err = c.callAPI(ctx, newSignedOrderRequest())
+	s.Require().NoError(err)
+	s.Equal("ctxAPIKey", s.sent.Header.Get("X-MBX-APIKEY"))
+	s.Equal("fd8fa08d5d82cb92d05564fa996371fae302f41e09bdb2037a0ce7344a649811", s.sent.URL.Query().Get(signatureKey))
+
+	// the request option wins over the context
+	_, err = c.callAPI(ctx, newSignedOrderRequest(), WithCredentials("otherAPIKey", "otherSecretKey"))
+	s.Require().NoError(err)
+	s.Equal("otherAPIKey", s.sent.Header.Get("X-MBX-APIKEY"))
+
+	_, err = c.callAPI(newContext(), newSignedOrderRequest())
+	s.Require().NoError(err)
+	s.Equal("dummyAPIKey", s.sent.Header.Get("X-MBX-APIKEY"))
+	s.Equal("60b8fb1b051671c50ae7d4d8d496932334d88f6a1ee9c9c086cff72ed0d42554", s.sent.URL.Query().Get(signatureKey))
+}
+
+func (s *clientTestSuite) TestPublicClient() {
+	c := NewPublicClient("https://dummyapi.com")
+	s.Equal("https://dummyapi.com", c.BaseURL)
+	s.recordRequests(c)
+
+	err := c.NewPingService().Do(newContext())
+	s.Require().NoError(err)
+	s.Require().NotNil(s.sent)
+	s.Empty(s.sent.Header.Get("X-MBX-APIKEY"))
+
+	s.sent = nil
+	_, err = c.NewGetAccountService().Do(newContext())
+	s.Require().ErrorIs(err, ErrCredentialsRequired)
+	var credErr *CredentialsRequiredError
+	s.Require().ErrorAs(err, &credErr)
+	s.Equal("GetAccountService", credErr.Service)
+	s.Equal("/api/v3/account", credErr.Endpoint)
+	s.Contains(err.Error(), "GetAccountService requires API credentials")
+	s.Nil(s.sent, "a request needing credentials must not be sent")
+
+	_, err = c.NewCreateUserStreamService().Do(newContext())
+	s.Require().ErrorAs(err, &credErr)
+	s.Equal("CreateUserStreamService", credErr.Service)
+
+	// cancelReplace returns its rejections as a response, not the errors raised before sending
+	_, err = c.NewCancelReplaceService().Symbol("BTCUSDT").Side("BUY").OrderType("LIMIT").
+		CancelReplaceMode("STOP_ON_FAILURE").CancelOrderId(1).Do(newContext())
+	s.Require().ErrorAs(err, &credErr)
+	s.Equal("CancelReplaceService", credErr.Service)
+	s.Nil(s.sent)
+
+	// a request without a service is named by its endpoint
+	_, err = c.Request(newContext(), http.MethodGet, "/api/v3/account", nil, SecurityTypeUserData)
+	s.Require().ErrorAs(err, &credErr)
+	s.Empty(credErr.Service)
+	s.Contains(err.Error(), "GET /api/v3/account requires API credentials")
+
+	// per-request credentials are accepted on a public client
+	_, err = c.NewGetAccountService().Do(newContext(), WithCredentials("dummyAPIKey", "dummySecretKey"))
+	s.Require().NoError(err)
+	s.Equal("dummyAPIKey", s.sent.Header.Get("X-MBX-APIKEY"))
+}
+
+func (s *clientTestSuite) TestSetTLSConfig() {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// the test server certificate is not trusted by default
+	c := NewPublicClient(server.URL)
+	err := c.NewPingService().Do(newContext())
+	var unknownAuthority x509.UnknownAuthorityError
+	s.Require().ErrorAs(err, &unknownAuthority)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	c.HTTPClient = &http.Client{Timeout: 5 * time.Second}
+	c.SetTLSConfig(&tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12})
+	s.Require().NoError(c.NewPingService().Do(newContext()))
+	s.Equal(5*time.Second, c.HTTPClient.Timeout)
+	s.NotSame(http.DefaultTransport, c.HTTPClient.Transport, "the default transport must not be modified")
+	if defaultTLS := http.DefaultTransport.(*http.Transport).TLSClientConfig; defaultTLS != nil {
+		s.Nil(defaultTLS.RootCAs)
+	}
+
+	insecure := NewPublicClient(server.URL).SetTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	s.Require().NoError(insecure.NewPingService().Do(newContext()))
+}
+
+func (s *clientTestSuite) TestMaxResponseSize() {
+	c := NewPublicClient("https://dummyapi.com")
+	body := bytes.Repeat([]byte(" "), 64)
+	closed := false
+	c.do = func(req *http.Request) (*http.Response, error) {
+		res := newHTTPResponse(append([]byte(`{}`), body...), http.StatusOK)
+		res.Body = closeNotifier{res.Body, &closed}
+		return res, nil
+	}
+
+	c.MaxResponseSize = 32
+	err := c.NewPingService().Do(newContext())
+	s.Require().ErrorIs(err, ErrResponseTooLarge)
+	s.Contains(err.Error(), "more than 32 bytes")
+	s.True(closed, "the body must be closed when it is too large")
+
+	c.MaxResponseSize = 66
+	s.NoError(c.NewPingService().Do(newContext()))
+
+	c.MaxResponseSize = -1
+	s.NoError(c.NewPingService().Do(newContext()))
+
+	c.MaxResponseSize = 0
+	s.NoError(c.NewPingService().Do(newContext()))
+	_, err = readResponseBody(bytes.NewReader(make([]byte, DefaultMaxResponseSize+1)), 0)
+	s.ErrorIs(err, ErrResponseTooLarge)
+}
+
+type closeNotifier struct {
+	io.ReadCloser
+	closed *bool
+}
+
+func (c closeNotifier) Close() error {
+	*c.closed = true
+	return c.ReadCloser.Close()
+}
+
+func (s *clientTestSuite) TestSecurityTypes() {
+	c := s.client
+	for _, tt := range []struct {
+		secType SecurityType
+		name    string
+		apiKey  bool
+		signed  bool
+	}{
+		{SecurityTypeNone, "NONE", false, false},
+		{SecurityTypeMarketData, "MARKET_DATA", true, false},
+		{SecurityTypeUserStream, "USER_STREAM", true, false},
+		{SecurityTypeTrade, "TRADE", true, true},
+		{SecurityTypeMargin, "MARGIN", true, true},
+		{SecurityTypeUserData, "USER_DATA", true, true},
+	} {
+		s.Equal(tt.name, tt.secType.String())
+		s.Equal(tt.apiKey, tt.secType.RequiresAPIKey(), tt.name)
+		s.Equal(tt.signed, tt.secType.Signed(), tt.name)
+
+		_, err := c.callAPI(newContext(), &request{method: http.MethodGet, endpoint: "/api/v3/test", secType: tt.secType})
+		s.Require().NoError(err)
+		s.Equal(tt.apiKey, s.sent.Header.Get("X-MBX-APIKEY") != "", tt.name)
+		s.Equal(tt.signed, s.sent.URL.Query().Has(signatureKey), tt.name)
+		s.Equal(tt.signed, s.sent.URL.Query().Has(timestampKey), tt.name)
+	}
+
+	// key-only endpoints work with an API key and no secret
+	c.SecretKey = ""
+	s.reply = []byte(`[]`)
+	_, err := c.NewHistoricalTradeLookupService().Symbol("BTCUSDT").Do(newContext())
+	s.Require().NoError(err)
+	s.Equal("dummyAPIKey", s.sent.Header.Get("X-MBX-APIKEY"))
+	s.False(s.sent.URL.Query().Has(signatureKey))
+	s.reply = []byte(`{"listenKey": "key"}`)
+	_, err = c.NewCreateUserStreamService().Do(newContext())
+	s.Require().NoError(err)
+	s.False(s.sent.URL.Query().Has(signatureKey))
+	_, err = c.NewGetAccountService().Do(newContext())
+	s.ErrorIs(err, ErrCredentialsRequired)
+}
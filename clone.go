@@ -0,0 +1,85 @@
+package binance_connector
+
+import (
+	"time"
+)
+
+// Clone return a shallow copy of the client, to change a setting for one subsystem without building a new client.
+//
+// The copy shares with the client the HTTPClient and its transport, the Logger, the RateLimiter, the
+// AuthBreaker, the ExchangeInfoCache, the rate limit ban penalty and the LastUsedWeight, so both stay within the
+// same limits.
+// Every other setting is copied, including the timeouts of SetDefaultTimeout and SetEndpointTimeout: changing
+// a field or a timeout of the copy does not change the client. Deprecation warnings are logged again once by the copy.
+func (c *Client) Clone() *Client {
+	clone := &Client{
+		APIKey:                       c.APIKey,
+		SecretKey:                    c.SecretKey,
+		BaseURL:                      c.BaseURL,
+		HTTPClient:                   c.HTTPClient,
+		Debug:                        c.Debug,
+		Logger:                       c.Logger,
+		TimeOffset:                   c.TimeOffset,
+		RecvWindow:                   c.RecvWindow,
+		Clock:                        c.Clock,
+		AuthBreaker:                  c.AuthBreaker,
+		ExchangeInfoCache:            c.ExchangeInfoCache,
+		MaxResponseSize:              c.MaxResponseSize,
+		DebugSignature:               c.DebugSignature,
+		SuppressDeprecationWarnings:  c.SuppressDeprecationWarnings,
+		StrictDecoding:               c.StrictDecoding,
+		CheckSymbolStatus:            c.CheckSymbolStatus,
+		CheckMaintenanceBeforeOrders: c.CheckMaintenanceBeforeOrders,
+		RateLimiter:                  c.RateLimiter,
+		DisableCompression:           c.DisableCompression,
+		DisableSymbolNormalization:   c.DisableSymbolNormalization,
+		OnRequest:                    c.OnRequest,
+		TraceRequests:                c.TraceRequests,
+		SBESchema:                    c.SBESchema,
+		DryRun:                       c.DryRun,
+		Retry:                        c.Retry,
+		FormatFloat:                  c.FormatFloat,
+		KeyType:                      c.KeyType,
+		do:                           c.do,
+		penalty:                      c.penalty,
+		usedWeight:                   c.usedWeight,
+		signer:                       &signerCache{},
+		defaultTimeout:               c.defaultTimeout,
+	}
+	c.endpointTimeouts.Range(func(key, value any) bool {
+		clone.endpointTimeouts.Store(key, value.(time.Duration))
+		return true
+	})
+	return clone
+}
+
+// WithBaseURL return a clone of the client sending its requests to baseURL, e.g. another Binance API cluster.
+// The clone shares the rate limiter and the exchange information of the client: use NewClient for another
+// environment such as the testnet.
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	clone := c.Clone()
+	clone.BaseURL = baseURL
+	return clone
+}
+
+// WithRecvWindow return a clone of the client sending its signed requests with recvWindow, in milliseconds.
+// A WithRecvWindow request option still takes precedence.
+func (c *Client) WithRecvWindow(recvWindow int64) *Client {
+	clone := c.Clone()
+	clone.RecvWindow = recvWindow
+	return clone
+}
+
+// WithCredentials return a clone of the client using the keys of another account. The clone has its own
+// AuthBreaker, with the settings of the client one, so the authentication failures of an account do not block
+// the other. The type of secretKey is detected again, set KeyType on the clone for a raw Ed25519 seed.
+func (c *Client) WithCredentials(apiKey, secretKey string) *Client {
+	clone := c.Clone()
+	clone.APIKey = apiKey
+	clone.SecretKey = secretKey
+	clone.KeyType = KeyTypeAuto
+	if c.AuthBreaker != nil {
+		clone.AuthBreaker = NewAuthBreaker(c.AuthBreaker.Threshold, c.AuthBreaker.Cooldown)
+	}
+	return clone
+}
@@ -0,0 +1,99 @@
+package binance_connector
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type cloneTestSuite struct {
+	suite.Suite
+	client *Client
+}
+
+func TestClone(t *testing.T) {
+	suite.Run(t, new(cloneTestSuite))
+}
+
+func (s *cloneTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+}
+
+func (s *cloneTestSuite) TestClientClone() {
+	c := s.client
+	c.Debug = true
+	c.TimeOffset = 12
+	c.RecvWindow = 3000
+	c.Clock = time.Now
+	c.MaxResponseSize = 1 << 10
+	c.DebugSignature = true
+	c.SuppressDeprecationWarnings = true
+	c.StrictDecoding = true
+	c.CheckSymbolStatus = true
+	c.CheckMaintenanceBeforeOrders = true
+	c.RateLimiter = &RateLimiter{}
+	c.DisableCompression = true
+	c.DisableSymbolNormalization = true
+	c.OnRequest = func(metrics *RequestMetrics) {}
+	c.TraceRequests = true
+	c.SBESchema = &DefaultSBESchema
+	c.DryRun = true
+	c.Retry = &DefaultRetryPolicy
+	c.FormatFloat = DefaultFloatFormatter
+	c.KeyType = KeyTypeHMAC
+	c.SetDefaultTimeout(time.Minute).SetEndpointTimeout(http.MethodPost, "/api/v3/order", time.Second)
+
+	clone := c.Clone()
+	cv, clonev := reflect.ValueOf(c).Elem(), reflect.ValueOf(clone).Elem()
+	for i := 0; i < cv.NumField(); i++ {
+		field := cv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		s.Require().False(cv.Field(i).IsZero(), "%s is not set by the test", field.Name)
+		if field.Type.Kind() == reflect.Func {
+			s.Equal(cv.Field(i).Pointer(), clonev.Field(i).Pointer(), field.Name)
+			continue
+		}
+		s.Equal(cv.Field(i).Interface(), clonev.Field(i).Interface(), field.Name)
+	}
+	s.Same(c.HTTPClient, clone.HTTPClient)
+	s.Same(c.RateLimiter, clone.RateLimiter)
+	s.Same(c.AuthBreaker, clone.AuthBreaker)
+	s.Same(c.penalty, clone.penalty)
+
+	clone.SetEndpointTimeout(http.MethodPost, "/api/v3/order", 0)
+	_, ok := c.endpointTimeouts.Load("POST /api/v3/order")
+	s.True(ok)
+	s.Equal(time.Minute, clone.defaultTimeout)
+}
+
+func (s *cloneTestSuite) TestClientWithOptions() {
+	c := s.client
+	var recvWindows []string
+	c.do = func(req *http.Request) (*http.Response, error) {
+		recvWindows = append(recvWindows, req.URL.Query().Get(recvWindowKey))
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+
+	derived := c.WithRecvWindow(10000)
+	_, err := derived.NewGetAccountService().Do(newContext())
+	s.Require().NoError(err)
+	_, err = derived.NewGetAccountService().Do(newContext(), WithRecvWindow(2000))
+	s.Require().NoError(err)
+	_, err = c.NewGetAccountService().Do(newContext())
+	s.Require().NoError(err)
+	s.Equal([]string{"10000", "2000", ""}, recvWindows)
+
+	other := c.WithCredentials("otherAPIKey", "otherSecretKey").WithBaseURL("https://api1.binance.com")
+	s.Equal("otherAPIKey", other.APIKey)
+	s.Equal("https://api1.binance.com", other.BaseURL)
+	s.Equal("dummyAPIKey", c.APIKey)
+	s.Equal("https://dummyapi.com", c.BaseURL)
+	s.NotSame(c.AuthBreaker, other.AuthBreaker)
+	s.Equal(c.AuthBreaker.Threshold, other.AuthBreaker.Threshold)
+	s.Same(c.penalty, other.penalty)
+}
@@ -0,0 +1,37 @@
+package binance_connector
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"strings"
+)
+
+// acceptEncoding is the Accept-Encoding of REST requests, unless Client.DisableCompression is set.
+// The library sets it itself, and decodes the responses, so that compression does not depend on the
+// transport of Client.HTTPClient.
+const acceptEncoding = "gzip, deflate"
+
+// countingReader count the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// decodeBody return a reader of body decompressed according to contentEncoding, body itself when
+// it is not compressed
+func decodeBody(body io.Reader, contentEncoding string) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "gzip":
+		return gzip.NewReader(body)
+	case "deflate":
+		return zlib.NewReader(body)
+	}
+	return body, nil
+}
@@ -0,0 +1,157 @@
+package binance_connector
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type compressionTestSuite struct {
+	suite.Suite
+	acceptEncoding string
+	metrics        *RequestMetrics
+}
+
+func TestCompression(t *testing.T) {
+	suite.Run(t, new(compressionTestSuite))
+}
+
+// largeExchangeInfo return an exchangeInfo response of n symbols
+func largeExchangeInfo(n int) []byte {
+	symbols := make([]string, n)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf(`{"symbol":"SYM%dUSDT","status":"TRADING","baseAsset":"SYM%d","baseAssetPrecision":8,"quoteAsset":"USDT","quotePrecision":8,`+
+			`"orderTypes":["LIMIT","LIMIT_MAKER","MARKET","STOP_LOSS_LIMIT","TAKE_PROFIT_LIMIT"],"icebergAllowed":true,"ocoAllowed":true,`+
+			`"isSpotTradingAllowed":true,"isMarginTradingAllowed":false,"filters":[{"filterType":"PRICE_FILTER","minPrice":"0.01000000",`+
+			`"maxPrice":"1000000.00000000","tickSize":"0.01000000"},{"filterType":"LOT_SIZE","minQty":"0.00001000","maxQty":"9000.00000000",`+
+			`"stepSize":"0.00001000"}],"permissions":[],"permissionSets":[["SPOT","MARGIN"]]}`, i, i)
+	}
+	return []byte(`{"timezone":"UTC","serverTime":1565246363776,"rateLimits":[],"exchangeFilters":[],"symbols":[` + strings.Join(symbols, ",") + `]}`)
+}
+
+// newCompressingServer serve data, gzip compressed when the request accepts it
+func newCompressingServer(data []byte, acceptEncoding *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if acceptEncoding != nil {
+			*acceptEncoding = r.Header.Get("Accept-Encoding")
+		}
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Write(data)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write(data)
+		gz.Close()
+	}))
+}
+
+func (s *compressionTestSuite) newClient(server *httptest.Server) *Client {
+	client := NewPublicClient(server.URL)
+	client.HTTPClient = server.Client()
+	client.OnRequest = func(metrics *RequestMetrics) { s.metrics = metrics }
+	return client
+}
+
+func (s *compressionTestSuite) TestGzip() {
+	data := largeExchangeInfo(200)
+	server := newCompressingServer(data, &s.acceptEncoding)
+	defer server.Close()
+	client := s.newClient(server)
+
+	info, err := client.NewExchangeInfoService().Do(context.Background())
+	s.Require().NoError(err)
+	s.Len(info.Symbols, 200)
+	s.Equal("gzip, deflate", s.acceptEncoding)
+	s.Equal(int64(len(data)), s.metrics.ResponseSize)
+	s.Less(s.metrics.TransferSize*5, s.metrics.ResponseSize, "the transfer is several times smaller")
+}
+
+func (s *compressionTestSuite) TestDisableCompression() {
+	data := largeExchangeInfo(10)
+	server := newCompressingServer(data, &s.acceptEncoding)
+	defer server.Close()
+	client := s.newClient(server)
+	client.DisableCompression = true
+
+	info, err := client.NewExchangeInfoService().Do(context.Background())
+	s.Require().NoError(err)
+	s.Len(info.Symbols, 10)
+	s.Equal("identity", s.acceptEncoding)
+	s.Equal(s.metrics.ResponseSize, s.metrics.TransferSize)
+}
+
+func (s *compressionTestSuite) TestDeflate() {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write([]byte(`{"serverTime": 1499827319559}`))
+	zw.Close()
+	client := NewPublicClient("https://dummyapi.com")
+	client.do = func(req *http.Request) (*http.Response, error) {
+		res := newHTTPResponse(compressed.Bytes(), http.StatusOK)
+		res.Header = http.Header{"Content-Encoding": {"deflate"}}
+		return res, nil
+	}
+	serverTime, err := client.NewServerTimeService().Do(context.Background())
+	s.Require().NoError(err)
+	s.Equal(uint64(1499827319559), serverTime.ServerTime)
+}
+
+func (s *compressionTestSuite) TestSizeLimitAfterDecompression() {
+	data := largeExchangeInfo(200)
+	server := newCompressingServer(data, nil)
+	defer server.Close()
+	client := s.newClient(server)
+	client.MaxResponseSize = int64(len(data)) / 2
+
+	_, err := client.NewExchangeInfoService().Do(context.Background())
+	s.ErrorIs(err, ErrResponseTooLarge)
+}
+
+func (s *compressionTestSuite) TestCorruptBody() {
+	client := NewPublicClient("https://dummyapi.com")
+	client.do = func(req *http.Request) (*http.Response, error) {
+		res := newHTTPResponse([]byte(`not gzip`), http.StatusOK)
+		res.Header = http.Header{"Content-Encoding": {"gzip"}}
+		return res, nil
+	}
+	s.Error(client.NewPingService().Do(context.Background()))
+}
+
+// BenchmarkExchangeInfoCompression compare the transfer of a full size exchangeInfo with and without compression
+func BenchmarkExchangeInfoCompression(b *testing.B) {
+	data := largeExchangeInfo(3000)
+	server := newCompressingServer(data, nil)
+	defer server.Close()
+	for _, disabled := range []bool{false, true} {
+		name := "gzip"
+		if disabled {
+			name = "identity"
+		}
+		b.Run(name, func(b *testing.B) {
+			var transfer int64
+			client := NewPublicClient(server.URL)
+			client.HTTPClient = server.Client()
+			client.DisableCompression = disabled
+			client.OnRequest = func(metrics *RequestMetrics) { transfer += metrics.TransferSize }
+			for i := 0; i < b.N; i++ {
+				res, err := client.Request(context.Background(), http.MethodGet, "/api/v3/exchangeInfo", nil, SecurityTypeNone)
+				if err != nil {
+					b.Fatal(err)
+				}
+				io.Discard.Write(res.Body)
+			}
+			b.ReportMetric(float64(transfer)/float64(b.N), "transfer-B/op")
+			b.ReportMetric(float64(len(data)), "body-B/op")
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package binance_connector
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Convert order statuses
+const (
+	ConvertOrderStatusProcess       = "PROCESS"
+	ConvertOrderStatusAcceptSuccess = "ACCEPT_SUCCESS"
+	ConvertOrderStatusSuccess       = "SUCCESS"
+	ConvertOrderStatusFail          = "FAIL"
+)
+
+// Order Status (USER_DATA)
+const (
+	convertOrderStatusEndpoint = "/sapi/v1/convert/orderStatus"
+)
+
+// ConvertOrderStatusService query the status of a convert order
+type ConvertOrderStatusService struct {
+	c       *Client
+	orderId *string
+	quoteId *string
+}
+
+// OrderId set orderId
+func (s *ConvertOrderStatusService) OrderId(orderId string) *ConvertOrderStatusService {
+	s.orderId = &orderId
+	return s
+}
+
+// QuoteId set quoteId
+func (s *ConvertOrderStatusService) QuoteId(quoteId string) *ConvertOrderStatusService {
+	s.quoteId = &quoteId
+	return s
+}
+
+func (s *ConvertOrderStatusService) Do(ctx context.Context, opts ...RequestOption) (res *ConvertOrderStatusResponse, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: convertOrderStatusEndpoint,
+		secType:  SecurityTypeUserData,
+		service:  "ConvertOrderStatusService",
+	}
+	if s.orderId != nil {
+		r.setParam("orderId", *s.orderId)
+	}
+	if s.quoteId != nil {
+		r.setParam("quoteId", *s.quoteId)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ConvertOrderStatusResponse)
+	err = s.c.unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ConvertOrderStatusResponse define response of ConvertOrderStatusService
+type ConvertOrderStatusResponse struct {
+	OrderId      int64  `json:"orderId"`
+	OrderStatus  string `json:"orderStatus"`
+	FromAsset    string `json:"fromAsset"`
+	FromAmount   string `json:"fromAmount"`
+	ToAsset      string `json:"toAsset"`
+	ToAmount     string `json:"toAmount"`
+	Ratio        string `json:"ratio"`
+	InverseRatio string `json:"inverseRatio"`
+	CreateTime   uint64 `json:"createTime"`
+}
+
+// IsFinal report whether the convert order reached SUCCESS or FAIL
+func (r *ConvertOrderStatusResponse) IsFinal() bool {
+	return r.OrderStatus == ConvertOrderStatusSuccess || r.OrderStatus == ConvertOrderStatusFail
+}
+
+var (
+	// ConvertPollInterval is the first delay between two orderStatus polls of WaitForConvertCompletion.
+	// orderStatus weighs 100 (UID), the delay doubles after each poll up to ConvertMaxPollInterval.
+	ConvertPollInterval = time.Second
+	// ConvertMaxPollInterval caps the delay between two orderStatus polls
+	ConvertMaxPollInterval = time.Second * 30
+)
+
+// WaitForConvertCompletion poll the status of the convert order until it is SUCCESS or FAIL.
+// The first delay can be set with pollInterval, ConvertPollInterval is used otherwise.
+// When ctx is done the last status received is returned with the context error.
+func (c *Client) WaitForConvertCompletion(ctx context.Context, orderId string, pollInterval ...time.Duration) (*ConvertOrderStatusResponse, error) {
+	backoff := Backoff{Base: ConvertPollInterval, Max: ConvertMaxPollInterval}
+	if len(pollInterval) > 0 && pollInterval[0] > 0 {
+		backoff.Base = pollInterval[0]
+	}
+	var last *ConvertOrderStatusResponse
+	for attempt := 0; ; attempt++ {
+		res, err := c.NewConvertOrderStatusService().OrderId(orderId).Do(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return last, err
+		}
+		if res.IsFinal() {
+			return res, nil
+		}
+		last = res
+
+		timer := time.NewTimer(backoff.Delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package binance_connector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type convertTestSuite struct {
+	baseTestSuite
+}
+
+func TestConvert(t *testing.T) {
+	suite.Run(t, new(convertTestSuite))
+}
+
+func (s *convertTestSuite) mockDoOnce(data []byte) {
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse(data, http.StatusOK), nil).Once()
+}
+
+func (s *convertTestSuite) TestConvertOrderStatus() {
+	data := []byte(`{
+		"orderId": 933256278426274426,
+		"orderStatus": "SUCCESS",
+		"fromAsset": "BTC",
+		"fromAmount": "0.00054414",
+		"toAsset": "USDT",
+		"toAmount": "20",
+		"ratio": "36755",
+		"inverseRatio": "0.00002721",
+		"createTime": 1623381330472
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParam("orderId", "933256278426274426")
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewConvertOrderStatusService().OrderId("933256278426274426").Do(newContext())
+	s.r().NoError(err)
+	s.Equal(int64(933256278426274426), res.OrderId)
+	s.Equal(ConvertOrderStatusSuccess, res.OrderStatus)
+	s.Equal("20", res.ToAmount)
+	s.True(res.IsFinal())
+}
+
+func (s *convertTestSuite) TestWaitForConvertCompletion() {
+	s.mockDoOnce([]byte(`{"orderId": 1, "orderStatus": "PROCESS"}`))
+	s.mockDoOnce([]byte(`{"orderId": 1, "orderStatus": "ACCEPT_SUCCESS"}`))
+	s.mockDoOnce([]byte(`{"orderId": 1, "orderStatus": "FAIL"}`))
+
+	res, err := s.client.WaitForConvertCompletion(newContext(), "1", time.Millisecond)
+	s.r().NoError(err)
+	s.Equal(ConvertOrderStatusFail, res.OrderStatus)
+	s.client.AssertNumberOfCalls(s.T(), "do", 3)
+}
+
+func (s *convertTestSuite) TestWaitForConvertCompletionContextDone() {
+	s.mockDo([]byte(`{"orderId": 1, "orderStatus": "PROCESS"}`), nil)
+
+	ctx, cancel := context.WithTimeout(newContext(), 20*time.Millisecond)
+	defer cancel()
+	res, err := s.client.WaitForConvertCompletion(ctx, "1", time.Hour)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.Equal(ConvertOrderStatusProcess, res.OrderStatus)
+}
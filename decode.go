@@ -0,0 +1,48 @@
+package binance_connector
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/goccy/go-json"
+)
+
+// decodeErrorPayloadLimit caps the payload quoted by DecodeError.Error, Payload keeps the whole body
+const decodeErrorPayloadLimit = 512
+
+// DecodeError is returned when a response body does not decode into the response struct,
+// e.g. a field unknown to the struct while Client.StrictDecoding is set
+type DecodeError struct {
+	// Payload is the response body
+	Payload []byte
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	payload := e.Payload
+	if len(payload) > decodeErrorPayloadLimit {
+		payload = append(payload[:decodeErrorPayloadLimit:decodeErrorPayloadLimit], "..."...)
+	}
+	return fmt.Sprintf("decode response: %v, payload: %s", e.Err, payload)
+}
+
+// Unwrap return the decoding error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// unmarshal decode a response body into v, rejecting the fields v does not define when StrictDecoding is set
+func (c *Client) unmarshal(data []byte, v interface{}) error {
+	var err error
+	if c.StrictDecoding {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(v)
+	} else {
+		err = json.Unmarshal(data, v)
+	}
+	if err != nil {
+		return &DecodeError{Payload: data, Err: err}
+	}
+	return nil
+}
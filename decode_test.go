@@ -0,0 +1,58 @@
+package binance_connector
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type decodeTestSuite struct {
+	suite.Suite
+	client *Client
+	body   []byte
+}
+
+func TestDecode(t *testing.T) {
+	suite.Run(t, new(decodeTestSuite))
+}
+
+func (s *decodeTestSuite) SetupTest() {
+	s.client = NewPublicClient("https://dummyapi.com")
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse(s.body, http.StatusOK), nil
+	}
+}
+
+func (s *decodeTestSuite) TestStrictDecoding() {
+	c := s.client
+	s.body = []byte(`{"serverTime": 1499827319559, "newField": true}`)
+
+	serverTime, err := c.NewServerTimeService().Do(newContext())
+	s.Require().NoError(err, "unknown fields are ignored by default")
+	s.Equal(uint64(1499827319559), serverTime.ServerTime)
+
+	c.StrictDecoding = true
+	_, err = c.NewServerTimeService().Do(newContext())
+	var decodeErr *DecodeError
+	s.Require().ErrorAs(err, &decodeErr)
+	s.Equal(s.body, decodeErr.Payload)
+	s.Contains(err.Error(), "newField")
+	s.Contains(err.Error(), `payload: {"serverTime": 1499827319559, "newField": true}`)
+
+	s.body = []byte(`{"serverTime": 1499827319559}`)
+	_, err = c.NewServerTimeService().Do(newContext())
+	s.NoError(err)
+}
+
+func (s *decodeTestSuite) TestDecodeError() {
+	s.body = append([]byte(`{"serverTime": "`), bytes.Repeat([]byte("1"), 1024)...)
+
+	_, err := s.client.NewServerTimeService().Do(newContext())
+	var decodeErr *DecodeError
+	s.Require().ErrorAs(err, &decodeErr)
+	s.Len(decodeErr.Payload, len(s.body))
+	s.Less(len(err.Error()), 700, "the payload quoted in the message is truncated")
+	s.Contains(err.Error(), "...")
+}
@@ -0,0 +1,48 @@
+package binance_connector
+
+import (
+	"fmt"
+)
+
+// DeprecationWarning define an endpoint Binance deprecated and what replaces it
+type DeprecationWarning struct {
+	Method      string
+	Endpoint    string
+	Replacement string
+}
+
+func (w DeprecationWarning) String() string {
+	return fmt.Sprintf("%s %s is deprecated by Binance and will be removed, use %s instead", w.Method, w.Endpoint, w.Replacement)
+}
+
+// deprecatedEndpoints is the registry of the deprecated endpoints used by the services, keyed by "METHOD /path"
+var deprecatedEndpoints = map[string]string{
+	"POST /api/v3/order/oco":        "POST /api/v3/orderList/oco",
+	"POST /api/v3/userDataStream":   "userDataStream.subscribe on the websocket API",
+	"PUT /api/v3/userDataStream":    "userDataStream.subscribe on the websocket API",
+	"DELETE /api/v3/userDataStream": "userDataStream.unsubscribe on the websocket API",
+}
+
+// EndpointDeprecation return the deprecation of an endpoint, false when it is not deprecated
+func EndpointDeprecation(method, endpoint string) (DeprecationWarning, bool) {
+	replacement, ok := deprecatedEndpoints[method+" "+endpoint]
+	if !ok {
+		return DeprecationWarning{}, false
+	}
+	return DeprecationWarning{Method: method, Endpoint: endpoint, Replacement: replacement}, true
+}
+
+// warnDeprecated log a warning the first time the client calls a deprecated endpoint
+func (c *Client) warnDeprecated(r *request) {
+	if c.SuppressDeprecationWarnings || c.Logger == nil {
+		return
+	}
+	warning, ok := EndpointDeprecation(r.method, r.endpoint)
+	if !ok {
+		return
+	}
+	if _, warned := c.deprecationsWarned.LoadOrStore(r.method+" "+r.endpoint, true); warned {
+		return
+	}
+	c.Logger.Printf("%sWARNING: %s", r.logPrefix(), warning)
+}
@@ -0,0 +1,62 @@
+package binance_connector
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type deprecationTestSuite struct {
+	suite.Suite
+	client *Client
+	logs   bytes.Buffer
+}
+
+func TestDeprecation(t *testing.T) {
+	suite.Run(t, new(deprecationTestSuite))
+}
+
+func (s *deprecationTestSuite) SetupTest() {
+	s.logs.Reset()
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.client.Logger = log.New(&s.logs, "", 0)
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+}
+
+func (s *deprecationTestSuite) newOCO() {
+	_, err := s.client.NewNewOCOService().Symbol("BTCUSDT").Side("SELL").Quantity(1).Price(2).StopPrice(1).Do(ContextWithRequestID(newContext(), "op-1"))
+	s.Require().NoError(err)
+}
+
+func (s *deprecationTestSuite) TestDeprecationWarning() {
+	s.newOCO()
+	s.newOCO()
+	s.Equal("[op-1] WARNING: POST /api/v3/order/oco is deprecated by Binance and will be removed, use POST /api/v3/orderList/oco instead\n", s.logs.String())
+
+	_, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).NewOrderRespType("ACK").Do(newContext())
+	s.Require().NoError(err)
+	_, err = s.client.NewCreateUserStreamService().Do(newContext())
+	s.Require().NoError(err)
+	s.Equal(2, strings.Count(s.logs.String(), "WARNING"))
+	s.Contains(s.logs.String(), "POST /api/v3/userDataStream is deprecated")
+}
+
+func (s *deprecationTestSuite) TestSuppressDeprecationWarnings() {
+	s.client.SuppressDeprecationWarnings = true
+	s.newOCO()
+	s.Empty(s.logs.String())
+}
+
+func (s *deprecationTestSuite) TestEndpointDeprecation() {
+	warning, ok := EndpointDeprecation("POST", "/api/v3/order/oco")
+	s.True(ok)
+	s.Equal(DeprecationWarning{Method: "POST", Endpoint: "/api/v3/order/oco", Replacement: "POST /api/v3/orderList/oco"}, warning)
+	_, ok = EndpointDeprecation("POST", "/api/v3/order")
+	s.False(ok)
+}
@@ -0,0 +1,50 @@
+package binance_connector
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrDryRun is matched by errors.Is on a DryRunError
+var ErrDryRun = errors.New("dry run, request not sent")
+
+// DryRunError is returned instead of a response by the signed requests of a client in DryRun mode. The request
+// was built, validated and signed like a real one but never sent, so nothing was executed on the exchange.
+type DryRunError struct {
+	Method string
+	// URL is the full URL, including the query string and its signature
+	URL string
+	// Header holds the API key
+	Header http.Header
+	Body   string
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("%v: %s %s", ErrDryRun, e.Method, redactURL(e.URL))
+}
+
+// Is return true for ErrDryRun
+func (e *DryRunError) Is(target error) bool {
+	return target == ErrDryRun
+}
+
+// dryRun log the request r would send and return it as a DryRunError, nil when r is to be sent
+func (c *Client) dryRun(r *request) error {
+	if !c.DryRun || !r.secType.Signed() {
+		return nil
+	}
+	e := &DryRunError{Method: r.method, URL: r.fullURL, Header: r.header.Clone()}
+	if r.body != nil {
+		body, err := io.ReadAll(r.body)
+		if err != nil {
+			return err
+		}
+		e.Body = string(body)
+	}
+	if c.Logger != nil {
+		c.Logger.Printf("%sDRY RUN: %s %s, body: %s", r.logPrefix(), e.Method, redactURL(e.URL), redactQuery(e.Body))
+	}
+	return e
+}
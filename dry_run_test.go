@@ -0,0 +1,72 @@
+package binance_connector
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type dryRunTestSuite struct {
+	suite.Suite
+	client *Client
+	logs   bytes.Buffer
+	sent   []string
+}
+
+func TestDryRun(t *testing.T) {
+	suite.Run(t, new(dryRunTestSuite))
+}
+
+func (s *dryRunTestSuite) SetupTest() {
+	s.logs.Reset()
+	s.sent = nil
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.client.Logger = log.New(&s.logs, "", 0)
+	s.client.DryRun = true
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.sent = append(s.sent, req.URL.Path)
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+}
+
+func (s *dryRunTestSuite) TestSignedRequestNotSent() {
+	_, err := s.client.NewCreateOrderService().Symbol("btcusdt").Side("BUY").Type("LIMIT").TimeInForce("GTC").
+		Quantity(1).Price(30000).Do(ContextWithRequestID(newContext(), "op-1"))
+	s.Require().ErrorIs(err, ErrDryRun)
+	var dryRun *DryRunError
+	s.Require().ErrorAs(err, &dryRun)
+	s.Equal(http.MethodPost, dryRun.Method)
+	s.Equal("dummyAPIKey", dryRun.Header.Get("X-MBX-APIKEY"))
+	s.Contains(dryRun.URL+dryRun.Body, "symbol=BTCUSDT")
+	s.Contains(dryRun.URL+dryRun.Body, "signature=")
+	s.Contains(s.logs.String(), "[op-1] DRY RUN: POST https://dummyapi.com/api/v3/order")
+	s.NotContains(s.logs.String(), "dummyAPIKey")
+	s.Empty(s.sent)
+}
+
+func (s *dryRunTestSuite) TestCancelReplace() {
+	// cancelReplace returns its rejections as a response, a dry run is not one of them
+	_, err := s.client.NewCancelReplaceService().Symbol("BTCUSDT").Side("BUY").OrderType("LIMIT").
+		CancelReplaceMode("STOP_ON_FAILURE").CancelOrderId(1).Do(newContext())
+	var dryRun *DryRunError
+	s.Require().ErrorAs(err, &dryRun)
+	s.Contains(dryRun.URL+dryRun.Body, "cancelReplaceMode=STOP_ON_FAILURE")
+	s.Empty(s.sent)
+}
+
+func (s *dryRunTestSuite) TestRequestCheckedBefore() {
+	anonymous := NewClient("", "", "https://dummyapi.com")
+	anonymous.DryRun = true
+	_, err := anonymous.NewGetAccountService().Do(newContext())
+	s.Require().ErrorIs(err, ErrCredentialsRequired)
+	s.NotErrorIs(err, ErrDryRun)
+}
+
+func (s *dryRunTestSuite) TestPublicRequestSent() {
+	_, err := s.client.NewServerTimeService().Do(newContext())
+	s.Require().NoError(err)
+	s.Equal([]string{"/api/v3/time"}, s.sent)
+}
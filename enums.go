@@ -0,0 +1,136 @@
+package binance_connector
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// enumNames map the upper-case string forms of an enum to its numeric code
+type enumNames map[string]int
+
+// unmarshalEnum decode an enum sent as a JSON number, a quoted number or one of its string forms, compared
+// case-insensitively. null and an empty string decode as unknown, so does a string the enum does not know: Binance
+// adds values without notice and a status must not fail the decoding of a whole response.
+func unmarshalEnum(data []byte, names enumNames, unknown int) (int, error) {
+	if bytes.Equal(data, []byte("null")) {
+		return unknown, nil
+	}
+	quoted := len(data) > 0 && data[0] == '"'
+	value := string(bytes.Trim(data, `"`))
+	if value == "" {
+		return unknown, nil
+	}
+	if code, err := strconv.Atoi(value); err == nil {
+		return code, nil
+	}
+	if !quoted {
+		return unknown, fmt.Errorf("invalid enum value %s", data)
+	}
+	if code, ok := names[strings.ToUpper(value)]; ok {
+		return code, nil
+	}
+	return unknown, nil
+}
+
+// enumString return the string form of code, its number when it has none
+func enumString(names enumNames, code int) string {
+	for name, c := range names {
+		if c == code {
+			return name
+		}
+	}
+	return strconv.Itoa(code)
+}
+
+// SystemStatus define the status of GET /sapi/v1/system/status, sent as 0 or 1 along with "normal" or
+// "system_maintenance" as msg
+type SystemStatus int
+
+const (
+	SystemStatusUnknown     SystemStatus = -1
+	SystemStatusNormal      SystemStatus = 0
+	SystemStatusMaintenance SystemStatus = 1
+)
+
+var systemStatusNames = enumNames{"NORMAL": 0, "SYSTEM_MAINTENANCE": 1}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *SystemStatus) UnmarshalJSON(data []byte) error {
+	code, err := unmarshalEnum(data, systemStatusNames, int(SystemStatusUnknown))
+	*s = SystemStatus(code)
+	return err
+}
+
+func (s SystemStatus) String() string {
+	return enumString(systemStatusNames, int(s))
+}
+
+// DepositStatus define the status of a deposit, a number in the deposit histories and a name elsewhere
+type DepositStatus int
+
+const (
+	DepositStatusUnknown            DepositStatus = -1
+	DepositStatusPending            DepositStatus = 0
+	DepositStatusSuccess            DepositStatus = 1
+	DepositStatusRejected           DepositStatus = 2
+	DepositStatusCredited           DepositStatus = 6 // credited but cannot withdraw
+	DepositStatusWrongDeposit       DepositStatus = 7
+	DepositStatusWaitingUserConfirm DepositStatus = 8
+)
+
+var depositStatusNames = enumNames{
+	"PENDING":              0,
+	"SUCCESS":              1,
+	"REJECTED":             2,
+	"CREDITED":             6,
+	"WRONG_DEPOSIT":        7,
+	"WAITING_USER_CONFIRM": 8,
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *DepositStatus) UnmarshalJSON(data []byte) error {
+	code, err := unmarshalEnum(data, depositStatusNames, int(DepositStatusUnknown))
+	*s = DepositStatus(code)
+	return err
+}
+
+func (s DepositStatus) String() string {
+	return enumString(depositStatusNames, int(s))
+}
+
+// WithdrawStatus define the status of a withdrawal, a number in the withdraw history and a name elsewhere
+type WithdrawStatus int
+
+const (
+	WithdrawStatusUnknown          WithdrawStatus = -1
+	WithdrawStatusEmailSent        WithdrawStatus = 0
+	WithdrawStatusCancelled        WithdrawStatus = 1
+	WithdrawStatusAwaitingApproval WithdrawStatus = 2
+	WithdrawStatusRejected         WithdrawStatus = 3
+	WithdrawStatusProcessing       WithdrawStatus = 4
+	WithdrawStatusFailure          WithdrawStatus = 5
+	WithdrawStatusCompleted        WithdrawStatus = 6
+)
+
+var withdrawStatusNames = enumNames{
+	"EMAIL_SENT":        0,
+	"CANCELLED":         1,
+	"AWAITING_APPROVAL": 2,
+	"REJECTED":          3,
+	"PROCESSING":        4,
+	"FAILURE":           5,
+	"COMPLETED":         6,
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *WithdrawStatus) UnmarshalJSON(data []byte) error {
+	code, err := unmarshalEnum(data, withdrawStatusNames, int(WithdrawStatusUnknown))
+	*s = WithdrawStatus(code)
+	return err
+}
+
+func (s WithdrawStatus) String() string {
+	return enumString(withdrawStatusNames, int(s))
+}
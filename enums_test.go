@@ -0,0 +1,64 @@
+package binance_connector
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/suite"
+)
+
+type enumsTestSuite struct {
+	suite.Suite
+}
+
+func TestEnums(t *testing.T) {
+	suite.Run(t, new(enumsTestSuite))
+}
+
+func (s *enumsTestSuite) TestSystemStatusUnmarshal() {
+	for _, fixture := range []string{`{"status": 1, "msg": "system_maintenance"}`, `{"status": "1"}`, `{"status": "system_maintenance"}`} {
+		var res SystemStatusResponse
+		s.Require().NoError(json.Unmarshal([]byte(fixture), &res), fixture)
+		s.Equal(SystemStatusMaintenance, res.Status, fixture)
+	}
+	var res SystemStatusResponse
+	s.Require().NoError(json.Unmarshal([]byte(`{"status": "NORMAL"}`), &res))
+	s.Equal(SystemStatusNormal, res.Status)
+	s.Equal("SYSTEM_MAINTENANCE", SystemStatusMaintenance.String())
+}
+
+func (s *enumsTestSuite) TestDepositStatusUnmarshal() {
+	// GET /sapi/v1/capital/deposit/hisrec sends a number, other deposit records a name
+	var deposits []*DepositHistoryResponse
+	err := json.Unmarshal([]byte(`[
+		{"id": "1", "coin": "BNB", "status": 6},
+		{"id": "2", "coin": "BNB", "status": "SUCCESS"},
+		{"id": "3", "coin": "BNB", "status": "waiting_user_confirm"},
+		{"id": "4", "coin": "BNB", "status": "NEW_STATUS"},
+		{"id": "5", "coin": "BNB", "status": null}
+	]`), &deposits)
+	s.Require().NoError(err)
+	s.Equal(DepositStatusCredited, deposits[0].Status)
+	s.Equal(DepositStatusSuccess, deposits[1].Status)
+	s.Equal(DepositStatusWaitingUserConfirm, deposits[2].Status)
+	s.Equal(DepositStatusUnknown, deposits[3].Status)
+	s.Equal(DepositStatusUnknown, deposits[4].Status)
+	s.Equal("CREDITED", deposits[0].Status.String())
+	s.Equal("-1", deposits[3].Status.String())
+
+	var sub GetSubAccountDepositHistoryResp
+	s.Require().NoError(json.Unmarshal([]byte(`{"depositList": [{"id": 1, "status": 1}]}`), &sub))
+	s.Equal(DepositStatusSuccess, sub.DepositList[0].Status)
+
+	var status DepositStatus
+	s.Error(json.Unmarshal([]byte(`true`), &status))
+}
+
+func (s *enumsTestSuite) TestWithdrawStatusUnmarshal() {
+	var withdrawals []*WithdrawHistoryResponse
+	err := json.Unmarshal([]byte(`[{"id": "1", "status": 6}, {"id": "2", "status": "Completed"}, {"id": "3", "status": "4"}]`), &withdrawals)
+	s.Require().NoError(err)
+	s.Equal(WithdrawStatusCompleted, withdrawals[0].Status)
+	s.Equal(WithdrawStatusCompleted, withdrawals[1].Status)
+	s.Equal(WithdrawStatusProcessing, withdrawals[2].Status)
+}
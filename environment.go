@@ -0,0 +1,63 @@
+package binance_connector
+
+// Environment define a Binance deployment, its REST, websocket API and stream hosts go together
+type Environment int
+
+const (
+	// EnvironmentProd is the production exchange
+	EnvironmentProd Environment = iota
+	// EnvironmentTestnet is the spot testnet, it needs testnet API keys and holds test funds only
+	EnvironmentTestnet
+)
+
+// EnvironmentURLs define the base URLs of an Environment
+type EnvironmentURLs struct {
+	REST         string
+	WebsocketAPI string
+	// Stream is the base URL of the market and user data streams, without the /ws or /stream path
+	Stream string
+}
+
+var environmentURLs = map[Environment]EnvironmentURLs{
+	EnvironmentProd: {
+		REST:         "https://api.binance.com",
+		WebsocketAPI: "wss://ws-api.binance.com:443/ws-api/v3",
+		Stream:       "wss://stream.binance.com:9443",
+	},
+	EnvironmentTestnet: {
+		REST:         "https://testnet.binance.vision",
+		WebsocketAPI: "wss://ws-api.testnet.binance.vision/ws-api/v3",
+		Stream:       "wss://stream.testnet.binance.vision",
+	},
+}
+
+// String return the name of the environment
+func (e Environment) String() string {
+	switch e {
+	case EnvironmentProd:
+		return "PROD"
+	case EnvironmentTestnet:
+		return "TESTNET"
+	}
+	return "UNKNOWN"
+}
+
+// URLs return the base URLs of the environment, empty for an unknown environment
+func (e Environment) URLs() EnvironmentURLs {
+	return environmentURLs[e]
+}
+
+// NewClientForEnv create a Client on the REST host of env
+func NewClientForEnv(env Environment, apiKey string, secretKey string) *Client {
+	return NewClient(apiKey, secretKey, env.URLs().REST)
+}
+
+// NewWebsocketStreamClientForEnv create a WebsocketStreamClient on the stream host of env
+func NewWebsocketStreamClientForEnv(env Environment, isCombined bool) *WebsocketStreamClient {
+	return NewWebsocketStreamClient(isCombined, env.URLs().Stream)
+}
+
+// NewWebsocketAPIClientForEnv create a WebsocketAPIClient on the websocket API host of env
+func NewWebsocketAPIClientForEnv(env Environment, apiKey string, apiSecret string) *WebsocketAPIClient {
+	return NewWebsocketAPIClient(apiKey, apiSecret, env.URLs().WebsocketAPI)
+}
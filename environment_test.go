@@ -0,0 +1,37 @@
+package binance_connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type environmentTestSuite struct {
+	suite.Suite
+}
+
+func TestEnvironment(t *testing.T) {
+	suite.Run(t, new(environmentTestSuite))
+}
+
+func (s *environmentTestSuite) TestClientsForEnv() {
+	s.Equal("PROD", EnvironmentProd.String())
+	s.Equal("TESTNET", EnvironmentTestnet.String())
+	s.Equal("UNKNOWN", Environment(42).String())
+	s.Equal(EnvironmentURLs{}, Environment(42).URLs())
+
+	prod := NewClientForEnv(EnvironmentProd, "dummyAPIKey", "dummySecretKey")
+	s.Equal(NewClient("dummyAPIKey", "dummySecretKey").BaseURL, prod.BaseURL)
+	s.Equal("dummyAPIKey", prod.APIKey)
+	s.Equal("dummySecretKey", prod.SecretKey)
+	s.Equal(NewWebsocketStreamClient(true).Endpoint, NewWebsocketStreamClientForEnv(EnvironmentProd, true).Endpoint)
+	s.Equal(NewWebsocketAPIClient("k", "s").Endpoint, NewWebsocketAPIClientForEnv(EnvironmentProd, "k", "s").Endpoint)
+
+	s.Equal("https://testnet.binance.vision", NewClientForEnv(EnvironmentTestnet, "k", "s").BaseURL)
+	s.Equal("wss://stream.testnet.binance.vision/ws", NewWebsocketStreamClientForEnv(EnvironmentTestnet, false).Endpoint)
+	s.Equal("wss://stream.testnet.binance.vision/stream?streams=", NewWebsocketStreamClientForEnv(EnvironmentTestnet, true).Endpoint)
+	wsAPI := NewWebsocketAPIClientForEnv(EnvironmentTestnet, "k", "s")
+	s.Equal("wss://ws-api.testnet.binance.vision/ws-api/v3", wsAPI.Endpoint)
+	s.Equal("k", wsAPI.APIKey)
+	s.Equal("s", wsAPI.APISecret)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	AllOrderList()
+}
+
+func AllOrderList() {
+	apiKey := "your api key"
+	secretKey := "your secret key"
+	baseURL := "https://api.binance.com"
+
+	client := binance_connector.NewClient(apiKey, secretKey, baseURL)
+
+	// Binance Query all Order lists (USER_DATA) - GET /api/v3/allOrderList
+	orderLists, err := client.NewAllOrderListService().FromId(1).Limit(100).Do(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(binance_connector.PrettyPrint(orderLists))
+}
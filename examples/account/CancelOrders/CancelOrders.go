@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	CancelOrders()
+}
+
+func CancelOrders() {
+	apiKey := "your api key"
+	secretKey := "your secret key"
+	baseURL := "https://api.binance.com"
+
+	client := binance_connector.NewClient(apiKey, secretKey, baseURL)
+
+	// Cancel several orders with concurrent DELETE /api/v3/order requests
+	results := client.CancelOrders(context.Background(), "BTCUSDT", []int64{123456789, 123456790})
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Println(result.OrderId, result.Err)
+			continue
+		}
+		fmt.Println(binance_connector.PrettyPrint(result.Response))
+	}
+}
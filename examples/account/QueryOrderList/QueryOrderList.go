@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	QueryOrderList()
+}
+
+func QueryOrderList() {
+	apiKey := "your api key"
+	secretKey := "your secret key"
+	baseURL := "https://api.binance.com"
+
+	client := binance_connector.NewClient(apiKey, secretKey, baseURL)
+
+	// Binance Query Order list (USER_DATA) - GET /api/v3/orderList
+	orderList, err := client.NewQueryOrderListService().OrderListId(27).Do(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(binance_connector.PrettyPrint(orderList))
+}
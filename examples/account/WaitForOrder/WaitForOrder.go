@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	WaitForOrder()
+}
+
+func WaitForOrder() {
+	apiKey := "your api key"
+	secretKey := "your secret key"
+	baseURL := "https://api.binance.com"
+
+	client := binance_connector.NewClient(apiKey, secretKey, baseURL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// Poll /api/v3/order until the order is FILLED, or fails with ErrOrderPredicateUnmet when it is canceled or expired
+	order, err := client.WaitForOrder(ctx, "BTCUSDT", 12345, binance_connector.OrderFilled, time.Second)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(binance_connector.PrettyPrint(order))
+}
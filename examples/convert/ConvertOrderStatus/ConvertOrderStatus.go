@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	ConvertOrderStatus()
+}
+
+func ConvertOrderStatus() {
+	apiKey := "your api key"
+	secretKey := "your secret key"
+	baseURL := "https://api.binance.com"
+
+	client := binance_connector.NewClient(apiKey, secretKey, baseURL)
+
+	// ConvertOrderStatusService - /sapi/v1/convert/orderStatus
+	convertOrderStatus, err := client.NewConvertOrderStatusService().OrderId("933256278426274426").Do(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(binance_connector.PrettyPrint(convertOrderStatus))
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	IsolatedMarginTransferHistory()
+}
+
+func IsolatedMarginTransferHistory() {
+	apiKey := "your api key"
+	secretKey := "your secret key"
+	baseURL := "https://api.binance.com"
+
+	client := binance_connector.NewClient(apiKey, secretKey, baseURL)
+
+	// IsolatedMarginTransferHistoryService - /sapi/v1/margin/transfer, every transfer of the last 90 days
+	end := time.Now()
+	transfers, err := client.NewIsolatedMarginTransferHistoryService().Symbol("BNBUSDT").
+		Paginate(end.AddDate(0, 0, -90), end).
+		All(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(binance_connector.PrettyPrint(transfers))
+}
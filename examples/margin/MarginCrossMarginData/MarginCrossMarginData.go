@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	MarginCrossMarginData()
+}
+
+func MarginCrossMarginData() {
+	apiKey := "your api key"
+	secretKey := "your secret key"
+	baseURL := "https://api.binance.com"
+
+	client := binance_connector.NewClient(apiKey, secretKey, baseURL)
+
+	// MarginCrossMarginDataService - /sapi/v1/margin/crossMarginData
+	crossMarginData, err := client.NewMarginCrossMarginDataService().Coin("BTC").
+		Do(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, data := range crossMarginData {
+		fmt.Printf("%s daily interest %.8f, borrow limit %.8f\n", data.Coin, data.DailyInterest.Float64(), data.BorrowLimit.Float64())
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	MarginIsolatedMarginData()
+}
+
+func MarginIsolatedMarginData() {
+	apiKey := "your api key"
+	secretKey := "your secret key"
+	baseURL := "https://api.binance.com"
+
+	client := binance_connector.NewClient(apiKey, secretKey, baseURL)
+
+	// MarginIsolatedMarginDataService - /sapi/v1/margin/isolatedMarginData
+	isolatedMarginData, err := client.NewMarginIsolatedMarginDataService().Symbol("BTCUSDT").
+		Do(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(binance_connector.PrettyPrint(isolatedMarginData))
+}
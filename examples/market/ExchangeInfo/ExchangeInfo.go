@@ -23,4 +23,12 @@ func ExchangeInfo() {
 		return
 	}
 	fmt.Println(binance_connector.PrettyPrint(exchangeInfo))
+
+	// ExchangeInfo of a few symbols only
+	symbolsInfo, err := client.NewExchangeInfoService().Symbols([]string{"BTCUSDT", "ETHUSDT"}).Do(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(binance_connector.PrettyPrint(symbolsInfo))
 }
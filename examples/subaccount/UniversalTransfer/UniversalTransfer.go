@@ -20,7 +20,7 @@ func UniversalTransfer() {
 
 	// Universal Transfer (For Master Account) - /sapi/v1/asset/universalTransfer
 	universalTransfer, err := client.NewUniversalTransferService().FromEmail("from@email.com").ToEmail("to@email.com").
-		FromAccountType("SPOT").ToAccountType("SPOT").ClientTranId("123123").Symbol("BTC").Asset("BTC").Amount(0.01).Do(context.Background())
+		FromAccountType(binance_connector.SubAccountTypeSpot).ToAccountType(binance_connector.SubAccountTypeUSDTFuture).ClientTranId("123123").Asset("BTC").Amount(0.01).Do(context.Background())
 	if err != nil {
 		fmt.Println(err)
 		return
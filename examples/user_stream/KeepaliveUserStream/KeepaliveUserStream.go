@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	KeepaliveUserStream()
+}
+
+func KeepaliveUserStream() {
+	apiKey := "your api key"
+	secretKey := "your secret key"
+	baseURL := "https://api.binance.com"
+
+	client := binance_connector.NewClient(apiKey, secretKey, baseURL)
+
+	err := client.NewKeepaliveUserStreamService().ListenKey("your_listen_key").
+		Do(context.Background())
+	fmt.Println(err)
+}
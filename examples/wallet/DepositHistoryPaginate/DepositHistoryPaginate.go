@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	DepositHistoryPaginate()
+}
+
+func DepositHistoryPaginate() {
+	apiKey := "your api key"
+	secretKey := "your secret key"
+	baseURL := "https://api.binance.com"
+
+	client := binance_connector.NewClient(apiKey, secretKey, baseURL)
+
+	// DepositHistoryService - /sapi/v1/capital/deposit/hisrec, every deposit of the last year
+	end := time.Now()
+	deposits, err := client.NewDepositHistoryService().Coin("BTC").
+		Paginate(end.AddDate(-1, 0, 0), end).Interval(time.Second).
+		All(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(binance_connector.PrettyPrint(deposits))
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	WsConnectionStateExample()
+}
+
+func WsConnectionStateExample() {
+	websocketStreamClient := binance_connector.NewWebsocketStreamClient(false)
+	websocketStreamClient.OnStateChange(func(stream string, oldState, newState binance_connector.WsConnState) {
+		fmt.Printf("%s: %s -> %s\n", stream, oldState, newState)
+	})
+	wsTradeHandler := func(event *binance_connector.WsTradeEvent) {
+		fmt.Println(websocketStreamClient.States())
+	}
+	errHandler := func(err error) {
+		fmt.Println(err)
+	}
+	doneCh, stopCh, err := websocketStreamClient.WsTradeServe("LTCBTC", wsTradeHandler, errHandler)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// use stopCh to exit
+	go func() {
+		time.Sleep(10 * time.Second)
+		stopCh <- struct{}{}
+	}()
+	<-doneCh
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	WsIsolatedMarginUserData()
+}
+
+func WsIsolatedMarginUserData() {
+	apiKey := "your api key"
+	secretKey := "your secret key"
+	baseURL := "https://api.binance.com"
+
+	client := binance_connector.NewClient(apiKey, secretKey, baseURL)
+
+	res, err := client.NewCreateIsolatedMarginUserStreamService().Symbol("BTCUSDT").
+		Do(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	websocketStreamClient := binance_connector.NewWebsocketStreamClient(false)
+
+	wsUserDataHandler := func(event *binance_connector.WsIsolatedMarginUserDataEvent) {
+		fmt.Println(binance_connector.PrettyPrint(event))
+	}
+	errHandler := func(err error) {
+		fmt.Println(err)
+	}
+	doneCh, _, err := websocketStreamClient.WsIsolatedMarginUserDataServe("BTCUSDT", res.ListenKey, wsUserDataHandler, errHandler)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	<-doneCh
+}
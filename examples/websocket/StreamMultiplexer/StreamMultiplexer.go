@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	WsStreamMultiplexerExample()
+}
+
+func WsStreamMultiplexerExample() {
+	websocketStreamClient := binance_connector.NewWebsocketStreamClient(true)
+	errHandler := func(err error) {
+		fmt.Println(err)
+	}
+	multiplexer := websocketStreamClient.NewStreamMultiplexer(errHandler).
+		Register("btcusdt@trade", func(message []byte) {
+			fmt.Println("trade:", string(message))
+		}).
+		Register("btcusdt@bookTicker", func(message []byte) {
+			fmt.Println("book ticker:", string(message))
+		})
+	doneCh, stopCh, err := multiplexer.Start()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// add a stream on the running connection
+	err = multiplexer.Subscribe(context.Background(), "bnbusdt@aggTrade", func(message []byte) {
+		fmt.Println("agg trade:", string(message))
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// use stopCh to exit
+	go func() {
+		time.Sleep(10 * time.Second)
+		stopCh <- struct{}{}
+	}()
+	// remove this if you do not want to be blocked here
+	<-doneCh
+}
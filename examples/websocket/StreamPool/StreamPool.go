@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	WsStreamPoolExample()
+}
+
+func WsStreamPoolExample() {
+	websocketStreamClient := binance_connector.NewWebsocketStreamClient(true)
+	errHandler := func(err error) {
+		fmt.Println(err)
+	}
+	pool := websocketStreamClient.NewStreamPool(errHandler)
+	defer pool.Close()
+
+	for _, symbol := range []string{"BTCUSDT", "BNBUSDT", "ETHUSDT"} {
+		err := pool.Subscribe(context.Background(), strings.ToLower(symbol)+"@trade", func(message []byte) {
+			fmt.Println(string(message))
+		})
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+	fmt.Println(binance_connector.PrettyPrint(pool.Stats()))
+
+	time.Sleep(10 * time.Second)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	SubscribeBookTickersExample()
+}
+
+func SubscribeBookTickersExample() {
+	websocketStreamClient := binance_connector.NewWebsocketStreamClient(false)
+	wsBookTickerHandler := func(event *binance_connector.WsBookTickerEvent) {
+		fmt.Println(event.Symbol, event.BestBidPrice, event.BestAskPrice)
+	}
+	errHandler := func(err error) {
+		fmt.Println(err)
+	}
+	doneCh, stopCh, err := websocketStreamClient.SubscribeBookTickers([]string{"BTCUSDT", "ETHUSDT", "BNBUSDT"}, wsBookTickerHandler, errHandler)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// use stopCh to exit
+	go func() {
+		time.Sleep(10 * time.Second)
+		stopCh <- struct{}{}
+	}()
+	<-doneCh
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	binance_connector "github.com/luciano-personal-org/binance-connector"
+)
+
+func main() {
+	Time()
+}
+
+func Time() {
+	client := binance_connector.NewWebsocketAPIClient("", "", "wss://ws-api.testnet.binance.vision/ws-api/v3")
+	err := client.Connect()
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return
+	}
+	defer client.Close()
+
+	response, err := client.NewTimeService().Do(context.Background())
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return
+	}
+	fmt.Println(binance_connector.PrettyPrint(response))
+
+	// sync the timestamps of a REST client on the websocket API clock
+	restClient := binance_connector.NewClient("", "", "https://testnet.binance.vision")
+	offset, err := restClient.SyncTime(context.Background(), client.ServerTime)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return
+	}
+	fmt.Println("local clock offset:", offset)
+}
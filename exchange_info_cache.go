@@ -0,0 +1,238 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultExchangeInfoTTL is the delay after which the cached exchange information is fetched again
+const DefaultExchangeInfoTTL = time.Hour
+
+var (
+	// ErrSymbolNotFound is matched by errors.Is on a SymbolNotFoundError
+	ErrSymbolNotFound = errors.New("symbol not found")
+	// ErrSymbolNotTrading is matched by errors.Is on a SymbolNotTradingError
+	ErrSymbolNotTrading = errors.New("symbol not trading")
+	// ErrInvalidInterval is returned for a kline interval Binance does not support
+	ErrInvalidInterval = errors.New("invalid kline interval")
+)
+
+// KlineIntervals are the kline intervals supported by Binance
+var KlineIntervals = []string{"1s", "1m", "3m", "5m", "15m", "30m", "1h", "2h", "4h", "6h", "8h", "12h", "1d", "3d", "1w", "1M"}
+
+// SymbolNotFoundError is returned for a symbol missing from the exchange information.
+// Suggestion is the closest listed symbol, empty when none is close enough.
+type SymbolNotFoundError struct {
+	Symbol     string
+	Suggestion string
+}
+
+func (e *SymbolNotFoundError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("symbol %s not found; did you mean %s?", e.Symbol, e.Suggestion)
+	}
+	return fmt.Sprintf("symbol %s not found", e.Symbol)
+}
+
+// Is return true for ErrSymbolNotFound
+func (e *SymbolNotFoundError) Is(target error) bool {
+	return target == ErrSymbolNotFound
+}
+
+// SymbolNotTradingError is returned for a listed symbol whose status is not TRADING, e.g. BREAK or HALT
+type SymbolNotTradingError struct {
+	Symbol string
+	Status string
+}
+
+func (e *SymbolNotTradingError) Error() string {
+	return fmt.Sprintf("symbol %s is not trading (status %s)", e.Symbol, e.Status)
+}
+
+// Is return true for ErrSymbolNotTrading
+func (e *SymbolNotTradingError) Is(target error) bool {
+	return target == ErrSymbolNotTrading
+}
+
+// ExchangeInfoCache keep the response of GET /api/v3/exchangeInfo and index its symbols.
+// It is fetched on first use and again once TTL passed, concurrent callers share one request.
+type ExchangeInfoCache struct {
+	// TTL is the lifetime of the cached exchange information, it is never fetched again when zero or negative
+	TTL time.Duration
+	// Permissions keeps only the symbols having one of these permissions, e.g. SPOT, in the cached exchange
+	// information: the others are dropped from its Symbols and are not found by Symbol. Every symbol is kept
+	// when empty, the default. A change applies from the next fetch.
+	Permissions []string
+
+	c         *Client
+	mu        sync.Mutex
+	info      *ExchangeInfoResponse
+	symbols   map[string]*SymbolInfo
+	filtered  int
+	fetchedAt time.Time
+}
+
+// NewExchangeInfoCache create a cache of the exchange information fetched with c
+func NewExchangeInfoCache(c *Client, ttl time.Duration) *ExchangeInfoCache {
+	return &ExchangeInfoCache{TTL: ttl, c: c}
+}
+
+// Get return the cached exchange information, fetching it when missing or expired
+func (e *ExchangeInfoCache) Get(ctx context.Context) (*ExchangeInfoResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.info != nil && (e.TTL <= 0 || time.Since(e.fetchedAt) < e.TTL) {
+		return e.info, nil
+	}
+	return e.refresh(ctx)
+}
+
+// Refresh fetch the exchange information again, whatever its age
+func (e *ExchangeInfoCache) Refresh(ctx context.Context) (*ExchangeInfoResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.refresh(ctx)
+}
+
+// Invalidate drop the cached exchange information, the next call fetches it again
+func (e *ExchangeInfoCache) Invalidate() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.info = nil
+	e.symbols = nil
+	e.filtered = 0
+}
+
+// SymbolCount return the number of symbols of the last exchange information fetched that were indexed, and
+// the number left out by Permissions
+func (e *ExchangeInfoCache) SymbolCount() (indexed, filtered int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.symbols), e.filtered
+}
+
+// Symbol return the information of symbol, a SymbolNotFoundError when it is not listed. The symbol is looked up
+// upper-cased, like it is sent in the requests.
+func (e *ExchangeInfoCache) Symbol(ctx context.Context, symbol string) (*SymbolInfo, error) {
+	if _, err := e.Get(ctx); err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if info, ok := e.symbols[strings.ToUpper(symbol)]; ok {
+		return info, nil
+	}
+	return nil, &SymbolNotFoundError{Symbol: symbol, Suggestion: suggestSymbol(symbol, e.symbols)}
+}
+
+func (e *ExchangeInfoCache) refresh(ctx context.Context) (*ExchangeInfoResponse, error) {
+	info, err := e.c.NewExchangeInfoService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filtered := 0
+	if len(e.Permissions) > 0 {
+		kept := info.Symbols[:0]
+		for _, symbol := range info.Symbols {
+			if symbol.hasAnyPermission(e.Permissions) {
+				kept = append(kept, symbol)
+			}
+		}
+		filtered = len(info.Symbols) - len(kept)
+		// release the symbols left out
+		info.Symbols = append([]*SymbolInfo(nil), kept...)
+	}
+	symbols := make(map[string]*SymbolInfo, len(info.Symbols))
+	for _, symbol := range info.Symbols {
+		symbols[symbol.Symbol] = symbol
+	}
+	e.info, e.symbols, e.filtered, e.fetchedAt = info, symbols, filtered, time.Now()
+	return info, nil
+}
+
+// hasAnyPermission return true when the symbol has one of permissions
+func (s *SymbolInfo) hasAnyPermission(permissions []string) bool {
+	for _, permission := range permissions {
+		if s.HasPermission(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// exchangeInfoCache return the client cache, an uncached one when it was disabled
+func (c *Client) exchangeInfoCache() *ExchangeInfoCache {
+	if c.ExchangeInfoCache != nil {
+		return c.ExchangeInfoCache
+	}
+	return NewExchangeInfoCache(c, 0)
+}
+
+// ValidateSymbol return an error when symbol is not listed or not TRADING, using the cached exchange information
+func (c *Client) ValidateSymbol(ctx context.Context, symbol string) error {
+	info, err := c.exchangeInfoCache().Symbol(ctx, symbol)
+	if err != nil {
+		return err
+	}
+	if info.Status != SymbolStatusTrading {
+		return &SymbolNotTradingError{Symbol: symbol, Status: info.Status}
+	}
+	return nil
+}
+
+// ValidateInterval return ErrInvalidInterval when interval is not a kline interval, intervals are case sensitive (1m, 1M)
+func ValidateInterval(interval string) error {
+	for _, supported := range KlineIntervals {
+		if interval == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q, supported intervals are %s", ErrInvalidInterval, interval, strings.Join(KlineIntervals, ", "))
+}
+
+// suggestSymbol return the listed symbol closest to symbol, empty when none is within 2 edits
+func suggestSymbol(symbol string, symbols map[string]*SymbolInfo) string {
+	target := strings.ToUpper(symbol)
+	if _, ok := symbols[target]; ok {
+		return target
+	}
+	names := make([]string, 0, len(symbols))
+	for name := range symbols {
+		names = append(names, name)
+	}
+	// a stable suggestion among symbols at the same distance
+	sort.Strings(names)
+	best, bestDistance := "", 3
+	for _, name := range names {
+		if d := editDistance(target, name); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// editDistance return the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
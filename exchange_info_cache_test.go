@@ -0,0 +1,140 @@
+package binance_connector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var exchangeInfoData = []byte(`{
+	"timezone": "UTC",
+	"serverTime": 1565246363776,
+	"symbols": [
+		{"symbol": "BTCUSDT", "status": "TRADING", "baseAsset": "BTC", "quoteAsset": "USDT"},
+		{"symbol": "ETHUSDT", "status": "TRADING", "baseAsset": "ETH", "quoteAsset": "USDT"},
+		{"symbol": "LUNAUSDT", "status": "BREAK", "baseAsset": "LUNA", "quoteAsset": "USDT"}
+	]
+}`)
+
+type exchangeInfoCacheTestSuite struct {
+	suite.Suite
+	client  *Client
+	fetched int
+}
+
+func TestExchangeInfoCache(t *testing.T) {
+	suite.Run(t, new(exchangeInfoCacheTestSuite))
+}
+
+func (s *exchangeInfoCacheTestSuite) SetupTest() {
+	s.client = NewPublicClient("https://dummyapi.com")
+	s.fetched = 0
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.Equal("/api/v3/exchangeInfo", req.URL.Path)
+		s.fetched++
+		return newHTTPResponse(exchangeInfoData, http.StatusOK), nil
+	}
+}
+
+func (s *exchangeInfoCacheTestSuite) TestValidateSymbol() {
+	ctx := context.Background()
+	s.NoError(s.client.ValidateSymbol(ctx, "BTCUSDT"))
+
+	err := s.client.ValidateSymbol(ctx, "BTCUSD")
+	s.ErrorIs(err, ErrSymbolNotFound)
+	s.EqualError(err, "symbol BTCUSD not found; did you mean BTCUSDT?")
+
+	s.NoError(s.client.ValidateSymbol(ctx, "ethusdt"), "the symbol is looked up upper-cased, like it is sent")
+	err = s.client.ValidateSymbol(ctx, "ethusd")
+	s.EqualError(err, "symbol ethusd not found; did you mean ETHUSDT?")
+
+	err = s.client.ValidateSymbol(ctx, "DOGEEUR")
+	s.EqualError(err, "symbol DOGEEUR not found")
+
+	err = s.client.ValidateSymbol(ctx, "LUNAUSDT")
+	s.ErrorIs(err, ErrSymbolNotTrading)
+	s.Equal(&SymbolNotTradingError{Symbol: "LUNAUSDT", Status: "BREAK"}, err)
+
+	s.Equal(1, s.fetched, "the exchange information is cached")
+}
+
+func (s *exchangeInfoCacheTestSuite) TestExpiry() {
+	ctx := context.Background()
+	s.client.ExchangeInfoCache.TTL = 20 * time.Millisecond
+	_, err := s.client.ExchangeInfoCache.Get(ctx)
+	s.Require().NoError(err)
+	_, err = s.client.ExchangeInfoCache.Get(ctx)
+	s.Require().NoError(err)
+	s.Equal(1, s.fetched)
+
+	time.Sleep(30 * time.Millisecond)
+	info, err := s.client.ExchangeInfoCache.Symbol(ctx, "ETHUSDT")
+	s.Require().NoError(err)
+	s.Equal("ETH", info.BaseAsset)
+	s.Equal(2, s.fetched)
+
+	s.client.ExchangeInfoCache.Invalidate()
+	s.NoError(s.client.ValidateSymbol(ctx, "ETHUSDT"))
+	s.Equal(3, s.fetched)
+}
+
+func (s *exchangeInfoCacheTestSuite) TestPermissions() {
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(`{"symbols": [
+			{"symbol": "BTCUSDT", "status": "TRADING", "permissions": [], "permissionSets": [["SPOT", "MARGIN"]]},
+			{"symbol": "BTCUPUSDT", "status": "TRADING", "permissions": ["LEVERAGED"], "permissionSets": []},
+			{"symbol": "ETHUSDT", "status": "TRADING", "permissions": ["SPOT"]},
+			{"symbol": "XYZUSDT", "status": "TRADING", "permissions": [], "permissionSets": [["MARGIN"]]}
+		]}`), http.StatusOK), nil
+	}
+	ctx := context.Background()
+	cache := s.client.ExchangeInfoCache
+	indexed, filtered := cache.SymbolCount()
+	s.Zero(indexed)
+	s.Zero(filtered)
+
+	cache.Permissions = []string{"SPOT"}
+	info, err := cache.Get(ctx)
+	s.Require().NoError(err)
+	s.Len(info.Symbols, 2)
+	s.NoError(s.client.ValidateSymbol(ctx, "ETHUSDT"))
+	s.ErrorIs(s.client.ValidateSymbol(ctx, "BTCUPUSDT"), ErrSymbolNotFound)
+	indexed, filtered = cache.SymbolCount()
+	s.Equal(2, indexed)
+	s.Equal(2, filtered)
+
+	cache.Permissions = nil
+	_, err = cache.Refresh(ctx)
+	s.Require().NoError(err)
+	indexed, filtered = cache.SymbolCount()
+	s.Equal(4, indexed)
+	s.Zero(filtered)
+}
+
+func (s *exchangeInfoCacheTestSuite) TestDisabled() {
+	s.client.ExchangeInfoCache = nil
+	s.NoError(s.client.ValidateSymbol(context.Background(), "BTCUSDT"))
+	s.NoError(s.client.ValidateSymbol(context.Background(), "BTCUSDT"))
+	s.Equal(2, s.fetched)
+}
+
+func (s *exchangeInfoCacheTestSuite) TestFetchError() {
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(`{"code":-1000,"msg":"unknown"}`), http.StatusInternalServerError), nil
+	}
+	s.Error(s.client.ValidateSymbol(context.Background(), "BTCUSDT"))
+	s.NotErrorIs(s.client.ValidateSymbol(context.Background(), "BTCUSDT"), ErrSymbolNotFound)
+}
+
+func (s *exchangeInfoCacheTestSuite) TestValidateInterval() {
+	for _, interval := range []string{"1s", "1m", "4h", "1M"} {
+		s.NoError(ValidateInterval(interval))
+	}
+	for _, interval := range []string{"", "2m", "1H", "1y"} {
+		s.ErrorIs(ValidateInterval(interval), ErrInvalidInterval)
+	}
+	s.Contains(ValidateInterval("2m").Error(), `"2m"`)
+}
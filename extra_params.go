@@ -0,0 +1,33 @@
+package binance_connector
+
+import (
+	"net/url"
+)
+
+// WithParam return an option adding the parameter key to a request of any service, e.g. a parameter Binance
+// added before the service got a setter. The parameters set by the service take precedence, and the
+// parameter is signed with the others. timestamp and signature are always set by the client.
+func WithParam(key, value string) RequestOption {
+	return WithExtraParams(map[string]string{key: value})
+}
+
+// WithExtraParams return an option adding params to a request of any service, like WithParam
+func WithExtraParams(params map[string]string) RequestOption {
+	return func(r *request) {
+		values := r.query
+		// the parameters go with those of the service, in the body when it sends a form
+		if len(r.form) > 0 {
+			values = r.form
+		}
+		if values == nil {
+			r.query = url.Values{}
+			values = r.query
+		}
+		for key, value := range params {
+			if key == signatureKey || r.query.Has(key) || r.form.Has(key) {
+				continue
+			}
+			values.Set(key, value)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package binance_connector
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type extraParamsTestSuite struct {
+	suite.Suite
+	client *Client
+	sent   *http.Request
+}
+
+func TestExtraParams(t *testing.T) {
+	suite.Run(t, new(extraParamsTestSuite))
+}
+
+func (s *extraParamsTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.sent = nil
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.sent = req
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+}
+
+func (s *extraParamsTestSuite) TestExtraParams() {
+	_, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).
+		Do(newContext(), WithParam("selfTradePreventionMode", "EXPIRE_MAKER"),
+			WithExtraParams(map[string]string{"side": "SELL", "pegPriceType": "PRIMARY_PEG", "signature": "forged"}))
+	s.Require().NoError(err)
+	query := s.sent.URL.Query()
+	s.Equal("EXPIRE_MAKER", query.Get("selfTradePreventionMode"))
+	s.Equal("PRIMARY_PEG", query.Get("pegPriceType"))
+	s.Equal("BUY", query.Get("side"), "the setters take precedence")
+	s.Require().Len(query[signatureKey], 1)
+
+	// the extra parameters are signed
+	signed := url.Values{}
+	for key, values := range query {
+		if key != signatureKey {
+			signed[key] = values
+		}
+	}
+	mac := hmac.New(sha256.New, []byte("dummySecretKey"))
+	mac.Write([]byte(signed.Encode()))
+	s.Equal(hex.EncodeToString(mac.Sum(nil)), query.Get(signatureKey))
+}
+
+func (s *extraParamsTestSuite) TestExtraParamsWithoutParams() {
+	r := &request{}
+	WithParam("symbol", "btcusdt")(r)
+	s.Equal("btcusdt", r.query.Get("symbol"))
+
+	r = &request{form: url.Values{"asset": {"BTC"}}}
+	WithParam("amount", "1")(r)
+	s.Equal("1", r.form.Get("amount"), "the parameters go in the form of a request sending one")
+	s.Empty(r.query)
+}
@@ -2,7 +2,6 @@ package binance_connector
 
 import (
 	"context"
-	"github.com/goccy/go-json"
 	"net/http"
 )
 
@@ -48,7 +47,8 @@ func (s *GetFiatDepositWithdrawHistoryService) Do(ctx context.Context) (res *Get
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: fiatDepositWithdrawHistory,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetFiatDepositWithdrawHistoryService",
 	}
 	r.setParam("transactionType", *s.transactionType)
 	if s.beginTime != nil {
@@ -68,7 +68,7 @@ func (s *GetFiatDepositWithdrawHistoryService) Do(ctx context.Context) (res *Get
 		return nil, err
 	}
 	res = new(GetFiatDepositWithdrawHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -135,7 +135,8 @@ func (s *GetFiatPaymentHistoryService) Do(ctx context.Context) (res *GetFiatPaym
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: fiatPaymentHistory,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetFiatPaymentHistoryService",
 	}
 	r.setParam("transactionType", *s.transactionType)
 	if s.beginTime != nil {
@@ -155,7 +156,7 @@ func (s *GetFiatPaymentHistoryService) Do(ctx context.Context) (res *GetFiatPaym
 		return nil, err
 	}
 	res = new(GetFiatPaymentHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
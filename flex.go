@@ -0,0 +1,31 @@
+package binance_connector
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// FlexFloat is a float64 that unmarshals from a JSON number or a quoted number.
+// An empty string, null or an absent field decode as zero, as Binance sends "" for
+// numeric fields that do not apply to an order type.
+type FlexFloat float64
+
+// UnmarshalJSON implements json.Unmarshaler
+func (f *FlexFloat) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		*f = 0
+		return nil
+	}
+	v, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+	*f = FlexFloat(v)
+	return nil
+}
+
+// Float64 return the value as a float64
+func (f FlexFloat) Float64() float64 {
+	return float64(f)
+}
@@ -0,0 +1,35 @@
+package binance_connector
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/suite"
+)
+
+type flexTestSuite struct {
+	suite.Suite
+}
+
+func TestFlex(t *testing.T) {
+	suite.Run(t, new(flexTestSuite))
+}
+
+func (s *flexTestSuite) TestFlexFloatUnmarshal() {
+	var v struct {
+		Number  FlexFloat `json:"number"`
+		Quoted  FlexFloat `json:"quoted"`
+		Empty   FlexFloat `json:"empty"`
+		Null    FlexFloat `json:"null"`
+		Missing FlexFloat `json:"missing"`
+	}
+	err := json.Unmarshal([]byte(`{"number": 1.5, "quoted": "0.00120000", "empty": "", "null": null}`), &v)
+	s.Require().NoError(err)
+	s.Equal(1.5, v.Number.Float64())
+	s.Equal(0.0012, v.Quoted.Float64())
+	s.Zero(v.Empty)
+	s.Zero(v.Null)
+	s.Zero(v.Missing)
+
+	s.Error(json.Unmarshal([]byte(`{"number": "abc"}`), &v))
+}
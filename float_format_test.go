@@ -0,0 +1,78 @@
+package binance_connector
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type floatFormatTestSuite struct {
+	suite.Suite
+	client *Client
+	query  url.Values
+}
+
+func TestFloatFormat(t *testing.T) {
+	suite.Run(t, new(floatFormatTestSuite))
+}
+
+func (s *floatFormatTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.query = nil
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.query = req.URL.Query()
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+}
+
+func (s *floatFormatTestSuite) TestDefaultFloatFormatter() {
+	tests := map[float64]string{
+		0.00001:        "0.00001",
+		1e-8:           "0.00000001",
+		30000:          "30000",
+		1.5:            "1.5",
+		123456789.1234: "123456789.1234",
+		1e21:           "1000000000000000000000",
+	}
+	for value, expected := range tests {
+		s.Equal(expected, DefaultFloatFormatter("quantity", value))
+	}
+
+	r := (&request{}).setParam("quantity", 0.00001).addParam("prices", 2e-7)
+	s.Equal("0.00001", r.query.Get("quantity"))
+	s.Equal("0.0000002", r.query.Get("prices"))
+}
+
+func (s *floatFormatTestSuite) TestFormatFloat() {
+	order := func() {
+		_, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("LIMIT").TimeInForce("GTC").
+			Quantity(0.00001).Price(30000.5).Do(newContext())
+		s.Require().NoError(err)
+	}
+
+	order()
+	s.Equal("0.00001", s.query.Get("quantity"))
+	s.Equal("30000.5", s.query.Get("price"))
+
+	var names []string
+	s.client.FormatFloat = func(name string, value float64) string {
+		names = append(names, name)
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	}
+	order()
+	s.ElementsMatch([]string{"quantity", "price"}, names)
+	s.Equal("0.00", s.query.Get("quantity"))
+	s.Equal("30000.50", s.query.Get("price"))
+	s.NotEmpty(s.query.Get(signatureKey))
+	s.NotEmpty(s.query.Get(timestampKey), "the integers are not passed to the hook")
+}
+
+func (s *floatFormatTestSuite) TestFormatFloatOverridden() {
+	r := (&request{}).setParam("price", 1.5)
+	r.setParam("price", "1.50")
+	r.formatFloats(func(name string, value float64) string { return "formatted" })
+	s.Equal("1.50", r.query.Get("price"), "a float param replaced by a string is not formatted")
+}
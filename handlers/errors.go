@@ -8,10 +8,15 @@ import (
 type APIError struct {
 	Code    int64  `json:"code"`
 	Message string `json:"msg"`
+	// Status is the HTTP status code, or its equivalent reported by the websocket API
+	Status int `json:"-"`
 }
 
-// Error return error code and message
+// Error return error code and message, and the status when it is known
 func (e APIError) Error() string {
+	if e.Status != 0 {
+		return fmt.Sprintf("<APIError> code=%d, msg=%s, status=%d", e.Code, e.Message, e.Status)
+	}
 	return fmt.Sprintf("<APIError> code=%d, msg=%s", e.Code, e.Message)
 }
 
@@ -0,0 +1,73 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidIceberg is returned by CreateOrderService, before sending the order, for an icebergQty Binance would reject
+var ErrInvalidIceberg = errors.New("invalid iceberg order")
+
+// IcebergQty set icebergQty, the visible part of a LIMIT order with timeInForce GTC or of a LIMIT_MAKER order.
+// It is rounded down to the LOT_SIZE step of the symbol, using the cached exchange information, and must be
+// at most the quantity and within the ICEBERG_PARTS limit, or the order fails with ErrInvalidIceberg.
+func (s *CreateOrderService) IcebergQty(icebergQty float64) *CreateOrderService {
+	s.icebergQty = &icebergQty
+	return s
+}
+
+// validateIceberg check the iceberg parameters of the order and round icebergQty to the LOT_SIZE step
+func (s *CreateOrderService) validateIceberg(ctx context.Context) error {
+	if s.icebergQty == nil {
+		return nil
+	}
+	iceberg := *s.icebergQty
+	switch s.orderType {
+	case "LIMIT":
+		if s.timeInForce == nil || *s.timeInForce != "GTC" {
+			return fmt.Errorf("%w: icebergQty requires timeInForce GTC", ErrInvalidIceberg)
+		}
+	case "LIMIT_MAKER":
+	default:
+		return fmt.Errorf("%w: icebergQty is only valid for LIMIT and LIMIT_MAKER orders, not %s", ErrInvalidIceberg, s.orderType)
+	}
+	if math.IsNaN(iceberg) || iceberg <= 0 {
+		return fmt.Errorf("%w: icebergQty must be positive, got %g", ErrInvalidIceberg, iceberg)
+	}
+	if s.quantity == nil {
+		return fmt.Errorf("%w: icebergQty requires a quantity", ErrInvalidIceberg)
+	}
+	if iceberg > *s.quantity {
+		return fmt.Errorf("%w: icebergQty %g is above the quantity %g", ErrInvalidIceberg, iceberg, *s.quantity)
+	}
+
+	info, err := s.c.exchangeInfoCache().Symbol(ctx, s.symbol)
+	if err != nil {
+		return err
+	}
+	var stepSize string
+	var maxParts uint
+	for _, filter := range info.Filters {
+		switch filter.FilterType {
+		case "LOT_SIZE":
+			stepSize = filter.StepSize
+		case "ICEBERG_PARTS":
+			maxParts = filter.Limit
+		}
+	}
+	if iceberg, err = snapToStep(iceberg, stepSize, math.Floor); err != nil {
+		return err
+	}
+	if iceberg <= 0 {
+		return fmt.Errorf("%w: icebergQty %g is below the LOT_SIZE step %s", ErrInvalidIceberg, *s.icebergQty, stepSize)
+	}
+	// the order is split in ceil(quantity / icebergQty) parts
+	if parts := math.Ceil(*s.quantity/iceberg - 1e-9); maxParts > 0 && parts > float64(maxParts) {
+		return fmt.Errorf("%w: icebergQty %g splits the quantity in %g parts, above the ICEBERG_PARTS limit %d",
+			ErrInvalidIceberg, iceberg, parts, maxParts)
+	}
+	s.icebergQty = &iceberg
+	return nil
+}
@@ -0,0 +1,80 @@
+package binance_connector
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var icebergExchangeInfoData = []byte(`{
+	"symbols": [
+		{
+			"symbol": "BTCUSDT",
+			"status": "TRADING",
+			"filters": [
+				{"filterType": "LOT_SIZE", "minQty": "0.00001000", "maxQty": "9000.00000000", "stepSize": "0.00001000"},
+				{"filterType": "ICEBERG_PARTS", "limit": 10}
+			]
+		}
+	]
+}`)
+
+type icebergTestSuite struct {
+	suite.Suite
+	client *Client
+	orders []url.Values
+}
+
+func TestIceberg(t *testing.T) {
+	suite.Run(t, new(icebergTestSuite))
+}
+
+func (s *icebergTestSuite) SetupTest() {
+	s.orders = nil
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/api/v3/exchangeInfo" {
+			return newHTTPResponse(icebergExchangeInfoData, http.StatusOK), nil
+		}
+		s.orders = append(s.orders, req.URL.Query())
+		return newHTTPResponse([]byte(`{"symbol":"BTCUSDT","orderId":1}`), http.StatusOK), nil
+	}
+}
+
+func (s *icebergTestSuite) newOrder() *CreateOrderService {
+	return s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("LIMIT").TimeInForce("GTC").
+		Quantity(1).Price(30000)
+}
+
+func (s *icebergTestSuite) TestRounded() {
+	_, err := s.newOrder().IcebergQty(0.123456789).Do(newContext())
+	s.Require().NoError(err)
+	s.Require().Len(s.orders, 1)
+	s.Equal("0.12345", s.orders[0].Get("icebergQty"), "rounded down to the LOT_SIZE step")
+
+	_, err = s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("SELL").Type("LIMIT_MAKER").
+		Quantity(1).Price(30000).IcebergQty(0.5).DoNormalized(newContext())
+	s.Require().NoError(err)
+	s.Equal("0.5", s.orders[1].Get("icebergQty"))
+}
+
+func (s *icebergTestSuite) TestInvalid() {
+	tests := map[string]*CreateOrderService{
+		"invalid iceberg order: icebergQty requires timeInForce GTC": s.newOrder().TimeInForce("IOC").IcebergQty(0.1),
+		"invalid iceberg order: icebergQty is only valid for LIMIT and LIMIT_MAKER orders, not MARKET": s.client.NewCreateOrderService().
+			Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).IcebergQty(0.1),
+		"invalid iceberg order: icebergQty 1.5 is above the quantity 1":                 s.newOrder().IcebergQty(1.5),
+		"invalid iceberg order: icebergQty must be positive, got 0":                     s.newOrder().IcebergQty(0),
+		"invalid iceberg order: icebergQty 1e-06 is below the LOT_SIZE step 0.00001000": s.newOrder().IcebergQty(0.000001),
+		"invalid iceberg order: icebergQty 0.05 splits the quantity in 20 parts, above the ICEBERG_PARTS limit 10": s.newOrder().
+			IcebergQty(0.05),
+	}
+	for expected, order := range tests {
+		_, err := order.Do(newContext())
+		s.ErrorIs(err, ErrInvalidIceberg)
+		s.EqualError(err, expected)
+	}
+	s.Empty(s.orders, "no invalid order is sent")
+}
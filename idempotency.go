@@ -0,0 +1,161 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/luciano-personal-org/binance-connector/handlers"
+)
+
+// ErrDuplicateOrder is matched by errors.Is on a DuplicateOrderError
+var ErrDuplicateOrder = errors.New("duplicate order")
+
+const (
+	// errCodeNewOrderRejected is the error code of a rejected order, "Duplicate order sent." among others
+	errCodeNewOrderRejected = -2010
+	// errCodeOrderArchived is returned when querying an order archived by Binance
+	errCodeOrderArchived = -2026
+)
+
+// DuplicateOrderError is returned when an order sent with an idempotency key was rejected as a duplicate
+// and the existing order could not be fetched, e.g. because Binance archived it (-2026)
+type DuplicateOrderError struct {
+	ClientOrderId string
+	// Err is the error of the query of the existing order
+	Err error
+}
+
+func (e *DuplicateOrderError) Error() string {
+	return fmt.Sprintf("order %s already sent, fetching it failed: %v", e.ClientOrderId, e.Err)
+}
+
+// Is return true for ErrDuplicateOrder
+func (e *DuplicateOrderError) Is(target error) bool {
+	return target == ErrDuplicateOrder
+}
+
+func (e *DuplicateOrderError) Unwrap() error {
+	return e.Err
+}
+
+// Archived return true when the existing order is archived and cannot be queried anymore
+func (e *DuplicateOrderError) Archived() bool {
+	var apiErr *handlers.APIError
+	return errors.As(e.Err, &apiErr) && apiErr.Code == errCodeOrderArchived
+}
+
+// isDuplicateOrder return true when err rejects an order whose newClientOrderId was already used
+func isDuplicateOrder(err error) bool {
+	var apiErr *handlers.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == errCodeNewOrderRejected &&
+		strings.Contains(strings.ToLower(apiErr.Message), "duplicate order")
+}
+
+// IdempotencyKey set newClientOrderId to key and make the order safe to retry: when Binance rejects it
+// as a duplicate of an order sent with the same key, Do and DoNormalized fetch and return the existing
+// order instead of the error. It is fetched with the credentials and the recvWindow of the order, without its
+// other options. Without an idempotency key the duplicate error is returned as is.
+func (s *CreateOrderService) IdempotencyKey(key string) *CreateOrderService {
+	s.newClientOrderId = &key
+	s.idempotent = true
+	return s
+}
+
+// existingOrder return the order already sent with the idempotency key when err rejects a duplicate,
+// err otherwise
+func (s *CreateOrderService) existingOrder(ctx context.Context, err error, opts ...RequestOption) (*CreateOrderResponseFULL, error) {
+	if !s.idempotent || s.newClientOrderId == nil || !isDuplicateOrder(err) {
+		return nil, err
+	}
+	order, qerr := s.c.NewGetOrderService().Symbol(s.symbol).OrigClientOrderId(*s.newClientOrderId).Do(ctx, queryOptions(opts)...)
+	if qerr != nil {
+		return nil, &DuplicateOrderError{ClientOrderId: *s.newClientOrderId, Err: qerr}
+	}
+	return &CreateOrderResponseFULL{
+		Symbol:                  order.Symbol,
+		OrderId:                 order.OrderId,
+		OrderListId:             order.OrderListId,
+		ClientOrderId:           order.ClientOrderId,
+		TransactTime:            order.Time,
+		Price:                   order.Price,
+		OrigQty:                 order.OrigQty,
+		ExecutedQty:             order.ExecutedQty,
+		CumulativeQuoteQty:      order.CumulativeQuoteQty,
+		OrigQuoteOrderQty:       order.OrigQuoteOrderQty,
+		Status:                  order.Status,
+		TimeInForce:             order.TimeInForce,
+		Type:                    order.Type,
+		Side:                    order.Side,
+		WorkingTime:             order.WorkingTime,
+		SelfTradePreventionMode: order.SelfTradePreventionMode,
+		IcebergQty:              order.IcebergQty,
+		PreventedMatchId:        order.PreventedMatchId,
+		PreventedQuantity:       order.PreventedQuantity,
+		StopPrice:               order.StopPrice,
+		StrategyId:              order.StrategyId,
+		StrategyType:            order.StrategyType,
+		TrailingDelta:           order.TrailingDelta,
+		TrailingTime:            order.TrailingTime,
+	}, nil
+}
+
+// queryOptions return the options of an order the query of the existing order is sent with: the credentials of
+// its account and its recvWindow. The others, e.g. WithParam, are about the order.
+func queryOptions(opts []RequestOption) []RequestOption {
+	r := &request{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	var query []RequestOption
+	if r.apiKey != "" {
+		query = append(query, credentials{apiKey: r.apiKey, secretKey: r.secretKey, signer: r.signer}.option())
+	}
+	if r.recvWindow > 0 {
+		query = append(query, WithRecvWindow(r.recvWindow))
+	}
+	return query
+}
+
+// asRespType return order as the response type Do decodes, the existing order has no fills
+func (order *CreateOrderResponseFULL) asRespType(respType int) interface{} {
+	switch respType {
+	case ACK:
+		return &CreateOrderResponseACK{
+			Symbol:        order.Symbol,
+			OrderId:       order.OrderId,
+			OrderListId:   order.OrderListId,
+			ClientOrderId: order.ClientOrderId,
+			TransactTime:  order.TransactTime,
+		}
+	case RESULT:
+		return &CreateOrderResponseRESULT{
+			Symbol:                  order.Symbol,
+			OrderId:                 order.OrderId,
+			OrderListId:             order.OrderListId,
+			ClientOrderId:           order.ClientOrderId,
+			TransactTime:            order.TransactTime,
+			Price:                   order.Price,
+			OrigQty:                 order.OrigQty,
+			ExecutedQty:             order.ExecutedQty,
+			CumulativeQuoteQty:      order.CumulativeQuoteQty,
+			OrigQuoteOrderQty:       order.OrigQuoteOrderQty,
+			Status:                  order.Status,
+			TimeInForce:             order.TimeInForce,
+			Type:                    order.Type,
+			Side:                    order.Side,
+			WorkingTime:             order.WorkingTime,
+			SelfTradePreventionMode: order.SelfTradePreventionMode,
+			IcebergQty:              order.IcebergQty,
+			PreventedMatchId:        order.PreventedMatchId,
+			PreventedQuantity:       order.PreventedQuantity,
+			StopPrice:               order.StopPrice,
+			StrategyId:              order.StrategyId,
+			StrategyType:            order.StrategyType,
+			TrailingDelta:           order.TrailingDelta,
+			TrailingTime:            order.TrailingTime,
+		}
+	}
+	return order
+}
@@ -0,0 +1,109 @@
+package binance_connector
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+type idempotencyTestSuite struct {
+	suite.Suite
+	client  *Client
+	queries int
+	query   func(req *http.Request) (*http.Response, error)
+}
+
+func TestIdempotency(t *testing.T) {
+	suite.Run(t, new(idempotencyTestSuite))
+}
+
+func (s *idempotencyTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.queries = 0
+	s.query = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(`{"symbol":"BTCUSDT","orderId":28,"orderListId":-1,"clientOrderId":"key-1","price":"30000.00","origQty":"0.01",
+			"executedQty":"0.00","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY","time":1499827319559,"workingTime":1499827319559}`), http.StatusOK), nil
+	}
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			s.queries++
+			s.Equal("key-1", req.URL.Query().Get("origClientOrderId"))
+			s.Equal("BTCUSDT", req.URL.Query().Get("symbol"))
+			return s.query(req)
+		}
+		return newHTTPResponse([]byte(`{"code":-2010,"msg":"Duplicate order sent."}`), http.StatusBadRequest), nil
+	}
+}
+
+func (s *idempotencyTestSuite) order() *CreateOrderService {
+	return s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("LIMIT").
+		TimeInForce("GTC").Quantity(0.01).Price(30000)
+}
+
+func (s *idempotencyTestSuite) TestDuplicateReturnsExistingOrder() {
+	res, err := s.order().IdempotencyKey("key-1").DoNormalized(newContext())
+	s.Require().NoError(err)
+	s.True(res.Duplicate())
+	s.Equal(RESULT, res.RespType())
+	s.Equal(int64(28), res.OrderId)
+	s.Equal("key-1", res.ClientOrderId)
+	s.Equal("NEW", res.Status)
+	s.Equal(uint64(1499827319559), res.TransactTime)
+
+	full, err := s.order().IdempotencyKey("key-1").Do(newContext())
+	s.Require().NoError(err)
+	s.Require().IsType(&CreateOrderResponseFULL{}, full)
+	s.Equal(int64(28), full.(*CreateOrderResponseFULL).OrderId)
+
+	ack, err := s.order().IdempotencyKey("key-1").NewOrderRespType("ACK").Do(newContext())
+	s.Require().NoError(err)
+	s.Equal(&CreateOrderResponseACK{Symbol: "BTCUSDT", OrderId: 28, OrderListId: -1, ClientOrderId: "key-1", TransactTime: 1499827319559}, ack)
+	s.Equal(3, s.queries)
+}
+
+func (s *idempotencyTestSuite) TestQueryOptions() {
+	s.query = func(req *http.Request) (*http.Response, error) {
+		s.Equal("otherAPIKey", req.Header.Get("X-MBX-APIKEY"))
+		s.Equal("5000", req.URL.Query().Get("recvWindow"))
+		s.False(req.URL.Query().Has("strategyId"), "the parameters of the order are not sent with the query")
+		return newHTTPResponse([]byte(`{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"key-1","status":"NEW"}`), http.StatusOK), nil
+	}
+	res, err := s.order().IdempotencyKey("key-1").DoNormalized(newContext(),
+		WithCredentials("otherAPIKey", "otherSecretKey"), WithRecvWindow(5000), WithParam("strategyId", "7"))
+	s.Require().NoError(err)
+	s.Equal(int64(28), res.OrderId)
+	s.Equal(1, s.queries)
+}
+
+func (s *idempotencyTestSuite) TestDuplicateWithoutIdempotencyKey() {
+	_, err := s.order().NewClientOrderId("key-1").DoNormalized(newContext())
+	var apiErr *handlers.APIError
+	s.Require().ErrorAs(err, &apiErr)
+	s.Equal(int64(-2010), apiErr.Code)
+	s.Zero(s.queries)
+}
+
+func (s *idempotencyTestSuite) TestOtherRejection() {
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.Equal(http.MethodPost, req.Method)
+		return newHTTPResponse([]byte(`{"code":-2010,"msg":"Account has insufficient balance for requested action."}`), http.StatusBadRequest), nil
+	}
+	_, err := s.order().IdempotencyKey("key-1").Do(newContext())
+	s.Require().Error(err)
+	s.NotErrorIs(err, ErrDuplicateOrder)
+}
+
+func (s *idempotencyTestSuite) TestArchivedOrder() {
+	s.query = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(`{"code":-2026,"msg":"Order was canceled or expired with no executed qty over 90 days ago and has been archived."}`), http.StatusBadRequest), nil
+	}
+	_, err := s.order().IdempotencyKey("key-1").DoNormalized(newContext())
+	s.Require().ErrorIs(err, ErrDuplicateOrder)
+	var dupErr *DuplicateOrderError
+	s.Require().ErrorAs(err, &dupErr)
+	s.Equal("key-1", dupErr.ClientOrderId)
+	s.True(dupErr.Archived())
+	s.Equal(1, s.queries)
+}
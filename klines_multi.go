@@ -0,0 +1,37 @@
+package binance_connector
+
+import (
+	"context"
+)
+
+// KlinesMultiConcurrency is the maximum number of kline requests KlinesMulti keeps in flight
+var KlinesMultiConcurrency = 4
+
+// KlinesMulti fetch the last limit klines of interval for each symbol concurrently, at most KlinesMultiConcurrency
+// requests at once (DefaultBatchConcurrency when it is not positive), and return the result of each symbol keyed
+// by symbol. A symbol failing, e.g. an unknown one, does not stop the others.
+// Each request costs the klines weight: enable the client RateLimiter to hold them back instead of getting 429
+// for a large universe. Once the client is rate limited the remaining symbols fail right away with a
+// RateLimitedError rather than being sent.
+func (c *Client) KlinesMulti(ctx context.Context, symbols []string, interval string, limit int, opts ...RequestOption) map[string]Result[[]*KlinesResponse] {
+	unique := make([]string, 0, len(symbols))
+	seen := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		if !seen[symbol] {
+			seen[symbol] = true
+			unique = append(unique, symbol)
+		}
+	}
+	results := RunBatch(ctx, unique, KlinesMultiConcurrency, func(ctx context.Context, symbol string) ([]*KlinesResponse, error) {
+		s := c.NewKlinesService().Symbol(symbol).Interval(interval)
+		if limit > 0 {
+			s.Limit(limit)
+		}
+		return s.Do(ctx, opts...)
+	})
+	bySymbol := make(map[string]Result[[]*KlinesResponse], len(unique))
+	for i, symbol := range unique {
+		bySymbol[symbol] = results[i]
+	}
+	return bySymbol
+}
@@ -0,0 +1,63 @@
+package binance_connector
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type klinesMultiTestSuite struct {
+	suite.Suite
+	client *Client
+}
+
+func TestKlinesMulti(t *testing.T) {
+	suite.Run(t, new(klinesMultiTestSuite))
+}
+
+func (s *klinesMultiTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+}
+
+func (s *klinesMultiTestSuite) TestKlinesBySymbol() {
+	var running, peak, calls atomic.Int32
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		query := req.URL.Query()
+		s.Equal("1h", query.Get("interval"))
+		s.Equal("2", query.Get("limit"))
+		if query.Get("symbol") == "UNKNOWN" {
+			return newHTTPResponse([]byte(`{"code":-1121,"msg":"Invalid symbol."}`), http.StatusBadRequest), nil
+		}
+		return newHTTPResponse([]byte(`[
+			[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368","0"],
+			[1499644800000,"0.01577100","0.01600000","0.01570000","0.01590000","1000.00000000",1500249599999,"15.90000000",12,"500.00000000","7.95000000","0"]
+		]`), http.StatusOK), nil
+	}
+
+	symbols := []string{"BTCUSDT", "ETHUSDT", "UNKNOWN", "BNBUSDT", "XRPUSDT", "ADAUSDT", "BTCUSDT"}
+	results := s.client.KlinesMulti(context.Background(), symbols, "1h", 2)
+
+	s.Require().Len(results, 6)
+	s.Equal(int32(6), calls.Load())
+	s.LessOrEqual(peak.Load(), int32(KlinesMultiConcurrency))
+	s.Require().True(results["BTCUSDT"].OK())
+	s.Require().Len(results["BTCUSDT"].Value, 2)
+	s.Equal(uint64(1499040000000), results["BTCUSDT"].Value[0].OpenTime)
+	s.Equal("0.01590000", results["ADAUSDT"].Value[1].Close)
+	s.False(results["UNKNOWN"].OK())
+	s.Contains(results["UNKNOWN"].Err.Error(), "Invalid symbol")
+}
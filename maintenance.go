@@ -0,0 +1,78 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/luciano-personal-org/binance-connector/handlers"
+)
+
+// ErrMaintenance is matched by errors.Is on a MaintenanceError
+var ErrMaintenance = errors.New("binance system maintenance")
+
+// MaintenanceError is returned while Binance is in system maintenance: a REST request answered 503
+// Service Unavailable or a maintenance message, a stream connection refused with 503, or a system status of 1.
+// Pause the requests until the maintenance ends rather than retrying them.
+type MaintenanceError struct {
+	// Status is the HTTP status of the response, zero when the maintenance was reported by the system status
+	Status int
+	// Message is the maintenance message of Binance when available
+	Message string
+	// Err is the response error, a *handlers.APIError for REST responses
+	Err error
+}
+
+func (e *MaintenanceError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("binance is in system maintenance: %s", e.Message)
+	}
+	return "binance is in system maintenance"
+}
+
+// Is return true for ErrMaintenance
+func (e *MaintenanceError) Is(target error) bool {
+	return target == ErrMaintenance
+}
+
+// Unwrap return the response error
+func (e *MaintenanceError) Unwrap() error {
+	return e.Err
+}
+
+// maintenanceError return a MaintenanceError when a REST error response reports a system maintenance.
+// 503 responses with an error code are left alone: Binance sends them for requests whose execution status
+// is unknown, e.g. "Unknown error, please check your request or try again later.", which need a status query.
+func maintenanceError(status int, apiErr *handlers.APIError) error {
+	maintenance := strings.Contains(strings.ToLower(apiErr.Message), "maintenance")
+	if status == http.StatusServiceUnavailable && apiErr.Code == 0 {
+		maintenance = true
+	}
+	if !maintenance {
+		return nil
+	}
+	message := apiErr.Message
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return &MaintenanceError{Status: status, Message: message, Err: apiErr}
+}
+
+// CheckMaintenance return a MaintenanceError when GET /sapi/v1/system/status reports a system maintenance,
+// nil when the system is normal
+func (c *Client) CheckMaintenance(ctx context.Context) error {
+	res, err := c.Request(ctx, http.MethodGet, systemStatusEndpoint, nil, SecurityTypeNone)
+	if err != nil {
+		return err
+	}
+	var status SystemStatusResponse
+	if err := c.unmarshal(res.Body, &status); err != nil {
+		return err
+	}
+	if status.Status == SystemStatusMaintenance {
+		return &MaintenanceError{Message: status.Msg}
+	}
+	return nil
+}
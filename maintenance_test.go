@@ -0,0 +1,107 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+type maintenanceTestSuite struct {
+	suite.Suite
+	client *Client
+	status string
+	orders int
+}
+
+func TestMaintenance(t *testing.T) {
+	suite.Run(t, new(maintenanceTestSuite))
+}
+
+func (s *maintenanceTestSuite) SetupTest() {
+	s.status = `{"status": 0, "msg": "normal"}`
+	s.orders = 0
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == systemStatusEndpoint {
+			return newHTTPResponse([]byte(s.status), http.StatusOK), nil
+		}
+		s.orders++
+		return newHTTPResponse([]byte(`{"symbol":"BTCUSDT","orderId":1}`), http.StatusOK), nil
+	}
+}
+
+func (s *maintenanceTestSuite) respond(data string, status int) {
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(data), status), nil
+	}
+}
+
+func (s *maintenanceTestSuite) TestServiceUnavailable() {
+	s.respond(`<html><body>Service Unavailable</body></html>`, http.StatusServiceUnavailable)
+	err := s.client.NewPingService().Do(context.Background())
+	s.Require().ErrorIs(err, ErrMaintenance)
+	var maintenance *MaintenanceError
+	s.Require().True(errors.As(err, &maintenance))
+	s.Equal(http.StatusServiceUnavailable, maintenance.Status)
+	s.Equal("Service Unavailable", maintenance.Message)
+	var apiErr *handlers.APIError
+	s.True(errors.As(err, &apiErr))
+}
+
+func (s *maintenanceTestSuite) TestMaintenanceMessage() {
+	s.respond(`{"code": 1, "msg": "System is under maintenance."}`, http.StatusBadRequest)
+	_, err := s.client.NewGetAccountService().Do(context.Background())
+	s.Require().ErrorIs(err, ErrMaintenance)
+	s.EqualError(err, "binance is in system maintenance: System is under maintenance.")
+}
+
+func (s *maintenanceTestSuite) TestUnknownExecutionStatus() {
+	// an order whose execution status is unknown is not a maintenance
+	s.respond(`{"code": -1006, "msg": "Unknown error, please check your request or try again later."}`, http.StatusServiceUnavailable)
+	_, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).Do(context.Background())
+	s.Require().Error(err)
+	s.NotErrorIs(err, ErrMaintenance)
+	s.True(handlers.IsAPIError(err))
+}
+
+func (s *maintenanceTestSuite) TestCheckMaintenance() {
+	s.NoError(s.client.CheckMaintenance(context.Background()))
+
+	s.status = `{"status": 1, "msg": "system_maintenance"}`
+	err := s.client.CheckMaintenance(context.Background())
+	s.Equal(&MaintenanceError{Message: "system_maintenance"}, err)
+}
+
+func (s *maintenanceTestSuite) TestCheckBeforeOrders() {
+	s.status = `{"status": 1, "msg": "system_maintenance"}`
+	order := func() error {
+		_, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).Do(context.Background())
+		return err
+	}
+	s.NoError(order())
+	s.Equal(1, s.orders)
+
+	s.client.CheckMaintenanceBeforeOrders = true
+	s.ErrorIs(order(), ErrMaintenance)
+	s.Equal(1, s.orders)
+
+	s.status = `{"status": 0, "msg": "normal"}`
+	s.NoError(order())
+	s.Equal(2, s.orders)
+}
+
+func (s *maintenanceTestSuite) TestStreamRefused() {
+	server, url := newWsTestServer(func(conn *websocket.Conn) {})
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	_, err := dialWs(newWsConfig(url))
+	s.ErrorIs(err, ErrMaintenance)
+}
@@ -2,10 +2,17 @@ package binance_connector
 
 import (
 	"context"
-	"github.com/goccy/go-json"
+	"errors"
 	"net/http"
+	"time"
 )
 
+// ErrIsolatedSymbolRequired is returned by isolated margin services sent without a symbol
+var ErrIsolatedSymbolRequired = errors.New("symbol is required for isolated margin")
+
+// marginTransferHistoryWindow is the longest time range of a margin transfer history request
+const marginTransferHistoryWindow = 30 * 24 * time.Hour
+
 // Get all margin assets API Endpoint
 const (
 	getAllMarginAssetsEndpoint = "/sapi/v1/margin/allAssets"
@@ -21,14 +28,15 @@ func (s *GetAllMarginAssetsService) Do(ctx context.Context, opts ...RequestOptio
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getAllMarginAssetsEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetAllMarginAssetsService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []*GetAllMarginAssetsResponse{}, err
 	}
 	res = make([]*GetAllMarginAssetsResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*GetAllMarginAssetsResponse{}, err
 	}
@@ -63,14 +71,15 @@ func (s *GetAllMarginPairsService) Do(ctx context.Context, opts ...RequestOption
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getAllMarginPairsEndpoint,
-		secType:  secTypeAPIKey,
+		secType:  SecurityTypeMarketData,
+		service:  "GetAllMarginPairsService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []*GetAllMarginPairsResponse{}, err
 	}
 	res = make([]*GetAllMarginPairsResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*GetAllMarginPairsResponse{}, err
 	}
@@ -110,7 +119,8 @@ func (s *QueryMarginPriceIndexService) Do(ctx context.Context, opts ...RequestOp
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: queryMarginPriceIndexEndpoint,
-		secType:  secTypeAPIKey,
+		secType:  SecurityTypeMarketData,
+		service:  "QueryMarginPriceIndexService",
 	}
 	r.setParam("symbol", s.symbol)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -118,7 +128,7 @@ func (s *QueryMarginPriceIndexService) Do(ctx context.Context, opts ...RequestOp
 		return &QueryMarginPriceIndexResponse{}, err
 	}
 	res = new(QueryMarginPriceIndexResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &QueryMarginPriceIndexResponse{}, err
 	}
@@ -239,7 +249,8 @@ func (s *MarginAccountNewOrderService) Do(ctx context.Context, opts ...RequestOp
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: marginAccountNewOrderEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "MarginAccountNewOrderService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -303,7 +314,7 @@ func (s *MarginAccountNewOrderService) Do(ctx context.Context, opts ...RequestOp
 	case FULL:
 		res = new(MarginAccountNewOrderResponseFULL)
 	}
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -338,21 +349,21 @@ type MarginAccountNewOrderResponseRESULT struct {
 
 // Create MarginAccountNewOrderResponseFULL
 type MarginAccountNewOrderResponseFULL struct {
-	Symbol                string  `json:"symbol"`
-	OrderId               int64   `json:"orderId"`
-	ClientOrderId         string  `json:"clientOrderId"`
-	TransactTime          uint64  `json:"transactTime"`
-	Price                 string  `json:"price"`
-	OrigQty               string  `json:"origQty"`
-	ExecutedQty           string  `json:"executedQty"`
-	CumulativeQuoteQty    string  `json:"cummulativeQuoteQty"`
-	Status                string  `json:"status"`
-	TimeInForce           string  `json:"timeInForce"`
-	Type                  string  `json:"type"`
-	Side                  string  `json:"side"`
-	MarginBuyBorrowAmount float64 `json:"marginBuyBorrowAmount"`
-	MarginBuyBorrowAsset  string  `json:"marginBuyBorrowAsset"`
-	IsIsolated            bool    `json:"isIsolated"`
+	Symbol                string    `json:"symbol"`
+	OrderId               int64     `json:"orderId"`
+	ClientOrderId         string    `json:"clientOrderId"`
+	TransactTime          uint64    `json:"transactTime"`
+	Price                 string    `json:"price"`
+	OrigQty               string    `json:"origQty"`
+	ExecutedQty           string    `json:"executedQty"`
+	CumulativeQuoteQty    string    `json:"cummulativeQuoteQty"`
+	Status                string    `json:"status"`
+	TimeInForce           string    `json:"timeInForce"`
+	Type                  string    `json:"type"`
+	Side                  string    `json:"side"`
+	MarginBuyBorrowAmount FlexFloat `json:"marginBuyBorrowAmount"`
+	MarginBuyBorrowAsset  string    `json:"marginBuyBorrowAsset"`
+	IsIsolated            bool      `json:"isIsolated"`
 	Fills                 []struct {
 		Price           string `json:"price"`
 		Qty             string `json:"qty"`
@@ -408,10 +419,14 @@ func (s *MarginAccountCancelOrderService) NewClientOrderId(newClientOrderId stri
 
 // Do send request
 func (s *MarginAccountCancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res *MarginAccountCancelOrderResponse, err error) {
+	if err := requireOrderId("MarginAccountCancelOrderService", orderIdParam{"orderId", s.orderId != nil}, orderIdParam{"origClientOrderId", isSet(s.origClientOrderId)}); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: marginAccountCancelOrderEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "MarginAccountCancelOrderService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -434,7 +449,7 @@ func (s *MarginAccountCancelOrderService) Do(ctx context.Context, opts ...Reques
 		return &MarginAccountCancelOrderResponse{}, err
 	}
 	res = new(MarginAccountCancelOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginAccountCancelOrderResponse{}, err
 	}
@@ -487,7 +502,8 @@ func (s *MarginAccountCancelAllOrdersService) Do(ctx context.Context, opts ...Re
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: marginAccountCancelAllOrdersEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "MarginAccountCancelAllOrdersService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -501,7 +517,7 @@ func (s *MarginAccountCancelAllOrdersService) Do(ctx context.Context, opts ...Re
 		return &MarginAccountCancelAllOrdersResponse{}, err
 	}
 	res = new(MarginAccountCancelAllOrdersResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginAccountCancelAllOrdersResponse{}, err
 	}
@@ -590,7 +606,8 @@ func (s *CrossMarginTransferHistoryService) Do(ctx context.Context, opts ...Requ
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: crossMarginTransferHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "CrossMarginTransferHistoryService",
 	}
 	if s.asset != nil {
 		r.setParam("asset", *s.asset)
@@ -618,24 +635,163 @@ func (s *CrossMarginTransferHistoryService) Do(ctx context.Context, opts ...Requ
 		return &CrossMarginTransferHistoryResponse{}, err
 	}
 	res = new(CrossMarginTransferHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &CrossMarginTransferHistoryResponse{}, err
 	}
 	return res, nil
 }
 
+// Paginate return a paginator of the transfers between start and end, in windows of at most 30 days.
+// Filters set on the service apply to every page.
+func (s *CrossMarginTransferHistoryService) Paginate(start, end time.Time) *Paginator[MarginTransferRecord] {
+	return NewPaginator(func(ctx context.Context, page Page) ([]MarginTransferRecord, error) {
+		s.StartTime(page.StartTime).EndTime(page.EndTime).Current(page.Number).Size(page.Limit)
+		res, err := s.Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return res.Rows, nil
+	}, start, end).Window(marginTransferHistoryWindow).PageSize(100)
+}
+
 // CrossMarginTransferHistoryResponse define cross margin transfer history response
 type CrossMarginTransferHistoryResponse struct {
-	Rows []struct {
-		Amount    string `json:"amount"`
-		Asset     string `json:"asset"`
-		Status    string `json:"status"`
-		Timestamp uint64 `json:"timestamp"`
-		TxId      int64  `json:"txId"`
-		Type      string `json:"type"`
-	} `json:"rows"`
-	Total int `json:"total"`
+	Rows  []MarginTransferRecord `json:"rows"`
+	Total int                    `json:"total"`
+}
+
+// MarginTransferRecord define a transfer in or out of a margin account.
+// Type is ROLL_IN or ROLL_OUT, TransFrom and TransTo are SPOT or ISOLATED_MARGIN for isolated transfers.
+type MarginTransferRecord struct {
+	Amount    string `json:"amount"`
+	Asset     string `json:"asset"`
+	Status    string `json:"status"`
+	Timestamp uint64 `json:"timestamp"`
+	TxId      int64  `json:"txId"`
+	Type      string `json:"type"`
+	TransFrom string `json:"transFrom"`
+	TransTo   string `json:"transTo"`
+}
+
+// IsolatedMarginTransferHistoryService get isolated margin transfer history, Binance serves it from
+// the cross margin transfer endpoint with the isolatedSymbol parameter
+type IsolatedMarginTransferHistoryService struct {
+	c         *Client
+	symbol    string
+	asset     *string
+	orderType *string
+	startTime *uint64
+	endTime   *uint64
+	current   *int
+	size      *int
+	archived  *string
+}
+
+// Symbol set isolatedSymbol
+func (s *IsolatedMarginTransferHistoryService) Symbol(symbol string) *IsolatedMarginTransferHistoryService {
+	s.symbol = symbol
+	return s
+}
+
+// Asset set asset
+func (s *IsolatedMarginTransferHistoryService) Asset(asset string) *IsolatedMarginTransferHistoryService {
+	s.asset = &asset
+	return s
+}
+
+// OrderType set orderType
+func (s *IsolatedMarginTransferHistoryService) OrderType(orderType string) *IsolatedMarginTransferHistoryService {
+	s.orderType = &orderType
+	return s
+}
+
+// StartTime set startTime
+func (s *IsolatedMarginTransferHistoryService) StartTime(startTime uint64) *IsolatedMarginTransferHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *IsolatedMarginTransferHistoryService) EndTime(endTime uint64) *IsolatedMarginTransferHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current set current
+func (s *IsolatedMarginTransferHistoryService) Current(current int) *IsolatedMarginTransferHistoryService {
+	s.current = &current
+	return s
+}
+
+// Size set size
+func (s *IsolatedMarginTransferHistoryService) Size(size int) *IsolatedMarginTransferHistoryService {
+	s.size = &size
+	return s
+}
+
+// Archived set archived
+func (s *IsolatedMarginTransferHistoryService) Archived(archived string) *IsolatedMarginTransferHistoryService {
+	s.archived = &archived
+	return s
+}
+
+// Do send request
+func (s *IsolatedMarginTransferHistoryService) Do(ctx context.Context, opts ...RequestOption) (res *CrossMarginTransferHistoryResponse, err error) {
+	if s.symbol == "" {
+		return nil, ErrIsolatedSymbolRequired
+	}
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: crossMarginTransferHistoryEndpoint,
+		secType:  SecurityTypeUserData,
+		service:  "IsolatedMarginTransferHistoryService",
+	}
+	r.setParam("isolatedSymbol", s.symbol)
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.orderType != nil {
+		r.setParam("type", *s.orderType)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	if s.archived != nil {
+		r.setParam("archived", *s.archived)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CrossMarginTransferHistoryResponse)
+	err = s.c.unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Paginate return a paginator of the transfers between start and end, in windows of at most 30 days.
+// Filters set on the service apply to every page.
+func (s *IsolatedMarginTransferHistoryService) Paginate(start, end time.Time) *Paginator[MarginTransferRecord] {
+	return NewPaginator(func(ctx context.Context, page Page) ([]MarginTransferRecord, error) {
+		s.StartTime(page.StartTime).EndTime(page.EndTime).Current(page.Number).Size(page.Limit)
+		res, err := s.Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return res.Rows, nil
+	}, start, end).Window(marginTransferHistoryWindow).PageSize(100)
 }
 
 // Query Interest History (USER_DATA) API Endpoint
@@ -702,7 +858,8 @@ func (s *InterestHistoryService) Do(ctx context.Context, opts ...RequestOption)
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: interestHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "InterestHistoryService",
 	}
 	if s.asset != nil {
 		r.setParam("asset", *s.asset)
@@ -730,7 +887,7 @@ func (s *InterestHistoryService) Do(ctx context.Context, opts ...RequestOption)
 		return &InterestHistoryResponse{}, err
 	}
 	res = new(InterestHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &InterestHistoryResponse{}, err
 	}
@@ -803,7 +960,8 @@ func (s *ForceLiquidationRecordService) Do(ctx context.Context, opts ...RequestO
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: forceLiquidationRecordEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "ForceLiquidationRecordService",
 	}
 	if s.startTime != nil {
 		r.setParam("startTime", *s.startTime)
@@ -825,7 +983,7 @@ func (s *ForceLiquidationRecordService) Do(ctx context.Context, opts ...RequestO
 		return &ForceLiquidationRecordResponse{}, err
 	}
 	res = new(ForceLiquidationRecordResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &ForceLiquidationRecordResponse{}, err
 	}
@@ -864,14 +1022,15 @@ func (s *CrossMarginAccountDetailService) Do(ctx context.Context, opts ...Reques
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: crossMarginAccountDetailEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "CrossMarginAccountDetailService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return &CrossMarginAccountDetailResponse{}, err
 	}
 	res = new(CrossMarginAccountDetailResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &CrossMarginAccountDetailResponse{}, err
 	}
@@ -937,10 +1096,14 @@ func (s *MarginAccountOrderService) OrigClientOrderId(origClientOrderId string)
 
 // Do send request
 func (s *MarginAccountOrderService) Do(ctx context.Context, opts ...RequestOption) (res *MarginAccountOrderResponse, err error) {
+	if err := requireOrderId("MarginAccountOrderService", orderIdParam{"orderId", s.orderId != nil}, orderIdParam{"origClientOrderId", isSet(s.origClientOrderId)}); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginAccountOrderEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginAccountOrderService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -960,7 +1123,7 @@ func (s *MarginAccountOrderService) Do(ctx context.Context, opts ...RequestOptio
 		return &MarginAccountOrderResponse{}, err
 	}
 	res = new(MarginAccountOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginAccountOrderResponse{}, err
 	}
@@ -1017,7 +1180,8 @@ func (s *MarginAccountOpenOrderService) Do(ctx context.Context, opts ...RequestO
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginAccountOpenOrderEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginAccountOpenOrderService",
 	}
 	if s.symbol != nil {
 		r.setParam("symbol", *s.symbol)
@@ -1030,7 +1194,7 @@ func (s *MarginAccountOpenOrderService) Do(ctx context.Context, opts ...RequestO
 		return []*MarginAccountOpenOrderResponse{}, err
 	}
 	res = make([]*MarginAccountOpenOrderResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginAccountOpenOrderResponse{}, err
 	}
@@ -1115,7 +1279,8 @@ func (s *MarginAccountAllOrderService) Do(ctx context.Context, opts ...RequestOp
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginAccountAllOrderEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginAccountAllOrderService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -1141,7 +1306,7 @@ func (s *MarginAccountAllOrderService) Do(ctx context.Context, opts ...RequestOp
 		return []*MarginAccountAllOrderResponse{}, err
 	}
 	res = make([]*MarginAccountAllOrderResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginAccountAllOrderResponse{}, err
 	}
@@ -1289,7 +1454,8 @@ func (s *MarginAccountNewOCOService) Do(ctx context.Context, opts ...RequestOpti
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: marginAccountNewOCOEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "MarginAccountNewOCOService",
 	}
 	m := params{
 		"symbol":    s.symbol,
@@ -1334,7 +1500,7 @@ func (s *MarginAccountNewOCOService) Do(ctx context.Context, opts ...RequestOpti
 		return &MarginAccountNewOCOResponse{}, err
 	}
 	res = new(MarginAccountNewOCOResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginAccountNewOCOResponse{}, err
 	}
@@ -1421,10 +1587,14 @@ func (s *MarginAccountCancelOCOService) NewClientOrderId(newClientOrderId string
 
 // Do send request
 func (s *MarginAccountCancelOCOService) Do(ctx context.Context, opts ...RequestOption) (res *MarginAccountCancelOCOResponse, err error) {
+	if err := requireOrderId("MarginAccountCancelOCOService", orderIdParam{"orderListId", s.orderListId != nil}, orderIdParam{"listClientOrderId", isSet(s.listClientOrderId)}); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: marginAccountCancelOCOEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "MarginAccountCancelOCOService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -1447,7 +1617,7 @@ func (s *MarginAccountCancelOCOService) Do(ctx context.Context, opts ...RequestO
 		return &MarginAccountCancelOCOResponse{}, err
 	}
 	res = new(MarginAccountCancelOCOResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginAccountCancelOCOResponse{}, err
 	}
@@ -1526,10 +1696,14 @@ func (s *MarginAccountQueryOCOService) OrigClientOrderId(origClientOrderId strin
 
 // Do send request
 func (s *MarginAccountQueryOCOService) Do(ctx context.Context, opts ...RequestOption) (res *MarginAccountQueryOCOResponse, err error) {
+	if err := requireOrderId("MarginAccountQueryOCOService", orderIdParam{"orderListId", s.orderListId != nil}, orderIdParam{"origClientOrderId", isSet(s.origClientOrderId)}); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginAccountQueryOCOEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginAccountQueryOCOService",
 	}
 	if s.isIsolated != nil {
 		r.setParam("isIsolated", *s.isIsolated)
@@ -1548,7 +1722,7 @@ func (s *MarginAccountQueryOCOService) Do(ctx context.Context, opts ...RequestOp
 		return &MarginAccountQueryOCOResponse{}, err
 	}
 	res = new(MarginAccountQueryOCOResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginAccountQueryOCOResponse{}, err
 	}
@@ -1628,7 +1802,8 @@ func (s *MarginAccountQueryAllOCOService) Do(ctx context.Context, opts ...Reques
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginAccountQueryAllOCOEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginAccountQueryAllOCOService",
 	}
 	if s.isIsolated != nil {
 		r.setParam("isIsolated", *s.isIsolated)
@@ -1653,7 +1828,7 @@ func (s *MarginAccountQueryAllOCOService) Do(ctx context.Context, opts ...Reques
 		return []*MarginAccountQueryAllOCOResponse{}, err
 	}
 	res = make([]*MarginAccountQueryAllOCOResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginAccountQueryAllOCOResponse{}, err
 	}
@@ -1705,7 +1880,8 @@ func (s *MarginAccountQueryOpenOCOService) Do(ctx context.Context, opts ...Reque
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginAccountQueryOpenOCOEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginAccountQueryOpenOCOService",
 	}
 	if s.isIsolated != nil {
 		r.setParam("isIsolated", *s.isIsolated)
@@ -1718,7 +1894,7 @@ func (s *MarginAccountQueryOpenOCOService) Do(ctx context.Context, opts ...Reque
 		return []*MarginAccountQueryOpenOCOResponse{}, err
 	}
 	res = make([]*MarginAccountQueryOpenOCOResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginAccountQueryOpenOCOResponse{}, err
 	}
@@ -1805,7 +1981,8 @@ func (s *MarginAccountQueryTradeListService) Do(ctx context.Context, opts ...Req
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginAccountQueryTradeListEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginAccountQueryTradeListService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -1834,7 +2011,7 @@ func (s *MarginAccountQueryTradeListService) Do(ctx context.Context, opts ...Req
 		return []*MarginAccountQueryTradeListResponse{}, err
 	}
 	res = make([]*MarginAccountQueryTradeListResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginAccountQueryTradeListResponse{}, err
 	}
@@ -1885,7 +2062,8 @@ func (s *MarginAccountQueryMaxBorrowService) Do(ctx context.Context, opts ...Req
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginAccountQueryMaxBorrowEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginAccountQueryMaxBorrowService",
 	}
 	m := params{
 		"asset": s.asset,
@@ -1899,7 +2077,7 @@ func (s *MarginAccountQueryMaxBorrowService) Do(ctx context.Context, opts ...Req
 		return &MarginAccountQueryMaxBorrowResponse{}, err
 	}
 	res = new(MarginAccountQueryMaxBorrowResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginAccountQueryMaxBorrowResponse{}, err
 	}
@@ -1940,7 +2118,8 @@ func (s *MarginAccountQueryMaxTransferOutAmountService) Do(ctx context.Context,
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginAccountQueryMaxTransferOutAmountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginAccountQueryMaxTransferOutAmountService",
 	}
 	m := params{
 		"asset": s.asset,
@@ -1954,7 +2133,7 @@ func (s *MarginAccountQueryMaxTransferOutAmountService) Do(ctx context.Context,
 		return &MarginAccountQueryMaxTransferOutAmountResponse{}, err
 	}
 	res = new(MarginAccountQueryMaxTransferOutAmountResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginAccountQueryMaxTransferOutAmountResponse{}, err
 	}
@@ -1980,14 +2159,15 @@ func (s *MarginAccountSummaryService) Do(ctx context.Context, opts ...RequestOpt
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginAccountSummaryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginAccountSummaryService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return &MarginAccountSummaryResponse{}, err
 	}
 	res = new(MarginAccountSummaryResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginAccountSummaryResponse{}, err
 	}
@@ -2022,7 +2202,8 @@ func (s *MarginIsolatedAccountInfoService) Do(ctx context.Context, opts ...Reque
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginIsolatedAccountInfoEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginIsolatedAccountInfoService",
 	}
 	if s.symbols != nil {
 		r.addParam("symbols", s.symbols)
@@ -2040,7 +2221,7 @@ func (s *MarginIsolatedAccountInfoService) Do(ctx context.Context, opts ...Reque
 	} else {
 		res = new(MarginIsolatedAccountInfoResponse)
 	}
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		if s.symbols != nil {
 			return &MarginIsolatedAccountInfoResponseSymbols{}, err
@@ -2120,7 +2301,8 @@ func (s *MarginIsolatedAccountDisableService) Do(ctx context.Context, opts ...Re
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: marginIsolatedAccountDisableEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "MarginIsolatedAccountDisableService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -2131,7 +2313,7 @@ func (s *MarginIsolatedAccountDisableService) Do(ctx context.Context, opts ...Re
 		return &MarginIsolatedAccountDisableResponse{}, err
 	}
 	res = new(MarginIsolatedAccountDisableResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginIsolatedAccountDisableResponse{}, err
 	}
@@ -2164,7 +2346,8 @@ func (s *MarginIsolatedAccountEnableService) Do(ctx context.Context, opts ...Req
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: marginIsolatedAccountEnableEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeTrade,
+		service:  "MarginIsolatedAccountEnableService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -2175,7 +2358,7 @@ func (s *MarginIsolatedAccountEnableService) Do(ctx context.Context, opts ...Req
 		return &MarginIsolatedAccountEnableResponse{}, err
 	}
 	res = new(MarginIsolatedAccountEnableResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginIsolatedAccountEnableResponse{}, err
 	}
@@ -2201,14 +2384,15 @@ func (s *MarginIsolatedAccountLimitService) Do(ctx context.Context, opts ...Requ
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginIsolatedAccountLimitEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginIsolatedAccountLimitService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return &MarginIsolatedAccountLimitResponse{}, err
 	}
 	res = new(MarginIsolatedAccountLimitResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginIsolatedAccountLimitResponse{}, err
 	}
@@ -2236,14 +2420,15 @@ func (s *AllIsolatedMarginSymbolService) Do(ctx context.Context, opts ...Request
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginIsolatedSymbolAllEndpoint,
-		secType:  secTypeAPIKey,
+		secType:  SecurityTypeMarketData,
+		service:  "AllIsolatedMarginSymbolService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []*MarginIsolatedSymbolResponse{}, err
 	}
 	res = make([]*MarginIsolatedSymbolResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginIsolatedSymbolResponse{}, err
 	}
@@ -2288,7 +2473,8 @@ func (s *MarginToggleBnbBurnService) Do(ctx context.Context, opts ...RequestOpti
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: marginToggleBnbBurnEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginToggleBnbBurnService",
 	}
 	if s.spotBNBBurn != nil {
 		r.addParam("spotBNBBurn", s.spotBNBBurn)
@@ -2301,7 +2487,7 @@ func (s *MarginToggleBnbBurnService) Do(ctx context.Context, opts ...RequestOpti
 		return &MarginToggleBnbBurnResponse{}, err
 	}
 	res = new(MarginToggleBnbBurnResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginToggleBnbBurnResponse{}, err
 	}
@@ -2328,14 +2514,15 @@ func (s *MarginBnbBurnStatusService) Do(ctx context.Context, opts ...RequestOpti
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginBnbBurnStatusEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginBnbBurnStatusService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return &MarginBnbBurnStatusResponse{}, err
 	}
 	res = new(MarginBnbBurnStatusResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return &MarginBnbBurnStatusResponse{}, err
 	}
@@ -2390,7 +2577,8 @@ func (s *MarginInterestRateHistoryService) Do(ctx context.Context, opts ...Reque
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginInterestRateHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginInterestRateHistoryService",
 	}
 	m := params{
 		"asset": s.asset,
@@ -2410,7 +2598,7 @@ func (s *MarginInterestRateHistoryService) Do(ctx context.Context, opts ...Reque
 		return []*MarginInterestRateHistoryResponse{}, err
 	}
 	res = make([]*MarginInterestRateHistoryResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginInterestRateHistoryResponse{}, err
 	}
@@ -2453,7 +2641,8 @@ func (s *MarginCrossMarginFeeService) Do(ctx context.Context, opts ...RequestOpt
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginCrossMarginFeeEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginCrossMarginFeeService",
 	}
 	if s.vipLevel != nil {
 		r.setParam("vipLevel", *s.vipLevel)
@@ -2466,14 +2655,14 @@ func (s *MarginCrossMarginFeeService) Do(ctx context.Context, opts ...RequestOpt
 		return []*MarginCrossMarginFeeResponse{}, err
 	}
 	res = make([]*MarginCrossMarginFeeResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginCrossMarginFeeResponse{}, err
 	}
 	return res, nil
 }
 
-// MarginCrossMarginFeeService response
+// MarginCrossMarginFeeService response, MarginCrossMarginDataService decodes the same data with numeric rates
 type MarginCrossMarginFeeResponse struct {
 	VIPLevel        int    `json:"vipLevel"`
 	Coin            string `json:"coin"`
@@ -2515,7 +2704,8 @@ func (s *MarginIsolatedMarginFeeService) Do(ctx context.Context, opts ...Request
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginIsolatedMarginFeeEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginIsolatedMarginFeeService",
 	}
 	if s.vipLevel != nil {
 		r.setParam("vipLevel", *s.vipLevel)
@@ -2528,14 +2718,14 @@ func (s *MarginIsolatedMarginFeeService) Do(ctx context.Context, opts ...Request
 		return []*MarginIsolatedMarginFeeResponse{}, err
 	}
 	res = make([]*MarginIsolatedMarginFeeResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginIsolatedMarginFeeResponse{}, err
 	}
 	return res, nil
 }
 
-// MarginIsolatedMarginFeeService response
+// MarginIsolatedMarginFeeService response, MarginIsolatedMarginDataService decodes the same data with numeric rates
 type MarginIsolatedMarginFeeResponse struct {
 	VIPLevel int    `json:"vipLevel"`
 	Symbol   string `json:"symbol"`
@@ -2547,6 +2737,135 @@ type MarginIsolatedMarginFeeResponse struct {
 	} `json:"data"`
 }
 
+// Query Cross Margin Data (USER_DATA), typed
+type MarginCrossMarginDataService struct {
+	c        *Client
+	vipLevel *int
+	coin     *string
+}
+
+// VipLevel set vipLevel, the one of the account when not set
+func (s *MarginCrossMarginDataService) VipLevel(vipLevel int) *MarginCrossMarginDataService {
+	s.vipLevel = &vipLevel
+	return s
+}
+
+// Coin set coin, all the coins when not set
+func (s *MarginCrossMarginDataService) Coin(coin string) *MarginCrossMarginDataService {
+	s.coin = &coin
+	return s
+}
+
+// Do send request
+func (s *MarginCrossMarginDataService) Do(ctx context.Context, opts ...RequestOption) (res []*CrossMarginData, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: marginCrossMarginFeeEndpoint,
+		secType:  SecurityTypeUserData,
+		service:  "MarginCrossMarginDataService",
+	}
+	if s.vipLevel != nil {
+		r.setParam("vipLevel", *s.vipLevel)
+	}
+	if s.coin != nil {
+		r.setParam("coin", *s.coin)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*CrossMarginData{}, err
+	}
+	res = make([]*CrossMarginData, 0)
+	err = s.c.unmarshal(data, &res)
+	if err != nil {
+		return []*CrossMarginData{}, err
+	}
+	return res, nil
+}
+
+// CrossMarginData is the borrow limit and interest rate of a coin in cross margin, for a VIP level
+type CrossMarginData struct {
+	VIPLevel   int    `json:"vipLevel"`
+	Coin       string `json:"coin"`
+	TransferIn bool   `json:"transferIn"`
+	Borrowable bool   `json:"borrowable"`
+	// DailyInterest and YearlyInterest are rates, e.g. 0.0002 for 0.02%
+	DailyInterest   FlexFloat `json:"dailyInterest"`
+	YearlyInterest  FlexFloat `json:"yearlyInterest"`
+	BorrowLimit     FlexFloat `json:"borrowLimit"`
+	MarginablePairs []string  `json:"marginablePairs"`
+}
+
+// Query Isolated Margin Data (USER_DATA), typed
+type MarginIsolatedMarginDataService struct {
+	c        *Client
+	vipLevel *int
+	symbol   *string
+}
+
+// VipLevel set vipLevel, the one of the account when not set
+func (s *MarginIsolatedMarginDataService) VipLevel(vipLevel int) *MarginIsolatedMarginDataService {
+	s.vipLevel = &vipLevel
+	return s
+}
+
+// Symbol set symbol, all the symbols when not set
+func (s *MarginIsolatedMarginDataService) Symbol(symbol string) *MarginIsolatedMarginDataService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *MarginIsolatedMarginDataService) Do(ctx context.Context, opts ...RequestOption) (res []*IsolatedMarginData, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: marginIsolatedMarginFeeEndpoint,
+		secType:  SecurityTypeUserData,
+		service:  "MarginIsolatedMarginDataService",
+	}
+	if s.vipLevel != nil {
+		r.setParam("vipLevel", *s.vipLevel)
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*IsolatedMarginData{}, err
+	}
+	res = make([]*IsolatedMarginData, 0)
+	err = s.c.unmarshal(data, &res)
+	if err != nil {
+		return []*IsolatedMarginData{}, err
+	}
+	return res, nil
+}
+
+// IsolatedMarginData is the leverage of an isolated margin symbol and the borrow limit and interest rate
+// of its two assets, for a VIP level. The tiers of the symbol are given by MarginIsolatedMarginTierService.
+type IsolatedMarginData struct {
+	VIPLevel int                      `json:"vipLevel"`
+	Symbol   string                   `json:"symbol"`
+	Leverage FlexFloat                `json:"leverage"`
+	Data     []IsolatedMarginCoinData `json:"data"`
+}
+
+// IsolatedMarginCoinData is the borrow limit and daily interest rate of an asset of an isolated margin symbol
+type IsolatedMarginCoinData struct {
+	Coin          string    `json:"coin"`
+	DailyInterest FlexFloat `json:"dailyInterest"`
+	BorrowLimit   FlexFloat `json:"borrowLimit"`
+}
+
+// Coin return the data of coin, false when it is not an asset of the symbol
+func (d *IsolatedMarginData) Coin(coin string) (IsolatedMarginCoinData, bool) {
+	for _, data := range d.Data {
+		if data.Coin == coin {
+			return data, true
+		}
+	}
+	return IsolatedMarginCoinData{}, false
+}
+
 // Query Isolated Margin Tier Data (USER_DATA)
 const (
 	marginIsolatedMarginTierEndpoint = "/sapi/v1/margin/isolatedMarginTier"
@@ -2575,7 +2894,8 @@ func (s *MarginIsolatedMarginTierService) Do(ctx context.Context, opts ...Reques
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginIsolatedMarginTierEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginIsolatedMarginTierService",
 	}
 	m := params{
 		"symbol": s.symbol,
@@ -2589,7 +2909,7 @@ func (s *MarginIsolatedMarginTierService) Do(ctx context.Context, opts ...Reques
 		return []*MarginIsolatedMarginTierResponse{}, err
 	}
 	res = make([]*MarginIsolatedMarginTierResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginIsolatedMarginTierResponse{}, err
 	}
@@ -2635,7 +2955,8 @@ func (s *MarginCurrentOrderCountService) Do(ctx context.Context, opts ...Request
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginCurrentOrderCountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginCurrentOrderCountService",
 	}
 	if s.isIsolated != nil {
 		r.setParam("isIsolated", *s.isIsolated)
@@ -2648,7 +2969,7 @@ func (s *MarginCurrentOrderCountService) Do(ctx context.Context, opts ...Request
 		return []*MarginCurrentOrderCountResponse{}, err
 	}
 	res = make([]*MarginCurrentOrderCountResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginCurrentOrderCountResponse{}, err
 	}
@@ -2697,14 +3018,15 @@ func (s *MarginCrossCollateralRatioService) Do(ctx context.Context, opts ...Requ
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginCrossCollateralRatioEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginCrossCollateralRatioService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []*MarginCrossCollateralRatioResponse{}, err
 	}
 	res = make([]*MarginCrossCollateralRatioResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginCrossCollateralRatioResponse{}, err
 	}
@@ -2737,14 +3059,15 @@ func (s *MarginSmallLiabilityExchangeCoinListService) Do(ctx context.Context, op
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginSmallLiabilityExchangeCoinListEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginSmallLiabilityExchangeCoinListService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []*MarginSmallLiabilityExchangeCoinListResponse{}, err
 	}
 	res = make([]*MarginSmallLiabilityExchangeCoinListResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginSmallLiabilityExchangeCoinListResponse{}, err
 	}
@@ -2780,7 +3103,8 @@ func (s *MarginSmallLiabilityExchangeService) Do(ctx context.Context, opts ...Re
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: marginSmallLiabilityExchangeEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeMargin,
+		service:  "MarginSmallLiabilityExchangeService",
 	}
 	m := params{
 		"assetNames": s.assetNames,
@@ -2791,7 +3115,7 @@ func (s *MarginSmallLiabilityExchangeService) Do(ctx context.Context, opts ...Re
 		return []*MarginSmallLiabilityExchangeResponse{}, err
 	}
 	res = make([]*MarginSmallLiabilityExchangeResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginSmallLiabilityExchangeResponse{}, err
 	}
@@ -2844,7 +3168,8 @@ func (s *MarginSmallLiabilityExchangeHistoryService) Do(ctx context.Context, opt
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: marginSmallLiabilityExchangeHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginSmallLiabilityExchangeHistoryService",
 	}
 	m := params{
 		"current": s.current,
@@ -2862,7 +3187,7 @@ func (s *MarginSmallLiabilityExchangeHistoryService) Do(ctx context.Context, opt
 		return []*MarginSmallLiabilityExchangeHistoryResponse{}, err
 	}
 	res = make([]*MarginSmallLiabilityExchangeHistoryResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return []*MarginSmallLiabilityExchangeHistoryResponse{}, err
 	}
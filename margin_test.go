@@ -2,7 +2,9 @@ package binance_connector
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -291,6 +293,100 @@ func (s *marginTestSuite) TestForceLiquidationRecordService() {
 	s.EqualValues(expectedRecord, record)
 }
 
+func (s *marginTestSuite) TestCrossMarginTransferHistory() {
+	data := []byte(`{
+		"rows": [
+			{
+				"amount": "0.10000000",
+				"asset": "BNB",
+				"status": "CONFIRMED",
+				"timestamp": 1566898617,
+				"txId": 5240372201,
+				"type": "ROLL_IN"
+			}
+		],
+		"total": 1
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"asset":     "BNB",
+			"type":      "ROLL_IN",
+			"startTime": 1566898000,
+			"current":   1,
+			"size":      10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCrossMarginTransferHistoryService().Asset("BNB").OrderType("ROLL_IN").
+		StartTime(1566898000).Current(1).Size(10).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(1, res.Total)
+	r.Equal([]MarginTransferRecord{{
+		Amount: "0.10000000", Asset: "BNB", Status: "CONFIRMED", Timestamp: 1566898617, TxId: 5240372201, Type: "ROLL_IN",
+	}}, res.Rows)
+}
+
+func (s *marginTestSuite) TestIsolatedMarginTransferHistory() {
+	data := []byte(`{
+		"rows": [
+			{
+				"amount": "0.10000000",
+				"asset": "BNB",
+				"status": "CONFIRMED",
+				"timestamp": 1566898617000,
+				"txId": 5240372201,
+				"transFrom": "SPOT",
+				"transTo": "ISOLATED_MARGIN"
+			}
+		],
+		"total": 1
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"isolatedSymbol": "BNBUSDT",
+			"asset":          "BNB",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewIsolatedMarginTransferHistoryService().Symbol("BNBUSDT").Asset("BNB").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res.Rows, 1)
+	r.Equal("SPOT", res.Rows[0].TransFrom)
+	r.Equal("ISOLATED_MARGIN", res.Rows[0].TransTo)
+}
+
+func (s *marginTestSuite) TestIsolatedMarginTransferHistorySymbolRequired() {
+	_, err := s.client.NewIsolatedMarginTransferHistoryService().Asset("BNB").Do(newContext())
+	s.r().ErrorIs(err, ErrIsolatedSymbolRequired)
+}
+
+func (s *marginTestSuite) TestMarginTransferHistoryPaginate() {
+	c := NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	var queries []string
+	c.do = func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		queries = append(queries, q.Get("current")+"/"+q.Get("size"))
+		return newHTTPResponse([]byte(`{"rows":[{"asset":"BNB","txId":1}],"total":1}`), http.StatusOK), nil
+	}
+	start := time.UnixMilli(0)
+	records, err := c.NewIsolatedMarginTransferHistoryService().Symbol("BNBUSDT").
+		Paginate(start, start.Add(45*24*time.Hour)).All(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(records, 2, "one record in each 30 day window")
+	r.Equal([]string{"1/100", "1/100"}, queries)
+}
+
 func (s *marginTestSuite) TestInterestHistory() {
 	data := []byte(`
 	{
@@ -1241,6 +1337,85 @@ func (s *marginTestSuite) TestMarginIsolatedMarginFee() {
 	s.Equal("2.00000000", resp[1].Data.BorrowLimit)
 }
 
+func (s *marginTestSuite) TestMarginCrossMarginData() {
+	data := []byte(`[
+		{
+			"vipLevel": 0,
+			"coin": "BTC",
+			"transferIn": true,
+			"borrowable": true,
+			"dailyInterest": "0.00026125",
+			"yearlyInterest": "0.0953",
+			"borrowLimit": "180",
+			"marginablePairs": ["BNBBTC", "ETHBTC", "BTCUSDT"]
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"vipLevel": 0,
+			"coin":     "BTC",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	resp, err := s.client.NewMarginCrossMarginDataService().
+		VipLevel(0).
+		Coin("BTC").
+		Do(newContext())
+	s.r().NoError(err)
+	s.r().Len(resp, 1)
+	s.Equal(&CrossMarginData{
+		VIPLevel:        0,
+		Coin:            "BTC",
+		TransferIn:      true,
+		Borrowable:      true,
+		DailyInterest:   0.00026125,
+		YearlyInterest:  0.0953,
+		BorrowLimit:     180,
+		MarginablePairs: []string{"BNBBTC", "ETHBTC", "BTCUSDT"},
+	}, resp[0])
+}
+
+func (s *marginTestSuite) TestMarginIsolatedMarginData() {
+	data := []byte(`[
+		{
+			"vipLevel": 0,
+			"symbol": "BTCUSDT",
+			"leverage": "10",
+			"data": [
+				{"coin": "BTC", "dailyInterest": "0.00026125", "borrowLimit": "270"},
+				{"coin": "USDT", "dailyInterest": "0.000475", "borrowLimit": "2100000"}
+			]
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol": "BTCUSDT",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	resp, err := s.client.NewMarginIsolatedMarginDataService().
+		Symbol("BTCUSDT").
+		Do(newContext())
+	s.r().NoError(err)
+	s.r().Len(resp, 1)
+	s.Equal("BTCUSDT", resp[0].Symbol)
+	s.Equal(10.0, resp[0].Leverage.Float64())
+	s.Len(resp[0].Data, 2)
+	usdt, ok := resp[0].Coin("USDT")
+	s.True(ok)
+	s.Equal(IsolatedMarginCoinData{Coin: "USDT", DailyInterest: 0.000475, BorrowLimit: 2100000}, usdt)
+	_, ok = resp[0].Coin("ETH")
+	s.False(ok)
+}
+
 func (s *marginTestSuite) TestMarginIsolatedMarginTier() {
 	data := []byte(`
 	[
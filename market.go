@@ -2,6 +2,7 @@ package binance_connector
 
 import (
 	"context"
+	"errors"
 	"github.com/goccy/go-json"
 	"math/big"
 	"net/http"
@@ -17,7 +18,8 @@ func (s *Ping) Do(ctx context.Context, opts ...RequestOption) (err error) {
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/ping",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "Ping",
 	}
 	_, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -36,14 +38,15 @@ func (s *ServerTime) Do(ctx context.Context, opts ...RequestOption) (res *Server
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/time",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "ServerTime",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(ServerTimeResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -57,22 +60,94 @@ type ServerTimeResponse struct {
 
 // Binance Exchange Information endpoint (GET /api/v3/exchangeInfo)
 type ExchangeInfo struct {
-	c *Client
+	c                  *Client
+	symbol             *string
+	symbols            *[]string
+	permissions        *[]string
+	showPermissionSets *bool
+}
+
+// ErrExchangeInfoFilter is returned when the symbol, symbols and permissions filters of ExchangeInfo are combined or empty
+var ErrExchangeInfoFilter = errors.New("only one non-empty filter of symbol, symbols and permissions can be set")
+
+// Symbol set symbol
+func (s *ExchangeInfo) Symbol(symbol string) *ExchangeInfo {
+	s.symbol = &symbol
+	return s
+}
+
+// Symbols set symbols
+func (s *ExchangeInfo) Symbols(symbols []string) *ExchangeInfo {
+	s.symbols = &symbols
+	return s
+}
+
+// Permissions set permissions, e.g. SPOT or MARGIN
+func (s *ExchangeInfo) Permissions(permissions []string) *ExchangeInfo {
+	s.permissions = &permissions
+	return s
+}
+
+// ShowPermissionSets set showPermissionSets, false leaves permissionSets out of the response
+func (s *ExchangeInfo) ShowPermissionSets(showPermissionSets bool) *ExchangeInfo {
+	s.showPermissionSets = &showPermissionSets
+	return s
+}
+
+// validate check that at most one non-empty filter is set, Binance rejects the others
+func (s *ExchangeInfo) validate() error {
+	filters := 0
+	if s.symbol != nil {
+		if *s.symbol == "" {
+			return ErrExchangeInfoFilter
+		}
+		filters++
+	}
+	for _, list := range []*[]string{s.symbols, s.permissions} {
+		if list != nil {
+			if len(*list) == 0 {
+				return ErrExchangeInfoFilter
+			}
+			filters++
+		}
+	}
+	if filters > 1 {
+		return ErrExchangeInfoFilter
+	}
+	return nil
 }
 
 // Send the request
 func (s *ExchangeInfo) Do(ctx context.Context, opts ...RequestOption) (res *ExchangeInfoResponse, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/exchangeInfo",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "ExchangeInfo",
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.symbols != nil {
+		symbols, _ := json.Marshal(s.symbols)
+		r.setParam("symbols", string(symbols))
+	}
+	if s.permissions != nil {
+		permissions, _ := json.Marshal(s.permissions)
+		r.setParam("permissions", string(permissions))
+	}
+	if s.showPermissionSets != nil {
+		r.setParam("showPermissionSets", *s.showPermissionSets)
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(ExchangeInfoResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -86,12 +161,20 @@ type ExchangeInfoResponse struct {
 	RateLimits      []*RateLimit      `json:"rateLimits"`
 	ExchangeFilters []*ExchangeFilter `json:"exchangeFilters"`
 	Symbols         []*SymbolInfo     `json:"symbols"`
+	Sors            []*SOR            `json:"sors"`
+}
+
+// SOR define the symbols of a base asset eligible for smart order routing
+type SOR struct {
+	BaseAsset string   `json:"baseAsset"`
+	Symbols   []string `json:"symbols"`
 }
 
 // RateLimit define rate limit
 type RateLimit struct {
 	RateLimitType string `json:"rateLimitType"`
 	Interval      string `json:"interval"`
+	IntervalNum   int    `json:"intervalNum"`
 	Limit         int    `json:"limit"`
 }
 
@@ -110,9 +193,12 @@ type SymbolInfo struct {
 	QuoteAsset                      string          `json:"quoteAsset"`
 	QuotePrecision                  int64           `json:"quotePrecision"`
 	QuoteAssetPrecision             int64           `json:"quoteAssetPrecision"`
+	BaseCommissionPrecision         int64           `json:"baseCommissionPrecision"`
+	QuoteCommissionPrecision        int64           `json:"quoteCommissionPrecision"`
 	OrderTypes                      []string        `json:"orderTypes"`
 	IcebergAllowed                  bool            `json:"icebergAllowed"`
 	OcoAllowed                      bool            `json:"ocoAllowed"`
+	OtoAllowed                      bool            `json:"otoAllowed"`
 	QuoteOrderQtyMarketAllowed      bool            `json:"quoteOrderQtyMarketAllowed"`
 	AllowTrailingStop               bool            `json:"allowTrailingStop"`
 	CancelReplaceAllowed            bool            `json:"cancelReplaceAllowed"`
@@ -125,10 +211,51 @@ type SymbolInfo struct {
 	AllowedSelfTradePreventionModes []string        `json:"allowedSelfTradePreventionModes"`
 }
 
+// HasPermission return true when permission is in the legacy permissions or in one of the
+// permission sets. A symbol is tradable by an account holding every permission of one set.
+func (s *SymbolInfo) HasPermission(permission string) bool {
+	for _, p := range s.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	for _, set := range s.PermissionSets {
+		for _, p := range set {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AllPermissions return the permissions of the symbol, from the permission sets and the legacy
+// permissions, without duplicates
+func (s *SymbolInfo) AllPermissions() []string {
+	seen := make(map[string]bool)
+	var permissions []string
+	add := func(p string) {
+		if !seen[p] {
+			seen[p] = true
+			permissions = append(permissions, p)
+		}
+	}
+	for _, set := range s.PermissionSets {
+		for _, p := range set {
+			add(p)
+		}
+	}
+	for _, p := range s.Permissions {
+		add(p)
+	}
+	return permissions
+}
+
 // SymbolFilter define symbol filter
 type SymbolFilter struct {
 	ApplyMinToMarket      bool   `json:"applyMinToMarket"`
 	ApplyMaxToMarket      bool   `json:"applyMaxToMarket"`
+	ApplyToMarket         bool   `json:"applyToMarket"`
 	AskMultiplierDown     string `json:"askMultiplierDown"`
 	AskMultiplierUp       string `json:"askMultiplierUp"`
 	AvgPriceMins          int64  `json:"avgPriceMins"`
@@ -176,18 +303,23 @@ func (s *OrderBook) Do(ctx context.Context, opts ...RequestOption) (res *OrderBo
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/depth",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "OrderBook",
 	}
 	r.setParam("symbol", s.symbol)
 	if s.limit != nil {
 		r.setParam("limit", *s.limit)
 	}
+	s.c.setSBEHeaders(r)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
+	if isSBEResponse(r) && s.c.SBESchema != nil {
+		return decodeSBEDepth(data, *s.c.SBESchema)
+	}
 	res = new(OrderBookResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -225,7 +357,8 @@ func (s *RecentTradesList) Do(ctx context.Context, opts ...RequestOption) (res [
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/trades",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "RecentTradesList",
 	}
 	r.setParam("symbol", s.symbol)
 	if s.limit != nil {
@@ -235,7 +368,7 @@ func (s *RecentTradesList) Do(ctx context.Context, opts ...RequestOption) (res [
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -284,7 +417,8 @@ func (s *HistoricalTradeLookup) Do(ctx context.Context, opts ...RequestOption) (
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/historicalTrades",
-		secType:  secTypeAPIKey,
+		secType:  SecurityTypeMarketData,
+		service:  "HistoricalTradeLookup",
 	}
 	r.setParam("symbol", s.symbol)
 	if s.limit != nil {
@@ -297,13 +431,16 @@ func (s *HistoricalTradeLookup) Do(ctx context.Context, opts ...RequestOption) (
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
+// ErrAggTradesFromIdWithTime is returned when the fromId of AggTradesList is combined with startTime or endTime
+var ErrAggTradesFromIdWithTime = errors.New("fromId can not be combined with startTime or endTime")
+
 // Binance Compressed/Aggregate Trades List endpoint (GET /api/v3/aggTrades)
 type AggTradesList struct {
 	c         *Client
@@ -346,10 +483,14 @@ func (s *AggTradesList) EndTime(endTime uint64) *AggTradesList {
 
 // Send the request
 func (s *AggTradesList) Do(ctx context.Context, opts ...RequestOption) (res []*AggTradesListResponse, err error) {
+	if s.fromId != nil && (s.startTime != nil || s.endTime != nil) {
+		return nil, ErrAggTradesFromIdWithTime
+	}
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/aggTrades",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "AggTradesList",
 	}
 	r.setParam("symbol", s.symbol)
 	if s.limit != nil {
@@ -368,7 +509,7 @@ func (s *AggTradesList) Do(ctx context.Context, opts ...RequestOption) (res []*A
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -431,7 +572,8 @@ func (s *Klines) Do(ctx context.Context, opts ...RequestOption) (res []*KlinesRe
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/klines",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "Klines",
 	}
 	r.setParam("symbol", s.symbol)
 	r.setParam("interval", s.interval)
@@ -449,7 +591,7 @@ func (s *Klines) Do(ctx context.Context, opts ...RequestOption) (res []*KlinesRe
 		return nil, err
 	}
 	var klinesResponseArray KlinesResponseArray
-	err = json.Unmarshal(data, &klinesResponseArray)
+	err = s.c.unmarshal(data, &klinesResponseArray)
 	if err != nil {
 		return nil, err
 	}
@@ -548,7 +690,8 @@ func (s *UiKlines) Do(ctx context.Context, opts ...RequestOption) (res []*UiKlin
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/uiKlines",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "UiKlines",
 	}
 	r.setParam("symbol", s.symbol)
 	r.setParam("interval", s.interval)
@@ -566,7 +709,7 @@ func (s *UiKlines) Do(ctx context.Context, opts ...RequestOption) (res []*UiKlin
 		return nil, err
 	}
 	var uiklinesResponseArray UiKlinesResponseArray
-	err = json.Unmarshal(data, &uiklinesResponseArray)
+	err = s.c.unmarshal(data, &uiklinesResponseArray)
 	if err != nil {
 		return nil, err
 	}
@@ -637,7 +780,8 @@ func (s *AvgPrice) Do(ctx context.Context, opts ...RequestOption) (res *AvgPrice
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/avgPrice",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "AvgPrice",
 	}
 	r.setParam("symbol", s.symbol)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -645,7 +789,7 @@ func (s *AvgPrice) Do(ctx context.Context, opts ...RequestOption) (res *AvgPrice
 		return nil, err
 	}
 	res = new(AvgPriceResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -658,13 +802,25 @@ type AvgPriceResponse struct {
 	Price string `json:"price"`
 }
 
-// Binance 24hr Ticker Price Change Statistics (GET /api/v3/ticker/24hr)
+// Ticker types of the ticker endpoints, MINI leaves out the bid, ask, price change and previous close fields
+const (
+	TickerTypeFull = "FULL"
+	TickerTypeMini = "MINI"
+)
+
+// Binance 24hr Ticker Price Change Statistics (GET /api/v3/ticker/24hr), over the last 24 hours.
+// Ticker is the rolling window variant with a configurable window.
 type Ticker24hr struct {
-	c       *Client
-	symbol  *string
-	symbols *[]string
+	c          *Client
+	symbol     *string
+	symbols    *[]string
+	allSymbols bool
+	tickerType *string
 }
 
+// ErrTicker24hrFilter is returned when none or several of the symbol, symbols and AllSymbols filters of Ticker24hr are set
+var ErrTicker24hrFilter = errors.New("exactly one of symbol, symbols and all symbols must be set")
+
 // Symbol set symbol
 func (s *Ticker24hr) Symbol(symbol string) *Ticker24hr {
 	s.symbol = &symbol
@@ -677,12 +833,49 @@ func (s *Ticker24hr) Symbols(symbols []string) *Ticker24hr {
 	return s
 }
 
-// Send the request
-func (s *Ticker24hr) Do(ctx context.Context, opts ...RequestOption) (res []*Ticker24hrResponse, err error) {
+// Type set type, TickerTypeFull or TickerTypeMini. FULL is the default, use DoMini for MINI tickers.
+func (s *Ticker24hr) Type(tickerType string) *Ticker24hr {
+	s.tickerType = &tickerType
+	return s
+}
+
+// AllSymbols request the tickers of every symbol. It weighs 80, as much as 40 single symbol requests, and
+// returns thousands of tickers: it has to be asked for explicitly, prefer Symbols for a known list.
+func (s *Ticker24hr) AllSymbols() *Ticker24hr {
+	s.allSymbols = true
+	return s
+}
+
+// validate check that exactly one filter is set, so the heavy all symbols request is never sent by mistake
+func (s *Ticker24hr) validate() error {
+	filters := 0
+	if s.symbol != nil {
+		if *s.symbol == "" {
+			return ErrTicker24hrFilter
+		}
+		filters++
+	}
+	if s.symbols != nil {
+		if len(*s.symbols) == 0 {
+			return ErrTicker24hrFilter
+		}
+		filters++
+	}
+	if s.allSymbols {
+		filters++
+	}
+	if filters != 1 {
+		return ErrTicker24hrFilter
+	}
+	return nil
+}
+
+func (s *Ticker24hr) request() *request {
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/ticker/24hr",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "Ticker24hr",
 	}
 	if s.symbol != nil {
 		r.setParam("symbol", *s.symbol)
@@ -691,32 +884,37 @@ func (s *Ticker24hr) Do(ctx context.Context, opts ...RequestOption) (res []*Tick
 		s, _ := json.Marshal(s.symbols)
 		r.setParam("symbols", string(s))
 	}
+	if s.tickerType != nil {
+		r.setParam("type", *s.tickerType)
+	}
+	return r
+}
+
+// DoMini send the request with type MINI
+func (s *Ticker24hr) DoMini(ctx context.Context, opts ...RequestOption) (res []*TickerMiniResponse, err error) {
+	if err := s.validate(); err != nil {
+		return []*TickerMiniResponse{}, err
+	}
+	r := s.request()
+	r.setParam("type", TickerTypeMini)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
+		return []*TickerMiniResponse{}, err
+	}
+	return decodeOneOrMany[TickerMiniResponse](s.c, data)
+}
+
+// Send the request, the response holds one ticker per symbol requested
+func (s *Ticker24hr) Do(ctx context.Context, opts ...RequestOption) (res []*Ticker24hrResponse, err error) {
+	if err := s.validate(); err != nil {
 		return []*Ticker24hrResponse{}, err
 	}
-	var raw json.RawMessage
-	err = json.Unmarshal(data, &raw)
+	r := s.request()
+	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []*Ticker24hrResponse{}, err
 	}
-
-	if raw[0] == '[' {
-		res = make([]*Ticker24hrResponse, 0)
-		err = json.Unmarshal(data, &res)
-		if err != nil {
-			return []*Ticker24hrResponse{}, err
-		}
-	} else {
-		// The response is a single object, not an array, make sure to add it to the slice
-		singleRes := new(Ticker24hrResponse)
-		err = json.Unmarshal(data, &singleRes)
-		if err != nil {
-			return []*Ticker24hrResponse{}, err
-		}
-		res = append(res, singleRes)
-	}
-	return res, nil
+	return decodeOneOrMany[Ticker24hrResponse](s.c, data)
 }
 
 // Define Ticker24hr response data
@@ -742,6 +940,42 @@ type Ticker24hrResponse struct {
 	Count              uint64 `json:"count"`
 }
 
+// TickerMiniResponse define the response of the ticker endpoints with type MINI
+type TickerMiniResponse struct {
+	Symbol      string `json:"symbol"`
+	OpenPrice   string `json:"openPrice"`
+	HighPrice   string `json:"highPrice"`
+	LowPrice    string `json:"lowPrice"`
+	LastPrice   string `json:"lastPrice"`
+	Volume      string `json:"volume"`
+	QuoteVolume string `json:"quoteVolume"`
+	OpenTime    uint64 `json:"openTime"`
+	CloseTime   uint64 `json:"closeTime"`
+	FirstId     uint64 `json:"firstId"`
+	LastId      uint64 `json:"lastId"`
+	Count       uint64 `json:"count"`
+}
+
+// decodeOneOrMany decode a response that is an object for one symbol and an array for several
+func decodeOneOrMany[T any](c *Client, data []byte) ([]*T, error) {
+	var raw json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return []*T{}, err
+	}
+	if raw[0] == '[' {
+		res := make([]*T, 0)
+		if err := c.unmarshal(data, &res); err != nil {
+			return []*T{}, err
+		}
+		return res, nil
+	}
+	single := new(T)
+	if err := c.unmarshal(data, single); err != nil {
+		return []*T{}, err
+	}
+	return []*T{single}, nil
+}
+
 // Binance Symbol Price Ticker (GET /api/v3/ticker/price)
 type TickerPrice struct {
 	c       *Client
@@ -766,7 +1000,8 @@ func (s *TickerPrice) Do(ctx context.Context, opts ...RequestOption) (res *Ticke
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/ticker/price",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "TickerPrice",
 	}
 	if s.symbol != nil {
 		r.setParam("symbol", *s.symbol)
@@ -779,7 +1014,7 @@ func (s *TickerPrice) Do(ctx context.Context, opts ...RequestOption) (res *Ticke
 		return nil, err
 	}
 	res = new(TickerPriceResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -815,7 +1050,8 @@ func (s *TickerBookTicker) Do(ctx context.Context, opts ...RequestOption) (res [
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/ticker/bookTicker",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "TickerBookTicker",
 	}
 	if s.symbol != nil {
 		r.setParam("symbol", *s.symbol)
@@ -829,7 +1065,7 @@ func (s *TickerBookTicker) Do(ctx context.Context, opts ...RequestOption) (res [
 		return []*TickerBookTickerResponse{}, err
 	}
 	var raw json.RawMessage
-	err = json.Unmarshal(data, &raw)
+	err = s.c.unmarshal(data, &raw)
 	if err != nil {
 		return []*TickerBookTickerResponse{}, err
 	}
@@ -837,14 +1073,14 @@ func (s *TickerBookTicker) Do(ctx context.Context, opts ...RequestOption) (res [
 	if raw[0] == '[' {
 		// The response is an array, unmarshal it as before
 		res = make([]*TickerBookTickerResponse, 0)
-		err = json.Unmarshal(data, &res)
+		err = s.c.unmarshal(data, &res)
 		if err != nil {
 			return []*TickerBookTickerResponse{}, err
 		}
 	} else {
 		// The response is a single object, not an array, make sure to add it to the slice
 		singleRes := new(TickerBookTickerResponse)
-		err = json.Unmarshal(data, &singleRes)
+		err = s.c.unmarshal(data, &singleRes)
 		if err != nil {
 			return []*TickerBookTickerResponse{}, err
 		}
@@ -883,18 +1119,18 @@ func (s *Ticker) WindowSize(windowSize string) *Ticker {
 	return s
 }
 
-// Type set type
+// Type set type, TickerTypeFull or TickerTypeMini. FULL is the default, use DoMini for MINI tickers.
 func (s *Ticker) Type(tickerType string) *Ticker {
 	s.tickerType = &tickerType
 	return s
 }
 
-// Send the request
-func (s *Ticker) Do(ctx context.Context, opts ...RequestOption) (res *TickerResponse, err error) {
+func (s *Ticker) request() *request {
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/api/v3/ticker",
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "Ticker",
 	}
 	r.setParam("symbol", s.symbol)
 	if s.windowSize != nil {
@@ -903,12 +1139,34 @@ func (s *Ticker) Do(ctx context.Context, opts ...RequestOption) (res *TickerResp
 	if s.tickerType != nil {
 		r.setParam("type", *s.tickerType)
 	}
+	return r
+}
+
+// DoMini send the request with type MINI
+func (s *Ticker) DoMini(ctx context.Context, opts ...RequestOption) (res *TickerMiniResponse, err error) {
+	r := s.request()
+	r.setParam("type", TickerTypeMini)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(TickerMiniResponse)
+	err = s.c.unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Send the request
+func (s *Ticker) Do(ctx context.Context, opts ...RequestOption) (res *TickerResponse, err error) {
+	r := s.request()
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(TickerResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -45,14 +45,12 @@ func (s *marketTestSuite) TestAggTradesList() {
 	defer s.assertDo()
 
 	symbol := "LTCBTC"
-	fromID := int(1)
 	startTime := uint64(1498793709153)
 	endTime := uint64(1498793709156)
 	limit := 1
 	s.assertReq(func(r *request) {
 		e := newRequest().setParams(params{
 			"symbol":    symbol,
-			"fromId":    fromID,
 			"startTime": startTime,
 			"endTime":   endTime,
 			"limit":     limit,
@@ -61,7 +59,7 @@ func (s *marketTestSuite) TestAggTradesList() {
 	})
 
 	aggTrades, err := s.client.NewAggTradesListService().Symbol(symbol).
-		FromId(fromID).StartTime(startTime).EndTime(endTime).Limit(limit).
+		StartTime(startTime).EndTime(endTime).Limit(limit).
 		Do(newContext())
 	r := s.r()
 	r.NoError(err)
@@ -79,6 +77,13 @@ func (s *marketTestSuite) TestAggTradesList() {
 	s.assertAggTradeEqual(e, aggTrades[0])
 }
 
+func (s *marketTestSuite) TestAggTradesFromIdWithTime() {
+	_, err := s.client.NewAggTradesService().Symbol("LTCBTC").FromId(1).StartTime(1498793709153).Do(newContext())
+	s.r().ErrorIs(err, ErrAggTradesFromIdWithTime)
+	_, err = s.client.NewAggTradesService().Symbol("LTCBTC").FromId(1).EndTime(1498793709153).Do(newContext())
+	s.r().ErrorIs(err, ErrAggTradesFromIdWithTime)
+}
+
 func (s *marketTestSuite) assertAggTradeEqual(e, a *AggTradesListResponse) {
 	r := s.r()
 	r.Equal(e.AggTradeId, a.AggTradeId, "AggTradeID")
@@ -118,7 +123,115 @@ func (s *marketTestSuite) assertServerTimeEqual(e, a *ServerTimeResponse) {
 }
 
 func (s *marketTestSuite) TestExchangeInfo() {
+	data := []byte(`{
+		"timezone": "UTC",
+		"serverTime": 1565246363776,
+		"rateLimits": [
+			{"rateLimitType": "REQUEST_WEIGHT", "interval": "MINUTE", "intervalNum": 1, "limit": 6000}
+		],
+		"exchangeFilters": [],
+		"symbols": [
+			{
+				"symbol": "BTCUSDT",
+				"status": "TRADING",
+				"baseAsset": "BTC",
+				"baseAssetPrecision": 8,
+				"quoteAsset": "USDT",
+				"quotePrecision": 8,
+				"quoteAssetPrecision": 8,
+				"baseCommissionPrecision": 8,
+				"quoteCommissionPrecision": 8,
+				"orderTypes": ["LIMIT", "MARKET"],
+				"icebergAllowed": true,
+				"ocoAllowed": true,
+				"otoAllowed": true,
+				"isSpotTradingAllowed": true,
+				"isMarginTradingAllowed": true,
+				"filters": [{"filterType": "PRICE_FILTER", "minPrice": "0.01000000", "maxPrice": "1000000.00000000", "tickSize": "0.01000000"}],
+				"permissions": [],
+				"permissionSets": [["SPOT", "MARGIN"], ["TRD_GRP_004"]],
+				"defaultSelfTradePreventionMode": "EXPIRE_MAKER",
+				"allowedSelfTradePreventionModes": ["EXPIRE_TAKER", "EXPIRE_MAKER", "EXPIRE_BOTH"]
+			},
+			{
+				"symbol": "ETHBTC",
+				"status": "TRADING",
+				"permissions": ["SPOT"]
+			}
+		],
+		"sors": [
+			{"baseAsset": "BTC", "symbols": ["BTCUSDT", "BTCUSDC"]}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
 
+	s.assertReq(func(r *request) {
+		s.assertRequestEqual(newRequest(), r)
+	})
+
+	res, err := s.client.NewExchangeInfoService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal("UTC", res.Timezone)
+	r.Equal([]*SOR{{BaseAsset: "BTC", Symbols: []string{"BTCUSDT", "BTCUSDC"}}}, res.Sors)
+	r.Len(res.Symbols, 2)
+
+	btc := res.Symbols[0]
+	r.Equal([][]string{{"SPOT", "MARGIN"}, {"TRD_GRP_004"}}, btc.PermissionSets)
+	r.Equal("EXPIRE_MAKER", btc.DefaultSelfTradePreventionMode)
+	r.Equal([]string{"EXPIRE_TAKER", "EXPIRE_MAKER", "EXPIRE_BOTH"}, btc.AllowedSelfTradePreventionModes)
+	r.True(btc.OtoAllowed)
+	r.Equal(int64(8), btc.BaseCommissionPrecision)
+	r.True(btc.HasPermission("MARGIN"))
+	r.False(btc.HasPermission("LEVERAGED"))
+	r.Equal([]string{"SPOT", "MARGIN", "TRD_GRP_004"}, btc.AllPermissions())
+
+	// legacy schema
+	eth := res.Symbols[1]
+	r.True(eth.HasPermission("SPOT"))
+	r.Equal([]string{"SPOT"}, eth.AllPermissions())
+}
+
+func (s *marketTestSuite) TestExchangeInfoFilters() {
+	data := []byte(`{"timezone":"UTC","symbols":[{"symbol":"BTCUSDT"},{"symbol":"ETHUSDT"}]}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		s.assertRequestEqual(newRequest().setParams(params{
+			"symbols":            `["BTCUSDT","ETHUSDT"]`,
+			"showPermissionSets": false,
+		}), r)
+	})
+	res, err := s.client.NewExchangeInfoService().Symbols([]string{"BTCUSDT", "ETHUSDT"}).ShowPermissionSets(false).Do(newContext())
+	s.r().NoError(err)
+	s.r().Len(res.Symbols, 2)
+}
+
+func (s *marketTestSuite) TestExchangeInfoPermissions() {
+	s.mockDo([]byte(`{}`), nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		s.assertRequestEqual(newRequest().setParams(params{"permissions": `["MARGIN","LEVERAGED"]`}), r)
+	})
+	_, err := s.client.NewExchangeInfoService().Permissions([]string{"MARGIN", "LEVERAGED"}).Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *marketTestSuite) TestExchangeInfoInvalidFilters() {
+	for _, service := range []*ExchangeInfo{
+		s.client.NewExchangeInfoService().Symbol("BTCUSDT").Symbols([]string{"ETHUSDT"}),
+		s.client.NewExchangeInfoService().Symbol("BTCUSDT").Permissions([]string{"SPOT"}),
+		s.client.NewExchangeInfoService().Symbols([]string{"BTCUSDT"}).Permissions([]string{"SPOT"}),
+		s.client.NewExchangeInfoService().Symbols([]string{}),
+		s.client.NewExchangeInfoService().Permissions(nil),
+		s.client.NewExchangeInfoService().Symbol(""),
+	} {
+		_, err := service.Do(newContext())
+		s.ErrorIs(err, ErrExchangeInfoFilter)
+	}
 }
 
 func (s *marketTestSuite) TestListBookTickers() {
@@ -382,3 +495,99 @@ func (s *marketTestSuite) assertPriceChangeStatsEqual(e, a *Ticker24hrResponse)
 	r.Equal(e.LastId, a.LastId, "LastID")
 	r.Equal(e.Count, a.Count, "Count")
 }
+
+func (s *marketTestSuite) TestTicker24hrMini() {
+	data := []byte(`[{
+        "symbol": "BNBBTC",
+        "openPrice": "99.00000000",
+        "highPrice": "100.00000000",
+        "lowPrice": "0.10000000",
+        "lastPrice": "4.00000200",
+        "volume": "8913.30000000",
+        "quoteVolume": "15.30000000",
+        "openTime": 1499783499040,
+        "closeTime": 1499869899040,
+        "firstId": 28385,
+        "lastId": 28460,
+        "count": 76
+    }, {
+        "symbol": "ETHBTC",
+        "lastPrice": "0.05000000"
+    }]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{"symbols": `["BNBBTC","ETHBTC"]`, "type": TickerTypeMini})
+		s.assertRequestEqual(e, r)
+	})
+	tickers, err := s.client.NewTicker24hrService().Symbols([]string{"BNBBTC", "ETHBTC"}).DoMini(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(tickers, 2)
+	s.Equal(&TickerMiniResponse{
+		Symbol: "BNBBTC", OpenPrice: "99.00000000", HighPrice: "100.00000000", LowPrice: "0.10000000",
+		LastPrice: "4.00000200", Volume: "8913.30000000", QuoteVolume: "15.30000000",
+		OpenTime: 1499783499040, CloseTime: 1499869899040, FirstId: 28385, LastId: 28460, Count: 76,
+	}, tickers[0])
+	s.Equal("ETHBTC", tickers[1].Symbol)
+}
+
+func (s *marketTestSuite) TestTicker24hrType() {
+	data := []byte(`{"symbol": "BNBBTC", "bidPrice": "4.00000000"}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{"symbol": "BNBBTC", "type": TickerTypeFull})
+		s.assertRequestEqual(e, r)
+	})
+	tickers, err := s.client.NewTicker24hrService().Symbol("BNBBTC").Type(TickerTypeFull).Do(newContext())
+	s.r().NoError(err)
+	s.r().Len(tickers, 1)
+	s.Equal("4.00000000", tickers[0].BidPrice)
+}
+
+func (s *marketTestSuite) TestTicker24hrAllSymbols() {
+	data := []byte(`[{"symbol": "BNBBTC", "lastPrice": "4.00000200"}, {"symbol": "ETHBTC", "lastPrice": "0.05000000"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newRequest()
+		s.assertRequestEqual(e, r)
+	})
+	tickers, err := s.client.NewTicker24hrService().AllSymbols().Do(newContext())
+	s.r().NoError(err)
+	s.r().Len(tickers, 2)
+	s.Equal("ETHBTC", tickers[1].Symbol)
+}
+
+func (s *marketTestSuite) TestTicker24hrInvalidFilters() {
+	for _, service := range []*Ticker24hr{
+		s.client.NewTicker24hrService(),
+		s.client.NewTicker24hrService().Symbol("BTCUSDT").Symbols([]string{"ETHUSDT"}),
+		s.client.NewTicker24hrService().Symbol("BTCUSDT").AllSymbols(),
+		s.client.NewTicker24hrService().Symbols([]string{}),
+		s.client.NewTicker24hrService().Symbol(""),
+	} {
+		_, err := service.Do(newContext())
+		s.ErrorIs(err, ErrTicker24hrFilter)
+		_, err = service.DoMini(newContext())
+		s.ErrorIs(err, ErrTicker24hrFilter)
+	}
+}
+
+func (s *marketTestSuite) TestTickerMini() {
+	data := []byte(`{"symbol": "BNBBTC", "openPrice": "99.00000000", "lastPrice": "4.00000200", "count": 76}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{"symbol": "BNBBTC", "windowSize": "1d", "type": TickerTypeMini})
+		s.assertRequestEqual(e, r)
+	})
+	ticker, err := s.client.NewTickerService().Symbol("BNBBTC").WindowSize("1d").Type(TickerTypeFull).DoMini(newContext())
+	s.r().NoError(err)
+	s.Equal(&TickerMiniResponse{Symbol: "BNBBTC", OpenPrice: "99.00000000", LastPrice: "4.00000200", Count: 76}, ticker)
+}
@@ -0,0 +1,125 @@
+package binance_connector
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// RequestMetrics describe a REST request sent by the client, passed to Client.OnRequest once it completed
+type RequestMetrics struct {
+	// RequestID is the ID of the request, set with ContextWithRequestID or generated
+	RequestID string
+	// Attempt is the number of the attempt, above 1 for the retries of Client.Retry
+	Attempt  int
+	Method   string
+	Endpoint string
+	// StatusCode is the HTTP status of the response, zero when none was received
+	StatusCode int
+	// Duration is the time from sending the request to reading the whole response body
+	Duration time.Duration
+	// TransferSize is the size of the response body as received, compressed or not
+	TransferSize int64
+	// ResponseSize is the size of the decompressed response body
+	ResponseSize int64
+	// Err is the error returned for the request, including Binance API errors
+	Err error
+	// Trace is the timing breakdown of the request, nil unless Client.TraceRequests is set
+	Trace *RequestTrace
+}
+
+// RequestTrace define the timing breakdown of a request, recorded through net/http/httptrace.
+// The DNS, connect and TLS durations are zero when a kept-alive connection was reused.
+type RequestTrace struct {
+	DNSLookup    time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+	// ConnReused is true when the request was sent on a kept-alive connection
+	ConnReused bool
+	// ServerProcessing is the time from writing the whole request to the first response byte
+	ServerProcessing time.Duration
+	// TimeToFirstByte is the time from the start of the request to the first response byte
+	TimeToFirstByte time.Duration
+}
+
+// requestTracer collect the httptrace events of a request, the dial events may come from other goroutines
+type requestTracer struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	wroteRequest time.Time
+	trace        RequestTrace
+}
+
+// withTrace return ctx tracing the request it is attached to
+func (t *requestTracer) withTrace(ctx context.Context) context.Context {
+	t.start = time.Now()
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.trace.DNSLookup = time.Since(t.dnsStart)
+		},
+		ConnectStart: func(network, addr string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			// dual stack dials start several connections, time the first one started
+			if t.connectStart.IsZero() {
+				t.connectStart = time.Now()
+			}
+		},
+		ConnectDone: func(network, addr string, err error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if err == nil && t.trace.Connect == 0 {
+				t.trace.Connect = time.Since(t.connectStart)
+			}
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.trace.TLSHandshake = time.Since(t.tlsStart)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.trace.ConnReused = info.Reused
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.wroteRequest = time.Now()
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			now := time.Now()
+			t.trace.TimeToFirstByte = now.Sub(t.start)
+			if !t.wroteRequest.IsZero() {
+				t.trace.ServerProcessing = now.Sub(t.wroteRequest)
+			}
+		},
+	})
+}
+
+// result return a copy of the trace recorded so far
+func (t *requestTracer) result() *RequestTrace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	trace := t.trace
+	return &trace
+}
@@ -0,0 +1,112 @@
+package binance_connector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type metricsTestSuite struct {
+	suite.Suite
+	metrics []*RequestMetrics
+}
+
+func TestMetrics(t *testing.T) {
+	suite.Run(t, new(metricsTestSuite))
+}
+
+func (s *metricsTestSuite) SetupTest() {
+	s.metrics = nil
+}
+
+func (s *metricsTestSuite) newClient(server *httptest.Server) *Client {
+	client := NewClient("dummyAPIKey", "dummySecretKey", server.URL)
+	client.HTTPClient = server.Client()
+	client.OnRequest = func(metrics *RequestMetrics) {
+		s.metrics = append(s.metrics, metrics)
+	}
+	return client
+}
+
+func (s *metricsTestSuite) newServer(tls bool) *httptest.Server {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v3/time" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"code": -1001, "msg": "Internal error"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	if tls {
+		return httptest.NewTLSServer(handler)
+	}
+	return httptest.NewServer(handler)
+}
+
+func (s *metricsTestSuite) TestOnRequest() {
+	server := s.newServer(false)
+	defer server.Close()
+	client := s.newClient(server)
+
+	s.Require().NoError(client.NewPingService().Do(context.Background()))
+	_, err := client.NewServerTimeService().Do(context.Background())
+	s.Require().Error(err)
+
+	s.Require().Len(s.metrics, 2)
+	s.Equal(http.MethodGet, s.metrics[0].Method)
+	s.Equal("/api/v3/ping", s.metrics[0].Endpoint)
+	s.Equal(http.StatusOK, s.metrics[0].StatusCode)
+	s.NoError(s.metrics[0].Err)
+	s.Positive(s.metrics[0].Duration)
+	// tracing is opt-in
+	s.Nil(s.metrics[0].Trace)
+
+	s.Equal("/api/v3/time", s.metrics[1].Endpoint)
+	s.Equal(http.StatusInternalServerError, s.metrics[1].StatusCode)
+	s.Equal(err, s.metrics[1].Err)
+}
+
+func (s *metricsTestSuite) TestTraceRequests() {
+	server := s.newServer(true)
+	defer server.Close()
+	client := s.newClient(server)
+	client.TraceRequests = true
+
+	s.Require().NoError(client.NewPingService().Do(context.Background()))
+	s.Require().NoError(client.NewPingService().Do(context.Background()))
+
+	s.Require().Len(s.metrics, 2)
+	trace := s.metrics[0].Trace
+	s.Require().NotNil(trace)
+	s.False(trace.ConnReused)
+	s.Positive(trace.Connect)
+	s.Positive(trace.TLSHandshake)
+	s.Positive(trace.TimeToFirstByte)
+	s.Positive(trace.ServerProcessing)
+	s.LessOrEqual(trace.ServerProcessing, trace.TimeToFirstByte)
+
+	trace = s.metrics[1].Trace
+	s.Require().NotNil(trace)
+	s.True(trace.ConnReused)
+	s.Zero(trace.Connect)
+	s.Zero(trace.TLSHandshake)
+	s.Positive(trace.TimeToFirstByte)
+}
+
+func (s *metricsTestSuite) TestTransportError() {
+	client := NewClient("dummyAPIKey", "dummySecretKey", "http://127.0.0.1:1")
+	client.OnRequest = func(metrics *RequestMetrics) {
+		s.metrics = append(s.metrics, metrics)
+	}
+	client.TraceRequests = true
+
+	err := client.NewPingService().Do(context.Background())
+	s.Require().Error(err)
+	s.Require().Len(s.metrics, 1)
+	s.Zero(s.metrics[0].StatusCode)
+	s.Equal(err, s.metrics[0].Err)
+	s.NotNil(s.metrics[0].Trace)
+}
@@ -0,0 +1,101 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrNotionalRejected is returned by NotionalCheck.Err for an order outside the notional limits
+var ErrNotionalRejected = errors.New("order notional rejected")
+
+// NotionalCheck define the result of CheckNotional
+type NotionalCheck struct {
+	// Notional is price * quantity, with the average price for market orders
+	Notional float64
+	// MinNotional and MaxNotional are the limits applied to the order, zero when there is none
+	MinNotional float64
+	MaxNotional float64
+	// FilterType is MIN_NOTIONAL or NOTIONAL, empty when the symbol has no notional filter
+	FilterType string
+	// Estimated is true for market orders, whose notional uses the average price of AvgPriceMins minutes
+	Estimated bool
+	Passed    bool
+}
+
+// Err return an error describing the failed limit, nil when the order passed
+func (n *NotionalCheck) Err() error {
+	if n.Passed {
+		return nil
+	}
+	if n.MinNotional > 0 && n.Notional < n.MinNotional {
+		return fmt.Errorf("%w: notional %g is below the %s minimum %g", ErrNotionalRejected, n.Notional, n.FilterType, n.MinNotional)
+	}
+	return fmt.Errorf("%w: notional %g is above the %s maximum %g", ErrNotionalRejected, n.Notional, n.FilterType, n.MaxNotional)
+}
+
+// CheckNotional check the order notional of symbol against its MIN_NOTIONAL or NOTIONAL filter, using
+// the cached exchange information. A zero price is a market order: its notional is estimated with the
+// average price, and the limits only apply when the filter applies them to market orders.
+func (c *Client) CheckNotional(ctx context.Context, symbol string, price, quantity float64) (*NotionalCheck, error) {
+	info, err := c.exchangeInfoCache().Symbol(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	market := price == 0
+	check := &NotionalCheck{Estimated: market, Passed: true}
+	if market {
+		avg, err := c.NewAvgPriceService().Symbol(symbol).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if price, err = strconv.ParseFloat(avg.Price, 64); err != nil {
+			return nil, fmt.Errorf("invalid average price %q: %w", avg.Price, err)
+		}
+	}
+	check.Notional = price * quantity
+
+	for _, filter := range info.Filters {
+		var applyMin, applyMax bool
+		switch filter.FilterType {
+		case "MIN_NOTIONAL":
+			applyMin = !market || filter.ApplyToMarket
+		case "NOTIONAL":
+			applyMin = !market || filter.ApplyMinToMarket
+			applyMax = !market || filter.ApplyMaxToMarket
+		default:
+			continue
+		}
+		check.FilterType = filter.FilterType
+		if applyMin {
+			if check.MinNotional, err = parseFilterValue(filter.MinNotional); err != nil {
+				return nil, err
+			}
+		}
+		if applyMax {
+			if check.MaxNotional, err = parseFilterValue(filter.MaxNotional); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if check.MinNotional > 0 && check.Notional < check.MinNotional {
+		check.Passed = false
+	}
+	if check.MaxNotional > 0 && check.Notional > check.MaxNotional {
+		check.Passed = false
+	}
+	return check, nil
+}
+
+// parseFilterValue parse a decimal of a symbol filter, an empty value is zero
+func parseFilterValue(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid filter value %q: %w", value, err)
+	}
+	return v, nil
+}
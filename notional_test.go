@@ -0,0 +1,112 @@
+package binance_connector
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var notionalExchangeInfoData = []byte(`{
+	"symbols": [
+		{
+			"symbol": "BTCUSDT",
+			"status": "TRADING",
+			"filters": [
+				{"filterType": "NOTIONAL", "minNotional": "5.00000000", "applyMinToMarket": true, "maxNotional": "9000000.00000000", "applyMaxToMarket": false, "avgPriceMins": 5}
+			]
+		},
+		{
+			"symbol": "ETHBTC",
+			"status": "TRADING",
+			"filters": [
+				{"filterType": "MIN_NOTIONAL", "minNotional": "0.00010000", "applyToMarket": false, "avgPriceMins": 5}
+			]
+		},
+		{
+			"symbol": "BNBBTC",
+			"status": "TRADING",
+			"filters": []
+		}
+	]
+}`)
+
+type notionalTestSuite struct {
+	suite.Suite
+	client   *Client
+	avgPrice string
+}
+
+func TestNotional(t *testing.T) {
+	suite.Run(t, new(notionalTestSuite))
+}
+
+func (s *notionalTestSuite) SetupTest() {
+	s.client = NewPublicClient("https://dummyapi.com")
+	s.avgPrice = "20000.00"
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/api/v3/exchangeInfo":
+			return newHTTPResponse(notionalExchangeInfoData, http.StatusOK), nil
+		case "/api/v3/avgPrice":
+			return newHTTPResponse([]byte(`{"mins":5,"price":"`+s.avgPrice+`"}`), http.StatusOK), nil
+		}
+		s.FailNow("unexpected request " + req.URL.Path)
+		return nil, nil
+	}
+}
+
+func (s *notionalTestSuite) TestLimitOrder() {
+	check, err := s.client.CheckNotional(newContext(), "BTCUSDT", 20000, 0.001)
+	s.Require().NoError(err)
+	s.Equal(&NotionalCheck{Notional: 20, MinNotional: 5, MaxNotional: 9000000, FilterType: "NOTIONAL", Passed: true}, check)
+	s.NoError(check.Err())
+
+	check, err = s.client.CheckNotional(newContext(), "BTCUSDT", 20000, 0.0001)
+	s.Require().NoError(err)
+	s.False(check.Passed)
+	s.ErrorIs(check.Err(), ErrNotionalRejected)
+	s.Contains(check.Err().Error(), "below the NOTIONAL minimum 5")
+
+	check, err = s.client.CheckNotional(newContext(), "BTCUSDT", 20000, 1000)
+	s.Require().NoError(err)
+	s.False(check.Passed)
+	s.Contains(check.Err().Error(), "above the NOTIONAL maximum")
+}
+
+func (s *notionalTestSuite) TestMarketOrder() {
+	check, err := s.client.CheckNotional(newContext(), "BTCUSDT", 0, 0.0001)
+	s.Require().NoError(err)
+	s.True(check.Estimated)
+	s.Equal(2.0, check.Notional)
+	s.Equal(5.0, check.MinNotional, "applyMinToMarket")
+	s.Equal(0.0, check.MaxNotional, "the maximum does not apply to market orders")
+	s.False(check.Passed)
+
+	check, err = s.client.CheckNotional(newContext(), "BTCUSDT", 0, 1000)
+	s.Require().NoError(err)
+	s.True(check.Passed)
+
+	// MIN_NOTIONAL not applied to market orders
+	s.avgPrice = "0.05"
+	check, err = s.client.CheckNotional(newContext(), "ETHBTC", 0, 0.0001)
+	s.Require().NoError(err)
+	s.Equal("MIN_NOTIONAL", check.FilterType)
+	s.Equal(0.0, check.MinNotional)
+	s.True(check.Passed)
+
+	check, err = s.client.CheckNotional(newContext(), "ETHBTC", 0.05, 0.0001)
+	s.Require().NoError(err)
+	s.Equal(0.0001, check.MinNotional)
+	s.False(check.Passed)
+}
+
+func (s *notionalTestSuite) TestNoFilterAndUnknownSymbol() {
+	check, err := s.client.CheckNotional(newContext(), "BNBBTC", 0.01, 0.0001)
+	s.Require().NoError(err)
+	s.Empty(check.FilterType)
+	s.True(check.Passed)
+
+	_, err = s.client.CheckNotional(newContext(), "BTCUSD", 20000, 1)
+	s.ErrorIs(err, ErrSymbolNotFound)
+}
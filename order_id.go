@@ -0,0 +1,55 @@
+package binance_connector
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrOrderIdRequired is matched by errors.Is on a MissingOrderIdError
+var ErrOrderIdRequired = errors.New("order identifier required")
+
+// MissingOrderIdError is returned before sending a request that must identify an order or an order list, such as a
+// query or a cancel, when none of its identifiers is set. Binance would answer with a -1102 error.
+type MissingOrderIdError struct {
+	Service string
+	// Params are the parameters of which one is required, e.g. orderId and origClientOrderId
+	Params []string
+	// OrderList is true for the services identifying an order list, whose errors also match ErrOrderListIdRequired
+	OrderList bool
+}
+
+func (e *MissingOrderIdError) Error() string {
+	return fmt.Sprintf("%s requires %s, none is set", e.Service, strings.Join(e.Params, " or "))
+}
+
+// Is return true for ErrOrderIdRequired, and for ErrOrderListIdRequired when the service identifies an order list
+func (e *MissingOrderIdError) Is(target error) bool {
+	return target == ErrOrderIdRequired || (e.OrderList && target == ErrOrderListIdRequired)
+}
+
+// orderIdParam is a parameter identifying an order or an order list, and whether it is set
+type orderIdParam struct {
+	name string
+	set  bool
+}
+
+// requireOrderId return a MissingOrderIdError for service when none of params is set, an empty client order id is
+// not set. The error lists the params in their order.
+func requireOrderId(service string, params ...orderIdParam) error {
+	names := make([]string, 0, len(params))
+	orderList := false
+	for _, param := range params {
+		if param.set {
+			return nil
+		}
+		names = append(names, param.name)
+		orderList = orderList || param.name == "orderListId"
+	}
+	return &MissingOrderIdError{Service: service, Params: names, OrderList: orderList}
+}
+
+// isSet return true when the client order id s is set and not empty
+func isSet(s *string) bool {
+	return s != nil && *s != ""
+}
@@ -0,0 +1,78 @@
+package binance_connector
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type orderIdTestSuite struct {
+	suite.Suite
+	client *Client
+	sent   int
+}
+
+func TestOrderId(t *testing.T) {
+	suite.Run(t, new(orderIdTestSuite))
+}
+
+func (s *orderIdTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.sent = 0
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.sent++
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+}
+
+func (s *orderIdTestSuite) TestMissingOrderId() {
+	_, err := s.client.NewGetOrderService().Symbol("BTCUSDT").Do(newContext())
+	s.ErrorIs(err, ErrOrderIdRequired)
+	s.NotErrorIs(err, ErrOrderListIdRequired)
+	s.EqualError(err, "GetOrderService requires orderId or origClientOrderId, none is set")
+
+	_, err = s.client.NewCancelOrderService().Symbol("BTCUSDT").OrigClientOrderId("").Do(newContext())
+	s.EqualError(err, "CancelOrderService requires orderId or origClientOrderId, none is set", "an empty client order id is not set")
+
+	_, err = s.client.NewCancelReplaceService().Symbol("BTCUSDT").Side("BUY").OrderType("MARKET").Quantity(1).
+		CancelReplaceMode("STOP_ON_FAILURE").Do(newContext())
+	var missing *MissingOrderIdError
+	s.Require().ErrorAs(err, &missing)
+	s.Equal(&MissingOrderIdError{Service: "CancelReplaceService", Params: []string{"cancelOrderId", "cancelOrigClientOrderId"}}, missing)
+
+	_, err = s.client.NewMarginAccountCancelOrderService().Symbol("BTCUSDT").Do(newContext())
+	s.ErrorIs(err, ErrOrderIdRequired)
+	s.Zero(s.sent, "the requests are not sent")
+
+	_, err = s.client.NewGetOrderService().Symbol("BTCUSDT").OrderId(1).Do(newContext())
+	s.NoError(err)
+	_, err = s.client.NewCancelOrderService().Symbol("BTCUSDT").OrigClientOrderId("my-order").Do(newContext())
+	s.NoError(err)
+	s.Equal(2, s.sent)
+}
+
+func (s *orderIdTestSuite) TestMissingOrderListId() {
+	_, err := s.client.NewQueryOCOService().Do(newContext())
+	s.ErrorIs(err, ErrOrderListIdRequired)
+	s.ErrorIs(err, ErrOrderIdRequired)
+	s.EqualError(err, "QueryOCOService requires orderListId or origClientOrderId, none is set")
+
+	_, err = s.client.NewCancelOCOService().Symbol("BTCUSDT").Do(newContext())
+	s.EqualError(err, "CancelOCOService requires orderListId or listClientOrderId, none is set")
+	_, err = s.client.NewMarginAccountQueryOCOService().Do(newContext())
+	s.ErrorIs(err, ErrOrderListIdRequired)
+	s.Zero(s.sent)
+
+	_, err = s.client.NewCancelOCOService().Symbol("BTCUSDT").ListClientOrderId("my-list").Do(newContext())
+	s.NoError(err)
+}
+
+func (s *orderIdTestSuite) TestWebsocketAPI() {
+	// the check runs before the connection is used
+	client := NewWebsocketAPIClient("apiKey", "secretKey")
+	_, err := client.NewCancelOrderService().Symbol("BTCUSDT").Do(newContext())
+	s.EqualError(err, "OrderCancelService requires orderId or origClientOrderId, none is set")
+	_, err = client.NewCancelOCOService().Symbol("BTCUSDT").Do(newContext())
+	s.ErrorIs(err, ErrOrderListIdRequired)
+}
@@ -0,0 +1,253 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// ErrOrderListIdRequired is matched by errors.Is on the MissingOrderIdError of the services identifying an
+	// order list, e.g. QueryOrderListService without orderListId nor origClientOrderId
+	ErrOrderListIdRequired = errors.New("orderListId or origClientOrderId is required")
+	// ErrOrderListFromIdWithTime is returned when the fromId of AllOrderListService is combined with startTime or endTime
+	ErrOrderListFromIdWithTime = errors.New("fromId can not be combined with startTime or endTime")
+)
+
+// OrderListOrder define an order of an order list
+type OrderListOrder struct {
+	Symbol        string `json:"symbol"`
+	OrderId       int64  `json:"orderId"`
+	ClientOrderId string `json:"clientOrderId"`
+}
+
+// OrderListFill define a fill of an order of an order list, in FULL responses
+type OrderListFill struct {
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	TradeId         int64  `json:"tradeId"`
+}
+
+// OrderListReport define the report of an order of an order list,
+// the fields an ACK or RESULT response does not carry are zero
+type OrderListReport struct {
+	Symbol                  string          `json:"symbol"`
+	OrderId                 int64           `json:"orderId"`
+	OrderListId             int64           `json:"orderListId"`
+	ClientOrderId           string          `json:"clientOrderId"`
+	TransactTime            uint64          `json:"transactTime"`
+	Price                   FlexFloat       `json:"price"`
+	OrigQty                 FlexFloat       `json:"origQty"`
+	ExecutedQty             FlexFloat       `json:"executedQty"`
+	CummulativeQuoteQty     FlexFloat       `json:"cummulativeQuoteQty"`
+	Status                  string          `json:"status"`
+	TimeInForce             string          `json:"timeInForce"`
+	Type                    string          `json:"type"`
+	Side                    string          `json:"side"`
+	StopPrice               string          `json:"stopPrice"`
+	WorkingTime             int64           `json:"workingTime"`
+	SelfTradePreventionMode string          `json:"selfTradePreventionMode"`
+	IcebergQty              string          `json:"icebergQty,omitempty"`
+	PreventedMatchId        int64           `json:"preventedMatchId,omitempty"`
+	PreventedQuantity       string          `json:"preventedQuantity,omitempty"`
+	StrategyId              int64           `json:"strategyId,omitempty"`
+	StrategyType            int64           `json:"strategyType,omitempty"`
+	TrailingDelta           string          `json:"trailingDelta,omitempty"`
+	TrailingTime            int64           `json:"trailingTime,omitempty"`
+	Fills                   []OrderListFill `json:"fills,omitempty"`
+}
+
+// OrderListResponse define an order list response of any newOrderRespType: the list fields, the orders
+// of the list and their reports
+type OrderListResponse struct {
+	OrderListId       int64             `json:"orderListId"`
+	ContingencyType   string            `json:"contingencyType"`
+	ListStatusType    string            `json:"listStatusType"`
+	ListOrderStatus   string            `json:"listOrderStatus"`
+	ListClientOrderId string            `json:"listClientOrderId"`
+	TransactionTime   uint64            `json:"transactionTime"`
+	Symbol            string            `json:"symbol"`
+	Orders            []OrderListOrder  `json:"orders"`
+	OrderReports      []OrderListReport `json:"orderReports"`
+	respType          int
+}
+
+// orderListResponse has the fields of OrderListResponse without its UnmarshalJSON
+type orderListResponse OrderListResponse
+
+// UnmarshalJSON implements json.Unmarshaler and records the response type received
+func (r *OrderListResponse) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		OrderReports []struct {
+			Status *string          `json:"status"`
+			Fills  *json.RawMessage `json:"fills"`
+		} `json:"orderReports"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*orderListResponse)(r)); err != nil {
+		return err
+	}
+	r.respType = ACK
+	for _, report := range probe.OrderReports {
+		switch {
+		case report.Fills != nil:
+			r.respType = FULL
+			return nil
+		case report.Status != nil:
+			r.respType = RESULT
+		}
+	}
+	return nil
+}
+
+// setRespType record the newOrderRespType requested, which a FULL response without fills can not be told from
+func (r *OrderListResponse) setRespType(respType string) {
+	switch respType {
+	case "ACK":
+		r.respType = ACK
+	case "RESULT":
+		r.respType = RESULT
+	case "FULL":
+		r.respType = FULL
+	}
+}
+
+// RespType return the type of the response received: ACK, RESULT or FULL
+func (r *OrderListResponse) RespType() int {
+	return r.respType
+}
+
+// Report return the report of the order orderId of the list, nil when the response has none
+func (r *OrderListResponse) Report(orderId int64) *OrderListReport {
+	for i := range r.OrderReports {
+		if r.OrderReports[i].OrderId == orderId {
+			return &r.OrderReports[i]
+		}
+	}
+	return nil
+}
+
+// Binance Query Order list (USER_DATA) (GET /api/v3/orderList)
+// QueryOrderListService query an order list of any contingency type, OCO, OTO or OTOCO. The response carries
+// the orders of the list, without their reports: query each order with GetOrderService for its status.
+type QueryOrderListService struct {
+	c                 *Client
+	orderListId       *int64
+	origClientOrderId *string
+}
+
+// OrderListId set orderListId
+func (s *QueryOrderListService) OrderListId(orderListId int64) *QueryOrderListService {
+	s.orderListId = &orderListId
+	return s
+}
+
+// OrigClientOrderId set origClientOrderId, the listClientOrderId of the list
+func (s *QueryOrderListService) OrigClientOrderId(origClientOrderId string) *QueryOrderListService {
+	s.origClientOrderId = &origClientOrderId
+	return s
+}
+
+// Do send request
+func (s *QueryOrderListService) Do(ctx context.Context, opts ...RequestOption) (res *OrderListResponse, err error) {
+	if err := requireOrderId("QueryOrderListService", orderIdParam{"orderListId", s.orderListId != nil}, orderIdParam{"origClientOrderId", isSet(s.origClientOrderId)}); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/orderList",
+		secType:  SecurityTypeUserData,
+		service:  "QueryOrderListService",
+	}
+	if s.orderListId != nil {
+		r.setParam("orderListId", *s.orderListId)
+	}
+	if s.origClientOrderId != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderId)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(OrderListResponse)
+	err = s.c.unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Binance Query all Order lists (USER_DATA) (GET /api/v3/allOrderList)
+// AllOrderListService query the order lists of the account, from fromId or within a time range
+type AllOrderListService struct {
+	c         *Client
+	fromId    *int64
+	startTime *uint64
+	endTime   *uint64
+	limit     *int
+}
+
+// FromId set fromId, the first orderListId returned
+func (s *AllOrderListService) FromId(fromId int64) *AllOrderListService {
+	s.fromId = &fromId
+	return s
+}
+
+// StartTime set startTime
+func (s *AllOrderListService) StartTime(startTime uint64) *AllOrderListService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *AllOrderListService) EndTime(endTime uint64) *AllOrderListService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit, 500 by default and at most 1000
+func (s *AllOrderListService) Limit(limit int) *AllOrderListService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *AllOrderListService) Do(ctx context.Context, opts ...RequestOption) (res []*OrderListResponse, err error) {
+	if s.fromId != nil && (s.startTime != nil || s.endTime != nil) {
+		return nil, ErrOrderListFromIdWithTime
+	}
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/allOrderList",
+		secType:  SecurityTypeUserData,
+		service:  "AllOrderListService",
+	}
+	if s.fromId != nil {
+		r.setParam("fromId", *s.fromId)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = make([]*OrderListResponse, 0)
+	err = s.c.unmarshal(data, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
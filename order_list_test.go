@@ -0,0 +1,215 @@
+package binance_connector
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type orderListTestSuite struct {
+	baseTestSuite
+}
+
+func TestOrderList(t *testing.T) {
+	suite.Run(t, new(orderListTestSuite))
+}
+
+var orderListFullData = []byte(`{
+	"orderListId": 1,
+	"contingencyType": "OCO",
+	"listStatusType": "EXEC_STARTED",
+	"listOrderStatus": "EXECUTING",
+	"listClientOrderId": "lH1YDkuQKWiXVXHPSKYEIp",
+	"transactionTime": 1710485608839,
+	"symbol": "LTCBTC",
+	"orders": [
+		{"symbol": "LTCBTC", "orderId": 10, "clientOrderId": "44nZvqpemY7sVYgPYbvPih"},
+		{"symbol": "LTCBTC", "orderId": 11, "clientOrderId": "NuMp0nVYnciDiFmVqfpBqK"}
+	],
+	"orderReports": [
+		{
+			"symbol": "LTCBTC",
+			"orderId": 10,
+			"orderListId": 1,
+			"clientOrderId": "44nZvqpemY7sVYgPYbvPih",
+			"transactTime": 1710485608839,
+			"price": "1.00000000",
+			"origQty": "5.00000000",
+			"executedQty": "0.00000000",
+			"cummulativeQuoteQty": "0.00000000",
+			"status": "NEW",
+			"timeInForce": "GTC",
+			"type": "STOP_LOSS_LIMIT",
+			"side": "SELL",
+			"stopPrice": "1.00000000",
+			"workingTime": -1,
+			"selfTradePreventionMode": "NONE",
+			"fills": []
+		},
+		{
+			"symbol": "LTCBTC",
+			"orderId": 11,
+			"orderListId": 1,
+			"clientOrderId": "NuMp0nVYnciDiFmVqfpBqK",
+			"transactTime": 1710485608839,
+			"price": "3.00000000",
+			"origQty": "5.00000000",
+			"executedQty": "5.00000000",
+			"cummulativeQuoteQty": "15.00000000",
+			"status": "FILLED",
+			"timeInForce": "GTC",
+			"type": "LIMIT_MAKER",
+			"side": "SELL",
+			"workingTime": 1710485608839,
+			"selfTradePreventionMode": "NONE",
+			"fills": [
+				{"price": "3.00000000", "qty": "5.00000000", "commission": "0.00000000", "commissionAsset": "BTC", "tradeId": 7}
+			]
+		}
+	]
+}`)
+
+func (s *orderListTestSuite) newOCO() *NewOCOService {
+	return s.client.NewNewOCOService().Symbol("LTCBTC").Side("SELL").Quantity(5).Price(3).StopPrice(1)
+}
+
+func (s *orderListTestSuite) TestDefaultFull() {
+	s.mockDo(orderListFullData, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":           "LTCBTC",
+			"side":             "SELL",
+			"quantity":         "5",
+			"price":            float64(3),
+			"stopPrice":        float64(1),
+			"newOrderRespType": "FULL",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.newOCO().Do(newContext())
+	s.r().NoError(err)
+	s.Equal(FULL, res.RespType())
+	s.Equal(int64(1), res.OrderListId)
+	s.Equal("lH1YDkuQKWiXVXHPSKYEIp", res.ListClientOrderId)
+	s.Equal("EXEC_STARTED", res.ListStatusType)
+	s.Equal("EXECUTING", res.ListOrderStatus)
+	s.r().Len(res.Orders, 2)
+
+	report := res.Report(res.Orders[1].OrderId)
+	s.r().NotNil(report)
+	s.Equal("FILLED", report.Status)
+	s.Equal(15.0, report.CummulativeQuoteQty.Float64())
+	s.Equal([]OrderListFill{{Price: "3.00000000", Qty: "5.00000000", Commission: "0.00000000", CommissionAsset: "BTC", TradeId: 7}}, report.Fills)
+	s.Empty(res.Report(10).Fills)
+	s.Nil(res.Report(12))
+}
+
+func (s *orderListTestSuite) TestAck() {
+	data := []byte(`{
+		"orderListId": 1,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "lH1YDkuQKWiXVXHPSKYEIp",
+		"transactionTime": 1710485608839,
+		"symbol": "LTCBTC",
+		"orders": [
+			{"symbol": "LTCBTC", "orderId": 10, "clientOrderId": "44nZvqpemY7sVYgPYbvPih"},
+			{"symbol": "LTCBTC", "orderId": 11, "clientOrderId": "NuMp0nVYnciDiFmVqfpBqK"}
+		],
+		"orderReports": [
+			{"symbol": "LTCBTC", "orderId": 10, "orderListId": 1, "clientOrderId": "44nZvqpemY7sVYgPYbvPih", "transactTime": 1710485608839},
+			{"symbol": "LTCBTC", "orderId": 11, "orderListId": 1, "clientOrderId": "NuMp0nVYnciDiFmVqfpBqK", "transactTime": 1710485608839}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		s.Equal("ACK", r.query.Get("newOrderRespType"))
+	})
+
+	res, err := s.newOCO().NewOrderRespType("ACK").Do(newContext())
+	s.r().NoError(err)
+	s.Equal(ACK, res.RespType())
+	s.r().Len(res.OrderReports, 2)
+	s.Equal(uint64(1710485608839), res.Report(11).TransactTime)
+	s.Empty(res.Report(11).Status)
+}
+
+func (s *orderListTestSuite) TestDetectRespType() {
+	var res OrderListResponse
+	s.r().NoError(json.Unmarshal(orderListFullData, &res))
+	s.Equal(FULL, res.RespType())
+
+	s.r().NoError(json.Unmarshal([]byte(`{"orderListId": 1, "orderReports": [{"orderId": 10, "status": "CANCELED"}]}`), &res))
+	s.Equal(RESULT, res.RespType())
+
+	s.r().NoError(json.Unmarshal([]byte(`{"orderListId": 1, "orderReports": [{"orderId": 10}]}`), &res))
+	s.Equal(ACK, res.RespType())
+}
+
+var orderListQueryData = []byte(`{
+	"orderListId": 27,
+	"contingencyType": "OTO",
+	"listStatusType": "EXEC_STARTED",
+	"listOrderStatus": "EXECUTING",
+	"listClientOrderId": "h2USkA5YQpaXHPIrkd96xE",
+	"transactionTime": 1565245656253,
+	"symbol": "LTCBTC",
+	"orders": [
+		{"symbol": "LTCBTC", "orderId": 4, "clientOrderId": "qD1gy3kc3Gx0rihm9Y3xwS"},
+		{"symbol": "LTCBTC", "orderId": 5, "clientOrderId": "ARzZ9I00CPM8i3NhmU9Ega"}
+	]
+}`)
+
+func (s *orderListTestSuite) TestQueryOrderList() {
+	s.mockDo(orderListQueryData, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"origClientOrderId": "h2USkA5YQpaXHPIrkd96xE",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewQueryOrderListService().OrigClientOrderId("h2USkA5YQpaXHPIrkd96xE").Do(newContext())
+	s.r().NoError(err)
+	s.Equal(int64(27), res.OrderListId)
+	s.Equal("OTO", res.ContingencyType)
+	s.Equal([]OrderListOrder{
+		{Symbol: "LTCBTC", OrderId: 4, ClientOrderId: "qD1gy3kc3Gx0rihm9Y3xwS"},
+		{Symbol: "LTCBTC", OrderId: 5, ClientOrderId: "ARzZ9I00CPM8i3NhmU9Ega"},
+	}, res.Orders)
+	s.Empty(res.OrderReports)
+}
+
+func (s *orderListTestSuite) TestQueryOrderListIdRequired() {
+	_, err := s.client.NewQueryOrderListService().Do(newContext())
+	s.ErrorIs(err, ErrOrderListIdRequired)
+}
+
+func (s *orderListTestSuite) TestAllOrderList() {
+	s.mockDo([]byte(`[`+string(orderListQueryData)+`]`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"startTime": uint64(1565245000000),
+			"endTime":   uint64(1565246000000),
+			"limit":     100,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewAllOrderListService().StartTime(1565245000000).EndTime(1565246000000).Limit(100).Do(newContext())
+	s.r().NoError(err)
+	s.r().Len(res, 1)
+	s.Equal(int64(27), res[0].OrderListId)
+	s.Len(res[0].Orders, 2)
+
+	_, err = s.client.NewAllOrderListService().FromId(27).StartTime(1565245000000).Do(newContext())
+	s.ErrorIs(err, ErrOrderListFromIdWithTime)
+}
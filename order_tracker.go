@@ -0,0 +1,209 @@
+package binance_connector
+
+import (
+	"math/big"
+	"sync"
+)
+
+// TrackedFill is a trade of a tracked order
+type TrackedFill struct {
+	TradeId         int64
+	Price           string
+	Qty             string
+	QuoteQty        string
+	Commission      string
+	CommissionAsset string
+	IsMaker         bool
+	Time            int64
+}
+
+// OrderState is the state of an order tracked by an OrderTracker
+type OrderState struct {
+	Symbol        string
+	ClientOrderId string
+	OrderId       int64
+	Side          string
+	Type          string
+	Price         string
+	Quantity      string
+	Status        string
+	// FilledQty and FilledQuoteQty are the cumulative filled quantities, in base and quote asset
+	FilledQty      string
+	FilledQuoteQty string
+	// AvgPrice is the average price of the fills, empty before the first fill
+	AvgPrice string
+	// Fills are the trades of the order in the order received
+	Fills      []TrackedFill
+	UpdateTime int64
+}
+
+// IsFinal report whether the order reached a status it can not leave
+func (s *OrderState) IsFinal() bool {
+	return isFinalOrderStatus(s.Status)
+}
+
+// OrderTransition is a change of status of a tracked order, e.g. from NEW to PARTIALLY_FILLED.
+// From is empty for the first event of an order.
+type OrderTransition struct {
+	From  string
+	To    string
+	Order OrderState
+}
+
+// OrderTransitionHandler handle the transitions of the tracked orders
+type OrderTransitionHandler func(transition OrderTransition)
+
+// OrderTracker follow the orders of an account through the executionReport events of its user data stream,
+// keyed by client order id, and report their status transitions. The fills of an order are coalesced into its
+// cumulative filled quantities and average price, with one transition per status: several partial fills
+// make a single NEW to PARTIALLY_FILLED transition.
+// The events are applied defensively: a trade already received is ignored, a final status is never left, and
+// an event older than the state, such as a NEW received after a fill, does not move the status back.
+// Handle is the handler of the stream, or is called from one:
+//
+//	tracker := NewOrderTracker().OnTransition(func(t OrderTransition) { ... })
+//	stream := wsClient.NewUserDataStream(client, tracker.Handle, errHandler)
+type OrderTracker struct {
+	mu           sync.Mutex
+	orders       map[string]*trackedOrder
+	onTransition OrderTransitionHandler
+}
+
+type trackedOrder struct {
+	state  OrderState
+	filled *big.Float
+	trades map[int64]bool
+}
+
+// NewOrderTracker create a tracker without orders
+func NewOrderTracker() *OrderTracker {
+	return &OrderTracker{orders: make(map[string]*trackedOrder)}
+}
+
+// OnTransition set the handler called with every transition, on the goroutine calling Handle
+func (t *OrderTracker) OnTransition(handler OrderTransitionHandler) *OrderTracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onTransition = handler
+	return t
+}
+
+// Handle apply an event of the user data stream, the events other than executionReport are ignored
+func (t *OrderTracker) Handle(event *WsUserDataEvent) {
+	if event == nil || event.Event != UserDataEventTypeExecutionReport {
+		return
+	}
+	t.mu.Lock()
+	transition, ok := t.apply(&event.OrderUpdate)
+	handler := t.onTransition
+	t.mu.Unlock()
+	if ok && handler != nil {
+		handler(transition)
+	}
+}
+
+// Order return a snapshot of the state of the order with clientOrderId, false when it is not tracked
+func (t *OrderTracker) Order(clientOrderId string) (OrderState, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	order, ok := t.orders[clientOrderId]
+	if !ok {
+		return OrderState{}, false
+	}
+	return order.snapshot(), true
+}
+
+// Forget stop tracking the order with clientOrderId, e.g. once it reached a final status
+func (t *OrderTracker) Forget(clientOrderId string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.orders, clientOrderId)
+}
+
+// apply apply an execution report and return the transition it caused
+func (t *OrderTracker) apply(update *WsOrderUpdate) (transition OrderTransition, ok bool) {
+	// the report of a cancelation carries the client order id of the cancel request, and the one of the
+	// order in OrigCustomOrderId
+	clientOrderId := update.ClientOrderId
+	if update.OrigCustomOrderId != "" {
+		clientOrderId = update.OrigCustomOrderId
+	}
+	order, tracked := t.orders[clientOrderId]
+	if !tracked {
+		order = &trackedOrder{
+			state: OrderState{
+				Symbol:        update.Symbol,
+				ClientOrderId: clientOrderId,
+				OrderId:       update.Id,
+				Side:          update.Side,
+				Type:          update.Type,
+				Price:         update.Price,
+				Quantity:      update.Volume,
+			},
+			filled: new(big.Float),
+			trades: make(map[int64]bool),
+		}
+		t.orders[clientOrderId] = order
+	}
+	if update.ExecutionType == "TRADE" && !order.trades[update.TradeId] {
+		order.trades[update.TradeId] = true
+		order.state.Fills = append(order.state.Fills, TrackedFill{
+			TradeId:         update.TradeId,
+			Price:           update.LatestPrice,
+			Qty:             update.LatestVolume,
+			QuoteQty:        update.LatestQuoteVolume,
+			Commission:      update.FeeCost,
+			CommissionAsset: update.FeeAsset,
+			IsMaker:         update.IsMaker,
+			Time:            update.TransactionTime,
+		})
+	}
+	// the cumulative quantities only grow, a smaller one comes from an older event
+	if filled, valid := new(big.Float).SetString(update.FilledVolume); valid && filled.Cmp(order.filled) > 0 {
+		order.filled = filled
+		order.state.FilledQty = update.FilledVolume
+		order.state.FilledQuoteQty = update.FilledQuoteVolume
+		if quote, valid := new(big.Float).SetString(update.FilledQuoteVolume); valid {
+			order.state.AvgPrice = new(big.Float).Quo(quote, filled).Text('f', -1)
+		}
+	}
+	from := order.state.Status
+	if tracked && (isFinalOrderStatus(from) || orderStatusRank(update.Status) < orderStatusRank(from)) {
+		return OrderTransition{}, false
+	}
+	if update.TransactionTime > order.state.UpdateTime {
+		order.state.UpdateTime = update.TransactionTime
+	}
+	if update.Status == from {
+		return OrderTransition{}, false
+	}
+	order.state.Status = update.Status
+	return OrderTransition{From: from, To: update.Status, Order: order.snapshot()}, true
+}
+
+func (o *trackedOrder) snapshot() OrderState {
+	state := o.state
+	state.Fills = append([]TrackedFill(nil), o.state.Fills...)
+	return state
+}
+
+func isFinalOrderStatus(status string) bool {
+	switch status {
+	case OrderStatusFilled, OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired, OrderStatusExpiredInMatch:
+		return true
+	}
+	return false
+}
+
+// orderStatusRank order the statuses an order goes through, an order never goes back to a lower rank
+func orderStatusRank(status string) int {
+	switch status {
+	case OrderStatusPendingNew:
+		return 0
+	case OrderStatusNew:
+		return 1
+	case OrderStatusPartiallyFilled, OrderStatusPendingCancel:
+		return 2
+	}
+	return 3
+}
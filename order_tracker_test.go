@@ -0,0 +1,109 @@
+package binance_connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type orderTrackerTestSuite struct {
+	suite.Suite
+	tracker     *OrderTracker
+	transitions []OrderTransition
+}
+
+func TestOrderTracker(t *testing.T) {
+	suite.Run(t, new(orderTrackerTestSuite))
+}
+
+func (s *orderTrackerTestSuite) SetupTest() {
+	s.transitions = nil
+	s.tracker = NewOrderTracker().OnTransition(func(transition OrderTransition) {
+		s.transitions = append(s.transitions, transition)
+	})
+}
+
+func executionReport(update WsOrderUpdate) *WsUserDataEvent {
+	update.Symbol = "BTCUSDT"
+	update.Id = 4293153
+	update.Side = "BUY"
+	update.Type = "LIMIT"
+	update.Price = "30000.00"
+	update.Volume = "1.00000000"
+	if update.ClientOrderId == "" {
+		update.ClientOrderId = "my-order"
+	}
+	return &WsUserDataEvent{Event: UserDataEventTypeExecutionReport, OrderUpdate: update}
+}
+
+func fillReport(tradeId int64, status, qty, price, filled, filledQuote string, time int64) *WsUserDataEvent {
+	return executionReport(WsOrderUpdate{ExecutionType: "TRADE", Status: status, TradeId: tradeId, LatestVolume: qty,
+		LatestPrice: price, FilledVolume: filled, FilledQuoteVolume: filledQuote, FeeCost: "0.00001", FeeAsset: "BTC",
+		TransactionTime: time})
+}
+
+func (s *orderTrackerTestSuite) statuses() [][2]string {
+	var statuses [][2]string
+	for _, t := range s.transitions {
+		statuses = append(statuses, [2]string{t.From, t.To})
+	}
+	return statuses
+}
+
+func (s *orderTrackerTestSuite) TestLifecycle() {
+	s.tracker.Handle(executionReport(WsOrderUpdate{ExecutionType: "NEW", Status: "NEW", FilledVolume: "0.00000000",
+		FilledQuoteVolume: "0.00000000", TransactionTime: 1}))
+	s.tracker.Handle(fillReport(1, "PARTIALLY_FILLED", "0.25000000", "30000.00", "0.25000000", "7500.00000000", 2))
+	s.tracker.Handle(fillReport(2, "PARTIALLY_FILLED", "0.25000000", "29990.00", "0.50000000", "14997.50000000", 3))
+	s.tracker.Handle(fillReport(3, "FILLED", "0.50000000", "29980.00", "1.00000000", "29987.50000000", 4))
+	s.tracker.Handle(&WsUserDataEvent{Event: UserDataEventTypeOutboundAccountPosition})
+
+	s.Equal([][2]string{{"", "NEW"}, {"NEW", "PARTIALLY_FILLED"}, {"PARTIALLY_FILLED", "FILLED"}}, s.statuses())
+	s.Equal("0.25000000", s.transitions[1].Order.FilledQty, "the transition carries the state of its event")
+
+	order, ok := s.tracker.Order("my-order")
+	s.Require().True(ok)
+	s.True(order.IsFinal())
+	s.Equal(int64(4293153), order.OrderId)
+	s.Equal("1.00000000", order.FilledQty)
+	s.Equal("29987.50000000", order.FilledQuoteQty)
+	s.Equal("29987.5", order.AvgPrice)
+	s.Len(order.Fills, 3)
+	s.Equal(TrackedFill{TradeId: 2, Price: "29990.00", Qty: "0.25000000", Commission: "0.00001", CommissionAsset: "BTC", Time: 3}, order.Fills[1])
+	s.Equal(int64(4), order.UpdateTime)
+
+	s.tracker.Forget("my-order")
+	_, ok = s.tracker.Order("my-order")
+	s.False(ok)
+}
+
+func (s *orderTrackerTestSuite) TestDuplicateAndOutOfOrder() {
+	s.tracker.Handle(fillReport(1, "PARTIALLY_FILLED", "0.25000000", "30000.00", "0.25000000", "7500.00000000", 2))
+	// the NEW report arrives after the first fill
+	s.tracker.Handle(executionReport(WsOrderUpdate{ExecutionType: "NEW", Status: "NEW", FilledVolume: "0.00000000", TransactionTime: 1}))
+	s.tracker.Handle(fillReport(2, "FILLED", "0.75000000", "30000.00", "1.00000000", "30000.00000000", 3))
+	// a duplicate of the first fill, then a late partial fill after the final status
+	s.tracker.Handle(fillReport(1, "PARTIALLY_FILLED", "0.25000000", "30000.00", "0.25000000", "7500.00000000", 2))
+
+	s.Equal([][2]string{{"", "PARTIALLY_FILLED"}, {"PARTIALLY_FILLED", "FILLED"}}, s.statuses())
+	order, _ := s.tracker.Order("my-order")
+	s.Equal("FILLED", order.Status)
+	s.Equal("1.00000000", order.FilledQty)
+	s.Equal("30000", order.AvgPrice)
+	s.Len(order.Fills, 2)
+}
+
+func (s *orderTrackerTestSuite) TestCancel() {
+	s.tracker.Handle(executionReport(WsOrderUpdate{ExecutionType: "NEW", Status: "NEW", TransactionTime: 1}))
+	// the cancelation carries the client order id of the cancel request
+	s.tracker.Handle(executionReport(WsOrderUpdate{ExecutionType: "CANCELED", Status: "CANCELED", ClientOrderId: "cancel-1",
+		OrigCustomOrderId: "my-order", TransactionTime: 2}))
+
+	s.Equal([][2]string{{"", "NEW"}, {"NEW", "CANCELED"}}, s.statuses())
+	order, ok := s.tracker.Order("my-order")
+	s.True(ok)
+	s.Equal("CANCELED", order.Status)
+	s.Empty(order.AvgPrice)
+	_, ok = s.tracker.Order("cancel-1")
+	s.False(ok)
+}
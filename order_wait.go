@@ -0,0 +1,94 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Order statuses
+const (
+	OrderStatusNew             = "NEW"
+	OrderStatusPendingNew      = "PENDING_NEW"
+	OrderStatusPartiallyFilled = "PARTIALLY_FILLED"
+	OrderStatusFilled          = "FILLED"
+	OrderStatusPendingCancel   = "PENDING_CANCEL"
+	OrderStatusCanceled        = "CANCELED"
+	OrderStatusRejected        = "REJECTED"
+	OrderStatusExpired         = "EXPIRED"
+	OrderStatusExpiredInMatch  = "EXPIRED_IN_MATCH"
+)
+
+// ErrOrderPredicateUnmet is returned by WaitForOrder when the order reached a final status without meeting the predicate
+var ErrOrderPredicateUnmet = errors.New("order reached a final status without meeting the predicate")
+
+// IsFinal report whether the order reached a status it can not leave
+func (r *GetOrderResponse) IsFinal() bool {
+	return isFinalOrderStatus(r.Status)
+}
+
+// OrderPredicate report whether WaitForOrder can stop waiting on order
+type OrderPredicate func(order *GetOrderResponse) bool
+
+// OrderFinal is met once the order reached a final status
+func OrderFinal(order *GetOrderResponse) bool {
+	return order.IsFinal()
+}
+
+// OrderFilled is met once the order is FILLED
+func OrderFilled(order *GetOrderResponse) bool {
+	return order.Status == OrderStatusFilled
+}
+
+var (
+	// OrderPollInterval is the first delay between two order polls of WaitForOrder.
+	// order weighs 4, the delay doubles after each poll up to OrderMaxPollInterval.
+	OrderPollInterval = time.Millisecond * 500
+	// OrderMaxPollInterval caps the delay between two order polls
+	OrderMaxPollInterval = time.Second * 10
+)
+
+// WaitForOrder poll the order until predicate is met, OrderFinal when predicate is nil, and return its state.
+// The first delay can be set with pollInterval, OrderPollInterval is used otherwise. While the client is
+// rate limited the polls wait for the end of the penalty instead of failing.
+// When ctx is done the last state received is returned with the context error. An order reaching a final
+// status without meeting predicate is returned with ErrOrderPredicateUnmet.
+func (c *Client) WaitForOrder(ctx context.Context, symbol string, orderId int64, predicate OrderPredicate, pollInterval ...time.Duration) (*GetOrderResponse, error) {
+	if predicate == nil {
+		predicate = OrderFinal
+	}
+	backoff := Backoff{Base: OrderPollInterval, Max: OrderMaxPollInterval}
+	if len(pollInterval) > 0 && pollInterval[0] > 0 {
+		backoff.Base = pollInterval[0]
+	}
+	var last *GetOrderResponse
+	attempt := 0
+	for {
+		res, err := c.NewGetOrderService().Symbol(symbol).OrderId(orderId).Do(ctx)
+		var limited *RateLimitedError
+		switch {
+		case err == nil:
+			if predicate(res) {
+				return res, nil
+			}
+			if res.IsFinal() {
+				return res, ErrOrderPredicateUnmet
+			}
+			last = res
+		case ctx.Err() != nil:
+			return last, ctx.Err()
+		case errors.As(err, &limited):
+			if serr := sleepContext(ctx, time.Until(limited.Until)); serr != nil {
+				return last, serr
+			}
+			continue
+		default:
+			return last, err
+		}
+
+		if err := sleepContext(ctx, backoff.Delay(attempt)); err != nil {
+			return last, err
+		}
+		attempt++
+	}
+}
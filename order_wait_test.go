@@ -0,0 +1,89 @@
+package binance_connector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type orderWaitTestSuite struct {
+	baseTestSuite
+}
+
+func TestOrderWait(t *testing.T) {
+	suite.Run(t, new(orderWaitTestSuite))
+}
+
+func (s *orderWaitTestSuite) mockDoOnce(data []byte, statusCode ...int) {
+	s.client.Client.do = s.client.do
+	code := http.StatusOK
+	if len(statusCode) > 0 {
+		code = statusCode[0]
+	}
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse(data, code), nil).Once()
+}
+
+func (s *orderWaitTestSuite) TestWaitForOrder() {
+	s.mockDoOnce([]byte(`{"symbol": "BTCUSDT", "orderId": 1, "status": "NEW"}`))
+	s.mockDoOnce([]byte(`{"symbol": "BTCUSDT", "orderId": 1, "status": "PARTIALLY_FILLED", "executedQty": "0.5"}`))
+	s.mockDoOnce([]byte(`{"symbol": "BTCUSDT", "orderId": 1, "status": "FILLED", "executedQty": "1"}`))
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{"symbol": "BTCUSDT", "orderId": 1})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.WaitForOrder(newContext(), "BTCUSDT", 1, nil, time.Millisecond)
+	s.r().NoError(err)
+	s.Equal(OrderStatusFilled, res.Status)
+	s.Equal("1", res.ExecutedQty)
+	s.client.AssertNumberOfCalls(s.T(), "do", 3)
+}
+
+func (s *orderWaitTestSuite) TestWaitForOrderPredicate() {
+	s.mockDoOnce([]byte(`{"orderId": 1, "status": "NEW"}`))
+	s.mockDoOnce([]byte(`{"orderId": 1, "status": "PARTIALLY_FILLED"}`))
+
+	partial := func(order *GetOrderResponse) bool { return order.Status != OrderStatusNew }
+	res, err := s.client.WaitForOrder(newContext(), "BTCUSDT", 1, partial, time.Millisecond)
+	s.r().NoError(err)
+	s.Equal(OrderStatusPartiallyFilled, res.Status)
+
+	s.mockDoOnce([]byte(`{"orderId": 2, "status": "CANCELED"}`))
+	res, err = s.client.WaitForOrder(newContext(), "BTCUSDT", 2, OrderFilled, time.Millisecond)
+	s.ErrorIs(err, ErrOrderPredicateUnmet)
+	s.Equal(OrderStatusCanceled, res.Status)
+}
+
+func (s *orderWaitTestSuite) TestWaitForOrderRateLimited() {
+	s.mockDoOnce([]byte(`{"orderId": 1, "status": "NEW"}`))
+	s.mockDoOnce([]byte(`{"orderId": 1, "status": "FILLED"}`))
+	s.client.penalty.penalize(time.Now().Add(30*time.Millisecond), ErrRateLimited)
+
+	start := time.Now()
+	res, err := s.client.WaitForOrder(newContext(), "BTCUSDT", 1, OrderFilled, time.Millisecond)
+	s.r().NoError(err)
+	s.Equal(OrderStatusFilled, res.Status)
+	s.GreaterOrEqual(time.Since(start), 30*time.Millisecond)
+	s.client.AssertNumberOfCalls(s.T(), "do", 2)
+}
+
+func (s *orderWaitTestSuite) TestWaitForOrderContextDone() {
+	s.mockDo([]byte(`{"orderId": 1, "status": "NEW"}`), nil)
+
+	ctx, cancel := context.WithTimeout(newContext(), 20*time.Millisecond)
+	defer cancel()
+	res, err := s.client.WaitForOrder(ctx, "BTCUSDT", 1, OrderFilled, time.Hour)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.Equal(OrderStatusNew, res.Status)
+}
+
+func (s *orderWaitTestSuite) TestWaitForOrderError() {
+	s.mockDoOnce([]byte(`{"code": -2013, "msg": "Order does not exist."}`), http.StatusBadRequest)
+
+	res, err := s.client.WaitForOrder(newContext(), "BTCUSDT", 1, nil, time.Millisecond)
+	s.Error(err)
+	s.Nil(res)
+}
@@ -0,0 +1,444 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrOrderBookOutOfSync is returned when a depth event does not follow the last applied update,
+// the local book has to be rebuilt from a fresh snapshot
+var ErrOrderBookOutOfSync = errors.New("order book out of sync, a new snapshot is required")
+
+// ErrOrderBookVerifyThrottled is returned when a verification is requested before the minimum interval elapsed
+var ErrOrderBookVerifyThrottled = errors.New("order book verification throttled")
+
+// ErrOrderBookNotAligned is returned by Verify when the snapshot cannot be brought to the update id of the local
+// book: the book did not catch up with the snapshot within MinInterval, or the diff events between them were not
+// sent through the verifier
+var ErrOrderBookNotAligned = errors.New("order book snapshot not aligned with the local book")
+
+// ErrInvalidDepthLimit is returned when the snapshot limit of a LocalOrderBook is not one of DepthLimits
+var ErrInvalidDepthLimit = errors.New("invalid depth limit")
+
+// DefaultOrderBookSnapshotLimit is the depth of the snapshot a LocalOrderBook is synced from
+const DefaultOrderBookSnapshotLimit = 1000
+
+// DepthLimits are the limits accepted by the depth endpoint
+var DepthLimits = []int{5, 10, 20, 50, 100, 500, 1000, 5000}
+
+// Order book sides
+const (
+	OrderBookSideBid = "BID"
+	OrderBookSideAsk = "ASK"
+)
+
+// OrderBookLevel define a parsed price level of the local order book
+type OrderBookLevel struct {
+	Price    float64
+	Quantity float64
+}
+
+// LocalOrderBook keeps an in-memory order book for a symbol built from a REST depth snapshot
+// and the diff depth stream (<symbol>@depth)
+type LocalOrderBook struct {
+	Symbol string
+
+	mu            sync.RWMutex
+	snapshotLimit int
+	lastUpdateID  int64
+	synced        bool
+	bids          map[float64]float64
+	asks          map[float64]float64
+}
+
+// NewLocalOrderBook create an empty local order book for symbol
+func NewLocalOrderBook(symbol string) *LocalOrderBook {
+	return &LocalOrderBook{
+		Symbol:        symbol,
+		snapshotLimit: DefaultOrderBookSnapshotLimit,
+		bids:          make(map[float64]float64),
+		asks:          make(map[float64]float64),
+	}
+}
+
+// SnapshotLimit set the depth of the snapshot the book is synced from, one of DepthLimits.
+// Shallow books are cheaper to bootstrap and resync but miss the levels beyond the limit,
+// deep books cost more weight: limit 100 weighs 5, 1000 weighs 50 and 5000 weighs 250.
+func (b *LocalOrderBook) SnapshotLimit(limit int) *LocalOrderBook {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.snapshotLimit = limit
+	return b
+}
+
+// SyncOrderBook fetch a depth snapshot of the book snapshot limit and apply it to the book.
+// It bootstraps the book, and rebuilds it with the same depth after ErrOrderBookOutOfSync.
+func (c *Client) SyncOrderBook(ctx context.Context, book *LocalOrderBook, opts ...RequestOption) error {
+	book.mu.RLock()
+	limit := book.snapshotLimit
+	book.mu.RUnlock()
+	if !validDepthLimit(limit) {
+		return fmt.Errorf("%w %d, expected one of %v", ErrInvalidDepthLimit, limit, DepthLimits)
+	}
+	snapshot, err := c.NewOrderBookService().Symbol(book.Symbol).Limit(limit).Do(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	book.ApplySnapshot(snapshot)
+	return nil
+}
+
+func validDepthLimit(limit int) bool {
+	for _, valid := range DepthLimits {
+		if limit == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplySnapshot replaces the content of the book with a REST depth snapshot
+func (b *LocalOrderBook) ApplySnapshot(snapshot *OrderBookResponse) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bids = make(map[float64]float64, len(snapshot.Bids))
+	b.asks = make(map[float64]float64, len(snapshot.Asks))
+	for _, level := range snapshot.Bids {
+		if price, qty, ok := parseSnapshotLevel(level); ok && qty > 0 {
+			b.bids[price] = qty
+		}
+	}
+	for _, level := range snapshot.Asks {
+		if price, qty, ok := parseSnapshotLevel(level); ok && qty > 0 {
+			b.asks[price] = qty
+		}
+	}
+	b.lastUpdateID = int64(snapshot.LastUpdateId)
+	b.synced = false
+}
+
+// ApplyDepthEvent applies a diff depth event on top of the book.
+// Events older than the book are ignored, a gap in the update ids returns ErrOrderBookOutOfSync.
+func (b *LocalOrderBook) ApplyDepthEvent(event *WsDepthEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if event.LastUpdateID <= b.lastUpdateID {
+		return nil
+	}
+	if b.synced {
+		if event.FirstUpdateID != b.lastUpdateID+1 {
+			return ErrOrderBookOutOfSync
+		}
+	} else if event.FirstUpdateID > b.lastUpdateID+1 {
+		return ErrOrderBookOutOfSync
+	}
+	if err := applyLevels(b.bids, event.Bids); err != nil {
+		return err
+	}
+	if err := applyLevels(b.asks, event.Asks); err != nil {
+		return err
+	}
+	b.lastUpdateID = event.LastUpdateID
+	b.synced = true
+	return nil
+}
+
+// levels return the id of the last update applied to the book with its bids and asks, read together
+func (b *LocalOrderBook) levels() (lastUpdateID int64, bids, asks []OrderBookLevel) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	bids = sortedLevels(b.bids)
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	asks = sortedLevels(b.asks)
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+	return b.lastUpdateID, bids, asks
+}
+
+// LastUpdateID return the id of the last update applied to the book
+func (b *LocalOrderBook) LastUpdateID() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.lastUpdateID
+}
+
+// Bids return the bid levels, best (highest) price first
+func (b *LocalOrderBook) Bids() []OrderBookLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	levels := sortedLevels(b.bids)
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Price > levels[j].Price })
+	return levels
+}
+
+// Asks return the ask levels, best (lowest) price first
+func (b *LocalOrderBook) Asks() []OrderBookLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	levels := sortedLevels(b.asks)
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Price < levels[j].Price })
+	return levels
+}
+
+func applyLevels(book map[float64]float64, levels []PriceLevel) error {
+	for i := range levels {
+		price, qty, err := levels[i].Parse()
+		if err != nil {
+			return err
+		}
+		if qty == 0 {
+			delete(book, price)
+			continue
+		}
+		book[price] = qty
+	}
+	return nil
+}
+
+func sortedLevels(book map[float64]float64) []OrderBookLevel {
+	levels := make([]OrderBookLevel, 0, len(book))
+	for price, qty := range book {
+		levels = append(levels, OrderBookLevel{Price: price, Quantity: qty})
+	}
+	return levels
+}
+
+func parseSnapshotLevel(level []*big.Float) (price, qty float64, ok bool) {
+	if len(level) < 2 || level[0] == nil || level[1] == nil {
+		return 0, 0, false
+	}
+	price, _ = level[0].Float64()
+	qty, _ = level[1].Float64()
+	return price, qty, true
+}
+
+// OrderBookLevelDiff define a price level whose quantity differs between the local book and the snapshot
+type OrderBookLevelDiff struct {
+	Side        string
+	Price       float64
+	LocalQty    float64
+	SnapshotQty float64
+}
+
+// OrderBookVerification define the result of comparing a local order book against a REST snapshot
+type OrderBookVerification struct {
+	Symbol           string
+	LocalUpdateID    int64
+	SnapshotUpdateID int64
+	Diffs            []OrderBookLevelDiff
+}
+
+// Consistent report whether no level differs beyond the tolerance
+func (v *OrderBookVerification) Consistent() bool {
+	return len(v.Diffs) == 0
+}
+
+// OrderBookVerifier periodically compares a LocalOrderBook with a REST depth snapshot
+// to catch silent divergence caused by dropped or misapplied diffs.
+// The diff events are sent through its ApplyDepthEvent, so a snapshot is compared at the update id of the book.
+type OrderBookVerifier struct {
+	c           *Client
+	book        *LocalOrderBook
+	limit       int
+	tolerance   float64
+	minInterval time.Duration
+
+	mu         sync.Mutex
+	lastVerify time.Time
+	// events are the last diff events applied to the book, at most orderBookVerifierEvents, kept until a
+	// snapshot is newer
+	events []*WsDepthEvent
+}
+
+// orderBookVerifierEvents is the number of diff events an OrderBookVerifier keeps to align a snapshot, more than
+// a depth stream sends during the fetch of a snapshot
+const orderBookVerifierEvents = 1000
+
+// NewOrderBookVerifier create a verifier for book, fetching snapshots with client
+func (c *Client) NewOrderBookVerifier(book *LocalOrderBook) *OrderBookVerifier {
+	return &OrderBookVerifier{
+		c:           c,
+		book:        book,
+		limit:       100,
+		minInterval: 10 * time.Second,
+	}
+}
+
+// Limit set the depth of the snapshot used for the comparison, one of DepthLimits. Deeper snapshots cost more weight.
+func (v *OrderBookVerifier) Limit(limit int) *OrderBookVerifier {
+	v.limit = limit
+	return v
+}
+
+// Tolerance set the absolute quantity difference accepted before a level is reported
+func (v *OrderBookVerifier) Tolerance(tolerance float64) *OrderBookVerifier {
+	v.tolerance = tolerance
+	return v
+}
+
+// MinInterval set the minimum time between two snapshot fetches
+func (v *OrderBookVerifier) MinInterval(interval time.Duration) *OrderBookVerifier {
+	v.minInterval = interval
+	return v
+}
+
+// ApplyDepthEvent apply a diff depth event to the book, like LocalOrderBook.ApplyDepthEvent, and keep it to align
+// the next snapshot with the book
+func (v *OrderBookVerifier) ApplyDepthEvent(event *WsDepthEvent) error {
+	v.mu.Lock()
+	v.events = append(v.events, event)
+	if len(v.events) > orderBookVerifierEvents {
+		v.events = v.events[len(v.events)-orderBookVerifierEvents:]
+	}
+	v.mu.Unlock()
+	return v.book.ApplyDepthEvent(event)
+}
+
+// Verify fetch a depth snapshot and compare it with the local book.
+// The snapshot is brought to the update id of the book with the diff events sent through ApplyDepthEvent, applied
+// like Binance documents it: sorted by first update id, those older than the snapshot dropped, and the first one
+// straddling the snapshot. When the snapshot is ahead of the book, Verify waits up to MinInterval for the book to
+// catch up. Only the price range covered by the snapshot is compared, since the local book can be deeper.
+func (v *OrderBookVerifier) Verify(ctx context.Context, opts ...RequestOption) (*OrderBookVerification, error) {
+	if !validDepthLimit(v.limit) {
+		return nil, fmt.Errorf("%w %d, expected one of %v", ErrInvalidDepthLimit, v.limit, DepthLimits)
+	}
+	v.mu.Lock()
+	if !v.lastVerify.IsZero() && time.Since(v.lastVerify) < v.minInterval {
+		v.mu.Unlock()
+		return nil, ErrOrderBookVerifyThrottled
+	}
+	v.lastVerify = time.Now()
+	v.mu.Unlock()
+
+	snapshot, err := v.c.NewOrderBookService().Symbol(v.book.Symbol).Limit(v.limit).Do(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	local, bids, asks, err := v.caughtUp(ctx, int64(snapshot.LastUpdateId))
+	if err != nil {
+		return nil, err
+	}
+	aligned, err := v.align(snapshot, local)
+	if err != nil {
+		return nil, err
+	}
+	res := &OrderBookVerification{
+		Symbol:           v.book.Symbol,
+		LocalUpdateID:    local,
+		SnapshotUpdateID: int64(snapshot.LastUpdateId),
+	}
+	res.Diffs = append(res.Diffs, diffLevels(OrderBookSideBid, bids, aligned.Bids(), snapshot.Bids, v.tolerance)...)
+	res.Diffs = append(res.Diffs, diffLevels(OrderBookSideAsk, asks, aligned.Asks(), snapshot.Asks, v.tolerance)...)
+	return res, nil
+}
+
+// caughtUp return the levels of the book once its last update id reached snapshotID, waiting up to MinInterval
+func (v *OrderBookVerifier) caughtUp(ctx context.Context, snapshotID int64) (lastUpdateID int64, bids, asks []OrderBookLevel, err error) {
+	deadline := time.Now().Add(v.minInterval)
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		lastUpdateID, bids, asks = v.book.levels()
+		if lastUpdateID >= snapshotID {
+			return lastUpdateID, bids, asks, nil
+		}
+		if time.Now().After(deadline) {
+			return 0, nil, nil, fmt.Errorf("%w: the snapshot is at %d, the book at %d", ErrOrderBookNotAligned, snapshotID, lastUpdateID)
+		}
+		select {
+		case <-ctx.Done():
+			return 0, nil, nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// align return a book built from snapshot and the kept diff events up to lastUpdateID, the events the snapshot
+// includes are forgotten
+func (v *OrderBookVerifier) align(snapshot *OrderBookResponse, lastUpdateID int64) (*LocalOrderBook, error) {
+	v.mu.Lock()
+	events := v.events[:0:0]
+	for _, event := range v.events {
+		if event.LastUpdateID > int64(snapshot.LastUpdateId) {
+			events = append(events, event)
+		}
+	}
+	v.events = events
+	events = append([]*WsDepthEvent(nil), events...)
+	v.mu.Unlock()
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].FirstUpdateID < events[j].FirstUpdateID })
+	aligned := NewLocalOrderBook(v.book.Symbol)
+	aligned.ApplySnapshot(snapshot)
+	for _, event := range events {
+		if event.LastUpdateID > lastUpdateID {
+			break
+		}
+		if err := aligned.ApplyDepthEvent(event); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrOrderBookNotAligned, err)
+		}
+	}
+	if aligned.LastUpdateID() != lastUpdateID {
+		return nil, fmt.Errorf("%w: the events from %d to %d are missing", ErrOrderBookNotAligned, aligned.LastUpdateID()+1, lastUpdateID)
+	}
+	return aligned, nil
+}
+
+// Run verify the book every MinInterval until ctx is done, passing each result to report
+func (v *OrderBookVerifier) Run(ctx context.Context, report func(res *OrderBookVerification, err error)) {
+	ticker := time.NewTicker(v.minInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			res, err := v.Verify(ctx)
+			if errors.Is(err, ErrOrderBookVerifyThrottled) {
+				continue
+			}
+			report(res, err)
+		}
+	}
+}
+
+// diffLevels compare the local levels with the expected ones, in the price range of the snapshot levels
+func diffLevels(side string, local, expected []OrderBookLevel, snapshot [][]*big.Float, tolerance float64) []OrderBookLevelDiff {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, level := range snapshot {
+		if price, _, ok := parseSnapshotLevel(level); ok {
+			low = math.Min(low, price)
+			high = math.Max(high, price)
+		}
+	}
+	quantities := make(map[float64]float64, len(expected))
+	for _, level := range expected {
+		if level.Price >= low && level.Price <= high {
+			quantities[level.Price] = level.Quantity
+		}
+	}
+	var diffs []OrderBookLevelDiff
+	seen := make(map[float64]bool, len(local))
+	for _, level := range local {
+		if level.Price < low || level.Price > high {
+			continue
+		}
+		seen[level.Price] = true
+		if math.Abs(level.Quantity-quantities[level.Price]) > tolerance {
+			diffs = append(diffs, OrderBookLevelDiff{Side: side, Price: level.Price, LocalQty: level.Quantity, SnapshotQty: quantities[level.Price]})
+		}
+	}
+	for price, qty := range quantities {
+		if !seen[price] && qty > tolerance {
+			diffs = append(diffs, OrderBookLevelDiff{Side: side, Price: price, SnapshotQty: qty})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Price < diffs[j].Price })
+	return diffs
+}
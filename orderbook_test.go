@@ -0,0 +1,188 @@
+package binance_connector
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type orderBookTestSuite struct {
+	baseTestSuite
+}
+
+func TestOrderBook(t *testing.T) {
+	suite.Run(t, new(orderBookTestSuite))
+}
+
+func (s *orderBookTestSuite) newSyncedBook() *LocalOrderBook {
+	book := NewLocalOrderBook("BNBUSDT")
+	book.ApplySnapshot(&OrderBookResponse{LastUpdateId: 100})
+	err := book.ApplyDepthEvent(&WsDepthEvent{
+		FirstUpdateID: 95,
+		LastUpdateID:  101,
+		Bids:          []Bid{{Price: "10.0", Quantity: "1.0"}, {Price: "9.0", Quantity: "2.0"}, {Price: "8.0", Quantity: "3.0"}},
+		Asks:          []Ask{{Price: "11.0", Quantity: "1.5"}, {Price: "12.0", Quantity: "2.5"}},
+	})
+	s.r().NoError(err)
+	return book
+}
+
+func (s *orderBookTestSuite) TestApplyDepthEvent() {
+	book := s.newSyncedBook()
+
+	err := book.ApplyDepthEvent(&WsDepthEvent{
+		FirstUpdateID: 102,
+		LastUpdateID:  103,
+		Bids:          []Bid{{Price: "9.0", Quantity: "0"}},
+		Asks:          []Ask{{Price: "11.0", Quantity: "4.0"}},
+	})
+	s.r().NoError(err)
+	s.Equal(int64(103), book.LastUpdateID())
+	s.Equal([]OrderBookLevel{{Price: 10, Quantity: 1}, {Price: 8, Quantity: 3}}, book.Bids())
+	s.Equal([]OrderBookLevel{{Price: 11, Quantity: 4}, {Price: 12, Quantity: 2.5}}, book.Asks())
+
+	// stale events are ignored
+	s.r().NoError(book.ApplyDepthEvent(&WsDepthEvent{FirstUpdateID: 90, LastUpdateID: 103}))
+
+	err = book.ApplyDepthEvent(&WsDepthEvent{FirstUpdateID: 105, LastUpdateID: 106})
+	s.ErrorIs(err, ErrOrderBookOutOfSync)
+}
+
+func (s *orderBookTestSuite) TestVerify() {
+	data := []byte(`{
+		"lastUpdateId": 101,
+		"bids": [["10.00000000", "1.00000000"], ["9.00000000", "2.50000000"]],
+		"asks": [["11.00000000", "1.50000000"], ["11.50000000", "0.70000000"], ["12.00000000", "2.50000000"]]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbol": "BNBUSDT",
+			"limit":  5,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	book := s.newSyncedBook()
+	verifier := s.client.NewOrderBookVerifier(book).Limit(5).Tolerance(0.1)
+	res, err := verifier.Verify(newContext())
+	s.r().NoError(err)
+	s.False(res.Consistent())
+	s.Equal(int64(101), res.LocalUpdateID)
+	s.Equal(int64(101), res.SnapshotUpdateID)
+	// the 8.0 bid sits outside the snapshot range and is not compared
+	s.Equal([]OrderBookLevelDiff{
+		{Side: OrderBookSideBid, Price: 9, LocalQty: 2, SnapshotQty: 2.5},
+		{Side: OrderBookSideAsk, Price: 11.5, SnapshotQty: 0.7},
+	}, res.Diffs)
+
+	_, err = verifier.Verify(newContext())
+	s.ErrorIs(err, ErrOrderBookVerifyThrottled)
+}
+
+func (s *orderBookTestSuite) TestVerifyAlignsSnapshot() {
+	// the snapshot is taken at 102, while the book applied the updates up to 105
+	data := []byte(`{
+		"lastUpdateId": 102,
+		"bids": [["10.00000000", "1.00000000"], ["9.00000000", "2.00000000"]],
+		"asks": [["11.00000000", "1.50000000"]]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	book := NewLocalOrderBook("BNBUSDT")
+	book.ApplySnapshot(&OrderBookResponse{LastUpdateId: 100})
+	verifier := s.client.NewOrderBookVerifier(book)
+	s.r().NoError(verifier.ApplyDepthEvent(&WsDepthEvent{
+		FirstUpdateID: 95,
+		LastUpdateID:  101,
+		Bids:          []Bid{{Price: "10.0", Quantity: "1.0"}, {Price: "9.0", Quantity: "2.0"}},
+		Asks:          []Ask{{Price: "11.0", Quantity: "1.5"}},
+	}))
+	late := &WsDepthEvent{FirstUpdateID: 102, LastUpdateID: 103, Bids: []Bid{{Price: "9.0", Quantity: "0"}}, Asks: []Ask{{Price: "11.0", Quantity: "4.0"}}}
+	early := &WsDepthEvent{FirstUpdateID: 104, LastUpdateID: 105, Bids: []Bid{{Price: "10.0", Quantity: "3.0"}}}
+	// the event received before the one it follows is sent again once the book caught up
+	s.ErrorIs(verifier.ApplyDepthEvent(early), ErrOrderBookOutOfSync)
+	s.r().NoError(verifier.ApplyDepthEvent(late))
+	s.r().NoError(verifier.ApplyDepthEvent(early))
+	// a stale event is ignored
+	s.r().NoError(verifier.ApplyDepthEvent(late))
+
+	res, err := verifier.Verify(newContext())
+	s.r().NoError(err)
+	s.Equal(int64(105), res.LocalUpdateID)
+	s.Equal(int64(102), res.SnapshotUpdateID)
+	s.True(res.Consistent(), "the snapshot is compared once the updates from 103 to 105 are applied: %v", res.Diffs)
+}
+
+func (s *orderBookTestSuite) TestVerifyInvalidLimit() {
+	verifier := s.client.NewOrderBookVerifier(s.newSyncedBook()).Limit(2)
+	_, err := verifier.Verify(newContext())
+	s.ErrorIs(err, ErrInvalidDepthLimit)
+	s.EqualError(err, "invalid depth limit 2, expected one of [5 10 20 50 100 500 1000 5000]")
+	s.client.AssertNotCalled(s.T(), "do", anyHTTPRequest())
+}
+
+func (s *orderBookTestSuite) TestVerifierKeepsLastEvents() {
+	book := NewLocalOrderBook("BNBUSDT")
+	book.ApplySnapshot(&OrderBookResponse{LastUpdateId: 100})
+	verifier := s.client.NewOrderBookVerifier(book)
+	for id := int64(101); id <= 100+2*orderBookVerifierEvents; id++ {
+		s.r().NoError(verifier.ApplyDepthEvent(&WsDepthEvent{FirstUpdateID: id, LastUpdateID: id}))
+	}
+	s.Len(verifier.events, orderBookVerifierEvents)
+	s.Equal(int64(101+orderBookVerifierEvents), verifier.events[0].FirstUpdateID)
+}
+
+func (s *orderBookTestSuite) TestVerifySnapshotAhead() {
+	s.mockDo([]byte(`{"lastUpdateId": 110, "bids": [], "asks": []}`), nil)
+	defer s.assertDo()
+
+	book := s.newSyncedBook()
+	_, err := s.client.NewOrderBookVerifier(book).MinInterval(20 * time.Millisecond).Verify(newContext())
+	s.ErrorIs(err, ErrOrderBookNotAligned)
+	s.EqualError(err, "order book snapshot not aligned with the local book: the snapshot is at 110, the book at 101")
+}
+
+func (s *orderBookTestSuite) TestSyncOrderBook() {
+	data := []byte(`{
+		"lastUpdateId": 200,
+		"bids": [["10.00000000", "1.00000000"]],
+		"asks": [["11.00000000", "1.50000000"]]
+	}`)
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse(data, http.StatusOK), nil).Once()
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse(data, http.StatusOK), nil).Once()
+	limit := DefaultOrderBookSnapshotLimit
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbol": "BNBUSDT",
+			"limit":  limit,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	book := NewLocalOrderBook("BNBUSDT")
+	s.r().NoError(s.client.SyncOrderBook(newContext(), book))
+	s.Equal(int64(200), book.LastUpdateID())
+	s.Equal([]OrderBookLevel{{Price: 10, Quantity: 1}}, book.Bids())
+
+	// the resync after a gap uses the configured limit
+	limit = 100
+	book.SnapshotLimit(100)
+	s.ErrorIs(book.ApplyDepthEvent(&WsDepthEvent{FirstUpdateID: 205, LastUpdateID: 206}), ErrOrderBookOutOfSync)
+	s.r().NoError(s.client.SyncOrderBook(newContext(), book))
+	s.client.AssertNumberOfCalls(s.T(), "do", 2)
+}
+
+func (s *orderBookTestSuite) TestSyncOrderBookInvalidLimit() {
+	book := NewLocalOrderBook("BNBUSDT").SnapshotLimit(200)
+	err := s.client.SyncOrderBook(newContext(), book)
+	s.ErrorIs(err, ErrInvalidDepthLimit)
+	s.Contains(err.Error(), "200")
+	s.client.AssertNotCalled(s.T(), "do", anyHTTPRequest())
+}
@@ -0,0 +1,143 @@
+package binance_connector
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultPageWindow is the longest time range accepted by the SAPI history endpoints
+	DefaultPageWindow = 90 * 24 * time.Hour
+	// DefaultPageSize is the largest limit accepted by most SAPI history endpoints
+	DefaultPageSize = 1000
+)
+
+// Page define the records requested from a history endpoint.
+// StartTime and EndTime are milliseconds and both inclusive, Offset is the number of records of the
+// window already fetched and Number the 1-based page, for endpoints paging with page or current.
+type Page struct {
+	StartTime uint64
+	EndTime   uint64
+	Offset    int
+	Number    int
+	Limit     int
+}
+
+// PageFetcher fetch the records of page, fewer than page.Limit records ends the time window
+type PageFetcher[T any] func(ctx context.Context, page Page) ([]T, error)
+
+// Paginator fetch every record of a time range through a PageFetcher, page after page, splitting
+// the range in windows no longer than the endpoint accepts.
+type Paginator[T any] struct {
+	fetch    PageFetcher[T]
+	start    time.Time
+	end      time.Time
+	window   time.Duration
+	pageSize int
+	interval time.Duration
+}
+
+// NewPaginator create a paginator of the records between start and end
+func NewPaginator[T any](fetch PageFetcher[T], start, end time.Time) *Paginator[T] {
+	return &Paginator[T]{
+		fetch:    fetch,
+		start:    start,
+		end:      end,
+		window:   DefaultPageWindow,
+		pageSize: DefaultPageSize,
+	}
+}
+
+// Window set the longest time range of one request
+func (p *Paginator[T]) Window(window time.Duration) *Paginator[T] {
+	p.window = window
+	return p
+}
+
+// PageSize set the number of records requested per page
+func (p *Paginator[T]) PageSize(pageSize int) *Paginator[T] {
+	p.pageSize = pageSize
+	return p
+}
+
+// Interval set the delay between two requests, to spread their weight
+func (p *Paginator[T]) Interval(interval time.Duration) *Paginator[T] {
+	p.interval = interval
+	return p
+}
+
+// Each call fn with every record, in the order of the pages. It stops at the first error of a
+// request, of fn or of ctx.
+func (p *Paginator[T]) Each(ctx context.Context, fn func(record T) error) error {
+	window, pageSize := p.window, p.pageSize
+	if window < time.Millisecond {
+		window = DefaultPageWindow
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	first := true
+	for windowStart := p.start; !windowStart.After(p.end); windowStart = windowStart.Add(window) {
+		windowEnd := windowStart.Add(window - time.Millisecond)
+		if windowEnd.After(p.end) {
+			windowEnd = p.end
+		}
+		page := Page{
+			StartTime: uint64(windowStart.UnixMilli()),
+			EndTime:   uint64(windowEnd.UnixMilli()),
+			Number:    1,
+			Limit:     pageSize,
+		}
+		for {
+			if !first {
+				if err := sleepContext(ctx, p.interval); err != nil {
+					return err
+				}
+			}
+			first = false
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			records, err := p.fetch(ctx, page)
+			if err != nil {
+				return err
+			}
+			for _, record := range records {
+				if err := fn(record); err != nil {
+					return err
+				}
+			}
+			if len(records) < pageSize {
+				break
+			}
+			page.Offset += len(records)
+			page.Number++
+		}
+	}
+	return nil
+}
+
+// All return every record of the time range
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var records []T
+	err := p.Each(ctx, func(record T) error {
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+// sleepContext wait for d, or return the error of ctx when it is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,102 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type paginatorTestSuite struct {
+	suite.Suite
+}
+
+func TestPaginator(t *testing.T) {
+	suite.Run(t, new(paginatorTestSuite))
+}
+
+func (s *paginatorTestSuite) TestPagesAndWindows() {
+	start := time.UnixMilli(0)
+	end := start.Add(200 * 24 * time.Hour)
+	var pages []Page
+	// 5 records in the first window, none in the second, 1 in the third
+	records := map[uint64]int{0: 5, uint64(DefaultPageWindow.Milliseconds() * 2): 1}
+	all, err := NewPaginator(func(ctx context.Context, page Page) ([]int, error) {
+		pages = append(pages, page)
+		n := records[page.StartTime] - page.Offset
+		if n > page.Limit {
+			n = page.Limit
+		}
+		res := make([]int, 0, n)
+		for i := 0; i < n; i++ {
+			res = append(res, page.Offset+i)
+		}
+		return res, nil
+	}, start, end).PageSize(2).All(context.Background())
+	s.Require().NoError(err)
+	s.Equal([]int{0, 1, 2, 3, 4, 0}, all)
+
+	window := uint64(DefaultPageWindow.Milliseconds())
+	s.Equal([]Page{
+		{StartTime: 0, EndTime: window - 1, Offset: 0, Number: 1, Limit: 2},
+		{StartTime: 0, EndTime: window - 1, Offset: 2, Number: 2, Limit: 2},
+		{StartTime: 0, EndTime: window - 1, Offset: 4, Number: 3, Limit: 2},
+		{StartTime: window, EndTime: 2*window - 1, Offset: 0, Number: 1, Limit: 2},
+		{StartTime: 2 * window, EndTime: uint64(end.UnixMilli()), Offset: 0, Number: 1, Limit: 2},
+	}, pages)
+}
+
+func (s *paginatorTestSuite) TestErrors() {
+	fetchErr := errors.New("fetch failed")
+	_, err := NewPaginator(func(ctx context.Context, page Page) ([]int, error) {
+		return nil, fetchErr
+	}, time.Now().Add(-time.Hour), time.Now()).All(context.Background())
+	s.ErrorIs(err, fetchErr)
+
+	stopErr := errors.New("stop")
+	calls := 0
+	err = NewPaginator(func(ctx context.Context, page Page) ([]int, error) {
+		calls++
+		return []int{1, 2}, nil
+	}, time.Now().Add(-time.Hour), time.Now()).PageSize(2).Each(context.Background(), func(record int) error {
+		return stopErr
+	})
+	s.ErrorIs(err, stopErr)
+	s.Equal(1, calls)
+}
+
+func (s *paginatorTestSuite) TestContextCancel() {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := NewPaginator(func(ctx context.Context, page Page) ([]int, error) {
+		calls++
+		cancel()
+		return []int{1}, nil
+	}, time.Now().Add(-time.Hour), time.Now()).PageSize(1).Interval(time.Hour).All(ctx)
+	s.ErrorIs(err, context.Canceled)
+	s.Equal(1, calls)
+}
+
+func (s *paginatorTestSuite) TestDepositHistory() {
+	c := NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	var queries []string
+	c.do = func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		queries = append(queries, fmt.Sprintf("%s %s-%s %s/%s", q.Get("coin"), q.Get("startTime"), q.Get("endTime"), q.Get("offset"), q.Get("limit")))
+		if q.Get("offset") == "0" {
+			return newHTTPResponse([]byte(`[{"id":"1","coin":"BTC"},{"id":"2","coin":"BTC"}]`), http.StatusOK), nil
+		}
+		return newHTTPResponse([]byte(`[{"id":"3","coin":"BTC"}]`), http.StatusOK), nil
+	}
+	deposits, err := c.NewDepositHistoryService().Coin("BTC").
+		Paginate(time.UnixMilli(1000), time.UnixMilli(5000)).PageSize(2).
+		All(newContext())
+	s.Require().NoError(err)
+	s.Len(deposits, 3)
+	s.Equal("3", deposits[2].Id)
+	s.Equal([]string{"BTC 1000-5000 0/2", "BTC 1000-5000 2/2"}, queries)
+}
@@ -0,0 +1,105 @@
+package binance_connector
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrNotQuoteOrder is returned by QuoteFill for an order response without origQuoteOrderQty, i.e. an order sent
+// with a base quantity
+var ErrNotQuoteOrder = errors.New("order not sent with quoteOrderQty")
+
+// QuoteFill define the execution of an order sent with quoteOrderQty against the quote amount requested.
+// The quote amount executed differs from the one requested since the base quantity is rounded down to the lot
+// step, and the price moves while a market order walks the book.
+type QuoteFill struct {
+	Side string
+	// RequestedQuote is the quoteOrderQty sent, origQuoteOrderQty
+	RequestedQuote float64
+	// ExecutedQuote is the quote amount executed, cummulativeQuoteQty: the cost of a BUY, the proceeds of a SELL
+	ExecutedQuote float64
+	// ExecutedQty is the base quantity executed
+	ExecutedQty float64
+	// AvgPrice is ExecutedQuote / ExecutedQty, zero when nothing was executed
+	AvgPrice float64
+	// Unfilled is RequestedQuote - ExecutedQuote, the part of the amount the order did not execute
+	Unfilled float64
+	// FillRatio is ExecutedQuote / RequestedQuote
+	FillRatio float64
+}
+
+// QuoteFill return the execution of an order sent with quoteOrderQty, ErrNotQuoteOrder for another order.
+// An order that executed nothing, e.g. an expired market order, returns a QuoteFill with zero amounts.
+func (r *CreateOrderResponseFULL) QuoteFill() (*QuoteFill, error) {
+	return newQuoteFill(r.Side, r.OrigQuoteOrderQty, r.CumulativeQuoteQty, r.ExecutedQty)
+}
+
+// QuoteFill return the execution of an order sent with quoteOrderQty, see CreateOrderResponseFULL.QuoteFill
+func (r *CreateOrderResponseRESULT) QuoteFill() (*QuoteFill, error) {
+	return newQuoteFill(r.Side, r.OrigQuoteOrderQty, r.CumulativeQuoteQty, r.ExecutedQty)
+}
+
+func newQuoteFill(side, requestedQuote, executedQuote, executedQty string) (*QuoteFill, error) {
+	fill := &QuoteFill{Side: side}
+	var err error
+	if fill.RequestedQuote, err = parseOrderAmount("origQuoteOrderQty", requestedQuote); err != nil {
+		return nil, err
+	}
+	if fill.RequestedQuote <= 0 {
+		return nil, ErrNotQuoteOrder
+	}
+	if fill.ExecutedQuote, err = parseOrderAmount("cummulativeQuoteQty", executedQuote); err != nil {
+		return nil, err
+	}
+	if fill.ExecutedQty, err = parseOrderAmount("executedQty", executedQty); err != nil {
+		return nil, err
+	}
+	if fill.ExecutedQty > 0 {
+		fill.AvgPrice = fill.ExecutedQuote / fill.ExecutedQty
+	}
+	fill.Unfilled = roundDecimals(fill.RequestedQuote-fill.ExecutedQuote, amountDecimals)
+	fill.FillRatio = fill.ExecutedQuote / fill.RequestedQuote
+	return fill, nil
+}
+
+// parseOrderAmount parse an amount of an order response, an empty amount is zero
+func parseOrderAmount(name, value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	return v, nil
+}
+
+// Filled return true when the order executed a quantity
+func (f *QuoteFill) Filled() bool {
+	return f.ExecutedQty > 0
+}
+
+// Slippage return the relative difference between the average price and reference, e.g. the best price
+// before sending the order, positive when the order executed worse: above reference for a BUY, below for a
+// SELL. It returns false when nothing was executed or reference is not positive.
+func (f *QuoteFill) Slippage(reference float64) (float64, bool) {
+	if !f.Filled() || reference <= 0 {
+		return 0, false
+	}
+	slippage := (f.AvgPrice - reference) / reference
+	if f.Side == "SELL" {
+		slippage = -slippage
+	}
+	return slippage, true
+}
+
+// SlippageCost return the quote amount lost against executing ExecutedQty at reference, negative when the
+// order executed better, zero when nothing was executed
+func (f *QuoteFill) SlippageCost(reference float64) float64 {
+	cost := f.ExecutedQuote - f.ExecutedQty*reference
+	if f.Side == "SELL" {
+		cost = -cost
+	}
+	return roundDecimals(cost, amountDecimals)
+}
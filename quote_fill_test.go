@@ -0,0 +1,72 @@
+package binance_connector
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/suite"
+)
+
+type quoteFillTestSuite struct {
+	suite.Suite
+}
+
+func TestQuoteFill(t *testing.T) {
+	suite.Run(t, new(quoteFillTestSuite))
+}
+
+func (s *quoteFillTestSuite) TestQuoteFillFilled() {
+	var res CreateOrderResponse
+	s.Require().NoError(json.Unmarshal([]byte(`{
+		"symbol": "BTCUSDT", "orderId": 28, "side": "BUY", "type": "MARKET", "status": "FILLED",
+		"origQty": "0.00331000", "executedQty": "0.00331000",
+		"cummulativeQuoteQty": "99.71030000", "origQuoteOrderQty": "100.00000000",
+		"fills": [
+			{"price": "30120.00", "qty": "0.00200000", "commission": "0", "commissionAsset": "BNB", "tradeId": 1},
+			{"price": "30130.00", "qty": "0.00131000", "commission": "0", "commissionAsset": "BNB", "tradeId": 2}
+		]
+	}`), &res))
+
+	fill, err := res.QuoteFill()
+	s.Require().NoError(err)
+	s.True(fill.Filled())
+	s.Equal(100.0, fill.RequestedQuote)
+	s.Equal(99.7103, fill.ExecutedQuote)
+	s.Equal(0.2897, fill.Unfilled)
+	s.InDelta(0.997103, fill.FillRatio, 1e-9)
+	s.InDelta(30123.96, fill.AvgPrice, 0.01)
+
+	slippage, ok := fill.Slippage(30100)
+	s.True(ok)
+	s.InDelta(0.000796, slippage, 1e-6)
+	s.Equal(0.0793, fill.SlippageCost(30100), "the BUY paid more than at the reference price")
+
+	fill.Side = "SELL"
+	slippage, _ = fill.Slippage(30100)
+	s.Negative(slippage, "a SELL above the reference price executed better")
+	s.Equal(-0.0793, fill.SlippageCost(30100))
+}
+
+func (s *quoteFillTestSuite) TestQuoteFillNotExecuted() {
+	res := &CreateOrderResponseRESULT{Side: "BUY", Status: "EXPIRED", ExecutedQty: "0.00000000",
+		CumulativeQuoteQty: "0.00000000", OrigQuoteOrderQty: "100.00000000"}
+	fill, err := res.QuoteFill()
+	s.Require().NoError(err)
+	s.False(fill.Filled())
+	s.Zero(fill.AvgPrice)
+	s.Zero(fill.FillRatio)
+	s.Equal(100.0, fill.Unfilled)
+	slippage, ok := fill.Slippage(30000)
+	s.False(ok)
+	s.Zero(slippage)
+	s.Zero(fill.SlippageCost(30000))
+}
+
+func (s *quoteFillTestSuite) TestQuoteFillErrors() {
+	_, err := (&CreateOrderResponseFULL{OrigQty: "1.00000000", OrigQuoteOrderQty: "0.00000000"}).QuoteFill()
+	s.ErrorIs(err, ErrNotQuoteOrder)
+	_, err = (&CreateOrderResponseFULL{}).QuoteFill()
+	s.ErrorIs(err, ErrNotQuoteOrder)
+	_, err = (&CreateOrderResponseFULL{OrigQuoteOrderQty: "100", ExecutedQty: "abc"}).QuoteFill()
+	s.EqualError(err, `invalid executedQty "abc": strconv.ParseFloat: parsing "abc": invalid syntax`)
+}
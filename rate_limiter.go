@@ -0,0 +1,259 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRateLimitReached is matched by errors.Is on a RateLimitReachedError
+var ErrRateLimitReached = errors.New("rate limit reached")
+
+const (
+	rateLimitTypeWeight = "REQUEST_WEIGHT"
+	rateLimitTypeOrders = "ORDERS"
+)
+
+// OrderCounts is the number of orders each order-creating endpoint counts against the ORDERS rate limits,
+// keyed like EndpointWeights. Endpoints missing from it, test orders included, are not counted.
+var OrderCounts = map[string]int{
+	"POST /api/v3/order":               1,
+	"POST /api/v3/order/cancelReplace": 1,
+	"POST /api/v3/sor/order":           1,
+	"POST /api/v3/order/oco":           2,
+	"POST /api/v3/orderList/oco":       2,
+	"POST /api/v3/orderList/oto":       2,
+	"POST /api/v3/orderList/otoco":     3,
+}
+
+// RateLimitReachedError is returned instead of sending a request that would exceed a rate limit
+type RateLimitReachedError struct {
+	Usage RateLimitUsage
+	// Cost is the weight or the number of orders of the request
+	Cost int
+}
+
+func (e *RateLimitReachedError) Error() string {
+	return fmt.Sprintf("%s limit reached: %d of %d per %d %s used, window resets at %s", e.Usage.RateLimitType,
+		e.Usage.Count, e.Usage.Limit, e.Usage.IntervalNum, e.Usage.Interval, e.Usage.ResetAt.Format(time.RFC3339))
+}
+
+// Is return true for ErrRateLimitReached
+func (e *RateLimitReachedError) Is(target error) bool {
+	return target == ErrRateLimitReached
+}
+
+// RateLimitUsage define the usage of a rate limit during the current window
+type RateLimitUsage struct {
+	// RateLimitType is REQUEST_WEIGHT or ORDERS
+	RateLimitType string
+	Interval      string
+	IntervalNum   int
+	Limit         int
+	Count         int
+	ResetAt       time.Time
+}
+
+// RateLimiter enforces the REQUEST_WEIGHT and ORDERS rate limits of exchangeInfo on the /api requests
+// of a client. A request that would exceed a limit is held back until the window resets, avoiding the
+// 429 and -1015 "Too many new orders" rejections. The local counts are replaced by the server ones
+// reported in the X-MBX-USED-WEIGHT-* and X-MBX-ORDER-COUNT-* headers of every response, so they
+// do not drift from the server accounting. ORDERS limits apply to the account, whatever the symbol.
+type RateLimiter struct {
+	// MaxWait is the longest a request waits for a window to reset, a request needing a longer
+	// wait is rejected with a RateLimitReachedError. Zero rejects right away.
+	MaxWait time.Duration
+	mu      sync.Mutex
+	windows []*rateWindow
+}
+
+type rateWindow struct {
+	rateLimitType string
+	interval      string
+	intervalNum   int
+	limit         int
+	length        time.Duration
+	start         time.Time
+	count         int
+}
+
+// rateLimitIntervals give the length of the intervals of the exchangeInfo rate limits
+var rateLimitIntervals = map[string]time.Duration{
+	"SECOND": time.Second,
+	"MINUTE": time.Minute,
+	"HOUR":   time.Hour,
+	"DAY":    24 * time.Hour,
+}
+
+// NewRateLimiter create a RateLimiter enforcing the REQUEST_WEIGHT and ORDERS entries of limits
+func NewRateLimiter(limits []*RateLimit) *RateLimiter {
+	l := &RateLimiter{}
+	for _, limit := range limits {
+		if limit.RateLimitType != rateLimitTypeWeight && limit.RateLimitType != rateLimitTypeOrders {
+			continue
+		}
+		unit := rateLimitIntervals[limit.Interval]
+		if unit == 0 {
+			continue
+		}
+		num := max(limit.IntervalNum, 1)
+		l.windows = append(l.windows, &rateWindow{
+			rateLimitType: limit.RateLimitType,
+			interval:      limit.Interval,
+			intervalNum:   num,
+			limit:         limit.Limit,
+			length:        time.Duration(num) * unit,
+		})
+	}
+	return l
+}
+
+// EnableRateLimiter set a RateLimiter built from the rate limits of exchangeInfo on the client
+func (c *Client) EnableRateLimiter(ctx context.Context) (*RateLimiter, error) {
+	info, err := c.exchangeInfoCache().Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.RateLimiter = NewRateLimiter(info.RateLimits)
+	return c.RateLimiter, nil
+}
+
+// Usage return the count of each rate limit during the current window
+func (l *RateLimiter) Usage() []RateLimitUsage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	usage := make([]RateLimitUsage, 0, len(l.windows))
+	for _, w := range l.windows {
+		w.roll(now)
+		usage = append(usage, w.usage())
+	}
+	return usage
+}
+
+// Available return the weight and the number of orders left before a limit is reached,
+// the tightest window of each type wins. A type without limit reports math.MaxInt.
+func (l *RateLimiter) Available() (weight, orders int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	weight, orders = math.MaxInt, math.MaxInt
+	for _, w := range l.windows {
+		w.roll(now)
+		left := max(w.limit-w.count, 0)
+		switch w.rateLimitType {
+		case rateLimitTypeWeight:
+			weight = min(weight, left)
+		case rateLimitTypeOrders:
+			orders = min(orders, left)
+		}
+	}
+	return weight, orders
+}
+
+// wait count weight and orders against every limit, once none of them would be exceeded
+func (l *RateLimiter) wait(ctx context.Context, weight, orders int) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		var blocked *rateWindow
+		for _, w := range l.windows {
+			w.roll(now)
+			if cost := w.cost(weight, orders); cost > 0 && w.count+cost > w.limit &&
+				(blocked == nil || w.resetAt().After(blocked.resetAt())) {
+				blocked = w
+			}
+		}
+		if blocked == nil {
+			for _, w := range l.windows {
+				w.count += w.cost(weight, orders)
+			}
+			l.mu.Unlock()
+			return nil
+		}
+		err := &RateLimitReachedError{Usage: blocked.usage(), Cost: blocked.cost(weight, orders)}
+		l.mu.Unlock()
+
+		delay := err.Usage.ResetAt.Sub(now)
+		if delay > l.MaxWait {
+			return err
+		}
+		if serr := sleepContext(ctx, delay); serr != nil {
+			return serr
+		}
+	}
+}
+
+// update replace the counts with the ones reported by Binance, e.g. X-Mbx-Used-Weight-1m
+func (l *RateLimiter) update(header http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	for _, w := range l.windows {
+		v := header.Get(w.header())
+		if v == "" {
+			continue
+		}
+		count, err := strconv.Atoi(v)
+		if err != nil {
+			continue
+		}
+		w.roll(now)
+		w.count = count
+	}
+}
+
+// cost return the part of a request counted by the window
+func (w *rateWindow) cost(weight, orders int) int {
+	if w.rateLimitType == rateLimitTypeOrders {
+		return orders
+	}
+	return weight
+}
+
+// header return the name of the response header reporting the count of the window
+func (w *rateWindow) header() string {
+	prefix := "X-Mbx-Used-Weight-"
+	if w.rateLimitType == rateLimitTypeOrders {
+		prefix = "X-Mbx-Order-Count-"
+	}
+	return prefix + strconv.Itoa(w.intervalNum) + strings.ToLower(w.interval[:1])
+}
+
+// roll start a new window once the current one is over, Binance windows are aligned on the clock
+func (w *rateWindow) roll(now time.Time) {
+	if start := now.Truncate(w.length); !start.Equal(w.start) {
+		w.start = start
+		w.count = 0
+	}
+}
+
+func (w *rateWindow) resetAt() time.Time {
+	return w.start.Add(w.length)
+}
+
+func (w *rateWindow) usage() RateLimitUsage {
+	return RateLimitUsage{
+		RateLimitType: w.rateLimitType,
+		Interval:      w.interval,
+		IntervalNum:   w.intervalNum,
+		Limit:         w.limit,
+		Count:         w.count,
+		ResetAt:       w.resetAt(),
+	}
+}
+
+// rateLimitCost return the weight and the number of orders of the request, the /sapi endpoints have
+// their own limits and are not counted
+func (r *request) rateLimitCost() (weight, orders int) {
+	if !strings.HasPrefix(r.endpoint, "/api/") {
+		return 0, 0
+	}
+	return r.weight(), OrderCounts[r.method+" "+r.endpoint]
+}
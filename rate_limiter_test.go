@@ -0,0 +1,153 @@
+package binance_connector
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type rateLimiterTestSuite struct {
+	suite.Suite
+	client *Client
+	sent   map[string]int
+	header http.Header
+}
+
+func TestRateLimiter(t *testing.T) {
+	suite.Run(t, new(rateLimiterTestSuite))
+}
+
+func (s *rateLimiterTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.sent = map[string]int{}
+	s.header = http.Header{}
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.sent[req.URL.Path]++
+		if req.URL.Path == "/api/v3/exchangeInfo" {
+			return newHTTPResponse([]byte(`{"rateLimits":[
+				{"rateLimitType":"REQUEST_WEIGHT","interval":"MINUTE","intervalNum":1,"limit":6000},
+				{"rateLimitType":"ORDERS","interval":"SECOND","intervalNum":10,"limit":5},
+				{"rateLimitType":"ORDERS","interval":"DAY","intervalNum":1,"limit":160000},
+				{"rateLimitType":"RAW_REQUESTS","interval":"MINUTE","intervalNum":5,"limit":61000}]}`), http.StatusOK), nil
+		}
+		res := newHTTPResponse([]byte(`{}`), http.StatusOK)
+		res.Header = s.header
+		return res, nil
+	}
+}
+
+func (s *rateLimiterTestSuite) order() error {
+	_, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).NewOrderRespType("ACK").Do(newContext())
+	return err
+}
+
+func (s *rateLimiterTestSuite) TestEnable() {
+	limiter, err := s.client.EnableRateLimiter(newContext())
+	s.Require().NoError(err)
+	s.Same(limiter, s.client.RateLimiter)
+	usage := limiter.Usage()
+	s.Require().Len(usage, 3)
+	s.Equal(RateLimitUsage{RateLimitType: "REQUEST_WEIGHT", Interval: "MINUTE", IntervalNum: 1, Limit: 6000, ResetAt: usage[0].ResetAt}, usage[0])
+	s.Equal("ORDERS", usage[1].RateLimitType)
+	s.Equal(10, usage[1].IntervalNum)
+	s.Equal(5, usage[1].Limit)
+	s.False(usage[1].ResetAt.After(time.Now().Add(10 * time.Second)))
+	s.Equal(160000, usage[2].Limit)
+
+	s.Require().NoError(s.order())
+	weight, orders := limiter.Available()
+	s.Equal(5999, weight)
+	s.Equal(4, orders)
+}
+
+func (s *rateLimiterTestSuite) TestRejectsOrdersOverLimit() {
+	s.client.RateLimiter = NewRateLimiter([]*RateLimit{{RateLimitType: "ORDERS", Interval: "DAY", IntervalNum: 1, Limit: 4}})
+	for i := 0; i < 4; i++ {
+		s.Require().NoError(s.order())
+	}
+	err := s.order()
+	s.Require().ErrorIs(err, ErrRateLimitReached)
+	var limitErr *RateLimitReachedError
+	s.Require().ErrorAs(err, &limitErr)
+	s.Equal("ORDERS", limitErr.Usage.RateLimitType)
+	s.Equal(4, limitErr.Usage.Count)
+	s.Equal(1, limitErr.Cost)
+	s.True(limitErr.Usage.ResetAt.After(time.Now()))
+	s.Equal(4, s.sent["/api/v3/order"])
+
+	// requests that place no order are not limited
+	s.NoError(s.client.NewPingService().Do(newContext()))
+	_, err = s.client.NewTestNewOrder().Symbol("BTCUSDT").Side("BUY").OrderType("MARKET").Quantity(1).Do(newContext())
+	s.NoError(err)
+	weight, orders := s.client.RateLimiter.Available()
+	s.Equal(math.MaxInt, weight)
+	s.Zero(orders)
+}
+
+func (s *rateLimiterTestSuite) TestRejectsWeightOverLimit() {
+	s.client.RateLimiter = NewRateLimiter([]*RateLimit{{RateLimitType: "REQUEST_WEIGHT", Interval: "MINUTE", IntervalNum: 1, Limit: 45}})
+	_, err := s.client.NewGetAccountService().Do(newContext())
+	s.Require().NoError(err)
+	_, err = s.client.NewGetAccountService().Do(newContext())
+	s.Require().NoError(err)
+	_, err = s.client.NewGetAccountService().Do(newContext())
+	var limitErr *RateLimitReachedError
+	s.Require().ErrorAs(err, &limitErr)
+	s.Equal(40, limitErr.Usage.Count)
+	s.Equal(20, limitErr.Cost)
+	s.Equal(2, s.sent["/api/v3/account"])
+
+	// the /sapi endpoints have their own limits
+	_, err = s.client.NewGetAllCoinsInfoService().Do(newContext())
+	s.Error(err)
+	s.Equal(1, s.sent["/sapi/v1/capital/config/getall"])
+}
+
+func (s *rateLimiterTestSuite) TestOrderListsCountEachOrder() {
+	s.client.RateLimiter = NewRateLimiter([]*RateLimit{{RateLimitType: "ORDERS", Interval: "DAY", IntervalNum: 1, Limit: 3}})
+	s.Require().NoError(s.order())
+	s.Require().NoError(s.order())
+	_, err := s.client.NewNewOCOService().Symbol("BTCUSDT").Side("SELL").Quantity(1).Price(2).StopPrice(1).Do(newContext())
+	s.ErrorIs(err, ErrRateLimitReached)
+	s.Zero(s.sent["/api/v3/order/oco"])
+	s.Equal(2, s.client.RateLimiter.Usage()[0].Count)
+}
+
+func (s *rateLimiterTestSuite) TestSyncsWithHeaders() {
+	s.client.RateLimiter = NewRateLimiter([]*RateLimit{
+		{RateLimitType: "REQUEST_WEIGHT", Interval: "MINUTE", IntervalNum: 1, Limit: 6000},
+		{RateLimitType: "ORDERS", Interval: "SECOND", IntervalNum: 10, Limit: 100},
+		{RateLimitType: "ORDERS", Interval: "DAY", IntervalNum: 1, Limit: 200000},
+	})
+	s.header.Set("X-MBX-USED-WEIGHT-1M", "321")
+	s.header.Set("X-MBX-ORDER-COUNT-10S", "7")
+	s.header.Set("X-MBX-ORDER-COUNT-1D", "1234")
+	s.Require().NoError(s.order())
+	usage := s.client.RateLimiter.Usage()
+	s.Equal(321, usage[0].Count)
+	s.Equal(7, usage[1].Count)
+	s.Equal(1234, usage[2].Count)
+	weight, orders := s.client.RateLimiter.Available()
+	s.Equal(5679, weight)
+	s.Equal(93, orders)
+}
+
+func (s *rateLimiterTestSuite) TestWaitsForWindowReset() {
+	s.client.RateLimiter = NewRateLimiter([]*RateLimit{{RateLimitType: "ORDERS", Interval: "SECOND", IntervalNum: 1, Limit: 1}})
+	s.client.RateLimiter.MaxWait = 2 * time.Second
+	s.Require().NoError(s.order())
+	start := time.Now()
+	s.Require().NoError(s.order())
+	s.WithinDuration(s.client.RateLimiter.Usage()[0].ResetAt.Add(-time.Second), time.Now(), 50*time.Millisecond)
+	s.Less(time.Since(start), 1100*time.Millisecond)
+	s.Equal(2, s.sent["/api/v3/order"])
+
+	ctx, cancel := context.WithCancel(newContext())
+	cancel()
+	_, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).Do(ctx)
+	s.ErrorIs(err, context.Canceled)
+}
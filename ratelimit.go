@@ -0,0 +1,134 @@
+package binance_connector
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/luciano-personal-org/binance-connector/handlers"
+)
+
+// ErrRateLimited is matched by errors.Is on a RateLimitedError
+var ErrRateLimited = errors.New("rate limited")
+
+// errCodeTooManyRequests is the error code of a rate limit violation or an IP ban, whatever the HTTP status
+const errCodeTooManyRequests = -1003
+
+// isRateLimitError return true when data is a -1003 error: it is a rate limit violation even when it is not sent
+// with an error status
+func isRateLimitError(r *request, data []byte) bool {
+	if isSBEResponse(r) || !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return false
+	}
+	apiErr := &handlers.APIError{}
+	return json.Unmarshal(data, apiErr) == nil && apiErr.Code == errCodeTooManyRequests
+}
+
+// banUntilPattern extract the end of an IP ban from messages like
+// "Way too many requests; IP(1.2.3.4) banned until 1596015863000. Please use the websocket for live updates to avoid bans."
+var banUntilPattern = regexp.MustCompile(`banned until (\d+)`)
+
+// RateLimitedError is returned instead of sending a request while the client is in the penalty box,
+// after Binance answered 429, 418 or -1003. Requests are sent again once Until passed.
+type RateLimitedError struct {
+	Until time.Time
+	// LastErr is the response that put the client in the penalty box
+	LastErr error
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("requests blocked until %s after a rate limit violation (last: %v)", e.Until.Format(time.RFC3339), e.LastErr)
+}
+
+// Is return true for ErrRateLimited
+func (e *RateLimitedError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// penaltyBox blocks every request of a client until the end of a rate limit penalty or IP ban. A nil penaltyBox,
+// the one of a Client built as a struct literal, never blocks.
+type penaltyBox struct {
+	mu      sync.Mutex
+	until   time.Time
+	lastErr error
+}
+
+// check return a RateLimitedError while the penalty runs
+func (p *penaltyBox) check() error {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if time.Now().Before(p.until) {
+		return &RateLimitedError{Until: p.until, LastErr: p.lastErr}
+	}
+	return nil
+}
+
+// penalize block requests until until, a shorter penalty never replaces a longer one
+func (p *penaltyBox) penalize(until time.Time, err error) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if until.After(p.until) {
+		p.until = until
+		p.lastErr = err
+	}
+}
+
+func (p *penaltyBox) bannedUntil() time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if time.Now().Before(p.until) {
+		return p.until
+	}
+	return time.Time{}
+}
+
+// rateLimitPenalty return the end of the penalty signaled by a response, false when it is not a rate limit violation.
+// The ban time of a -1003 message wins, then retryAfter (e.g. the Retry-After header), then the start of the
+// next minute, when Binance request weight windows reset.
+func rateLimitPenalty(status int, apiErr *handlers.APIError, retryAfter time.Duration, now time.Time) (time.Time, bool) {
+	limited := status == http.StatusTooManyRequests || status == http.StatusTeapot
+	if apiErr != nil && apiErr.Code == errCodeTooManyRequests {
+		limited = true
+		if m := banUntilPattern.FindStringSubmatch(apiErr.Message); m != nil {
+			if ms, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+				return time.UnixMilli(ms), true
+			}
+		}
+	}
+	if !limited {
+		return time.Time{}, false
+	}
+	if retryAfter > 0 {
+		return now.Add(retryAfter), true
+	}
+	return now.Truncate(time.Minute).Add(time.Minute), true
+}
+
+// parseRetryAfter return the delay of a Retry-After header given in seconds
+func parseRetryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// RateLimitedUntil return the end of the current rate limit penalty or IP ban, zero when requests are allowed
+func (c *Client) RateLimitedUntil() time.Time {
+	return c.penalty.bannedUntil()
+}
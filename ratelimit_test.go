@@ -0,0 +1,153 @@
+package binance_connector
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+type rateLimitTestSuite struct {
+	suite.Suite
+	client *Client
+	sent   int
+	reply  func() *http.Response
+}
+
+func TestRateLimit(t *testing.T) {
+	suite.Run(t, new(rateLimitTestSuite))
+}
+
+func (s *rateLimitTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.sent = 0
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.sent++
+		return s.reply(), nil
+	}
+}
+
+func (s *rateLimitTestSuite) TestRateLimitPenalty() {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	banned := &handlers.APIError{Code: -1003, Message: "Way too many requests; IP(1.2.3.4) banned until 1704164645000. Please use the websocket for live updates to avoid bans."}
+
+	until, ok := rateLimitPenalty(http.StatusTeapot, banned, 0, now)
+	s.True(ok)
+	s.Equal(time.UnixMilli(1704164645000), until)
+
+	// the ban time of the message wins over Retry-After
+	until, ok = rateLimitPenalty(http.StatusTeapot, banned, time.Second, now)
+	s.True(ok)
+	s.Equal(time.UnixMilli(1704164645000), until)
+
+	tooMany := &handlers.APIError{Code: -1003, Message: "Too many requests; current limit of IP(1.2.3.4) is 6000 requests per minute."}
+	until, ok = rateLimitPenalty(http.StatusTooManyRequests, tooMany, 7*time.Second, now)
+	s.True(ok)
+	s.Equal(now.Add(7*time.Second), until)
+
+	until, ok = rateLimitPenalty(http.StatusOK, tooMany, 0, now)
+	s.True(ok, "-1003 is a rate limit violation whatever the status")
+	s.Equal(time.Date(2024, 1, 2, 3, 5, 0, 0, time.UTC), until)
+
+	until, ok = rateLimitPenalty(http.StatusTooManyRequests, nil, 0, now)
+	s.True(ok)
+	s.Equal(time.Date(2024, 1, 2, 3, 5, 0, 0, time.UTC), until)
+
+	_, ok = rateLimitPenalty(http.StatusBadRequest, &handlers.APIError{Code: -1121, Message: "Invalid symbol."}, 0, now)
+	s.False(ok)
+}
+
+func (s *rateLimitTestSuite) TestBannedUntil() {
+	until := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	s.reply = func() *http.Response {
+		return newHTTPResponse([]byte(`{"code":-1003,"msg":"Way too many requests; IP(1.2.3.4) banned until `+strconv.FormatInt(until.UnixMilli(), 10)+`. Please use the websocket for live updates to avoid bans."}`), http.StatusTeapot)
+	}
+	_, err := s.client.NewOrderBookService().Symbol("BTCUSDT").Do(newContext())
+	var apiErr *handlers.APIError
+	s.Require().ErrorAs(err, &apiErr)
+	s.Equal(int64(-1003), apiErr.Code)
+	s.True(until.Equal(s.client.RateLimitedUntil()))
+
+	_, err = s.client.NewGetAccountService().Do(newContext())
+	s.Require().ErrorIs(err, ErrRateLimited)
+	var limited *RateLimitedError
+	s.Require().ErrorAs(err, &limited)
+	s.True(until.Equal(limited.Until))
+	s.Equal(apiErr, limited.LastErr)
+	s.Equal(1, s.sent, "no request is sent during the ban")
+}
+
+func (s *rateLimitTestSuite) TestTooManyRequestsOnSuccessStatus() {
+	for _, body := range []string{
+		`{"code":-1003,"msg":"Too many requests."}`,
+		"{\n  \"msg\": \"Too many requests.\",\n  \"code\": -1003\n}",
+	} {
+		s.SetupTest()
+		s.reply = func() *http.Response {
+			return newHTTPResponse([]byte(body), http.StatusOK)
+		}
+		_, err := s.client.NewOrderBookService().Symbol("BTCUSDT").Do(newContext())
+		var apiErr *handlers.APIError
+		s.Require().ErrorAs(err, &apiErr, body)
+		s.Equal(int64(-1003), apiErr.Code)
+		s.False(s.client.RateLimitedUntil().IsZero())
+		s.ErrorIs(s.client.NewPingService().Do(newContext()), ErrRateLimited)
+	}
+
+	// another code sent with a success status is a response
+	s.SetupTest()
+	s.reply = func() *http.Response {
+		return newHTTPResponse([]byte(`{"code":-1003000,"msg":"ok"}`), http.StatusOK)
+	}
+	s.NoError(s.client.NewPingService().Do(newContext()))
+	s.True(s.client.RateLimitedUntil().IsZero())
+}
+
+func (s *rateLimitTestSuite) TestRetryAfterHeader() {
+	s.reply = func() *http.Response {
+		res := newHTTPResponse([]byte(`{"code":-1003,"msg":"Too many requests."}`), http.StatusTooManyRequests)
+		res.Header = http.Header{"Retry-After": []string{"1"}}
+		return res
+	}
+	s.Error(s.client.NewPingService().Do(newContext()))
+	s.ErrorIs(s.client.NewPingService().Do(newContext()), ErrRateLimited)
+
+	time.Sleep(1100 * time.Millisecond)
+	s.reply = func() *http.Response {
+		return newHTTPResponse([]byte(`{}`), http.StatusOK)
+	}
+	s.NoError(s.client.NewPingService().Do(newContext()))
+	s.True(s.client.RateLimitedUntil().IsZero())
+	s.Equal(2, s.sent)
+}
+
+func (s *rateLimitTestSuite) TestOtherErrorsDoNotPenalize() {
+	s.reply = func() *http.Response {
+		return newHTTPResponse([]byte(`{"code":-1121,"msg":"Invalid symbol."}`), http.StatusBadRequest)
+	}
+	s.Error(s.client.NewPingService().Do(newContext()))
+	s.Error(s.client.NewPingService().Do(newContext()))
+	s.True(s.client.RateLimitedUntil().IsZero())
+	s.Equal(2, s.sent)
+}
+
+func (s *rateLimitTestSuite) TestStructLiteralClient() {
+	s.reply = func() *http.Response {
+		return newHTTPResponse([]byte(`{"code":-1003,"msg":"Too many requests."}`), http.StatusTooManyRequests)
+	}
+	client := &Client{APIKey: "dummyAPIKey", SecretKey: "dummySecretKey", BaseURL: "https://dummyapi.com", do: s.client.do}
+	var apiErr *handlers.APIError
+	s.Require().ErrorAs(client.NewPingService().Do(newContext()), &apiErr, "a client without a penalty box does not panic")
+	s.Equal(int64(-1003), apiErr.Code)
+	s.True(client.RateLimitedUntil().IsZero())
+
+	s.reply = func() *http.Response {
+		return newHTTPResponse([]byte(`{}`), http.StatusOK)
+	}
+	_, err := client.NewGetAccountService().Do(newContext())
+	s.NoError(err)
+	s.Equal(2, s.sent)
+}
@@ -0,0 +1,29 @@
+package binance_connector
+
+import (
+	"context"
+	"net/http"
+)
+
+// RawResponse define the response of Client.Request
+type RawResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Request send a request to an endpoint without a typed service, e.g. to prototype a new service.
+// params are sent in the query string, arrays are given JSON encoded like the services do. The request
+// is signed and carries the API key according to securityType, and goes through the same timestamp,
+// recvWindow, rate limiting and error handling as the services. The response is returned with the
+// APIError of an error status, and is nil when no response was received.
+func (c *Client) Request(ctx context.Context, method, path string, params map[string]interface{}, securityType SecurityType, opts ...RequestOption) (*RawResponse, error) {
+	r := &request{
+		method:   method,
+		endpoint: path,
+		secType:  securityType,
+	}
+	r.setParams(params)
+	_, err := c.callAPI(ctx, r, opts...)
+	return r.response, err
+}
@@ -0,0 +1,88 @@
+package binance_connector
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+type rawRequestTestSuite struct {
+	suite.Suite
+	client *Client
+	sent   *http.Request
+	reply  func() *http.Response
+}
+
+func TestRawRequest(t *testing.T) {
+	suite.Run(t, new(rawRequestTestSuite))
+}
+
+func (s *rawRequestTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.sent = nil
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.sent = req
+		return s.reply(), nil
+	}
+}
+
+func (s *rawRequestTestSuite) TestRawRequestSecurityTypes() {
+	c := s.client
+	s.reply = func() *http.Response {
+		res := newHTTPResponse([]byte(`{"orderId": 1}`), http.StatusOK)
+		res.Header = http.Header{"X-Mbx-Used-Weight-1m": []string{"12"}}
+		return res
+	}
+
+	res, err := c.Request(newContext(), http.MethodGet, "/api/v3/order", map[string]interface{}{
+		"symbol":  "BTCUSDT",
+		"orderId": 1,
+	}, SecurityTypeUserData, WithRecvWindow(5000))
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal("12", res.Header.Get("X-MBX-USED-WEIGHT-1M"))
+	s.Equal(`{"orderId": 1}`, string(res.Body))
+
+	query := s.sent.URL.Query()
+	s.Equal("/api/v3/order", s.sent.URL.Path)
+	s.Equal("BTCUSDT", query.Get("symbol"))
+	s.Equal("1", query.Get("orderId"))
+	s.Equal("5000", query.Get(recvWindowKey))
+	s.NotEmpty(query.Get(timestampKey))
+	s.NotEmpty(query.Get(signatureKey))
+	s.Equal("dummyAPIKey", s.sent.Header.Get("X-MBX-APIKEY"))
+
+	_, err = c.Request(newContext(), http.MethodGet, "/api/v3/historicalTrades", map[string]interface{}{"symbol": "BTCUSDT"}, SecurityTypeMarketData)
+	s.Require().NoError(err)
+	s.Equal("dummyAPIKey", s.sent.Header.Get("X-MBX-APIKEY"))
+	s.False(s.sent.URL.Query().Has(signatureKey))
+
+	_, err = c.Request(newContext(), http.MethodGet, "/api/v3/ticker/tradingDay", nil, SecurityTypeNone)
+	s.Require().NoError(err)
+	s.Empty(s.sent.Header.Get("X-MBX-APIKEY"))
+	s.Empty(s.sent.URL.RawQuery)
+}
+
+func (s *rawRequestTestSuite) TestRawRequestErrors() {
+	c := s.client
+	s.reply = func() *http.Response {
+		return newHTTPResponse([]byte(`{"code": -1121, "msg": "Invalid symbol."}`), http.StatusBadRequest)
+	}
+
+	res, err := c.Request(newContext(), http.MethodGet, "/api/v3/depth", map[string]interface{}{"symbol": "NOPE"}, SecurityTypeNone)
+	var apiErr *handlers.APIError
+	s.Require().ErrorAs(err, &apiErr)
+	s.Equal(int64(-1121), apiErr.Code)
+	s.Require().NotNil(res)
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+	s.Contains(string(res.Body), "Invalid symbol.")
+
+	// requests refused before sending have no response
+	c.penalty.penalize(time.Now().Add(time.Minute), apiErr)
+	res, err = c.Request(newContext(), http.MethodGet, "/api/v3/depth", nil, SecurityTypeNone)
+	s.ErrorIs(err, ErrRateLimited)
+	s.Nil(res)
+}
@@ -0,0 +1,53 @@
+package binance_connector
+
+import (
+	"net/http"
+	"strings"
+)
+
+// redactedValue replaces secrets in the debug logs
+const redactedValue = "<redacted>"
+
+// redactedParams are the parameters whose value never reaches the logs
+var redactedParams = map[string]bool{
+	signatureKey: true,
+	"apiKey":     true,
+	"secretKey":  true,
+}
+
+// redactQuery return query with the value of redactedParams replaced, the order of the parameters is kept
+// so the result still matches the payload that was signed
+func redactQuery(query string) string {
+	pairs := strings.Split(query, "&")
+	for i, pair := range pairs {
+		if key, _, ok := strings.Cut(pair, "="); ok && redactedParams[key] {
+			pairs[i] = key + "=" + redactedValue
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// redactURL return fullURL with its query redacted
+func redactURL(fullURL string) string {
+	base, query, ok := strings.Cut(fullURL, "?")
+	if !ok {
+		return fullURL
+	}
+	return base + "?" + redactQuery(query)
+}
+
+// redactHeader return a copy of header without the API key
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	if header.Get("X-MBX-APIKEY") != "" {
+		header.Set("X-MBX-APIKEY", redactedValue)
+	}
+	return header
+}
+
+// debugSignature log the payload of a signed request as it is signed, before the signature is added
+func (c *Client) debugSignature(r *request, payload string) {
+	if c.DebugSignature {
+		c.Logger.Printf("%ssigned payload: %s %s %s", r.logPrefix(), r.method, r.endpoint, redactQuery(payload))
+	}
+}
@@ -0,0 +1,66 @@
+package binance_connector
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type redactTestSuite struct {
+	suite.Suite
+	client *Client
+	logs   bytes.Buffer
+}
+
+func TestRedact(t *testing.T) {
+	suite.Run(t, new(redactTestSuite))
+}
+
+func (s *redactTestSuite) SetupTest() {
+	s.logs.Reset()
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.client.Logger = log.New(&s.logs, "", 0)
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+}
+
+func (s *redactTestSuite) TestRedactQuery() {
+	s.Equal("symbol=BTCUSDT&timestamp=1&signature=<redacted>", redactQuery("symbol=BTCUSDT&timestamp=1&signature=abcdef"))
+	s.Equal("apiKey=<redacted>&signature", redactQuery("apiKey=key&signature"))
+	s.Equal("", redactQuery(""))
+	s.Equal("https://dummyapi.com/api/v3/order?a=1&signature=<redacted>", redactURL("https://dummyapi.com/api/v3/order?a=1&signature=abc"))
+	s.Equal("https://dummyapi.com/api/v3/ping", redactURL("https://dummyapi.com/api/v3/ping"))
+
+	header := http.Header{}
+	header.Set("X-MBX-APIKEY", "dummyAPIKey")
+	s.Equal(redactedValue, redactHeader(header).Get("X-MBX-APIKEY"))
+	s.Equal("dummyAPIKey", header.Get("X-MBX-APIKEY"))
+}
+
+func (s *redactTestSuite) TestDebugSignature() {
+	c := s.client
+	c.DebugSignature = true
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).NewOrderRespType("ACK").Do(newContext())
+	s.Require().NoError(err)
+	s.Regexp(`^\[[0-9a-f]{16}\] signed payload: POST /api/v3/order newOrderRespType=ACK&quantity=1&side=BUY&symbol=BTCUSDT&timestamp=\d+&type=MARKET\n$`, s.logs.String())
+
+	// public requests are not signed
+	s.logs.Reset()
+	s.Require().NoError(c.NewPingService().Do(newContext()))
+	s.Empty(s.logs.String())
+
+	// debug logs never show the signature nor the API key
+	c.DebugSignature = false
+	c.Debug = true
+	_, err = c.NewGetAccountService().Do(newContext())
+	s.Require().NoError(err)
+	s.NotContains(s.logs.String(), "dummyAPIKey")
+	s.NotContains(s.logs.String(), "dummySecretKey")
+	s.Regexp(`signature=<redacted>`, s.logs.String())
+	s.NotRegexp(`signature=[0-9a-f]{64}`, s.logs.String())
+	s.NotContains(s.logs.String(), "signed payload")
+}
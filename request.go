@@ -1,20 +1,61 @@
 package binance_connector
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
-type secType int
+// SecurityType define the security type Binance gives an endpoint, which decides the credentials its requests carry
+type SecurityType int
 
 const (
-	secTypeNone secType = iota
-	secTypeAPIKey
-	secTypeSigned // if the 'timestamp' parameter is required
+	// SecurityTypeNone endpoints are public
+	SecurityTypeNone SecurityType = iota
+	// SecurityTypeMarketData endpoints require the API key header
+	SecurityTypeMarketData
+	// SecurityTypeUserStream endpoints require the API key header
+	SecurityTypeUserStream
+	// SecurityTypeTrade endpoints require the API key header and a signed timestamp
+	SecurityTypeTrade
+	// SecurityTypeMargin endpoints require the API key header and a signed timestamp
+	SecurityTypeMargin
+	// SecurityTypeUserData endpoints require the API key header and a signed timestamp
+	SecurityTypeUserData
 )
 
+// String return the name Binance documents the security type with
+func (t SecurityType) String() string {
+	switch t {
+	case SecurityTypeNone:
+		return "NONE"
+	case SecurityTypeMarketData:
+		return "MARKET_DATA"
+	case SecurityTypeUserStream:
+		return "USER_STREAM"
+	case SecurityTypeTrade:
+		return "TRADE"
+	case SecurityTypeMargin:
+		return "MARGIN"
+	case SecurityTypeUserData:
+		return "USER_DATA"
+	}
+	return "UNKNOWN"
+}
+
+// RequiresAPIKey report whether requests send the X-MBX-APIKEY header
+func (t SecurityType) RequiresAPIKey() bool {
+	return t != SecurityTypeNone
+}
+
+// Signed report whether requests carry a timestamp and a signature
+func (t SecurityType) Signed() bool {
+	return t == SecurityTypeTrade || t == SecurityTypeMargin || t == SecurityTypeUserData
+}
+
 type params map[string]interface{}
 
 // request define an API request
@@ -24,10 +65,37 @@ type request struct {
 	query      url.Values
 	form       url.Values
 	recvWindow int64
-	secType    secType
+	secType    SecurityType
 	header     http.Header
 	body       io.Reader
 	fullURL    string
+	apiKey     string
+	secretKey  string
+	// service is the name of the service sending the request, e.g. GetAccountService, reported by its errors
+	service string
+	// signer caches the signer of secretKey, for the credentials of WithCredentials and ContextWithCredentials
+	signer *signerCache
+	// requestID identifies the request in the logs and metrics, see ContextWithRequestID
+	requestID string
+	// response is set once a response was received
+	response *RawResponse
+	// attempt is the number of the attempt being sent, 1 for the first one
+	attempt int
+	// idempotent is set on the requests safe to send twice, other than GET
+	idempotent         bool
+	retryOn            RetryPredicate
+	retryNonIdempotent bool
+	// floats holds the float64 parameters of the query, rendered again by the FormatFloat hook of the client
+	floats map[string]float64
+}
+
+// FloatFormatter render the float64 parameter name of a request, e.g. price or quantity, as sent to Binance
+type FloatFormatter func(name string, value float64) string
+
+// DefaultFloatFormatter render value with the fewest decimals representing it exactly and never in scientific
+// notation, e.g. 0.00001 rather than 1e-05
+func DefaultFloatFormatter(name string, value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
 }
 
 // addParam add param with key/value to query string
@@ -35,6 +103,10 @@ func (r *request) addParam(key string, value interface{}) *request {
 	if r.query == nil {
 		r.query = url.Values{}
 	}
+	if f, ok := value.(float64); ok {
+		r.query.Add(key, DefaultFloatFormatter(key, f))
+		return r
+	}
 	r.query.Add(key, fmt.Sprintf("%v", value))
 	return r
 }
@@ -44,6 +116,10 @@ func (r *request) setParam(key string, value interface{}) *request {
 	if r.query == nil {
 		r.query = url.Values{}
 	}
+	if f, ok := value.(float64); ok {
+		return r.setFloat(key, f, DefaultFloatFormatter(key, f))
+	}
+	delete(r.floats, key)
 	r.query.Set(key, fmt.Sprintf("%v", value))
 	return r
 }
@@ -53,12 +129,16 @@ func (r *request) setPriceWithPrecision(key string, precision interface{}, value
 	if r.query == nil {
 		r.query = url.Values{}
 	}
+	delete(r.floats, key)
 	r.query.Set(key, fmt.Sprintf("%.*f", precision, value))
 	return r
 }
 
 // setParam set param with key/value to query string
 func (r *request) setParamFloat(key string, value interface{}) *request {
+	if f, ok := value.(float64); ok {
+		return r.setFloat(key, f, fmt.Sprintf("%.8f", f))
+	}
 	if r.query == nil {
 		r.query = url.Values{}
 	}
@@ -68,6 +148,9 @@ func (r *request) setParamFloat(key string, value interface{}) *request {
 
 // setParam set param with key/value to query string
 func (r *request) setParamHighFloat(key string, value interface{}) *request {
+	if f, ok := value.(float64); ok {
+		return r.setFloat(key, f, fmt.Sprintf("%.4f", f))
+	}
 	if r.query == nil {
 		r.query = url.Values{}
 	}
@@ -75,6 +158,27 @@ func (r *request) setParamHighFloat(key string, value interface{}) *request {
 	return r
 }
 
+// setFloat set the float64 param key to its default rendering formatted, recording value for the FormatFloat
+// hook of the client
+func (r *request) setFloat(key string, value float64, formatted string) *request {
+	if r.query == nil {
+		r.query = url.Values{}
+	}
+	if r.floats == nil {
+		r.floats = map[string]float64{}
+	}
+	r.floats[key] = value
+	r.query.Set(key, formatted)
+	return r
+}
+
+// formatFloats render the float64 params again with format
+func (r *request) formatFloats(format FloatFormatter) {
+	for key, value := range r.floats {
+		r.query.Set(key, format(key, value))
+	}
+}
+
 // setParams set params with key/values to query string
 func (r *request) setParams(m params) *request {
 	for k, v := range m {
@@ -100,5 +204,42 @@ func WithRecvWindow(recvWindow int64) RequestOption {
 	}
 }
 
+// Append `WithCredentials(apiKey, secretKey)` to request to send it with another account's keys than the client's.
+// The API key header and the signature both use the given keys.
+func WithCredentials(apiKey, secretKey string) RequestOption {
+	return credentials{apiKey: apiKey, secretKey: secretKey, signer: &signerCache{}}.option()
+}
+
+type credentialsContextKey struct{}
+
+type credentials struct {
+	apiKey    string
+	secretKey string
+	signer    *signerCache
+}
+
+// option return the RequestOption sending a request with the credentials
+func (c credentials) option() RequestOption {
+	return func(r *request) {
+		r.apiKey = c.apiKey
+		r.secretKey = c.secretKey
+		r.signer = c.signer
+	}
+}
+
+// ContextWithCredentials return a copy of ctx carrying keys used instead of the client's by every request sent with it.
+// A WithCredentials option on the request takes precedence.
+func ContextWithCredentials(ctx context.Context, apiKey, secretKey string) context.Context {
+	return context.WithValue(ctx, credentialsContextKey{}, credentials{apiKey: apiKey, secretKey: secretKey, signer: &signerCache{}})
+}
+
+// logPrefix return the prefix of the log lines about the request, empty when it has no request ID
+func (r *request) logPrefix() string {
+	if r.requestID == "" {
+		return ""
+	}
+	return "[" + r.requestID + "] "
+}
+
 // RequestOption define option type for request
 type RequestOption func(*request)
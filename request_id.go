@@ -0,0 +1,30 @@
+package binance_connector
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+)
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID return a copy of ctx carrying id, the request ID of every request sent with it instead of
+// a generated one. Sending the requests of one logical operation, retries included, with the same ID correlates
+// them in the debug logs and the OnRequest metrics.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext return the request ID set on ctx by ContextWithRequestID
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContextKey{}).(string)
+	return id, ok && id != ""
+}
+
+// requestID return the request ID of ctx, a random one when it has none
+func requestID(ctx context.Context) string {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		return id
+	}
+	return fmt.Sprintf("%016x", rand.Uint64())
+}
@@ -0,0 +1,60 @@
+package binance_connector
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type requestIDTestSuite struct {
+	suite.Suite
+	client *Client
+	logs   bytes.Buffer
+}
+
+func TestRequestID(t *testing.T) {
+	suite.Run(t, new(requestIDTestSuite))
+}
+
+func (s *requestIDTestSuite) SetupTest() {
+	s.logs.Reset()
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.client.Logger = log.New(&s.logs, "", 0)
+	s.client.Debug = true
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+}
+
+func (s *requestIDTestSuite) TestRequestIDFromContext() {
+	c := s.client
+	var ids []string
+	c.OnRequest = func(metrics *RequestMetrics) {
+		ids = append(ids, metrics.RequestID)
+	}
+
+	ctx := ContextWithRequestID(newContext(), "rebalance-42")
+	id, ok := RequestIDFromContext(ctx)
+	s.True(ok)
+	s.Equal("rebalance-42", id)
+	s.Require().NoError(c.NewPingService().Do(ctx))
+	s.Require().NoError(c.NewPingService().Do(ctx))
+	for _, line := range strings.Split(strings.TrimSpace(s.logs.String()), "\n") {
+		s.True(strings.HasPrefix(line, "[rebalance-42] "), line)
+	}
+
+	// requests sent without an ID get their own
+	s.Require().NoError(c.NewPingService().Do(newContext()))
+	s.Require().NoError(c.NewPingService().Do(newContext()))
+	s.Require().Len(ids, 4)
+	s.Equal([]string{"rebalance-42", "rebalance-42"}, ids[:2])
+	s.Regexp(`^[0-9a-f]{16}$`, ids[2])
+	s.NotEqual(ids[2], ids[3])
+
+	_, ok = RequestIDFromContext(newContext())
+	s.False(ok)
+}
@@ -0,0 +1,135 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/luciano-personal-org/binance-connector/handlers"
+)
+
+// DefaultRetryPolicy is used by the requests sent WithRetryOn by a client without a Retry policy
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     Backoff{Base: 200 * time.Millisecond, Max: 2 * time.Second, Jitter: JitterEqual},
+}
+
+// RetryAttempt describe a failed attempt of a request, for a RetryPredicate
+type RetryAttempt struct {
+	// Attempt is the number of the attempt that failed, 1 for the first one
+	Attempt  int
+	Method   string
+	Endpoint string
+	// Response is the response received, nil when the request failed before, e.g. on a network error
+	Response *RawResponse
+	// APIError is the error answered by Binance, nil when the response was not a Binance error
+	APIError *handlers.APIError
+	Err      error
+}
+
+// StatusCode return the HTTP status of the response, zero when none was received
+func (a *RetryAttempt) StatusCode() int {
+	if a.Response == nil {
+		return 0
+	}
+	return a.Response.StatusCode
+}
+
+// RetryPredicate report whether a failed attempt is sent again
+type RetryPredicate func(attempt *RetryAttempt) bool
+
+// RetryPolicy define how the failed requests of a client are retried
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of a request, the first included
+	MaxAttempts int
+	// Backoff is the delay before each retry
+	Backoff Backoff
+	// RetryOn decides whether an attempt is retried, DefaultRetryable when nil. WithRetryOn overrides it per request.
+	RetryOn RetryPredicate
+}
+
+// retryableErrorCodes are the Binance errors of a transient backend failure
+var retryableErrorCodes = map[int64]bool{
+	-1001: true, // DISCONNECTED, internal error
+	-1007: true, // TIMEOUT waiting for the backend
+	-1008: true, // server busy
+}
+
+// DefaultRetryable retry the network errors, the 5xx responses and the Binance errors of a transient backend
+// failure (-1001, -1007, -1008). A system maintenance is not retried, its requests fail until it ends.
+func DefaultRetryable(attempt *RetryAttempt) bool {
+	if errors.Is(attempt.Err, ErrMaintenance) {
+		return false
+	}
+	if attempt.APIError != nil && retryableErrorCodes[attempt.APIError.Code] {
+		return true
+	}
+	if attempt.Response != nil {
+		return attempt.Response.StatusCode >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	return errors.As(attempt.Err, &urlErr)
+}
+
+// WithRetryOn retry the request while predicate returns true, up to the attempts of the client Retry policy,
+// or of DefaultRetryPolicy when the client has none. It enables retries for this request only.
+func WithRetryOn(predicate RetryPredicate) RequestOption {
+	return func(r *request) {
+		r.retryOn = predicate
+	}
+}
+
+// WithRetryNonIdempotent allow the retry of a request that is not idempotent, e.g. an order sent without
+// IdempotencyKey: a retried attempt that reached Binance before failing executes the request twice.
+func WithRetryNonIdempotent() RequestOption {
+	return func(r *request) {
+		r.retryNonIdempotent = true
+	}
+}
+
+// idempotentRequest report whether sending r twice has the effect of sending it once
+func (r *request) idempotentRequest() bool {
+	return r.method == http.MethodGet || r.idempotent || r.retryNonIdempotent
+}
+
+// retryDelay return the delay before retrying r after it failed with err, false when it is not retried.
+// The rate limit errors are never retried: the penalty box would refuse the attempts until the end of the ban.
+func (c *Client) retryDelay(r *request, err error) (time.Duration, bool) {
+	if err == nil || errors.Is(err, ErrRateLimited) || errors.Is(err, ErrDryRun) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return 0, false
+	}
+	if (r.response != nil && rateLimitStatus(r.response.StatusCode)) || c.penalty.check() != nil {
+		return 0, false
+	}
+	policy := c.Retry
+	if policy == nil {
+		if r.retryOn == nil {
+			return 0, false
+		}
+		policy = &DefaultRetryPolicy
+	}
+	if r.attempt >= policy.MaxAttempts || !r.idempotentRequest() {
+		return 0, false
+	}
+	predicate := r.retryOn
+	if predicate == nil {
+		predicate = policy.RetryOn
+	}
+	if predicate == nil {
+		predicate = DefaultRetryable
+	}
+	attempt := &RetryAttempt{Attempt: r.attempt, Method: r.method, Endpoint: r.endpoint, Response: r.response, Err: err}
+	errors.As(err, &attempt.APIError)
+	if !predicate(attempt) {
+		return 0, false
+	}
+	return policy.Backoff.Delay(r.attempt - 1), true
+}
+
+// rateLimitStatus report whether status is a rate limit violation or an IP ban
+func rateLimitStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusTeapot
+}
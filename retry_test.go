@@ -0,0 +1,129 @@
+package binance_connector
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type retryTestSuite struct {
+	suite.Suite
+	client    *Client
+	responses []*http.Response
+	sent      int
+}
+
+func TestRetry(t *testing.T) {
+	suite.Run(t, new(retryTestSuite))
+}
+
+func (s *retryTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.client.Retry = &RetryPolicy{MaxAttempts: 3}
+	s.responses = nil
+	s.sent = 0
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.sent++
+		if len(s.responses) == 0 {
+			return nil, &url.Error{Op: "Get", URL: req.URL.String(), Err: errors.New("connection reset by peer")}
+		}
+		res := s.responses[0]
+		s.responses = s.responses[1:]
+		return res, nil
+	}
+}
+
+func (s *retryTestSuite) respond(responses ...*http.Response) {
+	s.responses = responses
+}
+
+func (s *retryTestSuite) TestRetryIdempotent() {
+	var attempts []int
+	s.client.OnRequest = func(metrics *RequestMetrics) { attempts = append(attempts, metrics.Attempt) }
+	s.respond(newHTTPResponse([]byte(`{"code":-1001,"msg":"Internal error; unable to process your request. Please try again."}`), http.StatusInternalServerError),
+		newHTTPResponse([]byte(`{"serverTime":1499827319559}`), http.StatusOK))
+
+	res, err := s.client.NewServerTimeService().Do(newContext())
+	s.Require().NoError(err)
+	s.EqualValues(1499827319559, res.ServerTime)
+	s.Equal(2, s.sent)
+	s.Equal([]int{1, 2}, attempts)
+}
+
+func (s *retryTestSuite) TestMaxAttempts() {
+	_, err := s.client.NewServerTimeService().Do(newContext())
+	var urlErr *url.Error
+	s.ErrorAs(err, &urlErr)
+	s.Equal(3, s.sent)
+
+	s.sent = 0
+	s.client.Retry = nil
+	_, err = s.client.NewServerTimeService().Do(newContext())
+	s.Error(err)
+	s.Equal(1, s.sent, "retries are off by default")
+}
+
+func (s *retryTestSuite) TestNonIdempotent() {
+	order := func(opts ...RequestOption) error {
+		_, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).Do(newContext(), opts...)
+		return err
+	}
+	s.Error(order())
+	s.Equal(1, s.sent, "an order is not retried")
+
+	s.sent = 0
+	s.Error(order(WithRetryNonIdempotent()))
+	s.Equal(3, s.sent)
+
+	// a retried order sent with an idempotency key returns the order of the first attempt
+	s.sent = 0
+	s.respond(newHTTPResponse([]byte(`{}`), http.StatusBadGateway),
+		newHTTPResponse([]byte(`{"code":-2010,"msg":"Duplicate order sent."}`), http.StatusBadRequest),
+		newHTTPResponse([]byte(`{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"key-1","status":"FILLED"}`), http.StatusOK))
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).
+		IdempotencyKey("key-1").Do(newContext())
+	s.Require().NoError(err)
+	s.Equal(int64(28), res.(*CreateOrderResponseFULL).OrderId)
+	s.Equal(3, s.sent)
+}
+
+func (s *retryTestSuite) TestRetryOn() {
+	s.client.Retry = nil
+	var received []*RetryAttempt
+	retryOn := WithRetryOn(func(attempt *RetryAttempt) bool {
+		received = append(received, attempt)
+		return attempt.APIError != nil && attempt.APIError.Code == -2013
+	})
+	s.respond(newHTTPResponse([]byte(`{"code":-2013,"msg":"Order does not exist."}`), http.StatusBadRequest),
+		newHTTPResponse([]byte(`{"symbol":"BTCUSDT","orderId":1}`), http.StatusOK))
+
+	order, err := s.client.NewGetOrderService().Symbol("BTCUSDT").OrderId(1).Do(newContext(), retryOn)
+	s.Require().NoError(err)
+	s.Equal(int64(1), order.OrderId)
+	s.Require().Len(received, 1)
+	s.Equal(1, received[0].Attempt)
+	s.Equal(http.StatusBadRequest, received[0].StatusCode())
+	s.Equal("/api/v3/order", received[0].Endpoint)
+
+	// the predicate replaces the default one, a network error is not retried anymore
+	s.sent = 0
+	_, err = s.client.NewGetOrderService().Symbol("BTCUSDT").OrderId(1).Do(newContext(), retryOn)
+	s.Error(err)
+	s.Equal(1, s.sent)
+}
+
+func (s *retryTestSuite) TestRateLimitNotRetried() {
+	called := false
+	s.respond(newHTTPResponse([]byte(`{"code":-1003,"msg":"Too many requests."}`), http.StatusTooManyRequests))
+
+	_, err := s.client.NewServerTimeService().Do(newContext(), WithRetryOn(func(attempt *RetryAttempt) bool {
+		called = true
+		return true
+	}))
+	s.Error(err)
+	s.False(called)
+	s.Equal(1, s.sent)
+}
@@ -0,0 +1,232 @@
+package binance_connector
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/luciano-personal-org/binance-connector/handlers"
+)
+
+// SBE (Simple Binary Encoding) responses are little-endian binary messages: a message header, the fixed
+// block of the message, then its repeating groups and variable length fields. The layouts below are the
+// ones of the spot schema, see https://github.com/binance/binance-spot-api-docs/tree/master/sbe/schemas.
+const (
+	sbeContentType = "application/sbe"
+	// sbeMessageHeaderSize is the size of blockLength, templateId, schemaId and version, all uint16
+	sbeMessageHeaderSize = 8
+	// sbeGroupHeaderSize is the size of the group blockLength uint16 and numInGroup uint32
+	sbeGroupHeaderSize = 6
+
+	sbeErrorTemplateID = 100
+	sbeDepthTemplateID = 200
+)
+
+// DefaultSBESchema is the spot SBE schema this version of the library decodes
+var DefaultSBESchema = SBESchema{ID: 3, Version: 1}
+
+// SBESchema identify the SBE schema requested with the X-MBX-SBE header. Binance answers with the
+// version requested as long as it is supported; a version it no longer supports is refused with an error.
+type SBESchema struct {
+	ID      uint16
+	Version uint16
+}
+
+func (s SBESchema) String() string {
+	return fmt.Sprintf("%d:%d", s.ID, s.Version)
+}
+
+// ErrSBEDecode is matched by errors.Is on the errors decoding an SBE response
+var ErrSBEDecode = errors.New("sbe decode error")
+
+// SBESchemaError is returned when an SBE response is encoded with another schema than the one requested
+type SBESchemaError struct {
+	Requested SBESchema
+	Received  SBESchema
+}
+
+func (e *SBESchemaError) Error() string {
+	return fmt.Sprintf("sbe response encoded with schema %s, requested %s", e.Received, e.Requested)
+}
+
+// Is return true for ErrSBEDecode
+func (e *SBESchemaError) Is(target error) bool {
+	return target == ErrSBEDecode
+}
+
+// setSBEHeaders request an SBE response with the schema of the client. The endpoints supporting SBE call it
+// and decode the response with isSBEResponse and the decoder of their message, the others stay in JSON.
+func (c *Client) setSBEHeaders(r *request) {
+	if c.SBESchema == nil {
+		return
+	}
+	if r.header == nil {
+		r.header = http.Header{}
+	}
+	r.header.Set("Accept", sbeContentType)
+	r.header.Set("X-MBX-SBE", c.SBESchema.String())
+}
+
+// isSBEResponse return true when the response of r is SBE encoded, Binance answers in JSON when it does not
+// support SBE for the endpoint
+func isSBEResponse(r *request) bool {
+	if r.response == nil {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.response.Header.Get("Content-Type"))
+	return mediaType == sbeContentType
+}
+
+// sbeDecoder read an SBE message, the first read past the end fails every next read
+type sbeDecoder struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (d *sbeDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || len(d.data)-d.pos < n {
+		d.err = fmt.Errorf("%w: message truncated at offset %d", ErrSBEDecode, d.pos)
+		return nil
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+func (d *sbeDecoder) uint16() uint16 {
+	if b := d.next(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (d *sbeDecoder) uint32() uint32 {
+	if b := d.next(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *sbeDecoder) int64() int64 {
+	if b := d.next(8); b != nil {
+		return int64(binary.LittleEndian.Uint64(b))
+	}
+	return 0
+}
+
+func (d *sbeDecoder) int8() int8 {
+	if b := d.next(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+// header read the message header and check the schema and template of the message. It returns the
+// decoder of the fixed block, the rest of the message is read from d once the block is read.
+func (d *sbeDecoder) header(schema SBESchema, templateID uint16) (block *sbeDecoder, err error) {
+	blockLength := d.uint16()
+	template := d.uint16()
+	received := SBESchema{ID: d.uint16(), Version: d.uint16()}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if received.ID != schema.ID {
+		return nil, &SBESchemaError{Requested: schema, Received: received}
+	}
+	if template != templateID {
+		return nil, fmt.Errorf("%w: template %d, expected %d", ErrSBEDecode, template, templateID)
+	}
+	// the fixed block of a newer version can be longer, its unknown fields are skipped
+	b := d.next(int(blockLength))
+	if d.err != nil {
+		return nil, d.err
+	}
+	return &sbeDecoder{data: b}, nil
+}
+
+// group read the header of a repeating group, each entry is read from the returned decoders
+func (d *sbeDecoder) group() []*sbeDecoder {
+	blockLength := int(d.uint16())
+	numInGroup := int(d.uint32())
+	if d.err != nil {
+		return nil
+	}
+	if numInGroup > (len(d.data)-d.pos)/max(blockLength, 1) {
+		d.err = fmt.Errorf("%w: group of %d entries truncated at offset %d", ErrSBEDecode, numInGroup, d.pos)
+		return nil
+	}
+	entries := make([]*sbeDecoder, numInGroup)
+	for i := range entries {
+		entries[i] = &sbeDecoder{data: d.next(blockLength)}
+	}
+	return entries
+}
+
+// varString read a variable length string prefixed by its uint16 length
+func (d *sbeDecoder) varString() string {
+	n := d.uint16()
+	return string(d.next(int(n)))
+}
+
+// sbeDecimal return the decimal mantissa * 10^exponent, the value of the same string in a JSON response
+func sbeDecimal(mantissa int64, exponent int8) *big.Float {
+	f, _ := new(big.Float).SetString(strconv.FormatInt(mantissa, 10) + "e" + strconv.Itoa(int(exponent)))
+	return f
+}
+
+// decodeSBEDepth decode a DepthResponse message into an OrderBookResponse
+func decodeSBEDepth(data []byte, schema SBESchema) (*OrderBookResponse, error) {
+	d := &sbeDecoder{data: data}
+	block, err := d.header(schema, sbeDepthTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	res := &OrderBookResponse{LastUpdateId: uint64(block.int64())}
+	priceExponent := block.int8()
+	qtyExponent := block.int8()
+	if block.err != nil {
+		return nil, block.err
+	}
+	levels := func() [][]*big.Float {
+		entries := d.group()
+		out := make([][]*big.Float, 0, len(entries))
+		for _, e := range entries {
+			price, qty := e.int64(), e.int64()
+			if e.err != nil {
+				d.err = e.err
+				return nil
+			}
+			out = append(out, []*big.Float{sbeDecimal(price, priceExponent), sbeDecimal(qty, qtyExponent)})
+		}
+		return out
+	}
+	res.Bids = levels()
+	res.Asks = levels()
+	if d.err != nil {
+		return nil, d.err
+	}
+	return res, nil
+}
+
+// decodeSBEError decode an ErrorResponse message into apiErr
+func decodeSBEError(data []byte, schema SBESchema, apiErr *handlers.APIError) error {
+	d := &sbeDecoder{data: data}
+	block, err := d.header(schema, sbeErrorTemplateID)
+	if err != nil {
+		return err
+	}
+	apiErr.Code = int64(int16(block.uint16()))
+	apiErr.Message = d.varString()
+	if block.err != nil {
+		return block.err
+	}
+	return d.err
+}
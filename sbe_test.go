@@ -0,0 +1,150 @@
+package binance_connector
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+// sbeMessage encode a message of the spot schema with templateID and block, followed by rest
+func sbeMessage(schema SBESchema, templateID uint16, block []byte, rest ...[]byte) []byte {
+	msg := binary.LittleEndian.AppendUint16(nil, uint16(len(block)))
+	msg = binary.LittleEndian.AppendUint16(msg, templateID)
+	msg = binary.LittleEndian.AppendUint16(msg, schema.ID)
+	msg = binary.LittleEndian.AppendUint16(msg, schema.Version)
+	msg = append(msg, block...)
+	for _, r := range rest {
+		msg = append(msg, r...)
+	}
+	return msg
+}
+
+// sbeLevels encode a bids or asks group of price and quantity mantissas
+func sbeLevels(levels ...[2]int64) []byte {
+	group := binary.LittleEndian.AppendUint16(nil, 16)
+	group = binary.LittleEndian.AppendUint32(group, uint32(len(levels)))
+	for _, l := range levels {
+		group = binary.LittleEndian.AppendUint64(group, uint64(l[0]))
+		group = binary.LittleEndian.AppendUint64(group, uint64(l[1]))
+	}
+	return group
+}
+
+func sbeDepthMessage(schema SBESchema) []byte {
+	block := binary.LittleEndian.AppendUint64(nil, 1027024)
+	block = append(block, byte(0xfe), byte(0xf8)) // price exponent -2, quantity exponent -8
+	return sbeMessage(schema, sbeDepthTemplateID, block,
+		sbeLevels([2]int64{400000, 43100000000}),
+		sbeLevels([2]int64{400002, 1200000000}, [2]int64{400010, 5}))
+}
+
+type sbeTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+	header http.Header
+}
+
+func TestSBE(t *testing.T) {
+	suite.Run(t, new(sbeTestSuite))
+}
+
+func (s *sbeTestSuite) TearDownTest() {
+	if s.server != nil {
+		s.server.Close()
+		s.server = nil
+	}
+	s.header = nil
+}
+
+// newClient returns a client requesting SBE from a server answering body
+func (s *sbeTestSuite) newClient(status int, contentType string, body []byte) *Client {
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.header = r.Header.Clone()
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		w.Write(body)
+	}))
+	client := NewPublicClient(s.server.URL)
+	client.SBESchema = &DefaultSBESchema
+	return client
+}
+
+func (s *sbeTestSuite) TestOrderBookSBE() {
+	client := s.newClient(http.StatusOK, "application/sbe", sbeDepthMessage(DefaultSBESchema))
+
+	res, err := client.NewOrderBookService().Symbol("BTCUSDT").Do(context.Background())
+	s.Require().NoError(err)
+	s.Require().Equal("application/sbe", s.header.Get("Accept"))
+	s.Require().Equal("3:1", s.header.Get("X-MBX-SBE"))
+
+	// the same values as the JSON response
+	var expected OrderBookResponse
+	s.Require().NoError(client.unmarshal([]byte(`{"lastUpdateId":1027024,"bids":[["4000.00","431.00000000"]],`+
+		`"asks":[["4000.02","12.00000000"],["4000.10","0.00000005"]]}`), &expected))
+	s.Require().Equal(expected.LastUpdateId, res.LastUpdateId)
+	equalLevels := func(expected, actual [][]*big.Float) {
+		s.Require().Len(actual, len(expected))
+		for i := range expected {
+			for j := range expected[i] {
+				s.Require().Zero(expected[i][j].Cmp(actual[i][j]), "level %d: %s != %s", i, expected[i][j], actual[i][j])
+			}
+		}
+	}
+	equalLevels(expected.Bids, res.Bids)
+	equalLevels(expected.Asks, res.Asks)
+}
+
+func (s *sbeTestSuite) TestOrderBookSBEFallbackToJSON() {
+	client := s.newClient(http.StatusOK, "application/json", []byte(`{"lastUpdateId":1,"bids":[],"asks":[]}`))
+	client.SBESchema = nil
+
+	res, err := client.NewOrderBookService().Symbol("BTCUSDT").Do(context.Background())
+	s.Require().NoError(err)
+	s.Require().Empty(s.header.Get("X-MBX-SBE"))
+	s.Require().Equal(uint64(1), res.LastUpdateId)
+
+	client.SBESchema = &DefaultSBESchema
+	res, err = client.NewOrderBookService().Symbol("BTCUSDT").Do(context.Background())
+	s.Require().NoError(err)
+	s.Require().Equal("3:1", s.header.Get("X-MBX-SBE"))
+	s.Require().Equal(uint64(1), res.LastUpdateId)
+}
+
+func (s *sbeTestSuite) TestOrderBookSBESchemaMismatch() {
+	client := s.newClient(http.StatusOK, "application/sbe", sbeDepthMessage(SBESchema{ID: 2, Version: 0}))
+
+	_, err := client.NewOrderBookService().Symbol("BTCUSDT").Do(context.Background())
+	var schemaErr *SBESchemaError
+	s.Require().ErrorAs(err, &schemaErr)
+	s.Require().Equal(SBESchema{ID: 2, Version: 0}, schemaErr.Received)
+	s.Require().ErrorIs(err, ErrSBEDecode)
+}
+
+func (s *sbeTestSuite) TestOrderBookSBETruncated() {
+	message := sbeDepthMessage(DefaultSBESchema)
+	client := s.newClient(http.StatusOK, "application/sbe", message[:len(message)-4])
+
+	_, err := client.NewOrderBookService().Symbol("BTCUSDT").Do(context.Background())
+	s.Require().ErrorIs(err, ErrSBEDecode)
+}
+
+func (s *sbeTestSuite) TestSBEError() {
+	code := int16(-1121)
+	block := binary.LittleEndian.AppendUint16(nil, uint16(code))
+	msg := "Invalid symbol."
+	text := append(binary.LittleEndian.AppendUint16(nil, uint16(len(msg))), msg...)
+	client := s.newClient(http.StatusBadRequest, "application/sbe", sbeMessage(DefaultSBESchema, sbeErrorTemplateID, block, text))
+
+	_, err := client.NewOrderBookService().Symbol("BTCUSDT").Do(context.Background())
+	var apiErr *handlers.APIError
+	s.Require().ErrorAs(err, &apiErr)
+	s.Require().Equal(int64(-1121), apiErr.Code)
+	s.Require().Equal(msg, apiErr.Message)
+	s.Require().Equal(http.StatusBadRequest, apiErr.Status)
+}
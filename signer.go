@@ -0,0 +1,199 @@
+package binance_connector
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidSecretKey is returned for a secret key that cannot be parsed as its KeyType
+var ErrInvalidSecretKey = errors.New("invalid secret key")
+
+// KeyType define the kind of secret key, which decides the algorithm signing the requests
+type KeyType int
+
+const (
+	// KeyTypeAuto detects the key type: a PEM private key is an RSA or Ed25519 key after its content, any other
+	// secret key is an HMAC secret
+	KeyTypeAuto KeyType = iota
+	// KeyTypeHMAC signs with HMAC-SHA256, the signature is hex encoded
+	KeyTypeHMAC
+	// KeyTypeRSA signs with RSASSA-PKCS1-v1_5 and SHA-256 from a PKCS#1 or PKCS#8 PEM private key, the signature
+	// is base64 encoded
+	KeyTypeRSA
+	// KeyTypeEd25519 signs with Ed25519 from a PKCS#8 PEM private key, or a raw 32 bytes seed in hex or base64,
+	// the signature is base64 encoded
+	KeyTypeEd25519
+)
+
+var keyTypeNames = map[KeyType]string{KeyTypeAuto: "AUTO", KeyTypeHMAC: "HMAC", KeyTypeRSA: "RSA", KeyTypeEd25519: "ED25519"}
+
+func (t KeyType) String() string {
+	if name, ok := keyTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("KeyType(%d)", int(t))
+}
+
+// DetectKeyType return the type of secretKey as detected by KeyTypeAuto, or an ErrInvalidSecretKey when it is a
+// PEM block that is not a supported private key
+func DetectKeyType(secretKey string) (KeyType, error) {
+	s, err := newSigner(secretKey, KeyTypeAuto)
+	if err != nil {
+		return KeyTypeAuto, err
+	}
+	return s.keyType, nil
+}
+
+// signer sign the payload of the requests with a parsed secret key
+type signer struct {
+	keyType    KeyType
+	hmacKey    []byte
+	rsaKey     *rsa.PrivateKey
+	ed25519Key ed25519.PrivateKey
+}
+
+// signerCache keeps the signer of the last secret key parsed, an RSA key is parsed once instead of on every request.
+// A client and each credentials of WithCredentials or ContextWithCredentials have their own, so a secret is only
+// kept as long as what holds it. A nil signerCache parses the key on every call.
+type signerCache struct {
+	mu        sync.Mutex
+	secretKey string
+	keyType   KeyType
+	signer    *signer
+}
+
+// get return the signer of secretKey parsed as keyType
+func (c *signerCache) get(secretKey string, keyType KeyType) (*signer, error) {
+	if c == nil {
+		return newSigner(secretKey, keyType)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.signer != nil && c.secretKey == secretKey && c.keyType == keyType {
+		return c.signer, nil
+	}
+	s, err := newSigner(secretKey, keyType)
+	if err != nil {
+		return nil, err
+	}
+	c.secretKey, c.keyType, c.signer = secretKey, keyType, s
+	return s, nil
+}
+
+// newSigner parse secretKey as keyType
+func newSigner(secretKey string, keyType KeyType) (*signer, error) {
+	if secretKey == "" {
+		return nil, fmt.Errorf("%w: the secret key is empty", ErrInvalidSecretKey)
+	}
+	trimmed := strings.TrimSpace(secretKey)
+	isPEM := strings.HasPrefix(trimmed, "-----BEGIN")
+	switch keyType {
+	case KeyTypeAuto:
+		if isPEM {
+			return parsePEMKey(trimmed)
+		}
+		return &signer{keyType: KeyTypeHMAC, hmacKey: []byte(secretKey)}, nil
+	case KeyTypeHMAC:
+		return &signer{keyType: KeyTypeHMAC, hmacKey: []byte(secretKey)}, nil
+	case KeyTypeRSA, KeyTypeEd25519:
+		var s *signer
+		var err error
+		switch {
+		case isPEM:
+			s, err = parsePEMKey(trimmed)
+		case keyType == KeyTypeEd25519:
+			s, err = parseEd25519Seed(trimmed)
+		default:
+			err = fmt.Errorf("%w: an RSA key must be a PEM private key", ErrInvalidSecretKey)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if s.keyType != keyType {
+			return nil, fmt.Errorf("%w: the key is an %s key, not an %s key", ErrInvalidSecretKey, s.keyType, keyType)
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("%w: unknown key type %s", ErrInvalidSecretKey, keyType)
+}
+
+// parsePEMKey parse an RSA or Ed25519 private key in PEM
+func parsePEMKey(data string) (*signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("%w: malformed PEM block", ErrInvalidSecretKey)
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" || strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+		return nil, fmt.Errorf("%w: encrypted PEM keys are not supported, decrypt the key first", ErrInvalidSecretKey)
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSecretKey, err)
+		}
+		return &signer{keyType: KeyTypeRSA, rsaKey: key}, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSecretKey, err)
+		}
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			return &signer{keyType: KeyTypeRSA, rsaKey: key}, nil
+		case ed25519.PrivateKey:
+			return &signer{keyType: KeyTypeEd25519, ed25519Key: key}, nil
+		}
+		return nil, fmt.Errorf("%w: unsupported %T private key, use an HMAC, RSA or Ed25519 key", ErrInvalidSecretKey, key)
+	case "PUBLIC KEY", "RSA PUBLIC KEY":
+		return nil, fmt.Errorf("%w: the PEM block is a public key, the secret key is the private key", ErrInvalidSecretKey)
+	}
+	return nil, fmt.Errorf("%w: unsupported PEM block %s", ErrInvalidSecretKey, block.Type)
+}
+
+// parseEd25519Seed parse a raw Ed25519 seed, or a full private key, in hex or base64
+func parseEd25519Seed(data string) (*signer, error) {
+	key, err := hex.DecodeString(data)
+	if err != nil {
+		if key, err = base64.StdEncoding.DecodeString(data); err != nil {
+			return nil, fmt.Errorf("%w: an Ed25519 key must be a PEM private key or a seed in hex or base64", ErrInvalidSecretKey)
+		}
+	}
+	switch len(key) {
+	case ed25519.SeedSize:
+		return &signer{keyType: KeyTypeEd25519, ed25519Key: ed25519.NewKeyFromSeed(key)}, nil
+	case ed25519.PrivateKeySize:
+		return &signer{keyType: KeyTypeEd25519, ed25519Key: ed25519.PrivateKey(key)}, nil
+	}
+	return nil, fmt.Errorf("%w: an Ed25519 seed is %d bytes, got %d", ErrInvalidSecretKey, ed25519.SeedSize, len(key))
+}
+
+// sign return the signature of payload as sent in the signature parameter
+func (s *signer) sign(payload string) (string, error) {
+	switch s.keyType {
+	case KeyTypeRSA:
+		hashed := sha256.Sum256([]byte(payload))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, s.rsaKey, crypto.SHA256, hashed[:])
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(signature), nil
+	case KeyTypeEd25519:
+		return base64.StdEncoding.EncodeToString(ed25519.Sign(s.ed25519Key, []byte(payload))), nil
+	}
+	mac := hmac.New(sha256.New, s.hmacKey)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,57 @@
+package binance_connector
+
+import "math"
+
+// DaysPerYear is the number of days used to annualize daily rates, as Binance Earn does
+const DaysPerYear = 365
+
+// AnnualizedRate define a rate expressed both as a simple annual rate (APR) and compounded (APY)
+type AnnualizedRate struct {
+	APR float64
+	APY float64
+}
+
+// AnnualizedRateFromDaily annualize a daily rate, e.g. 0.0001 for 0.01% a day.
+// The APY assumes the daily reward is compounded, as for Simple Earn flexible products.
+func AnnualizedRateFromDaily(daily float64) AnnualizedRate {
+	return AnnualizedRate{
+		APR: daily * DaysPerYear,
+		APY: math.Pow(1+daily, DaysPerYear) - 1,
+	}
+}
+
+// AnnualizedRateFromAPR build the rate of an APR compounded periodsPerYear times a year.
+// Binance Earn APIs return APRs (e.g. annualPercentageRate, latestAnnualPercentageRate).
+func AnnualizedRateFromAPR(apr float64, periodsPerYear int) AnnualizedRate {
+	return AnnualizedRate{
+		APR: apr,
+		APY: APRToAPY(apr, periodsPerYear),
+	}
+}
+
+// AnnualizedRateFromAPY build the rate of an APY compounded periodsPerYear times a year
+func AnnualizedRateFromAPY(apy float64, periodsPerYear int) AnnualizedRate {
+	return AnnualizedRate{
+		APR: APYToAPR(apy, periodsPerYear),
+		APY: apy,
+	}
+}
+
+// APRToAPY convert an APR to the APY obtained when compounding periodsPerYear times a year.
+// periodsPerYear below 1 means no compounding and the APR is returned.
+func APRToAPY(apr float64, periodsPerYear int) float64 {
+	if periodsPerYear < 1 {
+		return apr
+	}
+	n := float64(periodsPerYear)
+	return math.Pow(1+apr/n, n) - 1
+}
+
+// APYToAPR convert an APY back to the APR compounded periodsPerYear times a year
+func APYToAPR(apy float64, periodsPerYear int) float64 {
+	if periodsPerYear < 1 {
+		return apy
+	}
+	n := float64(periodsPerYear)
+	return n * (math.Pow(1+apy, 1/n) - 1)
+}
@@ -0,0 +1,39 @@
+package binance_connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type simpleEarnTestSuite struct {
+	suite.Suite
+}
+
+func TestSimpleEarn(t *testing.T) {
+	suite.Run(t, new(simpleEarnTestSuite))
+}
+
+func (s *simpleEarnTestSuite) TestAnnualizedRateFromDaily() {
+	rate := AnnualizedRateFromDaily(0.0001)
+	s.InDelta(0.0365, rate.APR, 1e-12)
+	s.InDelta(0.0371724113, rate.APY, 1e-9)
+}
+
+func (s *simpleEarnTestSuite) TestAPRToAPY() {
+	// 12% compounded monthly
+	s.InDelta(0.1268250301, APRToAPY(0.12, 12), 1e-9)
+	// 5% compounded daily
+	s.InDelta(0.0512674965, APRToAPY(0.05, DaysPerYear), 1e-9)
+	s.Equal(0.05, APRToAPY(0.05, 0))
+}
+
+func (s *simpleEarnTestSuite) TestAPYToAPR() {
+	s.InDelta(0.0487934252, APYToAPR(0.05, DaysPerYear), 1e-9)
+	s.InDelta(0.12, APYToAPR(APRToAPY(0.12, 12), 12), 1e-12)
+
+	rate := AnnualizedRateFromAPY(0.05, DaysPerYear)
+	s.Equal(0.05, rate.APY)
+	s.Equal(AnnualizedRateFromAPR(rate.APR, DaysPerYear).APR, rate.APR)
+	s.InDelta(0.05, AnnualizedRateFromAPR(rate.APR, DaysPerYear).APY, 1e-12)
+}
@@ -0,0 +1,156 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	// ErrInvalidOrderSize is returned by QuoteToBaseQty and BaseToQuoteQty for an amount or a price that is
+	// not a positive number
+	ErrInvalidOrderSize = errors.New("invalid order size")
+	// ErrLotSizeRejected is returned by QuoteToBaseQty and BaseToQuoteQty when the rounded quantity is
+	// outside the LOT_SIZE limits of the symbol
+	ErrLotSizeRejected = errors.New("order quantity rejected")
+)
+
+// amountDecimals is the precision of the amounts of Binance, used for the residual of a rounding
+const amountDecimals = 8
+
+// OrderSize define the result of QuoteToBaseQty and BaseToQuoteQty
+type OrderSize struct {
+	// Price is the price rounded to the tickSize of the PRICE_FILTER
+	Price float64
+	// BaseQty is the quantity rounded down to the stepSize of the LOT_SIZE filter
+	BaseQty float64
+	// QuoteQty is BaseQty * Price
+	QuoteQty float64
+	// Residual is the part of the amount left out by the rounding, in quote asset for QuoteToBaseQty and in
+	// base asset for BaseToQuoteQty
+	Residual float64
+}
+
+// QuoteToBaseQty convert the quote amount of an order on symbol into the base quantity bought or sold
+// at price, e.g. the BTC bought with 100 USDT. The price is rounded to the nearest tick and the quantity
+// down to the lot step, so the order never exceeds quote, using the cached exchange information.
+func (c *Client) QuoteToBaseQty(ctx context.Context, symbol string, quote, price float64) (*OrderSize, error) {
+	if err := checkOrderSizeInput("quote amount", quote); err != nil {
+		return nil, err
+	}
+	size, err := c.orderSize(ctx, symbol, price, func(price float64) float64 { return quote / price })
+	if err != nil {
+		return nil, err
+	}
+	size.Residual = roundDecimals(quote-size.QuoteQty, max(size.decimals, amountDecimals))
+	return &size.OrderSize, nil
+}
+
+// BaseToQuoteQty convert the base quantity of an order on symbol into its quote amount at price, e.g. the
+// USDT paid for 0.01 BTC. The price is rounded to the nearest tick and the quantity down to the lot step,
+// using the cached exchange information.
+func (c *Client) BaseToQuoteQty(ctx context.Context, symbol string, base, price float64) (*OrderSize, error) {
+	if err := checkOrderSizeInput("base quantity", base); err != nil {
+		return nil, err
+	}
+	size, err := c.orderSize(ctx, symbol, price, func(float64) float64 { return base })
+	if err != nil {
+		return nil, err
+	}
+	size.Residual = roundDecimals(base-size.BaseQty, max(size.qtyDecimals, amountDecimals))
+	return &size.OrderSize, nil
+}
+
+// roundedOrderSize is an OrderSize with the decimals of its rounding
+type roundedOrderSize struct {
+	OrderSize
+	qtyDecimals int
+	// decimals are the decimals of QuoteQty, those of the price and of the quantity
+	decimals int
+}
+
+// orderSize round price and the quantity returned by qty for it to the filters of symbol
+func (c *Client) orderSize(ctx context.Context, symbol string, price float64, qty func(price float64) float64) (*roundedOrderSize, error) {
+	if err := checkOrderSizeInput("price", price); err != nil {
+		return nil, err
+	}
+	info, err := c.exchangeInfoCache().Symbol(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	var tickSize, stepSize, minQty, maxQty string
+	for _, filter := range info.Filters {
+		switch filter.FilterType {
+		case "PRICE_FILTER":
+			tickSize = filter.TickSize
+		case "LOT_SIZE":
+			stepSize, minQty, maxQty = filter.StepSize, filter.MinQty, filter.MaxQty
+		}
+	}
+	size := &roundedOrderSize{qtyDecimals: stepDecimals(stepSize)}
+	if size.Price, err = snapToStep(price, tickSize, math.Round); err != nil {
+		return nil, err
+	}
+	if size.Price <= 0 {
+		return nil, fmt.Errorf("%w: price %g is below the tick size %s", ErrInvalidOrderSize, price, tickSize)
+	}
+	if size.BaseQty, err = snapToStep(qty(size.Price), stepSize, math.Floor); err != nil {
+		return nil, err
+	}
+	minimum, err := parseFilterValue(minQty)
+	if err != nil {
+		return nil, err
+	}
+	maximum, err := parseFilterValue(maxQty)
+	if err != nil {
+		return nil, err
+	}
+	if size.BaseQty <= 0 || size.BaseQty < minimum {
+		return nil, fmt.Errorf("%w: quantity %g is below the LOT_SIZE minimum %s", ErrLotSizeRejected, size.BaseQty, minQty)
+	}
+	if maximum > 0 && size.BaseQty > maximum {
+		return nil, fmt.Errorf("%w: quantity %g is above the LOT_SIZE maximum %s", ErrLotSizeRejected, size.BaseQty, maxQty)
+	}
+	size.decimals = stepDecimals(tickSize) + size.qtyDecimals
+	size.QuoteQty = roundDecimals(size.BaseQty*size.Price, size.decimals)
+	return size, nil
+}
+
+// checkOrderSizeInput return an error for a value that is not a positive number
+func checkOrderSizeInput(name string, value float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) || value <= 0 {
+		return fmt.Errorf("%w: %s must be positive, got %g", ErrInvalidOrderSize, name, value)
+	}
+	return nil
+}
+
+// snapToStep round value to a multiple of step with round, e.g. math.Floor, a zero or empty step leaves it unchanged
+func snapToStep(value float64, step string, round func(float64) float64) (float64, error) {
+	s, err := parseFilterValue(step)
+	if err != nil || s <= 0 {
+		return value, err
+	}
+	// the epsilon absorbs the float error of the division, e.g. 0.3 / 0.1 = 2.9999999999999996
+	return roundDecimals(round(value/s+1e-9)*s, stepDecimals(step)), nil
+}
+
+// stepDecimals return the decimals of a filter step, e.g. 3 for "0.00100000", and amountDecimals for a
+// zero or empty step, which disables the rounding
+func stepDecimals(step string) int {
+	if s, err := parseFilterValue(step); err != nil || s <= 0 {
+		return amountDecimals
+	}
+	i := strings.IndexByte(step, '.')
+	if i < 0 {
+		return 0
+	}
+	return len(strings.TrimRight(step[i+1:], "0"))
+}
+
+// roundDecimals round value to decimals, removing the float error of the arithmetic on decimal steps
+func roundDecimals(value float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,95 @@
+package binance_connector
+
+import (
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var sizingExchangeInfoData = []byte(`{
+	"symbols": [
+		{
+			"symbol": "BTCUSDT",
+			"status": "TRADING",
+			"filters": [
+				{"filterType": "PRICE_FILTER", "minPrice": "0.01000000", "maxPrice": "1000000.00000000", "tickSize": "0.01000000"},
+				{"filterType": "LOT_SIZE", "minQty": "0.00001000", "maxQty": "9000.00000000", "stepSize": "0.00001000"}
+			]
+		},
+		{
+			"symbol": "SHIBUSDT",
+			"status": "TRADING",
+			"filters": [
+				{"filterType": "PRICE_FILTER", "minPrice": "0.00000100", "maxPrice": "1.00000000", "tickSize": "0.00000100"},
+				{"filterType": "LOT_SIZE", "minQty": "1.00", "maxQty": "46116860414.00", "stepSize": "1.00"}
+			]
+		}
+	]
+}`)
+
+type sizingTestSuite struct {
+	suite.Suite
+	client *Client
+}
+
+func TestSizing(t *testing.T) {
+	suite.Run(t, new(sizingTestSuite))
+}
+
+func (s *sizingTestSuite) SetupTest() {
+	s.client = NewPublicClient("https://dummyapi.com")
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.Require().Equal("/api/v3/exchangeInfo", req.URL.Path)
+		return newHTTPResponse(sizingExchangeInfoData, http.StatusOK), nil
+	}
+}
+
+func (s *sizingTestSuite) TestQuoteToBaseQty() {
+	size, err := s.client.QuoteToBaseQty(newContext(), "BTCUSDT", 100, 30123.456)
+	s.Require().NoError(err)
+	s.Equal(&OrderSize{Price: 30123.46, BaseQty: 0.00331, QuoteQty: 99.7086526, Residual: 0.2913474}, size)
+
+	// exact amounts are not rounded down by the float error
+	size, err = s.client.QuoteToBaseQty(newContext(), "BTCUSDT", 3, 10000)
+	s.Require().NoError(err)
+	s.Equal(0.0003, size.BaseQty)
+	s.Zero(size.Residual)
+
+	size, err = s.client.QuoteToBaseQty(newContext(), "SHIBUSDT", 50, 0.0000123)
+	s.Require().NoError(err)
+	s.Equal(&OrderSize{Price: 0.000012, BaseQty: 4166666, QuoteQty: 49.999992, Residual: 0.000008}, size)
+}
+
+func (s *sizingTestSuite) TestBaseToQuoteQty() {
+	size, err := s.client.BaseToQuoteQty(newContext(), "BTCUSDT", 0.0123456, 30000)
+	s.Require().NoError(err)
+	s.Equal(&OrderSize{Price: 30000, BaseQty: 0.01234, QuoteQty: 370.2, Residual: 0.0000056}, size)
+}
+
+func (s *sizingTestSuite) TestInvalidInput() {
+	for _, amount := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		_, err := s.client.QuoteToBaseQty(newContext(), "BTCUSDT", amount, 30000)
+		s.ErrorIs(err, ErrInvalidOrderSize)
+		_, err = s.client.BaseToQuoteQty(newContext(), "BTCUSDT", 1, amount)
+		s.ErrorIs(err, ErrInvalidOrderSize)
+	}
+	_, err := s.client.BaseToQuoteQty(newContext(), "BTCUSDT", -0.5, 30000)
+	s.EqualError(err, "invalid order size: base quantity must be positive, got -0.5")
+
+	_, err = s.client.QuoteToBaseQty(newContext(), "BTCUSDT", 100, 0.001)
+	s.ErrorIs(err, ErrInvalidOrderSize, "a price rounded to zero")
+}
+
+func (s *sizingTestSuite) TestLotSize() {
+	_, err := s.client.QuoteToBaseQty(newContext(), "BTCUSDT", 0.1, 30000)
+	s.ErrorIs(err, ErrLotSizeRejected)
+	s.EqualError(err, "order quantity rejected: quantity 0 is below the LOT_SIZE minimum 0.00001000")
+
+	_, err = s.client.BaseToQuoteQty(newContext(), "BTCUSDT", 10000, 30000)
+	s.ErrorIs(err, ErrLotSizeRejected)
+
+	_, err = s.client.BaseToQuoteQty(newContext(), "ETHUSDT", 1, 2000)
+	s.ErrorIs(err, ErrSymbolNotFound)
+}
@@ -2,11 +2,25 @@ package binance_connector
 
 import (
 	"context"
-	"github.com/goccy/go-json"
+	"errors"
 	"net/http"
 	"strconv"
 )
 
+// ErrSubAccountEmailRequired is returned by sub-account services sent without the sub-account email
+var ErrSubAccountEmailRequired = errors.New("sub-account email is required")
+
+// SubAccountType define the account type of a sub-account universal transfer
+type SubAccountType string
+
+const (
+	SubAccountTypeSpot           SubAccountType = "SPOT"
+	SubAccountTypeUSDTFuture     SubAccountType = "USDT_FUTURE"
+	SubAccountTypeCoinFuture     SubAccountType = "COIN_FUTURE"
+	SubAccountTypeMargin         SubAccountType = "MARGIN"
+	SubAccountTypeIsolatedMargin SubAccountType = "ISOLATED_MARGIN"
+)
+
 // Create a Virtual Sub-account(For Master Account)
 const (
 	enableSubAccountEndpoint = "/sapi/v1/sub-account/virtualSubAccount"
@@ -26,7 +40,8 @@ func (s *CreateSubAccountService) Do(ctx context.Context, opts ...RequestOption)
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: enableSubAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "CreateSubAccountService",
 	}
 	r.setParam("subAccountString", s.subAccountString)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -34,7 +49,7 @@ func (s *CreateSubAccountService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = new(CreateSubAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +97,8 @@ func (s *QuerySubAccountListService) Do(ctx context.Context, opts ...RequestOpti
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: querySubAccountListEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QuerySubAccountListService",
 	}
 	if s.email != nil {
 		r.setParam("email", s.email)
@@ -101,7 +117,7 @@ func (s *QuerySubAccountListService) Do(ctx context.Context, opts ...RequestOpti
 		return nil, err
 	}
 	res = new(SubAccountListResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -169,7 +185,8 @@ func (s *QuerySubAccountSpotAssetTransferHistoryService) Do(ctx context.Context,
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: querySubAccountSpotAssetTransferHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QuerySubAccountSpotAssetTransferHistoryService",
 	}
 	if s.fromEmail != nil {
 		r.setParam("fromEmail", s.fromEmail)
@@ -194,7 +211,7 @@ func (s *QuerySubAccountSpotAssetTransferHistoryService) Do(ctx context.Context,
 		return nil, err
 	}
 	res = new(QuerySubAccountSpotAssetTransferHistoryResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -262,7 +279,8 @@ func (s *QuerySubAccountFuturesAssetTransferHistoryService) Do(ctx context.Conte
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: querySubAccountFuturesAssetTransferHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QuerySubAccountFuturesAssetTransferHistoryService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("futuresType", s.futuresType)
@@ -283,7 +301,7 @@ func (s *QuerySubAccountFuturesAssetTransferHistoryService) Do(ctx context.Conte
 		return nil, err
 	}
 	res = new(QuerySubAccountFuturesAssetTransferHistoryResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -346,7 +364,8 @@ func (s *SubAccountFuturesAssetTransferService) Do(ctx context.Context, opts ...
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: subAccountFuturesAssetTransferEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "SubAccountFuturesAssetTransferService",
 	}
 	r.setParam("fromEmail", s.fromEmail)
 	r.setParam("toEmail", s.toEmail)
@@ -358,7 +377,7 @@ func (s *SubAccountFuturesAssetTransferService) Do(ctx context.Context, opts ...
 		return nil, err
 	}
 	res = new(SubAccountFuturesAssetTransferResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -389,7 +408,8 @@ func (s *QuerySubAccountAssetsService) Do(ctx context.Context, opts ...RequestOp
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: querySubAccountAssetsEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QuerySubAccountAssetsService",
 	}
 	r.setParam("email", s.email)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -397,7 +417,7 @@ func (s *QuerySubAccountAssetsService) Do(ctx context.Context, opts ...RequestOp
 		return nil, err
 	}
 	res = new(QuerySubAccountAssetsResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -443,7 +463,8 @@ func (s *QuerySubAccountSpotAssetsSummaryService) Do(ctx context.Context, opts .
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: querySubAccountSpotAssetsSummaryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QuerySubAccountSpotAssetsSummaryService",
 	}
 	if s.email != nil {
 		r.setParam("email", *s.email)
@@ -459,7 +480,7 @@ func (s *QuerySubAccountSpotAssetsSummaryService) Do(ctx context.Context, opts .
 		return nil, err
 	}
 	res = new(QuerySubAccountSpotAssetsSummaryResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -506,7 +527,8 @@ func (s *GetSubAccountDepositAddressService) Do(ctx context.Context, opts ...Req
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getSubAccountDepositAddressEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetSubAccountDepositAddressService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("coin", s.coin)
@@ -518,7 +540,7 @@ func (s *GetSubAccountDepositAddressService) Do(ctx context.Context, opts ...Req
 		return nil, err
 	}
 	res = new(GetSubAccountDepositAddressResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -587,7 +609,8 @@ func (s *GetSubAccountDepositHistoryService) Do(ctx context.Context, opts ...Req
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getSubAccountDepositHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetSubAccountDepositHistoryService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("coin", s.coin)
@@ -611,7 +634,7 @@ func (s *GetSubAccountDepositHistoryService) Do(ctx context.Context, opts ...Req
 		return nil, err
 	}
 	res = new(GetSubAccountDepositHistoryResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -620,19 +643,19 @@ func (s *GetSubAccountDepositHistoryService) Do(ctx context.Context, opts ...Req
 
 type GetSubAccountDepositHistoryResp struct {
 	DepositList []struct {
-		Id            int64  `json:"id"`
-		Amount        string `json:"amount"`
-		Coin          string `json:"coin"`
-		Network       string `json:"network"`
-		Status        int64  `json:"status"`
-		Address       string `json:"address"`
-		AddressTag    string `json:"addressTag"`
-		TxId          string `json:"txId"`
-		InsertTime    uint64 `json:"insertTime"`
-		TransferType  int64  `json:"transferType"`
-		ConfirmTimes  string `json:"confirmTimes"`
-		UnlockConfirm int64  `json:"unlockConfirm"`
-		WalletType    int    `json:"walletType"`
+		Id            int64         `json:"id"`
+		Amount        string        `json:"amount"`
+		Coin          string        `json:"coin"`
+		Network       string        `json:"network"`
+		Status        DepositStatus `json:"status"`
+		Address       string        `json:"address"`
+		AddressTag    string        `json:"addressTag"`
+		TxId          string        `json:"txId"`
+		InsertTime    uint64        `json:"insertTime"`
+		TransferType  int64         `json:"transferType"`
+		ConfirmTimes  string        `json:"confirmTimes"`
+		UnlockConfirm int64         `json:"unlockConfirm"`
+		WalletType    int           `json:"walletType"`
 	}
 }
 
@@ -655,7 +678,8 @@ func (s *GetSubAccountStatusService) Do(ctx context.Context, opts ...RequestOpti
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getSubAccountStatusEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetSubAccountStatusService",
 	}
 	if s.email != nil {
 		r.setParam("email", *s.email)
@@ -665,7 +689,7 @@ func (s *GetSubAccountStatusService) Do(ctx context.Context, opts ...RequestOpti
 		return nil, err
 	}
 	res = new(GetSubAccountStatusResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -698,10 +722,14 @@ func (s *EnableMarginForSubAccountService) Email(email string) *EnableMarginForS
 }
 
 func (s *EnableMarginForSubAccountService) Do(ctx context.Context, opts ...RequestOption) (res *EnableMarginForSubAccountResp, err error) {
+	if s.email == "" {
+		return nil, ErrSubAccountEmailRequired
+	}
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: enableMarginForSubAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "EnableMarginForSubAccountService",
 	}
 	r.setParam("email", s.email)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -709,7 +737,7 @@ func (s *EnableMarginForSubAccountService) Do(ctx context.Context, opts ...Reque
 		return nil, err
 	}
 	res = new(EnableMarginForSubAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -740,7 +768,8 @@ func (s *GetDetailOnSubAccountMarginAccountService) Do(ctx context.Context, opts
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getDetailOnSubAccountMarginAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetDetailOnSubAccountMarginAccountService",
 	}
 	r.setParam("email", s.email)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -748,7 +777,7 @@ func (s *GetDetailOnSubAccountMarginAccountService) Do(ctx context.Context, opts
 		return nil, err
 	}
 	res = new(GetDetailOnSubAccountMarginAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -789,14 +818,15 @@ func (s *GetSummaryOfSubAccountMarginAccountService) Do(ctx context.Context, opt
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getSummaryOfSubAccountMarginAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetSummaryOfSubAccountMarginAccountService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(GetSummaryOfSubAccountMarginAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -831,10 +861,14 @@ func (s *EnableFuturesForSubAccountService) Email(email string) *EnableFuturesFo
 }
 
 func (s *EnableFuturesForSubAccountService) Do(ctx context.Context, opts ...RequestOption) (res *EnableFuturesForSubAccountResp, err error) {
+	if s.email == "" {
+		return nil, ErrSubAccountEmailRequired
+	}
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: enableFuturesForSubAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "EnableFuturesForSubAccountService",
 	}
 	r.setParam("email", s.email)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -842,7 +876,7 @@ func (s *EnableFuturesForSubAccountService) Do(ctx context.Context, opts ...Requ
 		return nil, err
 	}
 	res = new(EnableFuturesForSubAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -873,7 +907,8 @@ func (s *GetDetailOnSubAccountFuturesAccountService) Do(ctx context.Context, opt
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getDetailOnSubAccountFuturesAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetDetailOnSubAccountFuturesAccountService",
 	}
 	r.setParam("email", s.email)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -881,7 +916,7 @@ func (s *GetDetailOnSubAccountFuturesAccountService) Do(ctx context.Context, opt
 		return nil, err
 	}
 	res = new(GetDetailOnSubAccountFuturesAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -930,14 +965,15 @@ func (s *GetSummaryOfSubAccountFuturesAccountService) Do(ctx context.Context, op
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getSummaryOfSubAccountFuturesAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetSummaryOfSubAccountFuturesAccountService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(GetSummaryOfSubAccountFuturesAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -985,7 +1021,8 @@ func (s *GetFuturesPositionRiskOfSubAccountService) Do(ctx context.Context, opts
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getFuturesPositionRiskOfSubAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetFuturesPositionRiskOfSubAccountService",
 	}
 	r.setParam("email", s.email)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -993,7 +1030,7 @@ func (s *GetFuturesPositionRiskOfSubAccountService) Do(ctx context.Context, opts
 		return nil, err
 	}
 	res = new(GetFuturesPositionRiskOfSubAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1048,7 +1085,8 @@ func (s *FuturesTransferForSubAccountService) Do(ctx context.Context, opts ...Re
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: futuresTransferForSubAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "FuturesTransferForSubAccountService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("asset", s.asset)
@@ -1059,7 +1097,7 @@ func (s *FuturesTransferForSubAccountService) Do(ctx context.Context, opts ...Re
 		return nil, err
 	}
 	res = new(FuturesTransferForSubAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1107,7 +1145,8 @@ func (s *MarginTransferForSubAccountService) Do(ctx context.Context, opts ...Req
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: marginTransferForSubAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "MarginTransferForSubAccountService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("asset", s.asset)
@@ -1118,7 +1157,7 @@ func (s *MarginTransferForSubAccountService) Do(ctx context.Context, opts ...Req
 		return nil, err
 	}
 	res = new(MarginTransferForSubAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1160,7 +1199,8 @@ func (s *TransferToSubAccountOfSameMasterService) Do(ctx context.Context, opts .
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: transferToSubAccountOfSameMasterEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "TransferToSubAccountOfSameMasterService",
 	}
 	r.setParam("toEmail", s.toEmail)
 	r.setParam("asset", s.asset)
@@ -1170,7 +1210,7 @@ func (s *TransferToSubAccountOfSameMasterService) Do(ctx context.Context, opts .
 		return nil, err
 	}
 	res = new(TransferToSubAccountOfSameMasterResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1206,7 +1246,8 @@ func (s *TransferToMasterService) Do(ctx context.Context, opts ...RequestOption)
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: transferToMasterEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "TransferToMasterService",
 	}
 	r.setParam("asset", s.asset)
 	r.setParam("amount", s.amount)
@@ -1215,7 +1256,7 @@ func (s *TransferToMasterService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = new(TransferToMasterResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1269,7 +1310,8 @@ func (s *SubAccountTransferHistoryService) Do(ctx context.Context, opts ...Reque
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: subAccountTransferHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "SubAccountTransferHistoryService",
 	}
 	if s.asset != nil {
 		r.setParam("asset", *s.asset)
@@ -1291,7 +1333,7 @@ func (s *SubAccountTransferHistoryService) Do(ctx context.Context, opts ...Reque
 		return nil, err
 	}
 	res = new(SubAccountTransferHistoryResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1320,8 +1362,8 @@ type UniversalTransferService struct {
 	c               *Client
 	fromEmail       *string
 	toEmail         *string
-	fromAccountType string
-	toAccountType   string
+	fromAccountType SubAccountType
+	toAccountType   SubAccountType
 	clientTranId    *string
 	symbol          *string
 	asset           string
@@ -1338,12 +1380,12 @@ func (s *UniversalTransferService) ToEmail(toEmail string) *UniversalTransferSer
 	return s
 }
 
-func (s *UniversalTransferService) FromAccountType(fromAccountType string) *UniversalTransferService {
+func (s *UniversalTransferService) FromAccountType(fromAccountType SubAccountType) *UniversalTransferService {
 	s.fromAccountType = fromAccountType
 	return s
 }
 
-func (s *UniversalTransferService) ToAccountType(toAccountType string) *UniversalTransferService {
+func (s *UniversalTransferService) ToAccountType(toAccountType SubAccountType) *UniversalTransferService {
 	s.toAccountType = toAccountType
 	return s
 }
@@ -1368,11 +1410,21 @@ func (s *UniversalTransferService) Amount(amount float64) *UniversalTransferServ
 	return s
 }
 
+// Do send request. Transfers between two master account wallets are not supported, so at least one of fromEmail
+// and toEmail must be set, and the symbol is required when either side is ISOLATED_MARGIN.
 func (s *UniversalTransferService) Do(ctx context.Context, opts ...RequestOption) (res *UniversalTransferResp, err error) {
+	if s.fromEmail == nil && s.toEmail == nil {
+		return nil, ErrSubAccountEmailRequired
+	}
+	if (s.fromAccountType == SubAccountTypeIsolatedMargin || s.toAccountType == SubAccountTypeIsolatedMargin) &&
+		(s.symbol == nil || *s.symbol == "") {
+		return nil, ErrIsolatedSymbolRequired
+	}
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: universalTransferEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "UniversalTransferService",
 	}
 	r.setParam("fromAccountType", s.fromAccountType)
 	r.setParam("toAccountType", s.toAccountType)
@@ -1395,7 +1447,7 @@ func (s *UniversalTransferService) Do(ctx context.Context, opts ...RequestOption
 		return nil, err
 	}
 	res = new(UniversalTransferResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1462,7 +1514,8 @@ func (s *QueryUniversalTransferHistoryService) Do(ctx context.Context, opts ...R
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: queryUniversalTransferHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QueryUniversalTransferHistoryService",
 	}
 	if s.fromEmail != nil {
 		r.setParam("fromEmail", *s.fromEmail)
@@ -1490,7 +1543,7 @@ func (s *QueryUniversalTransferHistoryService) Do(ctx context.Context, opts ...R
 		return
 	}
 	res.Result = make([]*InternalUniversalTransfer, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return
 	}
@@ -1540,7 +1593,8 @@ func (s *GetDetailOnSubAccountFuturesAccountV2Service) Do(ctx context.Context, o
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getDetailOnSubAccountFuturesAccountV2Endpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetDetailOnSubAccountFuturesAccountV2Service",
 	}
 	r.setParam("email", s.email)
 	r.setParam("futuresType", s.futuresType)
@@ -1553,7 +1607,7 @@ func (s *GetDetailOnSubAccountFuturesAccountV2Service) Do(ctx context.Context, o
 	} else {
 		res = new(GetDetailOnSubAccountFuturesAccountV2COINResp)
 	}
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1643,7 +1697,8 @@ func (s *GetSummaryOfSubAccountFuturesAccountV2Service) Do(ctx context.Context,
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getSummaryOfSubAccountFuturesAccountV2Endpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetSummaryOfSubAccountFuturesAccountV2Service",
 	}
 	r.setParam("futuresType", s.futuresType)
 	if s.page != nil {
@@ -1661,7 +1716,7 @@ func (s *GetSummaryOfSubAccountFuturesAccountV2Service) Do(ctx context.Context,
 	} else {
 		res = new(GetSummaryOfSubAccountFuturesAccountV2COINResp)
 	}
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1733,7 +1788,8 @@ func (s *GetFuturesPositionRiskOfSubAccountV2Service) Do(ctx context.Context, op
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getFuturesPositionRiskOfSubAccountV2Endpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetFuturesPositionRiskOfSubAccountV2Service",
 	}
 	r.setParam("email", s.email)
 	r.setParam("futuresType", s.futuresType)
@@ -1746,7 +1802,7 @@ func (s *GetFuturesPositionRiskOfSubAccountV2Service) Do(ctx context.Context, op
 	} else {
 		res = new(GetFuturesPositionRiskOfSubAccountV2COINResp)
 	}
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1807,7 +1863,8 @@ func (s *EnableLeverageTokenForSubAccountService) Do(ctx context.Context, opts .
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: enableLeverageTokenForSubAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "EnableLeverageTokenForSubAccountService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("enableBlvt", s.enableBlvt)
@@ -1816,7 +1873,7 @@ func (s *EnableLeverageTokenForSubAccountService) Do(ctx context.Context, opts .
 		return nil, err
 	}
 	res = new(EnableLeverageTokenForSubAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1854,7 +1911,8 @@ func (s *GetIPRestrictionForSubAccountAPIKeyService) Do(ctx context.Context, opt
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getIPRestrictionForSubAccountAPIKeyEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetIPRestrictionForSubAccountAPIKeyService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("subAccountApiKey", s.subAccountApiKey)
@@ -1863,7 +1921,7 @@ func (s *GetIPRestrictionForSubAccountAPIKeyService) Do(ctx context.Context, opt
 		return nil, err
 	}
 	res = new(GetIPRestrictionForSubAccountAPIKeyResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1911,7 +1969,8 @@ func (s *DeleteIPListForSubAccountAPIKeyService) Do(ctx context.Context, opts ..
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: deleteIPListForSubAccountAPIKeyEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "DeleteIPListForSubAccountAPIKeyService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("subAccountApiKey", s.subAccountApiKey)
@@ -1923,7 +1982,7 @@ func (s *DeleteIPListForSubAccountAPIKeyService) Do(ctx context.Context, opts ..
 		return nil, err
 	}
 	res = new(DeleteIPListForSubAccountAPIKeyResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1977,7 +2036,8 @@ func (s *UpdateIPRestrictionForSubAccountAPIKeyService) Do(ctx context.Context,
 	r := &request{
 		method:   http.MethodPut,
 		endpoint: updateIPRestrictionForSubAccountAPIKeyEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "UpdateIPRestrictionForSubAccountAPIKeyService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("subAccountApiKey", s.subAccountApiKey)
@@ -1990,7 +2050,7 @@ func (s *UpdateIPRestrictionForSubAccountAPIKeyService) Do(ctx context.Context,
 		return nil, err
 	}
 	res = new(UpdateIPRestrictionForSubAccountAPIKeyResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2038,7 +2098,8 @@ func (s *DepositAssetsIntoTheManagedSubAccountService) Do(ctx context.Context, o
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: depositAssetsIntoTheManagedSubAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "DepositAssetsIntoTheManagedSubAccountService",
 	}
 	r.setParam("toEmail", s.toEmail)
 	r.setParam("asset", s.asset)
@@ -2048,7 +2109,7 @@ func (s *DepositAssetsIntoTheManagedSubAccountService) Do(ctx context.Context, o
 		return nil, err
 	}
 	res = new(DepositAssetsIntoTheManagedSubAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2079,7 +2140,8 @@ func (s *QueryManagedSubAccountAssetDetailsService) Do(ctx context.Context, opts
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: queryManagedSubAccountAssetDetailsEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QueryManagedSubAccountAssetDetailsService",
 	}
 	r.setParam("email", s.email)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -2087,7 +2149,7 @@ func (s *QueryManagedSubAccountAssetDetailsService) Do(ctx context.Context, opts
 		return nil, err
 	}
 	res = new(QueryManagedSubAccountAssetDetailsResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2143,7 +2205,8 @@ func (s *WithdrawAssetsFromTheManagedSubAccountService) Do(ctx context.Context,
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: withdrawAssetsFromTheManagedSubAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "WithdrawAssetsFromTheManagedSubAccountService",
 	}
 	r.setParam("fromEmail", s.fromEmail)
 	r.setParam("asset", s.asset)
@@ -2156,7 +2219,7 @@ func (s *WithdrawAssetsFromTheManagedSubAccountService) Do(ctx context.Context,
 		return nil, err
 	}
 	res = new(WithdrawAssetsFromTheManagedSubAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2211,7 +2274,8 @@ func (s *QueryManagedSubAccountSnapshotService) Do(ctx context.Context, opts ...
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: queryManagedSubAccountSnapshotEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QueryManagedSubAccountSnapshotService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("type", s.subType)
@@ -2229,7 +2293,7 @@ func (s *QueryManagedSubAccountSnapshotService) Do(ctx context.Context, opts ...
 		return nil, err
 	}
 	res = new(QueryManagedSubAccountSnapshotResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2308,7 +2372,8 @@ func (s *QueryManagedSubAccountTransferLogService) Do(ctx context.Context, opts
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: queryManagedSubAccountTransferLogEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QueryManagedSubAccountTransferLogService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("startTime", s.startTime)
@@ -2326,7 +2391,7 @@ func (s *QueryManagedSubAccountTransferLogService) Do(ctx context.Context, opts
 		return nil, err
 	}
 	res = new(QueryManagedSubAccountTransferLogResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2367,7 +2432,8 @@ func (s *QueryManagedSubAccountFuturesAssetDetailsService) Do(ctx context.Contex
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: queryManagedSubAccountFuturesAssetDetailsEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QueryManagedSubAccountFuturesAssetDetailsService",
 	}
 	r.setParam("email", s.email)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -2375,7 +2441,7 @@ func (s *QueryManagedSubAccountFuturesAssetDetailsService) Do(ctx context.Contex
 		return nil, err
 	}
 	res = new(QueryManagedSubAccountFuturesAssetDetailsResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2423,7 +2489,8 @@ func (s *QueryManagedSubAccountMarginAssetDetailsService) Do(ctx context.Context
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: queryManagedSubAccountMarginAssetDetailsEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QueryManagedSubAccountMarginAssetDetailsService",
 	}
 	r.setParam("email", s.email)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -2431,7 +2498,7 @@ func (s *QueryManagedSubAccountMarginAssetDetailsService) Do(ctx context.Context
 		return nil, err
 	}
 	res = new(QueryManagedSubAccountMarginAssetDetailsResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2508,7 +2575,8 @@ func (s *QueryManagedSubAccountTransferLogForTradingTeamService) Do(ctx context.
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: queryManagedSubAccountTransferLogForTradingTeamEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QueryManagedSubAccountTransferLogForTradingTeamService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("startTime", s.startTime)
@@ -2526,7 +2594,7 @@ func (s *QueryManagedSubAccountTransferLogForTradingTeamService) Do(ctx context.
 		return nil, err
 	}
 	res = new(QueryManagedSubAccountTransferLogForTradingTeamResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2567,7 +2635,8 @@ func (s *QuerySubAccountAssetsForMasterAccountService) Do(ctx context.Context, o
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: querySubAccountAssetsForMasterAccountEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QuerySubAccountAssetsForMasterAccountService",
 	}
 	r.setParam("email", s.email)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -2575,7 +2644,7 @@ func (s *QuerySubAccountAssetsForMasterAccountService) Do(ctx context.Context, o
 		return nil, err
 	}
 	res = new(QuerySubAccountAssetsForMasterAccountResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2621,7 +2690,8 @@ func (s *QueryManagedSubAccountList) Do(ctx context.Context, opts ...RequestOpti
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: queryManagedSubAccountListEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QueryManagedSubAccountList",
 	}
 	if s.email != nil {
 		r.setParam("email", *s.email)
@@ -2637,7 +2707,7 @@ func (s *QueryManagedSubAccountList) Do(ctx context.Context, opts ...RequestOpti
 		return nil, err
 	}
 	res = new(QueryManagedSubAccountListResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2680,7 +2750,8 @@ func (s *QuerySubAccountTransactionTatistics) Do(ctx context.Context, opts ...Re
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: QuerySubAccountTransactionTatisticsEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "QuerySubAccountTransactionTatistics",
 	}
 	r.setParam("email", s.email)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -2688,7 +2759,7 @@ func (s *QuerySubAccountTransactionTatistics) Do(ctx context.Context, opts ...Re
 		return nil, err
 	}
 	res = new(QuerySubAccountTransactionTatisticsResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -2745,7 +2816,8 @@ func (s *GetManagedSubAccountDepositAddressService) Do(ctx context.Context, opts
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: getManagedSubAccountDepositAddressEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetManagedSubAccountDepositAddressService",
 	}
 	r.setParam("email", s.email)
 	r.setParam("coin", s.coin)
@@ -2757,7 +2829,7 @@ func (s *GetManagedSubAccountDepositAddressService) Do(ctx context.Context, opts
 		return nil, err
 	}
 	res = new(GetManagedSubAccountDepositAddressResp)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
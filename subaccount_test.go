@@ -613,7 +613,7 @@ func (s *subAccountTestSuite) TestGetSubAccountDepositHistory() {
 	s.Equal("1.00000000", resp.DepositList[0].Amount)
 	s.Equal("BTC", resp.DepositList[0].Coin)
 	s.Equal("", resp.DepositList[0].Network)
-	s.Equal(int64(1), resp.DepositList[0].Status)
+	s.Equal(DepositStatusSuccess, resp.DepositList[0].Status)
 	s.Equal("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", resp.DepositList[0].Address)
 	s.Equal("", resp.DepositList[0].AddressTag)
 	s.Equal("tx123", resp.DepositList[0].TxId)
@@ -1504,6 +1504,64 @@ func (s *subAccountTestSuite) TestDo() {
 	s.Equal("123abc", resp.ClientTranId)
 }
 
+func (s *subAccountTestSuite) TestUniversalTransferIsolatedMargin() {
+	data := []byte(`{"tranId": 123456789}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"fromEmail":       "example1@gmail.com",
+			"fromAccountType": "SPOT",
+			"toAccountType":   "ISOLATED_MARGIN",
+			"symbol":          "BTCUSDT",
+			"asset":           "USDT",
+			"amount":          10.0,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	resp, err := s.client.NewUniversalTransferService().
+		FromEmail("example1@gmail.com").
+		FromAccountType(SubAccountTypeSpot).
+		ToAccountType(SubAccountTypeIsolatedMargin).
+		Symbol("BTCUSDT").
+		Asset("USDT").
+		Amount(10.0).
+		Do(context.Background())
+
+	s.r().NoError(err)
+	s.Equal(123456789, resp.TranId)
+}
+
+func (s *subAccountTestSuite) TestUniversalTransferEmailRequired() {
+	_, err := s.client.NewUniversalTransferService().
+		FromAccountType(SubAccountTypeSpot).
+		ToAccountType(SubAccountTypeUSDTFuture).
+		Asset("USDT").
+		Amount(10.0).
+		Do(context.Background())
+	s.r().ErrorIs(err, ErrSubAccountEmailRequired)
+}
+
+func (s *subAccountTestSuite) TestUniversalTransferIsolatedSymbolRequired() {
+	_, err := s.client.NewUniversalTransferService().
+		ToEmail("example2@gmail.com").
+		FromAccountType(SubAccountTypeIsolatedMargin).
+		ToAccountType(SubAccountTypeSpot).
+		Asset("USDT").
+		Amount(10.0).
+		Do(context.Background())
+	s.r().ErrorIs(err, ErrIsolatedSymbolRequired)
+}
+
+func (s *subAccountTestSuite) TestEnableForSubAccountEmailRequired() {
+	_, err := s.client.NewEnableFuturesForSubAccountService().Do(context.Background())
+	s.r().ErrorIs(err, ErrSubAccountEmailRequired)
+	_, err = s.client.NewEnableMarginForSubAccountService().Do(context.Background())
+	s.r().ErrorIs(err, ErrSubAccountEmailRequired)
+}
+
 func (s *subAccountTestSuite) TestUpdateIPRestrictionForSubAccountAPIKey() {
 	data := []byte(`{
 		"status": "SUCCESS",
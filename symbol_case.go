@@ -0,0 +1,40 @@
+package binance_connector
+
+import (
+	"strings"
+)
+
+// symbolParams are the REST parameters holding symbols, upper-cased unless Client.DisableSymbolNormalization is set
+var symbolParams = []string{"symbol", "symbols", "isolatedSymbol"}
+
+// normalizeSymbols upper-case the symbol parameters of the query and the form
+func (r *request) normalizeSymbols() {
+	for _, key := range symbolParams {
+		for _, values := range []map[string][]string{r.query, r.form} {
+			for i, value := range values[key] {
+				values[key][i] = strings.ToUpper(value)
+			}
+		}
+	}
+}
+
+// NormalizeStreamName lower-case the symbol of a stream name, e.g. "BTCUSDT@kline_1M" becomes "btcusdt@kline_1M".
+// The rest of the name is kept since it is case sensitive, and "!" market wide streams are returned unchanged.
+func NormalizeStreamName(stream string) string {
+	if strings.HasPrefix(stream, "!") {
+		return stream
+	}
+	symbol, rest, found := strings.Cut(stream, "@")
+	if !found {
+		return strings.ToLower(stream)
+	}
+	return strings.ToLower(symbol) + "@" + rest
+}
+
+// normalizeStream return the stream name subscribed for stream, normalized unless the client disables it
+func (c *WebsocketStreamClient) normalizeStream(stream string) string {
+	if c.DisableSymbolNormalization {
+		return stream
+	}
+	return NormalizeStreamName(stream)
+}
@@ -0,0 +1,74 @@
+package binance_connector
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type symbolCaseTestSuite struct {
+	suite.Suite
+	client *Client
+	symbol string
+}
+
+func TestSymbolCase(t *testing.T) {
+	suite.Run(t, new(symbolCaseTestSuite))
+}
+
+func (s *symbolCaseTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.symbol = ""
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.symbol = req.URL.Query().Get("symbol")
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+}
+
+func (s *symbolCaseTestSuite) TestSymbolNormalization() {
+	_, err := s.client.NewTickerPriceService().Symbol("btcusdt").Do(newContext())
+	s.Require().NoError(err)
+	s.Equal("BTCUSDT", s.symbol)
+
+	s.client.DisableSymbolNormalization = true
+	_, err = s.client.NewTickerPriceService().Symbol("btcusdt").Do(newContext())
+	s.Require().NoError(err)
+	s.Equal("btcusdt", s.symbol)
+}
+
+func (s *symbolCaseTestSuite) TestNormalizeSymbols() {
+	r := &request{}
+	r.setParams(params{"symbols": `["btcusdt","BNBusdt"]`, "asset": "usdt"})
+	r.form = url.Values{"isolatedSymbol": {"ethusdt"}}
+	r.normalizeSymbols()
+	s.Equal(`["BTCUSDT","BNBUSDT"]`, r.query.Get("symbols"))
+	s.Equal("usdt", r.query.Get("asset"))
+	s.Equal("ETHUSDT", r.form.Get("isolatedSymbol"))
+}
+
+func (s *symbolCaseTestSuite) TestNormalizeStreamName() {
+	tests := map[string]string{
+		"BTCUSDT@kline_1M":     "btcusdt@kline_1M",
+		"BNBusdt@depth5@100ms": "bnbusdt@depth5@100ms",
+		"btcusdt@aggTrade":     "btcusdt@aggTrade",
+		"!ticker@arr":          "!ticker@arr",
+		"ETHUSDT":              "ethusdt",
+	}
+	for stream, expected := range tests {
+		s.Equal(expected, NormalizeStreamName(stream), stream)
+	}
+}
+
+func (s *symbolCaseTestSuite) TestMultiplexerStreamNormalization() {
+	m := NewWebsocketStreamClient(true).NewStreamMultiplexer(func(err error) {})
+	m.Register("BTCUSDT@bookTicker", func(message []byte) {})
+	s.Equal([]string{"btcusdt@bookTicker"}, m.Streams())
+
+	c := NewWebsocketStreamClient(true)
+	c.DisableSymbolNormalization = true
+	m = c.NewStreamMultiplexer(func(err error) {})
+	m.Register("BTCUSDT@bookTicker", func(message []byte) {})
+	s.Equal([]string{"BTCUSDT@bookTicker"}, m.Streams())
+}
@@ -0,0 +1,43 @@
+package binance_connector
+
+import "context"
+
+// Symbol statuses of the exchange information
+const (
+	SymbolStatusPreTrading   = "PRE_TRADING"
+	SymbolStatusTrading      = "TRADING"
+	SymbolStatusPostTrading  = "POST_TRADING"
+	SymbolStatusEndOfDay     = "END_OF_DAY"
+	SymbolStatusHalt         = "HALT"
+	SymbolStatusAuctionMatch = "AUCTION_MATCH"
+	SymbolStatusBreak        = "BREAK"
+)
+
+// SymbolStatus return the status of symbol in the cached exchange information, e.g. TRADING, BREAK or HALT,
+// or a SymbolNotFoundError when it is not listed
+func (c *Client) SymbolStatus(ctx context.Context, symbol string) (string, error) {
+	info, err := c.exchangeInfoCache().Symbol(ctx, symbol)
+	if err != nil {
+		return "", err
+	}
+	return info.Status, nil
+}
+
+// CheckSymbolStatus set whether the symbol status is checked before the order is sent, overriding
+// Client.CheckSymbolStatus for this order
+func (s *CreateOrderService) CheckSymbolStatus(check bool) *CreateOrderService {
+	s.checkSymbolStatus = &check
+	return s
+}
+
+// validateSymbolStatus return a SymbolNotTradingError when the status check is enabled and the symbol is not TRADING
+func (s *CreateOrderService) validateSymbolStatus(ctx context.Context) error {
+	check := s.c.CheckSymbolStatus
+	if s.checkSymbolStatus != nil {
+		check = *s.checkSymbolStatus
+	}
+	if !check {
+		return nil
+	}
+	return s.c.ValidateSymbol(ctx, s.symbol)
+}
@@ -0,0 +1,84 @@
+package binance_connector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type symbolStatusTestSuite struct {
+	suite.Suite
+	client *Client
+	orders int
+}
+
+func TestSymbolStatus(t *testing.T) {
+	suite.Run(t, new(symbolStatusTestSuite))
+}
+
+func (s *symbolStatusTestSuite) SetupTest() {
+	s.orders = 0
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/api/v3/exchangeInfo" {
+			return newHTTPResponse(exchangeInfoData, http.StatusOK), nil
+		}
+		s.orders++
+		return newHTTPResponse([]byte(`{"symbol":"LUNAUSDT","orderId":1}`), http.StatusOK), nil
+	}
+}
+
+func (s *symbolStatusTestSuite) TestSymbolStatus() {
+	status, err := s.client.SymbolStatus(context.Background(), "LUNAUSDT")
+	s.Require().NoError(err)
+	s.Equal(SymbolStatusBreak, status)
+
+	status, err = s.client.SymbolStatus(context.Background(), "BTCUSDT")
+	s.Require().NoError(err)
+	s.Equal(SymbolStatusTrading, status)
+
+	_, err = s.client.SymbolStatus(context.Background(), "DOGEEUR")
+	s.ErrorIs(err, ErrSymbolNotFound)
+}
+
+func (s *symbolStatusTestSuite) newOrder(symbol string) *CreateOrderService {
+	return s.client.NewCreateOrderService().Symbol(symbol).Side("BUY").Type("MARKET").Quantity(1)
+}
+
+func (s *symbolStatusTestSuite) TestGuardDisabledByDefault() {
+	_, err := s.newOrder("LUNAUSDT").Do(context.Background())
+	s.NoError(err)
+	s.Equal(1, s.orders)
+}
+
+func (s *symbolStatusTestSuite) TestGlobalGuard() {
+	s.client.CheckSymbolStatus = true
+	_, err := s.newOrder("LUNAUSDT").Do(context.Background())
+	s.ErrorIs(err, ErrSymbolNotTrading)
+	_, err = s.newOrder("LUNAUSDT").DoNormalized(context.Background())
+	s.ErrorIs(err, ErrSymbolNotTrading)
+	s.Zero(s.orders)
+
+	_, err = s.newOrder("BTCUSDT").Do(context.Background())
+	s.NoError(err)
+	s.Equal(1, s.orders)
+
+	// the guard is overridden per order
+	_, err = s.newOrder("LUNAUSDT").CheckSymbolStatus(false).Do(context.Background())
+	s.NoError(err)
+	s.Equal(2, s.orders)
+}
+
+func (s *symbolStatusTestSuite) TestOrderGuard() {
+	_, err := s.newOrder("LUNAUSDT").CheckSymbolStatus(true).Do(context.Background())
+	s.Equal(&SymbolNotTradingError{Symbol: "LUNAUSDT", Status: SymbolStatusBreak}, err)
+	_, err = s.newOrder("BTCUSD").CheckSymbolStatus(true).Do(context.Background())
+	s.ErrorIs(err, ErrSymbolNotFound)
+	s.Zero(s.orders)
+
+	_, err = s.newOrder("btcusdt").CheckSymbolStatus(true).Do(context.Background())
+	s.NoError(err, "a lower case symbol is checked like it is sent")
+	s.Equal(1, s.orders)
+}
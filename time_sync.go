@@ -0,0 +1,41 @@
+package binance_connector
+
+import (
+	"context"
+	"time"
+)
+
+// ServerClock return the current time of the Binance server, e.g. Client.ServerTime or WebsocketAPIClient.ServerTime
+type ServerClock func(ctx context.Context) (time.Time, error)
+
+// ServerTime return the time of the server from the REST time endpoint
+func (c *Client) ServerTime(ctx context.Context) (time.Time, error) {
+	res, err := c.NewServerTimeService().Do(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(int64(res.ServerTime)), nil
+}
+
+// SyncTime set TimeOffset to the difference between the local clock, or Clock, and the server clock read with
+// source, so the timestamps of the signed requests follow the server. The server time is compared with the
+// middle of the round trip. A nil source uses the REST time endpoint. Call it before sending the requests
+// it applies to, TimeOffset is not synchronized.
+func (c *Client) SyncTime(ctx context.Context, source ServerClock) (offset time.Duration, err error) {
+	if source == nil {
+		source = c.ServerTime
+	}
+	now := time.Now
+	if c.Clock != nil {
+		now = c.Clock
+	}
+	start := now()
+	serverTime, err := source(ctx)
+	if err != nil {
+		return 0, err
+	}
+	end := now()
+	offset = start.Add(end.Sub(start) / 2).Sub(serverTime)
+	c.TimeOffset = offset.Milliseconds()
+	return offset, nil
+}
@@ -0,0 +1,66 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type timeSyncTestSuite struct {
+	suite.Suite
+	client *Client
+}
+
+func TestTimeSync(t *testing.T) {
+	suite.Run(t, new(timeSyncTestSuite))
+}
+
+func (s *timeSyncTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+}
+
+func (s *timeSyncTestSuite) TestSyncTime() {
+	c := s.client
+	local := time.UnixMilli(1499827319559)
+	c.Clock = func() time.Time { return local }
+	c.do = func(req *http.Request) (*http.Response, error) {
+		s.Equal("/api/v3/time", req.URL.Path)
+		serverTime := local.Add(-1500 * time.Millisecond).UnixMilli()
+		return newHTTPResponse([]byte(`{"serverTime":`+strconv.FormatInt(serverTime, 10)+`}`), http.StatusOK), nil
+	}
+
+	offset, err := c.SyncTime(newContext(), nil)
+	s.Require().NoError(err)
+	s.Equal(1500*time.Millisecond, offset)
+	s.Equal(int64(1500), c.TimeOffset)
+}
+
+func (s *timeSyncTestSuite) TestSyncTimeSource() {
+	c := s.client
+	start := time.UnixMilli(1499827319559)
+	calls := 0
+	c.Clock = func() time.Time {
+		calls++
+		return start.Add(time.Duration(calls-1) * 200 * time.Millisecond)
+	}
+
+	// the server time is compared with the middle of the round trip
+	offset, err := c.SyncTime(newContext(), func(ctx context.Context) (time.Time, error) {
+		return start.Add(300 * time.Millisecond), nil
+	})
+	s.Require().NoError(err)
+	s.Equal(-200*time.Millisecond, offset)
+	s.Equal(int64(-200), c.TimeOffset)
+
+	c.TimeOffset = 42
+	_, err = c.SyncTime(newContext(), func(ctx context.Context) (time.Time, error) {
+		return time.Time{}, errors.New("connection closed")
+	})
+	s.EqualError(err, "connection closed")
+	s.Equal(int64(42), c.TimeOffset, "a failed sync keeps the offset")
+}
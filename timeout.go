@@ -0,0 +1,40 @@
+package binance_connector
+
+import (
+	"context"
+	"time"
+)
+
+// SetDefaultTimeout set the timeout of every REST request sent with a context without a deadline, zero disables it.
+// A deadline already set on the context of the request is kept, even when it is later than the timeout.
+func (c *Client) SetDefaultTimeout(timeout time.Duration) *Client {
+	c.defaultTimeout = timeout
+	return c
+}
+
+// SetEndpointTimeout set the timeout of the requests to an endpoint, e.g. http.MethodPost and "/api/v3/order" for
+// a tighter order placement timeout than the default one. It overrides SetDefaultTimeout, zero removes the override.
+func (c *Client) SetEndpointTimeout(method, endpoint string, timeout time.Duration) *Client {
+	if timeout == 0 {
+		c.endpointTimeouts.Delete(method + " " + endpoint)
+		return c
+	}
+	c.endpointTimeouts.Store(method+" "+endpoint, timeout)
+	return c
+}
+
+// withRequestTimeout return a copy of ctx canceled after the timeout of the request, ctx itself when it already has
+// a deadline or no timeout is set
+func (c *Client) withRequestTimeout(ctx context.Context, r *request) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	timeout := c.defaultTimeout
+	if t, ok := c.endpointTimeouts.Load(r.method + " " + r.endpoint); ok {
+		timeout = t.(time.Duration)
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
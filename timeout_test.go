@@ -0,0 +1,70 @@
+package binance_connector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type timeoutTestSuite struct {
+	suite.Suite
+	client *Client
+}
+
+func TestTimeout(t *testing.T) {
+	suite.Run(t, new(timeoutTestSuite))
+}
+
+func (s *timeoutTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+}
+
+func (s *timeoutTestSuite) TestRequestTimeout() {
+	c := s.client
+	var deadline time.Time
+	var hasDeadline bool
+	c.do = func(req *http.Request) (*http.Response, error) {
+		deadline, hasDeadline = req.Context().Deadline()
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+
+	err := c.NewPingService().Do(context.Background())
+	s.Require().NoError(err)
+	s.False(hasDeadline)
+
+	c.SetDefaultTimeout(time.Minute).SetEndpointTimeout(http.MethodPost, "/api/v3/order", time.Second)
+	err = c.NewPingService().Do(context.Background())
+	s.Require().NoError(err)
+	s.Require().True(hasDeadline)
+	s.WithinDuration(time.Now().Add(time.Minute), deadline, 5*time.Second)
+
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).NewOrderRespType("ACK").Do(context.Background())
+	s.Require().NoError(err)
+	s.Require().True(hasDeadline)
+	s.WithinDuration(time.Now().Add(time.Second), deadline, 500*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).NewOrderRespType("ACK").Do(ctx)
+	s.Require().NoError(err)
+	expected, _ := ctx.Deadline()
+	s.Equal(expected, deadline)
+
+	c.SetEndpointTimeout(http.MethodPost, "/api/v3/order", 0)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side("BUY").Type("MARKET").Quantity(1).NewOrderRespType("ACK").Do(context.Background())
+	s.Require().NoError(err)
+	s.WithinDuration(time.Now().Add(time.Minute), deadline, 5*time.Second)
+}
+
+func (s *timeoutTestSuite) TestRequestTimeoutExpires() {
+	c := s.client.SetDefaultTimeout(10 * time.Millisecond)
+	c.do = func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+	err := c.NewPingService().Do(context.Background())
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
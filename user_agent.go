@@ -0,0 +1,29 @@
+package binance_connector
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// appName is prepended to the User-Agent of every request and websocket handshake
+var appName atomic.Value
+
+// SetAppName set the application name prepended to the User-Agent, e.g. "mybot/1.2".
+// The connector name and version stay in the User-Agent, an empty name removes the application name.
+func SetAppName(name string) {
+	appName.Store(name)
+}
+
+// AppName return the application name set with SetAppName
+func AppName() string {
+	name, _ := appName.Load().(string)
+	return name
+}
+
+// UserAgent return the User-Agent sent to Binance, e.g. "mybot/1.2 binance-connector-go/0.7.0"
+func UserAgent() string {
+	if name := AppName(); name != "" {
+		return fmt.Sprintf("%s %s/%s", name, Name, Version)
+	}
+	return fmt.Sprintf("%s/%s", Name, Version)
+}
@@ -0,0 +1,62 @@
+package binance_connector
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type userAgentTestSuite struct {
+	suite.Suite
+}
+
+func TestUserAgent(t *testing.T) {
+	suite.Run(t, new(userAgentTestSuite))
+}
+
+func (s *userAgentTestSuite) TearDownTest() {
+	SetAppName("")
+}
+
+func (s *userAgentTestSuite) TestUserAgent() {
+	s.Equal("binance-connector-go/"+Version, UserAgent())
+	SetAppName("mybot/1.2")
+	s.Equal("mybot/1.2", AppName())
+	s.Equal("mybot/1.2 binance-connector-go/"+Version, UserAgent())
+}
+
+func (s *userAgentTestSuite) TestRESTRequest() {
+	SetAppName("mybot/1.2")
+	var userAgent string
+	c := NewPublicClient("https://dummyapi.com")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		userAgent = req.Header.Get("User-Agent")
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+	s.Require().NoError(c.NewPingService().Do(newContext()))
+	s.Equal("mybot/1.2 binance-connector-go/"+Version, userAgent)
+}
+
+func (s *userAgentTestSuite) TestWebsocketHandshake() {
+	SetAppName("mybot/1.2")
+	userAgent := make(chan string, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent <- r.Header.Get("User-Agent")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	doneCh, _, err := wsServe(newWsConfig("ws"+strings.TrimPrefix(server.URL, "http")), func(message []byte) {}, func(err error) {})
+	s.Require().NoError(err)
+	<-doneCh
+	s.Equal("mybot/1.2 binance-connector-go/"+Version, <-userAgent)
+}
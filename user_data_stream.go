@@ -0,0 +1,246 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/luciano-personal-org/binance-connector/handlers"
+)
+
+// errCodeListenKeyNotFound is the error of a keepalive on an expired or deleted listen key
+const errCodeListenKeyNotFound = -1125
+
+var (
+	// UserDataKeepaliveInterval is the default delay between two keepalives of the listen key of a UserDataStream,
+	// Binance expires a listen key that was not kept alive for 60 minutes
+	UserDataKeepaliveInterval = time.Minute * 30
+	// UserDataListenKeyValidity is how long Binance keeps a listen key valid after its creation or last keepalive
+	UserDataListenKeyValidity = time.Minute * 60
+	// UserDataExpiryWarning is the default time before the expiry of the listen key of a UserDataStream at which
+	// a warning is logged when no keepalive succeeded since
+	UserDataExpiryWarning = time.Minute * 10
+	// UserDataReconnectDelay is the default delay before a UserDataStream retries a failed reconnection
+	UserDataReconnectDelay = time.Second * 5
+	// UserDataReconnectMaxDelay caps the growing delay between the failed reconnections of a UserDataStream
+	UserDataReconnectMaxDelay = time.Minute
+)
+
+// ListenKeyRotatedHandler handle the replacement of the listen key of a user data stream
+type ListenKeyRotatedHandler func(oldListenKey, newListenKey string)
+
+// UserDataStream serve the spot user data stream and keep it alive: the listen key is kept alive every
+// keepalive interval, and when the connection ends, Binance sends listenKeyExpired or a keepalive finds the key
+// expired, a listen key is created again and the stream reconnected. Events sent while disconnected are lost,
+// resync the account state from REST after a rotation if they matter.
+type UserDataStream struct {
+	c                 *Client
+	wsClient          *WebsocketStreamClient
+	handler           WsUserDataHandler
+	errHandler        ErrHandler
+	keepaliveInterval time.Duration
+	reconnectBackoff  Backoff
+	onRotated         ListenKeyRotatedHandler
+	expiryWarning     time.Duration
+
+	mu        sync.Mutex
+	listenKey string
+	// renewedAt is when the listen key was last created or kept alive
+	renewedAt time.Time
+	expired   chan struct{}
+}
+
+// NewUserDataStream create a user data stream of the account of client, served by the client connections
+func (c *WebsocketStreamClient) NewUserDataStream(client *Client, handler WsUserDataHandler, errHandler ErrHandler) *UserDataStream {
+	return &UserDataStream{
+		c:                 client,
+		wsClient:          c,
+		handler:           handler,
+		errHandler:        errHandler,
+		keepaliveInterval: UserDataKeepaliveInterval,
+		reconnectBackoff:  Backoff{Base: UserDataReconnectDelay, Max: UserDataReconnectMaxDelay, Jitter: JitterEqual},
+		expiryWarning:     UserDataExpiryWarning,
+		expired:           make(chan struct{}, 1),
+	}
+}
+
+// KeepaliveInterval set the delay between two keepalives of the listen key
+func (s *UserDataStream) KeepaliveInterval(interval time.Duration) *UserDataStream {
+	s.keepaliveInterval = interval
+	return s
+}
+
+// ReconnectDelay set a constant delay before a failed reconnection is retried
+func (s *UserDataStream) ReconnectDelay(delay time.Duration) *UserDataStream {
+	s.reconnectBackoff = Backoff{Base: delay, Multiplier: 1}
+	return s
+}
+
+// ReconnectBackoff set the delays between failed reconnections, by default they grow from UserDataReconnectDelay
+// to UserDataReconnectMaxDelay with equal jitter
+func (s *UserDataStream) ReconnectBackoff(backoff Backoff) *UserDataStream {
+	s.reconnectBackoff = backoff
+	return s
+}
+
+// ExpiryWarning set how long before the expiry of the listen key a warning is logged by the client Logger when
+// no keepalive succeeded meanwhile, revealing failing keepalives before the stream ends. Zero disables it.
+func (s *UserDataStream) ExpiryWarning(threshold time.Duration) *UserDataStream {
+	s.expiryWarning = threshold
+	return s
+}
+
+// OnListenKeyRotated set the handler called when the stream reconnected with another listen key
+func (s *UserDataStream) OnListenKeyRotated(handler ListenKeyRotatedHandler) *UserDataStream {
+	s.onRotated = handler
+	return s
+}
+
+// ListenKey return the listen key of the current connection
+func (s *UserDataStream) ListenKey() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listenKey
+}
+
+// RenewedAt return when the listen key was last created or successfully kept alive, zero before Start
+func (s *UserDataStream) RenewedAt() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.renewedAt
+}
+
+// TimeUntilExpiry return how long the listen key stays valid without another keepalive, zero before Start
+// and once expired
+func (s *UserDataStream) TimeUntilExpiry() time.Duration {
+	renewedAt := s.RenewedAt()
+	if renewedAt.IsZero() {
+		return 0
+	}
+	return max(UserDataListenKeyValidity-time.Since(renewedAt), 0)
+}
+
+// renewed record a successful creation or keepalive of the listen key
+func (s *UserDataStream) renewed() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.renewedAt = time.Now()
+}
+
+// resetExpiryWarning arm warning to fire expiryWarning before the listen key expires
+func (s *UserDataStream) resetExpiryWarning(warning *time.Timer) {
+	if s.expiryWarning <= 0 {
+		warning.Stop()
+		return
+	}
+	warning.Reset(s.TimeUntilExpiry() - s.expiryWarning)
+}
+
+// Start create a listen key and connect the stream, then keep it alive until ctx is done.
+// doneCh is closed once the stream stopped. An error is returned when the first connection fails,
+// later failures are reported to the error handler and retried.
+func (s *UserDataStream) Start(ctx context.Context) (doneCh chan struct{}, err error) {
+	if s.handler == nil {
+		return nil, ErrNilHandler
+	}
+	connDone, connStop, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	doneCh = make(chan struct{})
+	go s.run(ctx, doneCh, connDone, connStop)
+	return doneCh, nil
+}
+
+// run own the listen key, so that keepalives and rotations never race
+func (s *UserDataStream) run(ctx context.Context, doneCh, connDone, connStop chan struct{}) {
+	defer close(doneCh)
+	ticker := time.NewTicker(s.keepaliveInterval)
+	defer ticker.Stop()
+	warning := time.NewTimer(0)
+	defer warning.Stop()
+	s.resetExpiryWarning(warning)
+	for {
+		select {
+		case <-ctx.Done():
+			connStop <- struct{}{}
+			<-connDone
+			return
+		case <-ticker.C:
+			err := s.c.NewKeepaliveUserStreamService().ListenKey(s.ListenKey()).Do(ctx)
+			if err == nil {
+				s.renewed()
+				s.resetExpiryWarning(warning)
+				continue
+			}
+			if !isListenKeyNotFound(err) {
+				s.errHandler.report(err)
+				continue
+			}
+			connStop <- struct{}{}
+			<-connDone
+		case <-warning.C:
+			s.c.Logger.Printf("WARNING: the listen key of the user data stream expires in %s, no keepalive succeeded since %s",
+				s.TimeUntilExpiry().Round(time.Second), s.RenewedAt().Format(time.RFC3339))
+			continue
+		case <-s.expired:
+			connStop <- struct{}{}
+			<-connDone
+		case <-connDone:
+		}
+		// a listenKeyExpired received before the connection ended is handled by this reconnection
+		select {
+		case <-s.expired:
+		default:
+		}
+		var err error
+		for attempt := 0; ; attempt++ {
+			connDone, connStop, err = s.connect(ctx)
+			if err == nil {
+				break
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			s.errHandler.report(err)
+			if sleepContext(ctx, s.reconnectBackoff.Delay(attempt)) != nil {
+				return
+			}
+		}
+		ticker.Reset(s.keepaliveInterval)
+		s.resetExpiryWarning(warning)
+	}
+}
+
+// connect create a listen key, which Binance returns unchanged while the current one is valid, and serve it
+func (s *UserDataStream) connect(ctx context.Context) (doneCh, stopCh chan struct{}, err error) {
+	res, err := s.c.NewCreateUserStreamService().Do(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.mu.Lock()
+	old := s.listenKey
+	s.listenKey = res.ListenKey
+	s.renewedAt = time.Now()
+	s.mu.Unlock()
+	if old != "" && old != res.ListenKey && s.onRotated != nil {
+		s.onRotated(old, res.ListenKey)
+	}
+	return s.wsClient.WsUserDataServe(res.ListenKey, s.handleEvent, s.errHandler)
+}
+
+func (s *UserDataStream) handleEvent(event *WsUserDataEvent) {
+	if event.Event == UserDataEventTypeListenKeyExpired {
+		select {
+		case s.expired <- struct{}{}:
+		default:
+		}
+	}
+	s.handler(event)
+}
+
+func isListenKeyNotFound(err error) bool {
+	var apiErr *handlers.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == errCodeListenKeyNotFound
+}
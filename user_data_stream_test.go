@@ -0,0 +1,276 @@
+package binance_connector
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type userDataStreamTestSuite struct {
+	suite.Suite
+	client *Client
+	mu     sync.Mutex
+	keys   []string
+	// keepaliveErr is the response of the keepalive requests, nil answers {}
+	keepaliveErr []byte
+	keepalives   int
+	// connections receive the listen key of each stream connection and the connection
+	connections chan userDataConnection
+}
+
+type userDataConnection struct {
+	listenKey string
+	conn      *websocket.Conn
+	closed    chan struct{}
+}
+
+func TestUserDataStream(t *testing.T) {
+	suite.Run(t, new(userDataStreamTestSuite))
+}
+
+func (s *userDataStreamTestSuite) SetupTest() {
+	s.keys = nil
+	s.keepaliveErr = nil
+	s.keepalives = 0
+	s.connections = make(chan userDataConnection, 8)
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.client.SuppressDeprecationWarnings = true
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch req.Method {
+		case http.MethodPost:
+			key := s.keys[0]
+			if len(s.keys) > 1 {
+				s.keys = s.keys[1:]
+			}
+			return newHTTPResponse([]byte(`{"listenKey": "`+key+`"}`), http.StatusOK), nil
+		case http.MethodPut:
+			s.keepalives++
+			if s.keepaliveErr != nil {
+				return newHTTPResponse(s.keepaliveErr, http.StatusBadRequest), nil
+			}
+		}
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+}
+
+// setKeys set the listen keys returned by the next create requests, the last one is repeated
+func (s *userDataStreamTestSuite) setKeys(keys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *userDataStreamTestSuite) newServer() (*httptest.Server, *WebsocketStreamClient) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		closed := make(chan struct{})
+		s.connections <- userDataConnection{listenKey: strings.TrimPrefix(r.URL.Path, "/ws/"), conn: conn, closed: closed}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				close(closed)
+				return
+			}
+		}
+	}))
+	return server, NewWebsocketStreamClient(false, "ws"+strings.TrimPrefix(server.URL, "http"))
+}
+
+func (s *userDataStreamTestSuite) nextConnection() userDataConnection {
+	select {
+	case c := <-s.connections:
+		return c
+	case <-time.After(5 * time.Second):
+		s.FailNow("the stream did not connect")
+	}
+	return userDataConnection{}
+}
+
+func (s *userDataStreamTestSuite) TestReconnectWithSameKey() {
+	server, wsClient := s.newServer()
+	defer server.Close()
+	s.setKeys("key1")
+
+	events := make(chan *WsUserDataEvent, 4)
+	rotated := make(chan string, 4)
+	stream := wsClient.NewUserDataStream(s.client, func(event *WsUserDataEvent) { events <- event }, func(err error) {}).
+		ReconnectDelay(time.Millisecond).
+		OnListenKeyRotated(func(oldKey, newKey string) { rotated <- oldKey + ">" + newKey })
+	ctx, cancel := context.WithCancel(context.Background())
+	doneCh, err := stream.Start(ctx)
+	s.Require().NoError(err)
+	s.Equal("key1", stream.ListenKey())
+
+	conn := s.nextConnection()
+	s.Equal("key1", conn.listenKey)
+	conn.conn.Close()
+
+	// the listen key is still valid, Binance returns it again
+	conn = s.nextConnection()
+	s.Equal("key1", conn.listenKey)
+	s.Require().NoError(conn.conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"balanceUpdate","a":"BTC","d":"1.0"}`)))
+	s.Equal("BTC", (<-events).BalanceUpdate.Asset)
+	s.Empty(rotated)
+
+	cancel()
+	<-doneCh
+	<-conn.closed
+}
+
+func (s *userDataStreamTestSuite) TestKeyAlreadyExpired() {
+	server, wsClient := s.newServer()
+	defer server.Close()
+	s.setKeys("key1", "key2")
+
+	events := make(chan *WsUserDataEvent, 4)
+	rotated := make(chan string, 4)
+	stream := wsClient.NewUserDataStream(s.client, func(event *WsUserDataEvent) { events <- event }, func(err error) {}).
+		OnListenKeyRotated(func(oldKey, newKey string) { rotated <- oldKey + ">" + newKey })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	doneCh, err := stream.Start(ctx)
+	s.Require().NoError(err)
+
+	conn := s.nextConnection()
+	s.Require().NoError(conn.conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"listenKeyExpired","E":1576653824250}`)))
+	s.Equal(UserDataEventTypeListenKeyExpired, (<-events).Event)
+	<-conn.closed
+
+	conn = s.nextConnection()
+	s.Equal("key2", conn.listenKey)
+	s.Equal("key1>key2", <-rotated)
+	s.Equal("key2", stream.ListenKey())
+
+	cancel()
+	<-doneCh
+}
+
+func (s *userDataStreamTestSuite) TestKeepaliveFindsKeyExpired() {
+	server, wsClient := s.newServer()
+	defer server.Close()
+	s.setKeys("key1", "key2")
+	s.keepaliveErr = []byte(`{"code": -1125, "msg": "This listenKey does not exist."}`)
+
+	rotated := make(chan string, 4)
+	stream := wsClient.NewUserDataStream(s.client, func(event *WsUserDataEvent) {}, func(err error) {}).
+		KeepaliveInterval(20 * time.Millisecond).
+		OnListenKeyRotated(func(oldKey, newKey string) { rotated <- oldKey + ">" + newKey })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	doneCh, err := stream.Start(ctx)
+	s.Require().NoError(err)
+
+	conn := s.nextConnection()
+	<-conn.closed
+	s.Equal("key2", s.nextConnection().listenKey)
+	s.Equal("key1>key2", <-rotated)
+
+	cancel()
+	<-doneCh
+}
+
+func (s *userDataStreamTestSuite) TestKeepaliveError() {
+	server, wsClient := s.newServer()
+	defer server.Close()
+	s.setKeys("key1")
+	s.keepaliveErr = []byte(`{"code": -1001, "msg": "Internal error; unable to process your request. Please try again."}`)
+
+	errs := make(chan error, 8)
+	stream := wsClient.NewUserDataStream(s.client, func(event *WsUserDataEvent) {}, func(err error) { errs <- err }).
+		KeepaliveInterval(20 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	doneCh, err := stream.Start(ctx)
+	s.Require().NoError(err)
+	conn := s.nextConnection()
+
+	// other keepalive errors are reported and the connection kept
+	s.Contains((<-errs).Error(), "-1001")
+	s.Empty(s.connections)
+	cancel()
+	<-doneCh
+	<-conn.closed
+}
+
+func (s *userDataStreamTestSuite) TestExpiryWarning() {
+	defer func(validity time.Duration) { UserDataListenKeyValidity = validity }(UserDataListenKeyValidity)
+	UserDataListenKeyValidity = 300 * time.Millisecond
+	server, wsClient := s.newServer()
+	defer server.Close()
+	s.setKeys("key1")
+	s.keepaliveErr = []byte(`{"code": -1001, "msg": "Internal error; unable to process your request. Please try again."}`)
+	var logs bytes.Buffer
+	s.client.Logger = log.New(&logs, "", 0)
+
+	stream := wsClient.NewUserDataStream(s.client, func(event *WsUserDataEvent) {}, func(err error) {}).
+		KeepaliveInterval(20 * time.Millisecond).
+		ExpiryWarning(250 * time.Millisecond)
+	s.Zero(stream.TimeUntilExpiry())
+	s.Zero(stream.RenewedAt())
+	ctx, cancel := context.WithCancel(context.Background())
+	doneCh, err := stream.Start(ctx)
+	s.Require().NoError(err)
+	renewedAt := stream.RenewedAt()
+	s.WithinDuration(time.Now(), renewedAt, time.Second)
+	s.Positive(stream.TimeUntilExpiry())
+	s.LessOrEqual(stream.TimeUntilExpiry(), 300*time.Millisecond)
+	conn := s.nextConnection()
+
+	// the warning is logged 50ms after the creation, once
+	time.Sleep(150 * time.Millisecond)
+	cancel()
+	<-doneCh
+	<-conn.closed
+	s.Equal(renewedAt, stream.RenewedAt(), "the failed keepalives do not renew the listen key")
+	s.Equal(1, strings.Count(logs.String(), "WARNING: the listen key of the user data stream expires in"), logs.String())
+}
+
+func (s *userDataStreamTestSuite) TestExpiryWarningRenewed() {
+	defer func(validity time.Duration) { UserDataListenKeyValidity = validity }(UserDataListenKeyValidity)
+	UserDataListenKeyValidity = time.Second
+	server, wsClient := s.newServer()
+	defer server.Close()
+	s.setKeys("key1")
+	var logs bytes.Buffer
+	s.client.Logger = log.New(&logs, "", 0)
+
+	stream := wsClient.NewUserDataStream(s.client, func(event *WsUserDataEvent) {}, func(err error) {}).
+		KeepaliveInterval(20 * time.Millisecond).
+		ExpiryWarning(800 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	doneCh, err := stream.Start(ctx)
+	s.Require().NoError(err)
+	createdAt := stream.RenewedAt()
+	conn := s.nextConnection()
+
+	time.Sleep(300 * time.Millisecond)
+	cancel()
+	<-doneCh
+	<-conn.closed
+	s.True(stream.RenewedAt().After(createdAt), "the keepalives renew the listen key")
+	s.Empty(logs.String())
+}
+
+func (s *userDataStreamTestSuite) TestStartError() {
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(`{"code": -2015, "msg": "Invalid API-key, IP, or permissions for action."}`), http.StatusUnauthorized), nil
+	}
+	stream := NewWebsocketStreamClient(false, "ws://127.0.0.1:1").NewUserDataStream(s.client, func(event *WsUserDataEvent) {}, func(err error) {})
+	_, err := stream.Start(context.Background())
+	s.Error(err)
+	s.Empty(stream.ListenKey())
+}
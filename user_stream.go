@@ -2,9 +2,18 @@ package binance_connector
 
 import (
 	"context"
+	"errors"
 	"net/http"
 )
 
+// ErrUserStreamSymbolRequired is returned when an isolated margin user stream request has no symbol
+var ErrUserStreamSymbolRequired = errors.New("symbol is required for isolated margin user streams")
+
+const (
+	marginUserStreamEndpoint         = "/sapi/v1/userDataStream"
+	isolatedMarginUserStreamEndpoint = "/sapi/v1/userDataStream/isolated"
+)
+
 // Create Listen Key
 type CreateListenKey struct {
 	c *Client
@@ -12,10 +21,16 @@ type CreateListenKey struct {
 
 // Do send request
 func (s *CreateListenKey) Do(ctx context.Context, opts ...RequestOption) (listenKey string, err error) {
+	return s.do(ctx, "CreateListenKey", opts...)
+}
+
+// do create a listen key for service, CreateUserStreamService sends the same request
+func (s *CreateListenKey) do(ctx context.Context, service string, opts ...RequestOption) (listenKey string, err error) {
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: "/api/v3/userDataStream",
-		secType:  secTypeAPIKey,
+		secType:  SecurityTypeUserStream,
+		service:  service,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -43,10 +58,16 @@ func (s *PingUserStream) ListenKey(listenKey string) *PingUserStream {
 
 // Do send request
 func (s *PingUserStream) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	return s.do(ctx, "PingUserStream", opts...)
+}
+
+// do extend the listen key for service, KeepaliveUserStreamService sends the same request
+func (s *PingUserStream) do(ctx context.Context, service string, opts ...RequestOption) (err error) {
 	r := &request{
 		method:   http.MethodPut,
 		endpoint: "/api/v3/userDataStream",
-		secType:  secTypeAPIKey,
+		secType:  SecurityTypeUserStream,
+		service:  service,
 	}
 	r.setParam("listenKey", s.listenKey)
 	_, err = s.c.callAPI(ctx, r, opts...)
@@ -70,8 +91,236 @@ func (s *CloseUserStream) Do(ctx context.Context, opts ...RequestOption) (err er
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: "/api/v3/userDataStream",
-		secType:  secTypeAPIKey,
+		secType:  SecurityTypeUserStream,
+		service:  "CloseUserStream",
+	}
+	r.setParam("listenKey", s.listenKey)
+	_, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
+
+// Create User Stream (USER_STREAM)
+// CreateUserStreamService create a listen key, only the API key is sent
+type CreateUserStreamService struct {
+	c *Client
+}
+
+// Do send request
+func (s *CreateUserStreamService) Do(ctx context.Context, opts ...RequestOption) (res *ListenKeyResponse, err error) {
+	listenKey, err := (&CreateListenKey{c: s.c}).do(ctx, "CreateUserStreamService", opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ListenKeyResponse{ListenKey: listenKey}, nil
+}
+
+// ListenKeyResponse define response of CreateUserStreamService
+type ListenKeyResponse struct {
+	ListenKey string `json:"listenKey"`
+}
+
+// Keepalive User Stream (USER_STREAM)
+// KeepaliveUserStreamService extend the validity of a listen key by 60 minutes
+type KeepaliveUserStreamService struct {
+	c         *Client
+	listenKey string
+}
+
+// ListenKey set listenKey
+func (s *KeepaliveUserStreamService) ListenKey(listenKey string) *KeepaliveUserStreamService {
+	s.listenKey = listenKey
+	return s
+}
+
+// Do send request
+func (s *KeepaliveUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	return (&PingUserStream{c: s.c, listenKey: s.listenKey}).do(ctx, "KeepaliveUserStreamService", opts...)
+}
+
+// Create Margin User Stream (USER_STREAM)
+// CreateMarginUserStreamService create a cross margin listen key
+type CreateMarginUserStreamService struct {
+	c *Client
+}
+
+// Do send request
+func (s *CreateMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (res *ListenKeyResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: marginUserStreamEndpoint,
+		secType:  SecurityTypeUserStream,
+		service:  "CreateMarginUserStreamService",
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ListenKeyResponse)
+	err = s.c.unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Keepalive Margin User Stream (USER_STREAM)
+// KeepaliveMarginUserStreamService extend the validity of a cross margin listen key by 60 minutes
+type KeepaliveMarginUserStreamService struct {
+	c         *Client
+	listenKey string
+}
+
+// ListenKey set listenKey
+func (s *KeepaliveMarginUserStreamService) ListenKey(listenKey string) *KeepaliveMarginUserStreamService {
+	s.listenKey = listenKey
+	return s
+}
+
+// Do send request
+func (s *KeepaliveMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: marginUserStreamEndpoint,
+		secType:  SecurityTypeUserStream,
+		service:  "KeepaliveMarginUserStreamService",
+	}
+	r.setParam("listenKey", s.listenKey)
+	_, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
+
+// Close Margin User Stream (USER_STREAM)
+// CloseMarginUserStreamService delete a cross margin listen key
+type CloseMarginUserStreamService struct {
+	c         *Client
+	listenKey string
+}
+
+// ListenKey set listenKey
+func (s *CloseMarginUserStreamService) ListenKey(listenKey string) *CloseMarginUserStreamService {
+	s.listenKey = listenKey
+	return s
+}
+
+// Do send request
+func (s *CloseMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: marginUserStreamEndpoint,
+		secType:  SecurityTypeUserStream,
+		service:  "CloseMarginUserStreamService",
+	}
+	r.setParam("listenKey", s.listenKey)
+	_, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
+
+// Create Isolated Margin User Stream (USER_STREAM)
+// CreateIsolatedMarginUserStreamService create an isolated margin listen key for one symbol
+type CreateIsolatedMarginUserStreamService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *CreateIsolatedMarginUserStreamService) Symbol(symbol string) *CreateIsolatedMarginUserStreamService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *CreateIsolatedMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (res *ListenKeyResponse, err error) {
+	if s.symbol == "" {
+		return nil, ErrUserStreamSymbolRequired
+	}
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: isolatedMarginUserStreamEndpoint,
+		secType:  SecurityTypeUserStream,
+		service:  "CreateIsolatedMarginUserStreamService",
+	}
+	r.setParam("symbol", s.symbol)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ListenKeyResponse)
+	err = s.c.unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Keepalive Isolated Margin User Stream (USER_STREAM)
+// KeepaliveIsolatedMarginUserStreamService extend the validity of an isolated margin listen key by 60 minutes
+type KeepaliveIsolatedMarginUserStreamService struct {
+	c         *Client
+	symbol    string
+	listenKey string
+}
+
+// Symbol set symbol
+func (s *KeepaliveIsolatedMarginUserStreamService) Symbol(symbol string) *KeepaliveIsolatedMarginUserStreamService {
+	s.symbol = symbol
+	return s
+}
+
+// ListenKey set listenKey
+func (s *KeepaliveIsolatedMarginUserStreamService) ListenKey(listenKey string) *KeepaliveIsolatedMarginUserStreamService {
+	s.listenKey = listenKey
+	return s
+}
+
+// Do send request
+func (s *KeepaliveIsolatedMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	if s.symbol == "" {
+		return ErrUserStreamSymbolRequired
+	}
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: isolatedMarginUserStreamEndpoint,
+		secType:  SecurityTypeUserStream,
+		service:  "KeepaliveIsolatedMarginUserStreamService",
+	}
+	r.setParam("symbol", s.symbol)
+	r.setParam("listenKey", s.listenKey)
+	_, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
+
+// Close Isolated Margin User Stream (USER_STREAM)
+// CloseIsolatedMarginUserStreamService delete an isolated margin listen key
+type CloseIsolatedMarginUserStreamService struct {
+	c         *Client
+	symbol    string
+	listenKey string
+}
+
+// Symbol set symbol
+func (s *CloseIsolatedMarginUserStreamService) Symbol(symbol string) *CloseIsolatedMarginUserStreamService {
+	s.symbol = symbol
+	return s
+}
+
+// ListenKey set listenKey
+func (s *CloseIsolatedMarginUserStreamService) ListenKey(listenKey string) *CloseIsolatedMarginUserStreamService {
+	s.listenKey = listenKey
+	return s
+}
+
+// Do send request
+func (s *CloseIsolatedMarginUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	if s.symbol == "" {
+		return ErrUserStreamSymbolRequired
+	}
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: isolatedMarginUserStreamEndpoint,
+		secType:  SecurityTypeUserStream,
+		service:  "CloseIsolatedMarginUserStreamService",
 	}
+	r.setParam("symbol", s.symbol)
 	r.setParam("listenKey", s.listenKey)
 	_, err = s.c.callAPI(ctx, r, opts...)
 	return err
@@ -1,6 +1,7 @@
 package binance_connector
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -57,3 +58,152 @@ func (s *userStreamTestSuite) TestCloseUserStream() {
 	err := s.client.NewCloseUserStream().ListenKey(listenKey).Do(newContext())
 	s.r().NoError(err)
 }
+
+func (s *userStreamTestSuite) TestCreateUserStream() {
+	data := []byte(`{
+        "listenKey": "pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1"
+    }`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		s.r().Equal(http.MethodPost, r.method)
+		s.r().Equal("/api/v3/userDataStream", r.endpoint)
+		s.r().Equal(s.apiKey, r.header.Get("X-MBX-APIKEY"))
+		s.assertRequestEqual(newRequest(), r)
+	})
+
+	res, err := s.client.NewCreateUserStreamService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal("pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1", res.ListenKey)
+}
+
+func (s *userStreamTestSuite) TestKeepaliveUserStreamService() {
+	data := []byte(`{}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	listenKey := "dummykey"
+	s.assertReq(func(r *request) {
+		s.r().Equal(http.MethodPut, r.method)
+		s.r().Equal(s.apiKey, r.header.Get("X-MBX-APIKEY"))
+		s.assertRequestEqual(newRequest().setParam("listenKey", listenKey), r)
+	})
+
+	err := s.client.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *userStreamTestSuite) TestCreateMarginUserStream() {
+	data := []byte(`{"listenKey": "T3ee22BIYuWqmvne0HNq2A2WsFlEtLhvWCtItw6ffhhdmjifQ2tRbuKkTHhr"}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		s.r().Equal(http.MethodPost, r.method)
+		s.r().Equal("/sapi/v1/userDataStream", r.endpoint)
+		s.assertRequestEqual(newRequest(), r)
+	})
+
+	res, err := s.client.NewCreateMarginUserStreamService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal("T3ee22BIYuWqmvne0HNq2A2WsFlEtLhvWCtItw6ffhhdmjifQ2tRbuKkTHhr", res.ListenKey)
+}
+
+func (s *userStreamTestSuite) TestKeepaliveMarginUserStream() {
+	data := []byte(`{}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	listenKey := "dummykey"
+	s.assertReq(func(r *request) {
+		s.r().Equal(http.MethodPut, r.method)
+		s.r().Equal("/sapi/v1/userDataStream", r.endpoint)
+		s.assertRequestEqual(newRequest().setParam("listenKey", listenKey), r)
+	})
+
+	err := s.client.NewKeepaliveMarginUserStreamService().ListenKey(listenKey).Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *userStreamTestSuite) TestCloseMarginUserStream() {
+	data := []byte(`{}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	listenKey := "dummykey"
+	s.assertReq(func(r *request) {
+		s.r().Equal(http.MethodDelete, r.method)
+		s.r().Equal("/sapi/v1/userDataStream", r.endpoint)
+		s.assertRequestEqual(newRequest().setParam("listenKey", listenKey), r)
+	})
+
+	err := s.client.NewCloseMarginUserStreamService().ListenKey(listenKey).Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *userStreamTestSuite) TestCreateIsolatedMarginUserStream() {
+	data := []byte(`{"listenKey": "T3ee22BIYuWqmvne0HNq2A2WsFlEtLhvWCtItw6ffhhdmjifQ2tRbuKkTHhr"}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		s.r().Equal(http.MethodPost, r.method)
+		s.r().Equal("/sapi/v1/userDataStream/isolated", r.endpoint)
+		s.assertRequestEqual(newRequest().setParam("symbol", "BTCUSDT"), r)
+	})
+
+	res, err := s.client.NewCreateIsolatedMarginUserStreamService().Symbol("BTCUSDT").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal("T3ee22BIYuWqmvne0HNq2A2WsFlEtLhvWCtItw6ffhhdmjifQ2tRbuKkTHhr", res.ListenKey)
+}
+
+func (s *userStreamTestSuite) TestKeepaliveIsolatedMarginUserStream() {
+	data := []byte(`{}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	listenKey := "dummykey"
+	s.assertReq(func(r *request) {
+		s.r().Equal(http.MethodPut, r.method)
+		s.r().Equal("/sapi/v1/userDataStream/isolated", r.endpoint)
+		s.assertRequestEqual(newRequest().setParams(params{
+			"symbol":    "BTCUSDT",
+			"listenKey": listenKey,
+		}), r)
+	})
+
+	err := s.client.NewKeepaliveIsolatedMarginUserStreamService().Symbol("BTCUSDT").ListenKey(listenKey).Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *userStreamTestSuite) TestCloseIsolatedMarginUserStream() {
+	data := []byte(`{}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	listenKey := "dummykey"
+	s.assertReq(func(r *request) {
+		s.r().Equal(http.MethodDelete, r.method)
+		s.r().Equal("/sapi/v1/userDataStream/isolated", r.endpoint)
+		s.assertRequestEqual(newRequest().setParams(params{
+			"symbol":    "BTCUSDT",
+			"listenKey": listenKey,
+		}), r)
+	})
+
+	err := s.client.NewCloseIsolatedMarginUserStreamService().Symbol("BTCUSDT").ListenKey(listenKey).Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *userStreamTestSuite) TestIsolatedMarginUserStreamRequiresSymbol() {
+	_, err := s.client.NewCreateIsolatedMarginUserStreamService().Do(newContext())
+	s.r().ErrorIs(err, ErrUserStreamSymbolRequired)
+
+	err = s.client.NewKeepaliveIsolatedMarginUserStreamService().ListenKey("dummykey").Do(newContext())
+	s.r().ErrorIs(err, ErrUserStreamSymbolRequired)
+
+	err = s.client.NewCloseIsolatedMarginUserStreamService().ListenKey("dummykey").Do(newContext())
+	s.r().ErrorIs(err, ErrUserStreamSymbolRequired)
+	s.client.AssertNotCalled(s.T(), "do", anyHTTPRequest())
+}
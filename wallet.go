@@ -2,8 +2,8 @@ package binance_connector
 
 import (
 	"context"
-	"github.com/goccy/go-json"
 	"net/http"
+	"time"
 )
 
 // System Status (System)
@@ -20,14 +20,15 @@ func (s *GetSystemStatusService) Do(ctx context.Context, opts ...RequestOption)
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: systemStatusEndpoint,
-		secType:  secTypeNone,
+		secType:  SecurityTypeNone,
+		service:  "GetSystemStatusService",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = make([]*SystemStatusResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -36,8 +37,8 @@ func (s *GetSystemStatusService) Do(ctx context.Context, opts ...RequestOption)
 
 // SystemStatusResponse define response of GetSystemStatusService
 type SystemStatusResponse struct {
-	Status bool   `json:"status"`
-	Msg    string `json:"msg"`
+	Status SystemStatus `json:"status"`
+	Msg    string       `json:"msg"`
 }
 
 // All Coins' Information (USER_DATA)
@@ -54,14 +55,15 @@ func (s *GetAllCoinsInfoService) Do(ctx context.Context) (res []*CoinInfo, err e
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: allCoinsInfoEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetAllCoinsInfoService",
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 	res = make([]*CoinInfo, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +152,8 @@ func (s *GetAccountSnapshotService) Do(ctx context.Context) (res *AccountSnapsho
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: accountSnapshotEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "GetAccountSnapshotService",
 	}
 	r.setParam("type", s.marketType)
 	if s.startTime != nil {
@@ -167,7 +170,7 @@ func (s *GetAccountSnapshotService) Do(ctx context.Context) (res *AccountSnapsho
 		return nil, err
 	}
 	res = new(AccountSnapshotResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -206,14 +209,15 @@ func (s *DisableFastWithdrawSwitchService) Do(ctx context.Context) (res *Disable
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: disableFastWithdrawSwitchEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "DisableFastWithdrawSwitchService",
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 	res = new(DisableFastWithdrawSwitchResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -239,14 +243,15 @@ func (s *EnableFastWithdrawSwitchService) Do(ctx context.Context) (res *EnableFa
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: enableFastWithdrawSwitchEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "EnableFastWithdrawSwitchService",
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 	res = new(EnableFastWithdrawSwitchResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -275,6 +280,7 @@ type WithdrawService struct {
 	transactionFeeFlag *bool
 	name               *string
 	walletType         *int
+	skipValidation     bool
 }
 
 // Coin set coin
@@ -331,11 +337,31 @@ func (s *WithdrawService) WalletType(walletType int) *WithdrawService {
 	return s
 }
 
+// SkipAddressValidation set whether Do sends the withdrawal without checking the address
+// against the coin configuration with ValidateWithdrawAddress
+func (s *WithdrawService) SkipAddressValidation(skip bool) *WithdrawService {
+	s.skipValidation = skip
+	return s
+}
+
 func (s *WithdrawService) Do(ctx context.Context) (res *WithdrawResponse, err error) {
+	if !s.skipValidation {
+		var network, addressTag string
+		if s.network != nil {
+			network = *s.network
+		}
+		if s.addressTag != nil {
+			addressTag = *s.addressTag
+		}
+		if err := s.c.ValidateWithdrawAddress(ctx, s.coin, network, s.address, addressTag); err != nil {
+			return nil, err
+		}
+	}
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: withdrawEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "WithdrawService",
 	}
 	r.setParam("coin", s.coin)
 	r.setParam("address", s.address)
@@ -363,7 +389,7 @@ func (s *WithdrawService) Do(ctx context.Context) (res *WithdrawResponse, err er
 		return nil, err
 	}
 	res = new(WithdrawResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -438,7 +464,8 @@ func (s *DepositHistoryService) Do(ctx context.Context) (res []*DepositHistoryRe
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: depositHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "DepositHistoryService",
 	}
 	if s.coin != nil {
 		r.setParam("coin", *s.coin)
@@ -466,28 +493,37 @@ func (s *DepositHistoryService) Do(ctx context.Context) (res []*DepositHistoryRe
 		return nil, err
 	}
 	res = make([]*DepositHistoryResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
+// Paginate return a paginator of the records between start and end, in windows of at most 90 days.
+// Filters set on the service apply to every page.
+func (s *DepositHistoryService) Paginate(start, end time.Time) *Paginator[*DepositHistoryResponse] {
+	return NewPaginator(func(ctx context.Context, page Page) ([]*DepositHistoryResponse, error) {
+		s.StartTime(page.StartTime).EndTime(page.EndTime).Offset(page.Offset).Limit(page.Limit)
+		return s.Do(ctx)
+	}, start, end)
+}
+
 // DepositHistoryResponse define response of DepositHistoryService
 type DepositHistoryResponse struct {
-	Id            string `json:"id"`
-	Amount        string `json:"amount"`
-	Coin          string `json:"coin"`
-	Network       string `json:"network"`
-	Status        int    `json:"status"`
-	Address       string `json:"address"`
-	AddressTag    string `json:"addressTag"`
-	TxId          string `json:"txId"`
-	InsertTime    uint64 `json:"insertTime"`
-	TransferType  int    `json:"transferType"`
-	ConfirmTimes  string `json:"confirmTimes"`
-	UnlockConfirm int    `json:"unlockConfirm"`
-	WalletType    int    `json:"walletType"`
+	Id            string        `json:"id"`
+	Amount        string        `json:"amount"`
+	Coin          string        `json:"coin"`
+	Network       string        `json:"network"`
+	Status        DepositStatus `json:"status"`
+	Address       string        `json:"address"`
+	AddressTag    string        `json:"addressTag"`
+	TxId          string        `json:"txId"`
+	InsertTime    uint64        `json:"insertTime"`
+	TransferType  int           `json:"transferType"`
+	ConfirmTimes  string        `json:"confirmTimes"`
+	UnlockConfirm int           `json:"unlockConfirm"`
+	WalletType    int           `json:"walletType"`
 }
 
 // Withdraw History (supporting network) (USER_DATA)
@@ -553,7 +589,8 @@ func (s *WithdrawHistoryService) Do(ctx context.Context) (res []*WithdrawHistory
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: withdrawHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "WithdrawHistoryService",
 	}
 	if s.coin != nil {
 		r.setParam("coin", *s.coin)
@@ -581,30 +618,39 @@ func (s *WithdrawHistoryService) Do(ctx context.Context) (res []*WithdrawHistory
 		return nil, err
 	}
 	res = make([]*WithdrawHistoryResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
+// Paginate return a paginator of the records between start and end, in windows of at most 90 days.
+// Filters set on the service apply to every page.
+func (s *WithdrawHistoryService) Paginate(start, end time.Time) *Paginator[*WithdrawHistoryResponse] {
+	return NewPaginator(func(ctx context.Context, page Page) ([]*WithdrawHistoryResponse, error) {
+		s.StartTime(page.StartTime).EndTime(page.EndTime).Offset(page.Offset).Limit(page.Limit)
+		return s.Do(ctx)
+	}, start, end)
+}
+
 // WithdrawHistoryResponse define response of WithdrawHistoryService
 type WithdrawHistoryResponse struct {
-	Id              string `json:"id"`
-	Amount          string `json:"amount"`
-	TransactionFee  string `json:"transactionFee"`
-	Coin            string `json:"coin"`
-	Status          int    `json:"status"`
-	Address         string `json:"address"`
-	TxId            string `json:"txId"`
-	ApplyTime       string `json:"applyTime"`
-	Network         string `json:"network"`
-	TransferType    int    `json:"transferType"`
-	WithdrawOrderId string `json:"withdrawOrderId"`
-	Info            string `json:"info"`
-	ConfirmNo       int    `json:"confirmNo"`
-	WalletType      int    `json:"walletType"`
-	TxKey           string `json:"txKey"`
+	Id              string         `json:"id"`
+	Amount          string         `json:"amount"`
+	TransactionFee  string         `json:"transactionFee"`
+	Coin            string         `json:"coin"`
+	Status          WithdrawStatus `json:"status"`
+	Address         string         `json:"address"`
+	TxId            string         `json:"txId"`
+	ApplyTime       string         `json:"applyTime"`
+	Network         string         `json:"network"`
+	TransferType    int            `json:"transferType"`
+	WithdrawOrderId string         `json:"withdrawOrderId"`
+	Info            string         `json:"info"`
+	ConfirmNo       int            `json:"confirmNo"`
+	WalletType      int            `json:"walletType"`
+	TxKey           string         `json:"txKey"`
 }
 
 // Deposit Address (supporting network) (USER_DATA)
@@ -635,7 +681,8 @@ func (s *DepositAddressService) Do(ctx context.Context) (res *DepositAddressResp
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: depositAddressEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "DepositAddressService",
 	}
 	r.setParam("coin", s.coin)
 	if s.network != nil {
@@ -646,7 +693,7 @@ func (s *DepositAddressService) Do(ctx context.Context) (res *DepositAddressResp
 		return nil, err
 	}
 	res = new(DepositAddressResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -675,14 +722,15 @@ func (s *AccountStatusService) Do(ctx context.Context) (res *AccountStatusRespon
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: accountStatusEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "AccountStatusService",
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 	res = new(AccountStatusResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -708,14 +756,15 @@ func (s *AccountApiTradingStatusService) Do(ctx context.Context) (res *AccountAp
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: accountApiTradingStatusEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "AccountApiTradingStatusService",
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 	res = new(AccountApiTradingStatusResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -764,7 +813,8 @@ func (s *DustLogService) Do(ctx context.Context) (res *DustLogResponse, err erro
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: dustLogEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "DustLogService",
 	}
 	if s.startTime != nil {
 		r.setParam("startTime", *s.startTime)
@@ -777,7 +827,7 @@ func (s *DustLogService) Do(ctx context.Context) (res *DustLogResponse, err erro
 		return nil, err
 	}
 	res = new(DustLogResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -817,14 +867,15 @@ func (s *AssetDetailService) Do(ctx context.Context) (res *AssetDetailResponse,
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: assetDetailEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "AssetDetailService",
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 	res = new(AssetDetailResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -868,7 +919,8 @@ func (s *DustTransferService) Do(ctx context.Context) (res *DustTransferResponse
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: dustTransferEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "DustTransferService",
 	}
 	for _, a := range s.asset {
 		r.addParam("asset", a)
@@ -878,7 +930,7 @@ func (s *DustTransferService) Do(ctx context.Context) (res *DustTransferResponse
 		return nil, err
 	}
 	res = new(DustTransferResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -944,7 +996,8 @@ func (s *AssetDividendRecordService) Do(ctx context.Context) (res *AssetDividend
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: assetDividendRecordEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "AssetDividendRecordService",
 	}
 	if s.asset != nil {
 		r.setParam("asset", *s.asset)
@@ -963,7 +1016,7 @@ func (s *AssetDividendRecordService) Do(ctx context.Context) (res *AssetDividend
 		return nil, err
 	}
 	res = new(AssetDividendRecordResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1004,7 +1057,8 @@ func (s *AssetDetailV2Service) Do(ctx context.Context) (res *AssetDetailV2Respon
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: assetDetailV2Endpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "AssetDetailV2Service",
 	}
 	if s.asset != nil {
 		r.setParam("asset", *s.asset)
@@ -1014,7 +1068,7 @@ func (s *AssetDetailV2Service) Do(ctx context.Context) (res *AssetDetailV2Respon
 		return nil, err
 	}
 	res = new(AssetDetailV2Response)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1053,7 +1107,8 @@ func (s *TradeFeeService) Do(ctx context.Context) (res []*TradeFeeResponse, err
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: tradeFeeEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "TradeFeeService",
 	}
 	if s.symbol != nil {
 		r.setParam("symbol", *s.symbol)
@@ -1063,7 +1118,7 @@ func (s *TradeFeeService) Do(ctx context.Context) (res []*TradeFeeResponse, err
 		return nil, err
 	}
 	res = make([]*TradeFeeResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -1126,7 +1181,8 @@ func (s *UserUniversalTransferService) Do(ctx context.Context) (res *UserUnivers
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: userUniversalTransferEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "UserUniversalTransferService",
 	}
 	r.setParam("type", s.transferType)
 	r.setParam("asset", s.asset)
@@ -1142,7 +1198,7 @@ func (s *UserUniversalTransferService) Do(ctx context.Context) (res *UserUnivers
 		return nil, err
 	}
 	res = new(UserUniversalTransferResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1217,7 +1273,8 @@ func (s *UserUniversalTransferHistoryService) Do(ctx context.Context) (res *User
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: userUniversalTransferHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "UserUniversalTransferHistoryService",
 	}
 	r.setParam("type", s.transferType)
 	if s.startTime != nil {
@@ -1243,7 +1300,7 @@ func (s *UserUniversalTransferHistoryService) Do(ctx context.Context) (res *User
 		return nil, err
 	}
 	res = new(UserUniversalTransferHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1291,7 +1348,8 @@ func (s *FundingWalletService) Do(ctx context.Context) (res []*FundingWalletResp
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: fundingWalletEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "FundingWalletService",
 	}
 	if s.asset != nil {
 		r.setParam("asset", *s.asset)
@@ -1304,7 +1362,7 @@ func (s *FundingWalletService) Do(ctx context.Context) (res []*FundingWalletResp
 		return nil, err
 	}
 	res = make([]*FundingWalletResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -1349,7 +1407,8 @@ func (s *UserAssetService) Do(ctx context.Context) (res []*UserAssetResponse, er
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: userAssetEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "UserAssetService",
 	}
 	if s.asset != nil {
 		r.setParam("asset", *s.asset)
@@ -1362,7 +1421,7 @@ func (s *UserAssetService) Do(ctx context.Context) (res []*UserAssetResponse, er
 		return nil, err
 	}
 	res = make([]*UserAssetResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = s.c.unmarshal(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -1429,7 +1488,8 @@ func (s *BUSDConvertService) Do(ctx context.Context) (res *BUSDConvertResponse,
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: bUSDConvertEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "BUSDConvertService",
 	}
 	r.setParam("clientTranId", s.clientTranId)
 	r.setParam("asset", s.asset)
@@ -1443,7 +1503,7 @@ func (s *BUSDConvertService) Do(ctx context.Context) (res *BUSDConvertResponse,
 		return nil, err
 	}
 	res = new(BUSDConvertResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1526,7 +1586,8 @@ func (s *BUSDConvertHistoryService) Do(ctx context.Context) (res *BUSDConvertHis
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: bUSDConvertHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "BUSDConvertHistoryService",
 	}
 	r.setParam("startTime", s.startTime)
 	r.setParam("endTime", s.endTime)
@@ -1553,7 +1614,7 @@ func (s *BUSDConvertHistoryService) Do(ctx context.Context) (res *BUSDConvertHis
 		return nil, err
 	}
 	res = new(BUSDConvertHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1639,7 +1700,8 @@ func (s *CloudMiningPaymentHistoryService) Do(ctx context.Context) (res *CloudMi
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: cloudMiningPaymentHistoryEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "CloudMiningPaymentHistoryService",
 	}
 	r.setParam("startTime", s.startTime)
 	r.setParam("endTime", s.endTime)
@@ -1663,7 +1725,7 @@ func (s *CloudMiningPaymentHistoryService) Do(ctx context.Context) (res *CloudMi
 		return nil, err
 	}
 	res = new(CloudMiningPaymentHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1697,14 +1759,15 @@ func (s *APIKeyPermissionService) Do(ctx context.Context) (res *APIKeyPermission
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: apiKeyPermissionEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "APIKeyPermissionService",
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 	res = new(APIKeyPermissionResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1740,14 +1803,15 @@ func (s *AutoConvertStableCoinService) Do(ctx context.Context) (res *AutoConvert
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: autoConvertStableCoinEndpoint,
-		secType:  secTypeSigned,
+		secType:  SecurityTypeUserData,
+		service:  "AutoConvertStableCoinService",
 	}
 	data, err := s.c.callAPI(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 	res = new(AutoConvertStableCoinResponse)
-	err = json.Unmarshal(data, res)
+	err = s.c.unmarshal(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -55,6 +55,7 @@ func (s *walletTestSuite) TestCreateWithdraw() {
 		Amount(amount).
 		TransactionFeeFlag(transactionFeeFlag).
 		Name(name).
+		SkipAddressValidation(true).
 		Do(newContext())
 
 	r := s.r()
@@ -609,7 +610,7 @@ func (s *walletTestSuite) TestDepositHistory() {
 	s.Equal("0.001", resp[0].Amount)
 	s.Equal("BNB", resp[0].Coin)
 	s.Equal("BNB", resp[0].Network)
-	s.Equal(0, resp[0].Status)
+	s.Equal(DepositStatusPending, resp[0].Status)
 	s.Equal("bnb136ns6lfw4zs5hg4n85vdthaad7hq5m4gtkgf23", resp[0].Address)
 	s.Equal("101764890", resp[0].AddressTag)
 	s.Equal("98A3EA560C6B3336D348B6C83F0F95ECE4F1F5919E94BD006E5BF3BF264FACFC", resp[0].TxId)
@@ -860,7 +861,7 @@ func (s *walletTestSuite) TestGetSystemStatus() {
 	data := []byte(`
 	[
 		{
-			"status": true,
+			"status": 0,
 			"msg": "normal"
 		}
 	]
@@ -873,7 +874,7 @@ func (s *walletTestSuite) TestGetSystemStatus() {
 
 	s.r().NoError(err)
 	s.Len(resp, 1)
-	s.Equal(true, resp[0].Status)
+	s.Equal(SystemStatusNormal, resp[0].Status)
 	s.Equal("normal", resp[0].Msg)
 }
 
@@ -1017,7 +1018,7 @@ func (s *walletTestSuite) TestWithdrawHistory() {
 	s.Equal("1.00000000", resp[0].Amount)
 	s.Equal("0.00010000", resp[0].TransactionFee)
 	s.Equal("BTC", resp[0].Coin)
-	s.Equal(6, resp[0].Status)
+	s.Equal(WithdrawStatusCompleted, resp[0].Status)
 	s.Equal("abc123", resp[0].Address)
 	s.Equal("def456", resp[0].TxId)
 	s.Equal("1617233588000", resp[0].ApplyTime)
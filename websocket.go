@@ -1,8 +1,15 @@
 package binance_connector
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -17,16 +24,101 @@ type ErrHandler func(err error)
 // WsConfig webservice configuration
 type WsConfig struct {
 	Endpoint string
+	// TLSConfig is used for wss endpoints, the system roots are trusted when nil
+	TLSConfig *tls.Config
+	// EnableCompression offers permessage-deflate in the handshake, used when the server accepts it
+	EnableCompression bool
+
+	// onConnect is called with the connection once dialed, before it is read
+	onConnect func(c *wsConn)
+	// keepAlive overrides WebsocketKeepalive when not nil, and keepAliveTimeout the keepalive intervals when set
+	keepAlive        *bool
+	keepAliveTimeout time.Duration
+}
+
+// keepAliveConfig return the keepalive of the connection, false when it is disabled
+func (cfg *WsConfig) keepAliveConfig() (keepAliveConfig, bool) {
+	enabled := WebsocketKeepalive
+	if cfg.keepAlive != nil {
+		enabled = *cfg.keepAlive
+	}
+	if !enabled {
+		return keepAliveConfig{}, false
+	}
+	if cfg.keepAliveTimeout > 0 {
+		return keepAliveConfig{
+			pingInterval:     cfg.keepAliveTimeout,
+			pingWriteTimeout: WebsocketPingWriteTimeout,
+			pongTimeout:      cfg.keepAliveTimeout,
+		}, true
+	}
+	return newKeepAliveConfig(WebsocketTimeout), true
 }
 
 type WebsocketStreamClient struct {
 	Endpoint   string
 	IsCombined bool
+	// EnableCompression negotiates permessage-deflate on the stream connections, which reduces the
+	// bandwidth of large messages such as depth snapshots at the cost of CPU. Off by default.
+	EnableCompression bool
+	// DisableSymbolNormalization subscribes the stream names given to a multiplexer or a pool as is, instead of
+	// lower-casing their symbol as Binance expects
+	DisableSymbolNormalization bool
+
+	stateMu sync.Mutex
+	states  map[string]WsConnState
+	// stateNames holds the name each connection reports its state under, a connection is keyed by the
+	// configuration or the multiplexer that dialed it
+	stateNames       map[any]string
+	onStateChange    WsStateChangeHandler
+	onRawMessage     WsRawMessageHandler
+	onConnect        WsConnectHandler
+	keepAlive        *bool
+	keepAliveTimeout time.Duration
+	tlsConfig        *tls.Config
+}
+
+// SetKeepalive enable or disable keepalive on the connections the client dials from now on, multiplexers and
+// pools included, overriding WebsocketKeepalive. A positive timeout is both the ping interval and the pong
+// timeout of these connections, overriding WebsocketTimeout, WebsocketPingInterval and WebsocketPongTimeout;
+// zero keeps the package settings.
+func (c *WebsocketStreamClient) SetKeepalive(enabled bool, timeout time.Duration) *WebsocketStreamClient {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.keepAlive = &enabled
+	c.keepAliveTimeout = timeout
+	return c
+}
+
+// applyKeepAlive set the keepalive of the client on cfg
+func (c *WebsocketStreamClient) applyKeepAlive(cfg *WsConfig) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	cfg.keepAlive = c.keepAlive
+	cfg.keepAliveTimeout = c.keepAliveTimeout
+}
+
+// SetTLSConfig set the TLS configuration of the connections the client dials from now on, multiplexers and pools
+// included, e.g. a custom CA pool behind a TLS inspecting proxy. Setting InsecureSkipVerify disables the
+// verification of the server certificate and exposes the streams to interception, only use it against a local
+// test server.
+func (c *WebsocketStreamClient) SetTLSConfig(tlsConfig *tls.Config) *WebsocketStreamClient {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.tlsConfig = tlsConfig
+	return c
+}
+
+// getTLSConfig return the TLS configuration of SetTLSConfig
+func (c *WebsocketStreamClient) getTLSConfig() *tls.Config {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.tlsConfig
 }
 
 func NewWebsocketStreamClient(isCombined bool, baseURL ...string) *WebsocketStreamClient {
 	// Set default base URL to production WS URL
-	url := "wss://stream.binance.com:9443"
+	url := EnvironmentProd.URLs().Stream
 
 	if len(baseURL) > 0 {
 		url = baseURL[0]
@@ -45,97 +137,234 @@ func NewWebsocketStreamClient(isCombined bool, baseURL ...string) *WebsocketStre
 	}
 }
 
+// wsConn wraps a websocket connection and serializes every write on it.
+// gorilla/websocket supports only one concurrent writer, so keepalive pings
+// and any other frames sent by the library must go through this mutex.
+type wsConn struct {
+	*websocket.Conn
+	writeMu sync.Mutex
+	// stalled is set when keepalive closed the connection after the pong timeout
+	stalled atomic.Bool
+	// readTimeout and writeTimeout are the deadlines of the data frames, zero when disabled
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+// readError return the error to report for a read error of the connection
+func (c *wsConn) readError(err error) error {
+	if c.stalled.Load() {
+		return fmt.Errorf("%w: %v", ErrWsPongTimeout, err)
+	}
+	var netErr net.Error
+	if c.readTimeout > 0 && errors.As(err, &netErr) && netErr.Timeout() {
+		return fmt.Errorf("%w: %v", ErrWsReadTimeout, err)
+	}
+	return wrapWsCloseError(err)
+}
+
+func newWsConn(c *websocket.Conn) *wsConn {
+	return &wsConn{Conn: c, readTimeout: WebsocketReadTimeout, writeTimeout: WebsocketWriteTimeout}
+}
+
+// ReadMessage reads the next data frame, within the read timeout when set
+func (c *wsConn) ReadMessage() (int, []byte, error) {
+	c.extendReadDeadline()
+	return c.Conn.ReadMessage()
+}
+
+// NextReader returns a reader of the next data frame, which must be read within the read timeout when set
+func (c *wsConn) NextReader() (int, io.Reader, error) {
+	c.extendReadDeadline()
+	return c.Conn.NextReader()
+}
+
+// extendReadDeadline refresh the read deadline before each read, the control frames read meanwhile,
+// such as pongs, do not extend it
+func (c *wsConn) extendReadDeadline() {
+	if c.readTimeout > 0 {
+		c.Conn.SetReadDeadline(time.Now().Add(c.readTimeout))
+	}
+}
+
+// extendWriteDeadline refresh the write deadline before each data frame, under the write lock
+func (c *wsConn) extendWriteDeadline() {
+	if c.writeTimeout > 0 {
+		c.Conn.SetWriteDeadline(time.Now().Add(c.writeTimeout))
+	}
+}
+
+// WriteMessage writes a data frame while holding the write lock
+func (c *wsConn) WriteMessage(messageType int, data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.extendWriteDeadline()
+	return c.Conn.WriteMessage(messageType, data)
+}
+
+// WriteControl writes a control frame while holding the write lock
+func (c *wsConn) WriteControl(messageType int, data []byte, deadline time.Time) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.Conn.WriteControl(messageType, data, deadline)
+}
+
+// WriteJSON writes a JSON encoded data frame while holding the write lock
+func (c *wsConn) WriteJSON(v interface{}) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.extendWriteDeadline()
+	return c.Conn.WriteJSON(v)
+}
+
 func newWsConfig(endpoint string) *WsConfig {
 	return &WsConfig{
 		Endpoint: endpoint,
 	}
 }
 
-var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+// dialWs opens a stream connection to cfg.Endpoint with the library headers and read limit
+func dialWs(cfg *WsConfig) (*wsConn, error) {
+	endpoint := cfg.Endpoint
 	Dialer := websocket.Dialer{
 		Proxy:             http.ProxyFromEnvironment,
 		HandshakeTimeout:  24 * time.Hour, // 24 hours connected, it is the maximum time allowed by the Binance server
-		EnableCompression: false,
-		// HandshakeTimeout:  time.Duration(10) * time.Second,
-		// TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		EnableCompression: cfg.EnableCompression,
+		TLSClientConfig:   cfg.TLSConfig,
 	}
 	headers := http.Header{}
-	headers.Add("User-Agent", fmt.Sprintf("%s/%s", Name, Version))
-	c, httpResponse, err := Dialer.Dial(cfg.Endpoint, headers)
+	headers.Add("User-Agent", UserAgent())
+	conn, httpResponse, err := Dialer.Dial(endpoint, headers)
 	if err != nil {
-		fmt.Printf("Connecting to: %s\n", cfg.Endpoint)
-		fmt.Printf("HTTP Response Status: %s\n", httpResponse.Status)
-		fmt.Printf("HTTP Response Body: %s\n", httpResponse.Body)
-		fmt.Printf("HTTP Response TLS NegotiatedProtocol: %s\n", httpResponse.TLS.NegotiatedProtocol)
+		fmt.Printf("Connecting to: %s\n", endpoint)
+		if httpResponse != nil {
+			fmt.Printf("HTTP Response Status: %s\n", httpResponse.Status)
+			fmt.Printf("HTTP Response Body: %s\n", httpResponse.Body)
+			if httpResponse.TLS != nil {
+				fmt.Printf("HTTP Response TLS NegotiatedProtocol: %s\n", httpResponse.TLS.NegotiatedProtocol)
+			}
+		}
+		if httpResponse != nil && httpResponse.StatusCode == http.StatusServiceUnavailable {
+			return nil, &MaintenanceError{Status: httpResponse.StatusCode, Message: http.StatusText(httpResponse.StatusCode), Err: err}
+		}
 		switch err.(type) {
 		case *websocket.CloseError:
-			err = fmt.Errorf("websocket.CloseError: %v", err)
+			err = wrapWsCloseError(err)
 		case *websocket.HandshakeError:
 			err = fmt.Errorf("websocket.Handshake: %v", err)
 		}
+		return nil, err
+	}
+	c := newWsConn(conn)
+	c.SetReadLimit(WebsocketReadLimit)
+	return c, nil
+}
+
+// compressionNegotiated return true when the handshake response accepted permessage-deflate
+func compressionNegotiated(res *http.Response) bool {
+	return res != nil && strings.Contains(res.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+}
+
+// wsServe connect to cfg.Endpoint and call handler for each message until the connection ends.
+// The caller stops the connection by sending on or closing stopCh; stopCh is buffered so a late
+// stop does not block once the connection is gone. doneCh is closed, only by wsServe, after the
+// last call to handler returned.
+var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	c, err := dialWs(cfg)
+	if err != nil {
 		return nil, nil, err
 	}
-	c.SetReadLimit(655350)
 	doneCh = make(chan struct{})
-	stopCh = make(chan struct{})
+	stopCh = make(chan struct{}, 1)
+	readDone := make(chan struct{})
+	if keepAliveCfg, ok := cfg.keepAliveConfig(); ok {
+		keepAlive(c, keepAliveCfg)
+	}
+	if cfg.onConnect != nil {
+		cfg.onConnect(c)
+	}
+	var stopping atomic.Bool
+	reader := newMessageReader()
+	// the reader owns readDone, it exits on the first read error, including the
+	// one caused by closing the connection below
 	go func() {
-		// This function will exit either on error from
-		// websocket.Conn.ReadMessage or when the stopC channel is
-		// closed by the client.
-		defer close(doneCh)
-		if WebsocketKeepalive {
-			keepAlive(c, WebsocketTimeout)
-		}
-		// Wait for the stopC channel to be closed.  We do that in a
-		// separate goroutine because ReadMessage is a blocking
-		// operation.
-		silent := false
-		go func() {
-			for {
-				_, message, err := c.ReadMessage()
-				if err != nil {
-					if !silent {
-						errHandler(err)
-					}
-					stopCh <- struct{}{}
-					return
-				}
-				handler(message)
-			}
-		}()
-
+		defer close(readDone)
 		for {
-			select {
-			case <-stopCh:
-				silent = true
+			message, buf, err := reader.read(c)
+			if err != nil {
+				if !stopping.Load() {
+					errHandler.report(c.readError(err))
+				}
 				return
-			case <-doneCh:
 			}
+			reader.handle(handler, message, errHandler)
+			reader.release(buf)
 		}
 	}()
-	return
+	// this goroutine owns the connection and doneCh
+	go func() {
+		defer close(doneCh)
+		select {
+		case <-stopCh:
+			stopping.Store(true)
+		case <-readDone:
+		}
+		c.Close()
+		<-readDone
+	}()
+	return doneCh, stopCh, nil
+}
+
+// keepAliveConfig holds the three independent keepalive knobs:
+// how often to ping, how long a single ping write may take and
+// how long to tolerate silence from the server before giving up.
+type keepAliveConfig struct {
+	pingInterval     time.Duration
+	pingWriteTimeout time.Duration
+	pongTimeout      time.Duration
+}
 
+// newKeepAliveConfig builds a keepAliveConfig from the package level settings,
+// using timeout for the interval and the staleness threshold when they are not set
+func newKeepAliveConfig(timeout time.Duration) keepAliveConfig {
+	cfg := keepAliveConfig{
+		pingInterval:     WebsocketPingInterval,
+		pingWriteTimeout: WebsocketPingWriteTimeout,
+		pongTimeout:      WebsocketPongTimeout,
+	}
+	if cfg.pingInterval <= 0 {
+		cfg.pingInterval = timeout
+	}
+	if cfg.pongTimeout <= 0 {
+		cfg.pongTimeout = timeout
+	}
+	return cfg
 }
 
-func keepAlive(c *websocket.Conn, timeout time.Duration) {
-	ticker := time.NewTicker(timeout)
+func keepAlive(c *wsConn, cfg keepAliveConfig) {
+	ticker := time.NewTicker(cfg.pingInterval)
 
-	lastResponse := time.Now()
+	// the pong handler runs on the reader goroutine, so the timestamp is
+	// shared with the ping loop below
+	var lastResponse atomic.Int64
+	lastResponse.Store(time.Now().UnixNano())
 	c.SetPongHandler(func(msg string) error {
-		lastResponse = time.Now()
+		lastResponse.Store(time.Now().UnixNano())
 		return nil
 	})
 
 	go func() {
 		defer ticker.Stop()
 		for {
-			deadline := time.Now().Add(10 * time.Second)
+			deadline := time.Now().Add(cfg.pingWriteTimeout)
 			err := c.WriteControl(websocket.PingMessage, []byte{}, deadline)
 			if err != nil {
 				return
 			}
 			<-ticker.C
-			if time.Since(lastResponse) > timeout {
+			if time.Since(time.Unix(0, lastResponse.Load())) > cfg.pongTimeout {
+				// the reader fails on the closed connection and reports ErrWsPongTimeout
+				c.stalled.Store(true)
+				c.Close()
 				return
 			}
 		}
@@ -2,9 +2,7 @@ package binance_connector
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
+	"crypto/tls"
 	"github.com/goccy/go-json"
 	"fmt"
 	"log"
@@ -19,15 +17,24 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/luciano-personal-org/binance-connector/handlers"
 )
 
 type WebsocketAPIClient struct {
-	APIKey         string
-	APISecret      string
+	APIKey    string
+	APISecret string
+	// KeyType is the type of APISecret, see Client.KeyType
+	KeyType        KeyType
 	Endpoint       string
 	Conn           *websocket.Conn
 	Dialer         *websocket.Dialer
 	ReqResponseMap map[string]chan []byte
+	conn           *wsConn
+	pacer          wsAPIPacer
+	penalty        penaltyBox
+	signer         signerCache
+	compressed     bool
+	userData       wsAPIUserData
 }
 
 type WsAPIRateLimit struct {
@@ -44,16 +51,66 @@ type WsAPIErrorResponse struct {
 	Message string `json:"msg"`
 }
 
+// wsAPIError return an *handlers.APIError if the response carries a non-2xx status
+func wsAPIError(message []byte) error {
+	var response struct {
+		Status int                 `json:"status"`
+		Error  *WsAPIErrorResponse `json:"error"`
+	}
+	if err := json.Unmarshal(message, &response); err != nil {
+		// let the caller report the malformed response
+		return nil
+	}
+	return newWsAPIError(response.Status, response.Error)
+}
+
+func newWsAPIError(status int, e *WsAPIErrorResponse) error {
+	if e == nil && (status == 0 || status/100 == 2) {
+		return nil
+	}
+	apiErr := &handlers.APIError{Status: status}
+	if e != nil {
+		apiErr.Code = int64(e.Code)
+		apiErr.Message = e.Message
+	}
+	return apiErr
+}
+
 var (
 	// WebsocketAPITimeout is an interval for sending ping/pong messages if WebsocketKeepalive is enabled
 	WebsocketAPITimeout = time.Second * 60
 	// WebsocketAPIKeepalive enables sending ping/pong messages to check the connection stability
 	WebsocketAPIKeepalive = true
+	// WebsocketAPIPingInterval controls how often a ping frame is sent on the websocket API connection.
+	// Zero means WebsocketAPITimeout is used.
+	WebsocketAPIPingInterval time.Duration
+	// WebsocketAPIPongTimeout is how long the websocket API connection may go without receiving a pong
+	// before keepalive closes it. Zero means WebsocketAPITimeout is used.
+	WebsocketAPIPongTimeout time.Duration
+	// WebsocketAPIReadLimit is the maximum size in bytes of a websocket API response, large enough
+	// for a full exchangeInfo. The limit applies to the whole message across fragments.
+	WebsocketAPIReadLimit int64 = 16 << 20
 )
 
+// newWsAPIKeepAliveConfig builds the keepAliveConfig of the websocket API connection
+func newWsAPIKeepAliveConfig() keepAliveConfig {
+	cfg := keepAliveConfig{
+		pingInterval:     WebsocketAPIPingInterval,
+		pingWriteTimeout: WebsocketPingWriteTimeout,
+		pongTimeout:      WebsocketAPIPongTimeout,
+	}
+	if cfg.pingInterval <= 0 {
+		cfg.pingInterval = WebsocketAPITimeout
+	}
+	if cfg.pongTimeout <= 0 {
+		cfg.pongTimeout = WebsocketAPITimeout
+	}
+	return cfg
+}
+
 func NewWebsocketAPIClient(apiKey string, apiSecret string, baseURL ...string) *WebsocketAPIClient {
 	// Set default base URL to production WS URL
-	url := "wss://ws-api.binance.com:443/ws-api/v3"
+	url := EnvironmentProd.URLs().WebsocketAPI
 
 	if len(baseURL) > 0 {
 		url = baseURL[0]
@@ -71,31 +128,77 @@ func NewWebsocketAPIClient(apiKey string, apiSecret string, baseURL ...string) *
 	}
 }
 
+// NewWebsocketAPIClientWithKey create a websocket API client signing with apiSecret, an HMAC secret or an RSA or
+// Ed25519 private key, like NewClientWithKey
+func NewWebsocketAPIClientWithKey(apiKey string, apiSecret string, keyType KeyType, baseURL ...string) (*WebsocketAPIClient, error) {
+	c := NewWebsocketAPIClient(apiKey, apiSecret, baseURL...)
+	c.KeyType = keyType
+	if _, err := c.signer.get(apiSecret, keyType); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SetTLSConfig set the TLS configuration used by Connect, e.g. a custom CA pool behind a TLS inspecting proxy.
+// Setting InsecureSkipVerify disables the verification of the server certificate and exposes the
+// credentials and orders to interception, only use it against a local test server.
+func (c *WebsocketAPIClient) SetTLSConfig(tlsConfig *tls.Config) *WebsocketAPIClient {
+	if c.Dialer == nil {
+		c.Dialer = &websocket.Dialer{Proxy: http.ProxyFromEnvironment}
+	}
+	c.Dialer.TLSClientConfig = tlsConfig
+	return c
+}
+
+// SetCompression offer permessage-deflate in the handshake of the next Connect, which reduces the
+// bandwidth of large requests and responses such as batch queries at the cost of CPU. Off by default.
+func (c *WebsocketAPIClient) SetCompression(enabled bool) *WebsocketAPIClient {
+	if c.Dialer == nil {
+		c.Dialer = &websocket.Dialer{Proxy: http.ProxyFromEnvironment}
+	}
+	c.Dialer.EnableCompression = enabled
+	return c
+}
+
+// Compressed return true when the server accepted permessage-deflate on the current connection
+func (c *WebsocketAPIClient) Compressed() bool {
+	return c.compressed
+}
+
 func (c *WebsocketAPIClient) Connect() error {
 	if c.Dialer == nil {
 		return fmt.Errorf("dialer not initialized")
 	}
 	headers := http.Header{}
-	headers.Add("User-Agent", fmt.Sprintf("%s/%s", Name, Version))
-	conn, _, err := c.Dialer.Dial(c.Endpoint, headers)
+	headers.Add("User-Agent", UserAgent())
+	conn, res, err := c.Dialer.Dial(c.Endpoint, headers)
 	if err != nil {
 		return err
 	}
+	c.compressed = compressionNegotiated(res)
 
 	fmt.Println("Connected to Binance Websocket API")
 	c.Conn = conn
+	c.conn = newWsConn(conn)
+	c.conn.SetReadLimit(WebsocketAPIReadLimit)
 
 	c.ReqResponseMap = make(map[string]chan []byte)
+	c.userData.reset()
+	// server pings are answered by the default ping handler of the connection while the reader runs
+	if WebsocketAPIKeepalive {
+		keepAlive(c.conn, newWsAPIKeepAliveConfig())
+	}
 	c.startReader() // start reader again
 	return nil
 }
 
 func (c *WebsocketAPIClient) startReader() {
+	conn := c.conn
 	go func() {
 		for {
-			_, message, err := c.Conn.ReadMessage()
+			_, message, err := conn.ReadMessage()
 			if err != nil {
-				log.Println("Error reading:", err)
+				log.Println("Error reading:", conn.readError(err))
 				return
 			}
 			c.Handler(message)
@@ -105,12 +208,42 @@ func (c *WebsocketAPIClient) startReader() {
 
 // Handler function to handle responses
 func (c *WebsocketAPIClient) Handler(message []byte) {
-	var response WsAPIErrorResponse
+	var response struct {
+		ID         string            `json:"id"`
+		Status     int               `json:"status"`
+		RateLimits []*WsAPIRateLimit `json:"rateLimits"`
+		Error      *struct {
+			Code    int64  `json:"code"`
+			Message string `json:"msg"`
+			Data    *struct {
+				RetryAfter int64 `json:"retryAfter"`
+			} `json:"data"`
+		} `json:"error"`
+	}
 	err := json.Unmarshal(message, &response)
 	if err != nil {
 		log.Println("Error unmarshaling:", err)
 		return
 	}
+	if response.ID == "" {
+		var event wsAPIUserDataEvent
+		if err := json.Unmarshal(message, &event); err == nil && len(event.Event) > 0 {
+			c.userData.dispatch(&event)
+			return
+		}
+	}
+	c.pacer.update(response.RateLimits)
+	if response.Error != nil {
+		apiErr := &handlers.APIError{Code: response.Error.Code, Message: response.Error.Message, Status: response.Status}
+		var retryAfter time.Duration
+		if response.Error.Data != nil && response.Error.Data.RetryAfter > 0 {
+			retryAfter = time.Until(time.UnixMilli(response.Error.Data.RetryAfter))
+		}
+		if until, ok := rateLimitPenalty(response.Status, apiErr, retryAfter, time.Now()); ok {
+			c.penalty.penalize(until, apiErr)
+		}
+	}
+	c.userData.responded(response.ID, message)
 	// Send the message to the corresponding request
 	if channel, ok := c.ReqResponseMap[response.ID]; ok {
 		channel <- message
@@ -128,7 +261,7 @@ func (c *WebsocketAPIClient) Close() error {
 }
 
 func (c *WebsocketAPIClient) SendMessage(msg interface{}) error {
-	return c.Conn.WriteJSON(msg)
+	return c.conn.WriteJSON(msg)
 }
 
 func (c *WebsocketAPIClient) RequestHandler(req interface{}, handler WsHandler, errHandler ErrHandler) (stopCh chan struct{}, err error) {
@@ -136,19 +269,17 @@ func (c *WebsocketAPIClient) RequestHandler(req interface{}, handler WsHandler,
 	if err != nil {
 		return nil, err
 	}
-	stopCh, err = wsApiServe(c.Conn, handler, errHandler)
+	stopCh, err = wsApiServe(c.conn, handler, errHandler)
 	if err != nil {
 		return nil, err
 	}
 	return stopCh, nil
 }
 
-func wsApiServe(c *websocket.Conn, handler WsHandler, errHandler ErrHandler) (stopCh chan struct{}, err error) {
+func wsApiServe(c *wsConn, handler WsHandler, errHandler ErrHandler) (stopCh chan struct{}, err error) {
 	stopCh = make(chan struct{})
 	go func() {
-		if WebsocketAPIKeepalive {
-			keepAlive(c, WebsocketAPITimeout)
-		}
+		// the keepalive of the connection is started by Connect
 		silent := false
 		for {
 			select {
@@ -159,7 +290,7 @@ func wsApiServe(c *websocket.Conn, handler WsHandler, errHandler ErrHandler) (st
 				if err != nil {
 					if !silent {
 						fmt.Println(err)
-						errHandler(err)
+						errHandler.report(c.readError(err))
 					}
 					continue
 				}
@@ -170,7 +301,7 @@ func wsApiServe(c *websocket.Conn, handler WsHandler, errHandler ErrHandler) (st
 	return stopCh, nil
 }
 
-func websocketAPISignature(apiKey string, apiSecret string, parameters map[string]string) (map[string]string, error) {
+func websocketAPISignature(apiKey string, apiSecret string, keyType KeyType, signers *signerCache, parameters map[string]string) (map[string]string, error) {
 	if apiKey == "" || apiSecret == "" {
 		return nil, &WebsocketClientError{
 			Message: "api_key and api_secret are required for websocket API signature",
@@ -195,19 +326,20 @@ func websocketAPISignature(apiKey string, apiSecret string, parameters map[strin
 
 	// Calculate signature
 	queryString := strings.Join(sortedParams, "&")
-	signature := hmacHashing(apiSecret, queryString)
+	signer, err := signers.get(apiSecret, keyType)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.sign(queryString)
+	if err != nil {
+		return nil, err
+	}
 
 	parameters["signature"] = signature
 
 	return parameters, nil
 }
 
-func hmacHashing(apiSecret string, data string) string {
-	mac := hmac.New(sha256.New, []byte(apiSecret))
-	mac.Write([]byte(data))
-	return hex.EncodeToString(mac.Sum(nil))
-}
-
 func getUUID() string {
 	return fmt.Sprintf("%s-%s-%s-%s-%s", randomHex(8), randomHex(4), randomHex(4), randomHex(4), randomHex(12))
 }
@@ -245,7 +377,7 @@ func (s *TestConnectivityService) Do(ctx context.Context) (*TestConnectivityResp
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -254,6 +386,9 @@ func (s *TestConnectivityService) Do(ctx context.Context) (*TestConnectivityResp
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var pingResponse TestConnectivityResponse
 		err = json.Unmarshal(response, &pingResponse)
 		if err != nil {
@@ -270,7 +405,7 @@ type TestConnectivityResponse struct {
 	Status     int                 `json:"status"`
 	Error      *WsAPIErrorResponse `json:"error,omitempty"`
 	Result     struct{}            `json:"result,omitempty"`
-	RateLimits WsAPIRateLimit      `json:"rateLimits,omitempty"`
+	RateLimits []*WsAPIRateLimit   `json:"rateLimits,omitempty"`
 }
 
 type CheckServerTimeService struct {
@@ -288,7 +423,7 @@ func (s *CheckServerTimeService) Do(ctx context.Context) (*CheckServerTimeRespon
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -297,6 +432,9 @@ func (s *CheckServerTimeService) Do(ctx context.Context) (*CheckServerTimeRespon
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var timeResponse CheckServerTimeResponse
 		err = json.Unmarshal(response, &timeResponse)
 		if err != nil {
@@ -315,7 +453,7 @@ type CheckServerTimeResponse struct {
 	Result struct {
 		ServerTime uint64 `json:"serverTime"`
 	} `json:"result,omitempty"`
-	RateLimits WsAPIRateLimit `json:"rateLimits,omitempty"`
+	RateLimits []*WsAPIRateLimit `json:"rateLimits,omitempty"`
 }
 
 type ExchangeInformationService struct {
@@ -377,6 +515,12 @@ func (s *ExchangeInformationService) Do(ctx context.Context) (*ExchangeInformati
 		fmt.Println("Error:", err)
 	}
 
+	if err := s.websocketAPI.penalty.check(); err != nil {
+		return nil, err
+	}
+	if err := s.websocketAPI.pacer.wait(ctx); err != nil {
+		return nil, err
+	}
 	doneCh, err := s.websocketAPI.RequestHandler(payload, handler, errHandler)
 	if err != nil {
 		return nil, err
@@ -386,6 +530,13 @@ func (s *ExchangeInformationService) Do(ctx context.Context) (*ExchangeInformati
 	case <-doneCh:
 		return nil, ctx.Err()
 	case response := <-responseCh:
+		if err := newWsAPIError(response.Status, response.Error); err != nil {
+			// the response is read here rather than by the client, its ban is recorded the same way
+			if until, ok := rateLimitPenalty(response.Status, err.(*handlers.APIError), 0, time.Now()); ok {
+				s.websocketAPI.penalty.penalize(until, err)
+			}
+			return nil, err
+		}
 		return response, nil
 	}
 }
@@ -411,6 +562,16 @@ func (w *WebsocketAPIClient) NewExchangeInformationService() *ExchangeInformatio
 	return &ExchangeInformationService{websocketAPI: w}
 }
 
+// NewExchangeInfoService query exchangeInfo and decode it into the ExchangeInfoResponse of the REST client
+func (w *WebsocketAPIClient) NewExchangeInfoService() *WsAPIExchangeInfoService {
+	return &WsAPIExchangeInfoService{websocketAPI: w}
+}
+
+// NewTimeService query the server time and decode it into the ServerTimeResponse of the REST client
+func (w *WebsocketAPIClient) NewTimeService() *WsAPITimeService {
+	return &WsAPITimeService{websocketAPI: w}
+}
+
 // Account Websocket API Endpoints:
 func (w *WebsocketAPIClient) NewAccountInformationService() *AccountInformationService {
 	return &AccountInformationService{websocketAPI: w}
@@ -23,9 +23,9 @@ func (s *AccountInformationService) Do(ctx context.Context) (*AccountInformation
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -39,7 +39,7 @@ func (s *AccountInformationService) Do(ctx context.Context) (*AccountInformation
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -48,6 +48,9 @@ func (s *AccountInformationService) Do(ctx context.Context) (*AccountInformation
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var accInfoResponse AccountInformationResponse
 		err = json.Unmarshal(response, &accInfoResponse)
 		if err != nil {
@@ -114,9 +117,9 @@ func (s *AccountOrderRateLimitsService) Do(ctx context.Context) (*AccountOrderRa
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -130,7 +133,7 @@ func (s *AccountOrderRateLimitsService) Do(ctx context.Context) (*AccountOrderRa
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -139,6 +142,9 @@ func (s *AccountOrderRateLimitsService) Do(ctx context.Context) (*AccountOrderRa
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var orderRateLimitsResponse AccountOrderRateLimitsResponse
 		err = json.Unmarshal(response, &orderRateLimitsResponse)
 		if err != nil {
@@ -231,9 +237,9 @@ func (s *AccountOrderHistoryService) Do(ctx context.Context) (*AccountOrderHisto
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -247,7 +253,7 @@ func (s *AccountOrderHistoryService) Do(ctx context.Context) (*AccountOrderHisto
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -256,6 +262,9 @@ func (s *AccountOrderHistoryService) Do(ctx context.Context) (*AccountOrderHisto
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var orderHistoryResponse AccountOrderHistoryResponse
 		err = json.Unmarshal(response, &orderHistoryResponse)
 		if err != nil {
@@ -357,9 +366,9 @@ func (s *AccountOCOHistoryService) Do(ctx context.Context) (*AccountOCOHistoryRe
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -373,7 +382,7 @@ func (s *AccountOCOHistoryService) Do(ctx context.Context) (*AccountOCOHistoryRe
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -382,6 +391,9 @@ func (s *AccountOCOHistoryService) Do(ctx context.Context) (*AccountOCOHistoryRe
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var ocoHistoryResponse AccountOCOHistoryResponse
 		err = json.Unmarshal(response, &ocoHistoryResponse)
 		if err != nil {
@@ -495,9 +507,9 @@ func (s *AccountTradeHistoryService) Do(ctx context.Context) (*AccountTradeHisto
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -511,7 +523,7 @@ func (s *AccountTradeHistoryService) Do(ctx context.Context) (*AccountTradeHisto
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -520,6 +532,9 @@ func (s *AccountTradeHistoryService) Do(ctx context.Context) (*AccountTradeHisto
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var tradeHistoryResponse AccountTradeHistoryResponse
 		err = json.Unmarshal(response, &tradeHistoryResponse)
 		if err != nil {
@@ -572,9 +587,9 @@ func (s *AccountPreventedMatchesService) Do(ctx context.Context) (*AccountPreven
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -588,7 +603,7 @@ func (s *AccountPreventedMatchesService) Do(ctx context.Context) (*AccountPreven
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -597,6 +612,9 @@ func (s *AccountPreventedMatchesService) Do(ctx context.Context) (*AccountPreven
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var preventedMatchesResponse AccountPreventedMatchesResponse
 		err = json.Unmarshal(response, &preventedMatchesResponse)
 		if err != nil {
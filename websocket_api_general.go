@@ -0,0 +1,124 @@
+package binance_connector
+
+import (
+	"context"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// WsAPIExchangeInfoService query the exchange information over the websocket API connection, decoded into
+// the ExchangeInfoResponse of the REST ExchangeInfo
+type WsAPIExchangeInfoService struct {
+	websocketAPI *WebsocketAPIClient
+	filters      ExchangeInfo
+}
+
+// Symbol set symbol
+func (s *WsAPIExchangeInfoService) Symbol(symbol string) *WsAPIExchangeInfoService {
+	s.filters.Symbol(symbol)
+	return s
+}
+
+// Symbols set symbols
+func (s *WsAPIExchangeInfoService) Symbols(symbols []string) *WsAPIExchangeInfoService {
+	s.filters.Symbols(symbols)
+	return s
+}
+
+// Permissions set permissions, e.g. SPOT or MARGIN
+func (s *WsAPIExchangeInfoService) Permissions(permissions []string) *WsAPIExchangeInfoService {
+	s.filters.Permissions(permissions)
+	return s
+}
+
+// ShowPermissionSets set showPermissionSets, false leaves permissionSets out of the response
+func (s *WsAPIExchangeInfoService) ShowPermissionSets(showPermissionSets bool) *WsAPIExchangeInfoService {
+	s.filters.ShowPermissionSets(showPermissionSets)
+	return s
+}
+
+// Do send the request, combined or empty filters return ErrExchangeInfoFilter like the REST service
+func (s *WsAPIExchangeInfoService) Do(ctx context.Context) (*ExchangeInfoResponse, error) {
+	if err := s.filters.validate(); err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{}
+	if s.filters.symbol != nil {
+		params["symbol"] = *s.filters.symbol
+	}
+	if s.filters.symbols != nil {
+		params["symbols"] = *s.filters.symbols
+	}
+	if s.filters.permissions != nil {
+		params["permissions"] = *s.filters.permissions
+	}
+	if s.filters.showPermissionSets != nil {
+		params["showPermissionSets"] = *s.filters.showPermissionSets
+	}
+	res := new(ExchangeInfoResponse)
+	if err := s.websocketAPI.call(ctx, "exchangeInfo", params, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// WsAPITimeService query the server time over the websocket API connection, decoded into the
+// ServerTimeResponse of the REST ServerTime
+type WsAPITimeService struct {
+	websocketAPI *WebsocketAPIClient
+}
+
+// Do send the request
+func (s *WsAPITimeService) Do(ctx context.Context) (*ServerTimeResponse, error) {
+	res := new(ServerTimeResponse)
+	if err := s.websocketAPI.call(ctx, "time", nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ServerTime return the time of the server, a ServerClock for Client.SyncTime using the websocket API
+// connection rather than a REST request
+func (c *WebsocketAPIClient) ServerTime(ctx context.Context) (time.Time, error) {
+	res, err := c.NewTimeService().Do(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(int64(res.ServerTime)), nil
+}
+
+// call send a request of method and decode the result of its response into result
+func (c *WebsocketAPIClient) call(ctx context.Context, method string, params map[string]interface{}, result interface{}) error {
+	id := getUUID()
+	payload := map[string]interface{}{
+		"id":     id,
+		"method": method,
+	}
+	if len(params) > 0 {
+		payload["params"] = params
+	}
+
+	messageCh := make(chan []byte)
+	c.ReqResponseMap[id] = messageCh
+	defer delete(c.ReqResponseMap, id)
+
+	if err := c.sendRequest(ctx, payload); err != nil {
+		return err
+	}
+	select {
+	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return err
+		}
+		var envelope struct {
+			Result json.RawMessage `json:"result"`
+		}
+		if err := json.Unmarshal(response, &envelope); err != nil {
+			return err
+		}
+		return json.Unmarshal(envelope.Result, result)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -42,7 +42,7 @@ func (s *DepthService) Do(ctx context.Context) (*DepthResponse, error) {
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -51,6 +51,9 @@ func (s *DepthService) Do(ctx context.Context) (*DepthResponse, error) {
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var depthResponse DepthResponse
 		err = json.Unmarshal(response, &depthResponse)
 		if err != nil {
@@ -112,7 +115,7 @@ func (s *RecentTradesService) Do(ctx context.Context) (*RecentTradesResponse, er
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +124,9 @@ func (s *RecentTradesService) Do(ctx context.Context) (*RecentTradesResponse, er
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var recentTradesResponse RecentTradesResponse
 		err = json.Unmarshal(response, &recentTradesResponse)
 		if err != nil {
@@ -196,7 +202,7 @@ func (s *HistoricalTradesService) Do(ctx context.Context) (*HistoricalTradesResp
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -205,6 +211,9 @@ func (s *HistoricalTradesService) Do(ctx context.Context) (*HistoricalTradesResp
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var historicalTradesResponse HistoricalTradesResponse
 		err = json.Unmarshal(response, &historicalTradesResponse)
 		if err != nil {
@@ -300,7 +309,7 @@ func (s *AggregateTradesService) Do(ctx context.Context) (*AggregateTradesRespon
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -309,6 +318,9 @@ func (s *AggregateTradesService) Do(ctx context.Context) (*AggregateTradesRespon
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var aggTradesResponse AggregateTradesResponse
 		err = json.Unmarshal(response, &aggTradesResponse)
 		if err != nil {
@@ -402,7 +414,7 @@ func (s *KlinesService) Do(ctx context.Context) (*WsAPIKlinesResponse, error) {
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -411,6 +423,9 @@ func (s *KlinesService) Do(ctx context.Context) (*WsAPIKlinesResponse, error) {
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var klinesResponse WsAPIKlinesResponse
 		err = json.Unmarshal(response, &klinesResponse)
 		if err != nil {
@@ -456,7 +471,7 @@ func (s *AvgPriceService) Do(ctx context.Context) (*WsAPIAvgPriceResponse, error
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -465,6 +480,9 @@ func (s *AvgPriceService) Do(ctx context.Context) (*WsAPIAvgPriceResponse, error
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var avgPriceResponse WsAPIAvgPriceResponse
 		err = json.Unmarshal(response, &avgPriceResponse)
 		if err != nil {
@@ -515,7 +533,7 @@ func (s *Ticker24hrService) Do(ctx context.Context) (*WsAPITicker24hrResponse, e
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -524,6 +542,9 @@ func (s *Ticker24hrService) Do(ctx context.Context) (*WsAPITicker24hrResponse, e
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var twentyFourHrResponse WsAPITicker24hrResponse
 		err = json.Unmarshal(response, &twentyFourHrResponse)
 		if err != nil {
@@ -593,7 +614,7 @@ func (s *TickerService) Do(ctx context.Context) (*WsAPITickerResponse, error) {
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -602,6 +623,9 @@ func (s *TickerService) Do(ctx context.Context) (*WsAPITickerResponse, error) {
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var tickerResponse WsAPITickerResponse
 		err = json.Unmarshal(response, &tickerResponse)
 		if err != nil {
@@ -665,7 +689,7 @@ func (s *TickerPriceService) Do(ctx context.Context) (*WsAPIPriceTickerResponse,
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -674,6 +698,9 @@ func (s *TickerPriceService) Do(ctx context.Context) (*WsAPIPriceTickerResponse,
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var priceTickerResponse WsAPIPriceTickerResponse
 		err = json.Unmarshal(response, &priceTickerResponse)
 		if err != nil {
@@ -724,7 +751,7 @@ func (s *TickerBookService) Do(ctx context.Context) (*WsAPIBookTickerResponse, e
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -733,6 +760,9 @@ func (s *TickerBookService) Do(ctx context.Context) (*WsAPIBookTickerResponse, e
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var bookTickerResponse WsAPIBookTickerResponse
 		err = json.Unmarshal(response, &bookTickerResponse)
 		if err != nil {
@@ -822,7 +852,7 @@ func (s *UIKlinesService) Do(ctx context.Context) (*UIKlinesResponse, error) {
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -831,6 +861,9 @@ func (s *UIKlinesService) Do(ctx context.Context) (*UIKlinesResponse, error) {
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var uiKlinesResponse UIKlinesResponse
 		err = json.Unmarshal(response, &uiKlinesResponse)
 		if err != nil {
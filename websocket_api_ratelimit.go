@@ -0,0 +1,127 @@
+package binance_connector
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	// WebsocketAPIRequestInterval is the minimum delay between two requests sent on one websocket API connection.
+	// Zero disables the pacing, the request weight reported by the server is still honored.
+	WebsocketAPIRequestInterval = time.Millisecond * 100
+)
+
+// wsAPIPacer queues outgoing websocket API requests. It spaces them by WebsocketAPIRequestInterval
+// and holds them while the REQUEST_WEIGHT budget reported in the rateLimits of the responses is spent.
+type wsAPIPacer struct {
+	mu        sync.Mutex
+	next      time.Time
+	usage     []*WsAPIRateLimit
+	updatedAt time.Time
+}
+
+// wait block until a request may be sent or ctx is done
+func (p *wsAPIPacer) wait(ctx context.Context) error {
+	for {
+		p.mu.Lock()
+		now := time.Now()
+		at := p.next
+		if reset := p.exhaustedUntil(now); reset.After(at) {
+			at = reset
+		}
+		if !at.After(now) {
+			p.next = now.Add(WebsocketAPIRequestInterval)
+			p.mu.Unlock()
+			return nil
+		}
+		p.mu.Unlock()
+
+		timer := time.NewTimer(at.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// exhaustedUntil return the end of the window of a spent REQUEST_WEIGHT limit, zero when none is spent
+func (p *wsAPIPacer) exhaustedUntil(now time.Time) time.Time {
+	var until time.Time
+	for _, limit := range p.usage {
+		if limit.RateLimitType != "REQUEST_WEIGHT" || limit.Count < limit.Limit {
+			continue
+		}
+		window := wsAPIRateLimitWindow(limit)
+		if window == 0 {
+			continue
+		}
+		// Binance windows are aligned on the clock, e.g. a MINUTE window resets at the next minute
+		reset := p.updatedAt.Truncate(window).Add(window)
+		if reset.After(now) && reset.After(until) {
+			until = reset
+		}
+	}
+	return until
+}
+
+// update record the rateLimits of a response
+func (p *wsAPIPacer) update(rateLimits []*WsAPIRateLimit) {
+	if len(rateLimits) == 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.usage = rateLimits
+	p.updatedAt = time.Now()
+}
+
+// snapshot return a copy of the last rateLimits received
+func (p *wsAPIPacer) snapshot() []WsAPIRateLimit {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	usage := make([]WsAPIRateLimit, 0, len(p.usage))
+	for _, limit := range p.usage {
+		usage = append(usage, *limit)
+	}
+	return usage
+}
+
+func wsAPIRateLimitWindow(limit *WsAPIRateLimit) time.Duration {
+	var unit time.Duration
+	switch limit.Interval {
+	case "SECOND":
+		unit = time.Second
+	case "MINUTE":
+		unit = time.Minute
+	case "HOUR":
+		unit = time.Hour
+	case "DAY":
+		unit = 24 * time.Hour
+	}
+	return unit * time.Duration(limit.IntervalNum)
+}
+
+// RateLimitUsage return the rate limits and their usage as reported by the last response
+func (c *WebsocketAPIClient) RateLimitUsage() []WsAPIRateLimit {
+	return c.pacer.snapshot()
+}
+
+// RateLimitedUntil return the end of the current rate limit penalty or IP ban, zero when requests are allowed
+func (c *WebsocketAPIClient) RateLimitedUntil() time.Time {
+	return c.penalty.bannedUntil()
+}
+
+// sendRequest wait for the pacer then send the request, ctx bounds the time spent queued.
+// During a rate limit penalty the request fails with a RateLimitedError without being sent.
+func (c *WebsocketAPIClient) sendRequest(ctx context.Context, msg interface{}) error {
+	if err := c.penalty.check(); err != nil {
+		return err
+	}
+	if err := c.pacer.wait(ctx); err != nil {
+		return err
+	}
+	return c.SendMessage(msg)
+}
@@ -0,0 +1,330 @@
+package binance_connector
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/gorilla/websocket"
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+type websocketAPITestSuite struct {
+	suite.Suite
+}
+
+func TestWebsocketAPI(t *testing.T) {
+	suite.Run(t, new(websocketAPITestSuite))
+}
+
+// newWsAPITestClient connects a websocket API client to a server answering
+// every request with the response built by reply
+func (s *websocketAPITestSuite) newWsAPITestClient(reply func(id, method string) string) *WebsocketAPIClient {
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		for {
+			var request struct {
+				ID     string `json:"id"`
+				Method string `json:"method"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(reply(request.ID, request.Method))); err != nil {
+				return
+			}
+		}
+	})
+	s.T().Cleanup(server.Close)
+
+	client := NewWebsocketAPIClient("dummyAPIKey", "dummySecretKey", url)
+	s.Require().NoError(client.Connect())
+	s.T().Cleanup(func() { client.Close() })
+	return client
+}
+
+func (s *websocketAPITestSuite) TestErrorResponse() {
+	client := s.newWsAPITestClient(func(id, method string) string {
+		if method == "ping" {
+			return `{"id":"` + id + `","status":200,"result":{}}`
+		}
+		return `{"id":"` + id + `","status":400,"error":{"code":-1121,"msg":"Invalid symbol."}}`
+	})
+
+	res, err := client.NewQueryOrderService().Symbol("UNKNOWN").OrderId(1).Do(newContext())
+	s.Nil(res)
+	s.Require().True(handlers.IsAPIError(err))
+	apiErr := err.(*handlers.APIError)
+	s.Equal(int64(-1121), apiErr.Code)
+	s.Equal("Invalid symbol.", apiErr.Message)
+	s.Equal(400, apiErr.Status)
+	s.EqualError(err, "<APIError> code=-1121, msg=Invalid symbol., status=400")
+
+	ping, err := client.NewTestConnectivityService().Do(newContext())
+	s.Require().NoError(err)
+	s.Equal(200, ping.Status)
+}
+
+func (s *websocketAPITestSuite) TestWsAPIError() {
+	s.NoError(wsAPIError([]byte(`{"id":"1","status":200,"result":{}}`)))
+	s.NoError(wsAPIError([]byte(`not json`)))
+
+	err := wsAPIError([]byte(`{"id":"1","status":429,"error":{"code":-1003,"msg":"Too many requests."}}`))
+	s.Equal(&handlers.APIError{Code: -1003, Message: "Too many requests.", Status: 429}, err)
+	s.EqualError(err, "<APIError> code=-1003, msg=Too many requests., status=429")
+	s.EqualError(&handlers.APIError{Code: -1003, Message: "Too many requests."}, "<APIError> code=-1003, msg=Too many requests.",
+		"the status of a stream error is unknown")
+
+	var response WsAPIErrorResponse
+	s.Require().NoError(json.Unmarshal([]byte(`{"id":"2","code":-2013,"msg":"Order does not exist."}`), &response))
+	s.Equal(&handlers.APIError{Code: -2013, Message: "Order does not exist.", Status: 400}, newWsAPIError(400, &response))
+}
+
+func (s *websocketAPITestSuite) TestExchangeInfoAndTime() {
+	params := make(chan string, 1)
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		for {
+			var request struct {
+				ID     string          `json:"id"`
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			result := `{"serverTime":1499827319559}`
+			if request.Method == "exchangeInfo" {
+				params <- string(request.Params)
+				result = `{"timezone":"UTC","serverTime":1499827319559,"symbols":[{"symbol":"BTCUSDT","status":"TRADING",` +
+					`"filters":[{"filterType":"LOT_SIZE","minQty":"0.00001000","maxQty":"9000.00000000","stepSize":"0.00001000"}]}]}`
+			}
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"id":"`+request.ID+`","status":200,"result":`+result+`}`))
+		}
+	})
+	defer server.Close()
+	client := NewWebsocketAPIClient("dummyAPIKey", "dummySecretKey", url)
+	s.Require().NoError(client.Connect())
+	defer client.Close()
+
+	info, err := client.NewExchangeInfoService().Symbols([]string{"BTCUSDT"}).Do(newContext())
+	s.Require().NoError(err)
+	s.JSONEq(`{"symbols":["BTCUSDT"]}`, <-params)
+	s.Equal("UTC", info.Timezone)
+	s.Require().Len(info.Symbols, 1)
+	s.Equal("0.00001000", info.Symbols[0].Filters[0].StepSize)
+
+	_, err = client.NewExchangeInfoService().Symbol("BTCUSDT").Permissions([]string{"SPOT"}).Do(newContext())
+	s.ErrorIs(err, ErrExchangeInfoFilter)
+
+	serverTime, err := client.NewTimeService().Do(newContext())
+	s.Require().NoError(err)
+	s.Equal(&ServerTimeResponse{ServerTime: 1499827319559}, serverTime)
+
+	// the websocket API is a source of the server time of a REST client
+	rest := NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	rest.Clock = func() time.Time { return time.UnixMilli(1499827320559) }
+	offset, err := rest.SyncTime(newContext(), client.ServerTime)
+	s.Require().NoError(err)
+	s.Equal(time.Second, offset)
+}
+
+func (s *websocketAPITestSuite) TestRateLimitUsage() {
+	client := s.newWsAPITestClient(func(id, method string) string {
+		return `{"id":"` + id + `","status":200,"result":{},"rateLimits":[` +
+			`{"rateLimitType":"REQUEST_WEIGHT","interval":"MINUTE","intervalNum":1,"limit":6000,"count":2}]}`
+	})
+
+	res, err := client.NewTestConnectivityService().Do(newContext())
+	s.Require().NoError(err)
+	s.Require().Len(res.RateLimits, 1)
+	s.Equal([]WsAPIRateLimit{{RateLimitType: "REQUEST_WEIGHT", Interval: "MINUTE", IntervalNum: 1, Limit: 6000, Count: 2}}, client.RateLimitUsage())
+}
+
+func (s *websocketAPITestSuite) TestPacerSpacesRequests() {
+	origInterval := WebsocketAPIRequestInterval
+	defer func() { WebsocketAPIRequestInterval = origInterval }()
+	WebsocketAPIRequestInterval = 50 * time.Millisecond
+
+	var p wsAPIPacer
+	start := time.Now()
+	s.Require().NoError(p.wait(newContext()))
+	s.Require().NoError(p.wait(newContext()))
+	s.GreaterOrEqual(time.Since(start), 50*time.Millisecond)
+}
+
+func (s *websocketAPITestSuite) TestPacerHoldsWhileWeightSpent() {
+	var p wsAPIPacer
+	p.update([]*WsAPIRateLimit{{RateLimitType: "REQUEST_WEIGHT", Interval: "DAY", IntervalNum: 1, Limit: 6000, Count: 6000}})
+
+	ctx, cancel := context.WithTimeout(newContext(), 20*time.Millisecond)
+	defer cancel()
+	s.ErrorIs(p.wait(ctx), context.DeadlineExceeded)
+
+	// an exhausted ORDERS limit does not hold other requests
+	p.update([]*WsAPIRateLimit{{RateLimitType: "ORDERS", Interval: "DAY", IntervalNum: 1, Limit: 10, Count: 10}})
+	s.NoError(p.wait(newContext()))
+}
+
+func (s *websocketAPITestSuite) TestBannedUntil() {
+	until := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	retryAfter := strconv.FormatInt(until.UnixMilli(), 10)
+	requests := 0
+	client := s.newWsAPITestClient(func(id, method string) string {
+		requests++
+		return `{"id":"` + id + `","status":418,"error":{"code":-1003,"msg":"Way too many requests; IP(1.2.3.4) banned until ` +
+			retryAfter + `. Please use the websocket for live updates to avoid bans.","data":{"serverTime":1659142907531,"retryAfter":` + retryAfter + `}}}`
+	})
+
+	_, err := client.NewTestConnectivityService().Do(newContext())
+	var apiErr *handlers.APIError
+	s.Require().ErrorAs(err, &apiErr)
+	s.Equal(int64(-1003), apiErr.Code)
+	s.Equal(418, apiErr.Status)
+	s.True(until.Equal(client.RateLimitedUntil()))
+
+	_, err = client.NewTestConnectivityService().Do(newContext())
+	s.ErrorIs(err, ErrRateLimited)
+	_, err = client.NewExchangeInformationService().Do(newContext())
+	s.ErrorIs(err, ErrRateLimited)
+	s.Equal(1, requests, "no request is sent during the ban")
+}
+
+// setWsAPIKeepalive shortens the websocket API keepalive for the test
+func (s *websocketAPITestSuite) setWsAPIKeepalive(pingInterval, pongTimeout time.Duration) {
+	origInterval, origPong := WebsocketAPIPingInterval, WebsocketAPIPongTimeout
+	s.T().Cleanup(func() {
+		WebsocketAPIPingInterval, WebsocketAPIPongTimeout = origInterval, origPong
+	})
+	WebsocketAPIPingInterval, WebsocketAPIPongTimeout = pingInterval, pongTimeout
+}
+
+func (s *websocketAPITestSuite) TestKeepAliveConfig() {
+	s.setWsAPIKeepalive(0, 0)
+	cfg := newWsAPIKeepAliveConfig()
+	s.Equal(WebsocketAPITimeout, cfg.pingInterval)
+	s.Equal(WebsocketAPITimeout, cfg.pongTimeout)
+
+	s.setWsAPIKeepalive(5*time.Second, 2*time.Minute)
+	cfg = newWsAPIKeepAliveConfig()
+	s.Equal(5*time.Second, cfg.pingInterval)
+	s.Equal(2*time.Minute, cfg.pongTimeout)
+}
+
+func (s *websocketAPITestSuite) TestAnswersServerPing() {
+	pongs := make(chan string, 1)
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		conn.SetPongHandler(func(data string) error {
+			pongs <- data
+			return nil
+		})
+		conn.WriteControl(websocket.PingMessage, []byte("server ping"), time.Now().Add(time.Second))
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	defer server.Close()
+
+	client := NewWebsocketAPIClient("dummyAPIKey", "dummySecretKey", url)
+	s.Require().NoError(client.Connect())
+	defer client.Close()
+	select {
+	case data := <-pongs:
+		s.Equal("server ping", data)
+	case <-time.After(5 * time.Second):
+		s.FailNow("server ping not answered")
+	}
+}
+
+func (s *websocketAPITestSuite) TestSendsPings() {
+	s.setWsAPIKeepalive(20*time.Millisecond, time.Minute)
+	pings := make(chan struct{}, 10)
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		conn.SetPingHandler(func(data string) error {
+			select {
+			case pings <- struct{}{}:
+			default:
+			}
+			return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	defer server.Close()
+
+	client := NewWebsocketAPIClient("dummyAPIKey", "dummySecretKey", url)
+	s.Require().NoError(client.Connect())
+	defer client.Close()
+	for i := 0; i < 3; i++ {
+		select {
+		case <-pings:
+		case <-time.After(5 * time.Second):
+			s.FailNow("no ping received")
+		}
+	}
+	s.False(client.conn.stalled.Load())
+}
+
+func (s *websocketAPITestSuite) TestPongTimeout() {
+	s.setWsAPIKeepalive(20*time.Millisecond, 50*time.Millisecond)
+	closed := make(chan struct{})
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		// never answer pings
+		conn.SetPingHandler(func(string) error { return nil })
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	defer server.Close()
+
+	client := NewWebsocketAPIClient("dummyAPIKey", "dummySecretKey", url)
+	s.Require().NoError(client.Connect())
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		s.FailNow("stale connection not closed")
+	}
+	s.True(client.conn.stalled.Load())
+}
+
+func (s *websocketAPITestSuite) TestCompression() {
+	large := strings.Repeat("a", 64*1024)
+	offered := make(chan string, 2)
+	server, url := newCompressedWsTestServer(offered, func(conn *websocket.Conn) {
+		for {
+			var request struct {
+				ID string `json:"id"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"id":"`+request.ID+`","status":200,"result":{"padding":"`+large+`"}}`))
+		}
+	})
+	defer server.Close()
+
+	plain := NewWebsocketAPIClient("dummyAPIKey", "dummySecretKey", url)
+	s.Require().NoError(plain.Connect())
+	defer plain.Close()
+	s.Empty(<-offered)
+	s.False(plain.Compressed())
+
+	client := NewWebsocketAPIClient("dummyAPIKey", "dummySecretKey", url).SetCompression(true)
+	s.Require().NoError(client.Connect())
+	defer client.Close()
+	s.Contains(<-offered, "permessage-deflate")
+	s.True(client.Compressed())
+	ping, err := client.NewTestConnectivityService().Do(newContext())
+	s.Require().NoError(err)
+	s.Equal(200, ping.Status)
+}
@@ -153,9 +153,9 @@ func (s *OrderPlacementService) Do(ctx context.Context) (*OrderPlacementResponse
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -169,7 +169,7 @@ func (s *OrderPlacementService) Do(ctx context.Context) (*OrderPlacementResponse
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -178,6 +178,9 @@ func (s *OrderPlacementService) Do(ctx context.Context) (*OrderPlacementResponse
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var orderPlacementResponse OrderPlacementResponse
 		err = json.Unmarshal(response, &orderPlacementResponse)
 		if err != nil {
@@ -379,9 +382,9 @@ func (s *TestOrderPlacementService) Do(ctx context.Context) (*OrderPlacementResp
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -395,7 +398,7 @@ func (s *TestOrderPlacementService) Do(ctx context.Context) (*OrderPlacementResp
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -404,6 +407,9 @@ func (s *TestOrderPlacementService) Do(ctx context.Context) (*OrderPlacementResp
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var orderPlacementResponse OrderPlacementResponse
 		err = json.Unmarshal(response, &orderPlacementResponse)
 		if err != nil {
@@ -444,6 +450,9 @@ func (s *OrderStatusService) RecvWindow(recvWindow int64) *OrderStatusService {
 }
 
 func (s *OrderStatusService) Do(ctx context.Context) (*OrderStatusResponse, error) {
+	if err := requireOrderId("OrderStatusService", orderIdParam{"orderId", s.orderId != nil}, orderIdParam{"origClientOrderId", isSet(s.origClientOrderId)}); err != nil {
+		return nil, err
+	}
 	parameters := map[string]string{
 		"symbol": s.symbol,
 	}
@@ -460,9 +469,9 @@ func (s *OrderStatusService) Do(ctx context.Context) (*OrderStatusResponse, erro
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -476,7 +485,7 @@ func (s *OrderStatusService) Do(ctx context.Context) (*OrderStatusResponse, erro
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -485,6 +494,9 @@ func (s *OrderStatusService) Do(ctx context.Context) (*OrderStatusResponse, erro
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var orderStatusResponse OrderStatusResponse
 		err = json.Unmarshal(response, &orderStatusResponse)
 		if err != nil {
@@ -522,7 +534,7 @@ type OrderStatusResult struct {
 	Time                    uint64 `json:"time"`
 	UpdateTime              uint64 `json:"updateTime"`
 	IsWorking               bool   `json:"isWorking"`
-	WorkingTime             uint64 `json:"workingTime"`
+	WorkingTime             int64  `json:"workingTime"`
 	OrigQuoteOrderQty       string `json:"origQuoteOrderQty"`
 	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
 	PreventedMatchId        int64  `json:"preventedMatchId,omitempty"`
@@ -574,6 +586,9 @@ func (s *OrderCancelService) RecvWindow(recvWindow int64) *OrderCancelService {
 }
 
 func (s *OrderCancelService) Do(ctx context.Context) (*OrderCancelResponse, error) {
+	if err := requireOrderId("OrderCancelService", orderIdParam{"orderId", s.orderId != nil}, orderIdParam{"origClientOrderId", isSet(s.origClientOrderId)}); err != nil {
+		return nil, err
+	}
 	parameters := map[string]string{
 		"symbol": s.symbol,
 	}
@@ -598,9 +613,9 @@ func (s *OrderCancelService) Do(ctx context.Context) (*OrderCancelResponse, erro
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -614,7 +629,7 @@ func (s *OrderCancelService) Do(ctx context.Context) (*OrderCancelResponse, erro
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -623,6 +638,9 @@ func (s *OrderCancelService) Do(ctx context.Context) (*OrderCancelResponse, erro
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var orderCancelResponse OrderCancelResponse
 		err = json.Unmarshal(response, &orderCancelResponse)
 		if err != nil {
@@ -798,6 +816,9 @@ func (s *OrderCancelReplaceService) RecvWindow(recvWindow int64) *OrderCancelRep
 }
 
 func (s *OrderCancelReplaceService) Do(ctx context.Context) (*OrderCancelReplaceResponse, error) {
+	if err := requireOrderId("OrderCancelReplaceService", orderIdParam{"cancelOrderId", s.cancelOrderId != nil}, orderIdParam{"cancelOrigClientOrderId", isSet(s.cancelOrigClientOrderId)}); err != nil {
+		return nil, err
+	}
 	parameters := map[string]string{
 		"symbol":            s.symbol,
 		"cancelReplaceMode": s.cancelReplaceMode,
@@ -873,9 +894,9 @@ func (s *OrderCancelReplaceService) Do(ctx context.Context) (*OrderCancelReplace
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -889,7 +910,7 @@ func (s *OrderCancelReplaceService) Do(ctx context.Context) (*OrderCancelReplace
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -898,6 +919,9 @@ func (s *OrderCancelReplaceService) Do(ctx context.Context) (*OrderCancelReplace
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var orderCancelReplaceResponse OrderCancelReplaceResponse
 		err = json.Unmarshal(response, &orderCancelReplaceResponse)
 		if err != nil {
@@ -938,7 +962,7 @@ type OrderPlacedResult struct {
 	TimeInForce             string  `json:"timeInForce,omitempty"`
 	Type                    string  `json:"type,omitempty"`
 	Side                    string  `json:"side,omitempty"`
-	WorkingTime             uint64  `json:"workingTime,omitempty"`
+	WorkingTime             int64   `json:"workingTime,omitempty"`
 	Fills                   []*Fill `json:"fills,omitempty"`
 	SelfTradePreventionMode string  `json:"selfTradePreventionMode,omitempty"`
 }
@@ -995,9 +1019,9 @@ func (s *OpenOrdersStatusService) Do(ctx context.Context) (*OpenOrdersStatusResp
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -1011,7 +1035,7 @@ func (s *OpenOrdersStatusService) Do(ctx context.Context) (*OpenOrdersStatusResp
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -1020,6 +1044,9 @@ func (s *OpenOrdersStatusService) Do(ctx context.Context) (*OpenOrdersStatusResp
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var openOrdersStatusResponse OpenOrdersStatusResponse
 		err = json.Unmarshal(response, &openOrdersStatusResponse)
 		if err != nil {
@@ -1057,7 +1084,7 @@ type OpenOrdersResult struct {
 	Time                    uint64 `json:"time"`
 	UpdateTime              uint64 `json:"updateTime"`
 	IsWorking               bool   `json:"isWorking"`
-	WorkingTime             uint64 `json:"workingTime"`
+	WorkingTime             int64  `json:"workingTime"`
 	OrigQuoteOrderQty       string `json:"origQuoteOrderQty"`
 	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
 }
@@ -1087,9 +1114,9 @@ func (s *OpenOrdersCancelAllService) Do(ctx context.Context) (*OpenOrdersCancelA
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -1103,7 +1130,7 @@ func (s *OpenOrdersCancelAllService) Do(ctx context.Context) (*OpenOrdersCancelA
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -1112,6 +1139,9 @@ func (s *OpenOrdersCancelAllService) Do(ctx context.Context) (*OpenOrdersCancelA
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var openOrdersCancelAllResponse OpenOrdersCancelAllResponse
 		err = json.Unmarshal(response, &openOrdersCancelAllResponse)
 		if err != nil {
@@ -1343,9 +1373,9 @@ func (s *OrderListPlaceService) Do(ctx context.Context) (*OrderListPlaceResponse
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -1359,7 +1389,7 @@ func (s *OrderListPlaceService) Do(ctx context.Context) (*OrderListPlaceResponse
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -1368,6 +1398,9 @@ func (s *OrderListPlaceService) Do(ctx context.Context) (*OrderListPlaceResponse
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var orderListPlaceResponse OrderListPlaceResponse
 		err = json.Unmarshal(response, &orderListPlaceResponse)
 		if err != nil {
@@ -1419,7 +1452,7 @@ type OrderReport struct {
 	TimeInForce             string `json:"timeInForce"`
 	Type                    string `json:"type"`
 	Side                    string `json:"side"`
-	WorkingTime             uint64 `json:"workingTime"`
+	WorkingTime             int64  `json:"workingTime"`
 	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
 }
 
@@ -1446,6 +1479,9 @@ func (s *OrderListStatusService) RecvWindow(recvWindow int64) *OrderListStatusSe
 }
 
 func (s *OrderListStatusService) Do(ctx context.Context) (*OrderListStatusResponse, error) {
+	if err := requireOrderId("OrderListStatusService", orderIdParam{"orderListId", s.orderListId != nil}, orderIdParam{"origClientOrderId", isSet(s.origClientOrderId)}); err != nil {
+		return nil, err
+	}
 	parameters := map[string]string{}
 
 	if s.origClientOrderId != nil {
@@ -1460,9 +1496,9 @@ func (s *OrderListStatusService) Do(ctx context.Context) (*OrderListStatusRespon
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -1476,7 +1512,7 @@ func (s *OrderListStatusService) Do(ctx context.Context) (*OrderListStatusRespon
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -1485,6 +1521,9 @@ func (s *OrderListStatusService) Do(ctx context.Context) (*OrderListStatusRespon
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var orderListStatusResponse OrderListStatusResponse
 		err = json.Unmarshal(response, &orderListStatusResponse)
 		if err != nil {
@@ -1556,6 +1595,9 @@ func (s *OrderListCancelService) RecvWindow(recvWindow int64) *OrderListCancelSe
 }
 
 func (s *OrderListCancelService) Do(ctx context.Context) (*OrderListCancelResponse, error) {
+	if err := requireOrderId("OrderListCancelService", orderIdParam{"orderListId", s.orderListId != nil}, orderIdParam{"listClientOrderId", isSet(s.listClientOrderId)}); err != nil {
+		return nil, err
+	}
 	parameters := map[string]string{
 		"symbol": s.symbol,
 	}
@@ -1576,9 +1618,9 @@ func (s *OrderListCancelService) Do(ctx context.Context) (*OrderListCancelRespon
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -1592,7 +1634,7 @@ func (s *OrderListCancelService) Do(ctx context.Context) (*OrderListCancelRespon
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -1601,6 +1643,9 @@ func (s *OrderListCancelService) Do(ctx context.Context) (*OrderListCancelRespon
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var orderListCancelResponse OrderListCancelResponse
 		err = json.Unmarshal(response, &orderListCancelResponse)
 		if err != nil {
@@ -1667,9 +1712,9 @@ func (s *OpenOrderListsStatusService) Do(ctx context.Context) (*OpenOrderListsSt
 		parameters["recvWindow"] = strconv.FormatInt(*s.recvWindow, 10)
 	}
 
-	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, parameters)
+	signedParams, err := websocketAPISignature(s.websocketAPI.APIKey, s.websocketAPI.APISecret, s.websocketAPI.KeyType, &s.websocketAPI.signer, parameters)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	id := getUUID()
@@ -1683,7 +1728,7 @@ func (s *OpenOrderListsStatusService) Do(ctx context.Context) (*OpenOrderListsSt
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err2 := s.websocketAPI.SendMessage(payload)
+	err2 := s.websocketAPI.sendRequest(ctx, payload)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -1692,6 +1737,9 @@ func (s *OpenOrderListsStatusService) Do(ctx context.Context) (*OpenOrderListsSt
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var openOrderListsStatusResponse OpenOrderListsStatusResponse
 		err = json.Unmarshal(response, &openOrderListsStatusResponse)
 		if err != nil {
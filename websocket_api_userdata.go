@@ -3,6 +3,7 @@ package binance_connector
 import (
 	"context"
 	"github.com/goccy/go-json"
+	"sync"
 )
 
 type StartUserDataStreamService struct {
@@ -25,7 +26,7 @@ func (s *StartUserDataStreamService) Do(ctx context.Context) (*StartUserDataStre
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -34,6 +35,9 @@ func (s *StartUserDataStreamService) Do(ctx context.Context) (*StartUserDataStre
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var startResponse StartUserDataStreamResponse
 		err = json.Unmarshal(response, &startResponse)
 		if err != nil {
@@ -82,7 +86,7 @@ func (s *PingUserDataStreamService) Do(ctx context.Context) (*PingUserDataStream
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +95,9 @@ func (s *PingUserDataStreamService) Do(ctx context.Context) (*PingUserDataStream
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var pingResponse PingUserDataStreamResponse
 		err = json.Unmarshal(response, &pingResponse)
 		if err != nil {
@@ -137,7 +144,7 @@ func (s *StopUserDataStreamService) Do(ctx context.Context) (*StopUserDataStream
 	messageCh := make(chan []byte)
 	s.websocketAPI.ReqResponseMap[id] = messageCh
 
-	err := s.websocketAPI.SendMessage(payload)
+	err := s.websocketAPI.sendRequest(ctx, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -146,6 +153,9 @@ func (s *StopUserDataStreamService) Do(ctx context.Context) (*StopUserDataStream
 
 	select {
 	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return nil, err
+		}
 		var stopResponse StopUserDataStreamResponse
 		err = json.Unmarshal(response, &stopResponse)
 		if err != nil {
@@ -164,3 +174,185 @@ type StopUserDataStreamResponse struct {
 	Response   struct{}            `json:"result,omitempty"`
 	RateLimits []*WsAPIRateLimit   `json:"rateLimits,omitempty"`
 }
+
+// wsAPIUserData hold the user data subscriptions of a websocket API connection, keyed by subscription id.
+// pending and unsubscribing hold the subscribe and unsubscribe requests in flight, keyed by request id, so that
+// the reader updates the subscriptions before reading the next event.
+type wsAPIUserData struct {
+	mu            sync.Mutex
+	handlers      map[int]WsHandler
+	pending       map[string]WsHandler
+	unsubscribing map[string]int
+}
+
+// wsAPIUserDataEvent define a user data event received on the websocket API connection
+type wsAPIUserDataEvent struct {
+	SubscriptionId *int            `json:"subscriptionId"`
+	Event          json.RawMessage `json:"event"`
+}
+
+// UserDataSubscribeResponse define the response of userDataStream.subscribe.signature
+type UserDataSubscribeResponse struct {
+	ID     string              `json:"id"`
+	Status int                 `json:"status"`
+	Error  *WsAPIErrorResponse `json:"error,omitempty"`
+	Result struct {
+		SubscriptionId int `json:"subscriptionId"`
+	} `json:"result,omitempty"`
+	RateLimits []*WsAPIRateLimit `json:"rateLimits,omitempty"`
+}
+
+// SubscribeUserData subscribe to the user data stream of the account on the websocket API connection, without
+// a listen key to create and keep alive. The request is signed with the API key, so it needs no session.logon
+// and works with HMAC keys. The events are decoded like the ones of WsUserDataServe and passed to handler until
+// UnsubscribeUserData or the end of the connection. The subscription id is returned.
+func (c *WebsocketAPIClient) SubscribeUserData(ctx context.Context, handler WsUserDataHandler, errHandler ErrHandler) (subscriptionId int, err error) {
+	signedParams, err := websocketAPISignature(c.APIKey, c.APISecret, c.KeyType, &c.signer, map[string]string{})
+	if err != nil {
+		return 0, err
+	}
+
+	id := getUUID()
+
+	payload := map[string]interface{}{
+		"id":     id,
+		"method": "userDataStream.subscribe.signature",
+		"params": signedParams,
+	}
+
+	messageCh := make(chan []byte, 1)
+	c.ReqResponseMap[id] = messageCh
+	defer delete(c.ReqResponseMap, id)
+	c.userData.addPending(id, newUserDataWsHandler(handler, errHandler))
+	defer c.userData.removePending(id)
+
+	err = c.sendRequest(ctx, payload)
+	if err != nil {
+		return 0, err
+	}
+
+	select {
+	case response := <-messageCh:
+		if err := wsAPIError(response); err != nil {
+			return 0, err
+		}
+		var subscribeResponse UserDataSubscribeResponse
+		err = json.Unmarshal(response, &subscribeResponse)
+		if err != nil {
+			return 0, err
+		}
+		return subscribeResponse.Result.SubscriptionId, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// UnsubscribeUserData stop the user data subscription subscriptionId of the connection
+func (c *WebsocketAPIClient) UnsubscribeUserData(ctx context.Context, subscriptionId int) error {
+	id := getUUID()
+
+	payload := map[string]interface{}{
+		"id":     id,
+		"method": "userDataStream.unsubscribe",
+		"params": map[string]interface{}{
+			"subscriptionId": subscriptionId,
+		},
+	}
+
+	messageCh := make(chan []byte, 1)
+	c.ReqResponseMap[id] = messageCh
+	defer delete(c.ReqResponseMap, id)
+	c.userData.addUnsubscribing(id, subscriptionId)
+	defer c.userData.removePending(id)
+
+	err := c.sendRequest(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case response := <-messageCh:
+		return wsAPIError(response)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (u *wsAPIUserData) addPending(id string, handler WsHandler) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.pending == nil {
+		u.pending = make(map[string]WsHandler)
+	}
+	u.pending[id] = handler
+}
+
+func (u *wsAPIUserData) addUnsubscribing(id string, subscriptionId int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.unsubscribing == nil {
+		u.unsubscribing = make(map[string]int)
+	}
+	u.unsubscribing[id] = subscriptionId
+}
+
+func (u *wsAPIUserData) removePending(id string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.pending, id)
+	delete(u.unsubscribing, id)
+}
+
+// reset drop the subscriptions of a previous connection
+func (u *wsAPIUserData) reset() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.handlers = nil
+	u.pending = nil
+	u.unsubscribing = nil
+}
+
+// responded update the subscriptions once the response of the subscribe or unsubscribe request id arrived
+func (u *wsAPIUserData) responded(id string, message []byte) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	handler, subscribing := u.pending[id]
+	subscriptionId, unsubscribing := u.unsubscribing[id]
+	if !subscribing && !unsubscribing {
+		return
+	}
+	delete(u.pending, id)
+	delete(u.unsubscribing, id)
+	var response UserDataSubscribeResponse
+	if err := json.Unmarshal(message, &response); err != nil || newWsAPIError(response.Status, response.Error) != nil {
+		return
+	}
+	if unsubscribing {
+		delete(u.handlers, subscriptionId)
+		return
+	}
+	if u.handlers == nil {
+		u.handlers = make(map[int]WsHandler)
+	}
+	u.handlers[response.Result.SubscriptionId] = handler
+}
+
+// dispatch pass a user data event to the handler of its subscription, every handler when the event does not
+// name its subscription
+func (u *wsAPIUserData) dispatch(event *wsAPIUserDataEvent) {
+	u.mu.Lock()
+	var handlers []WsHandler
+	if event.SubscriptionId != nil {
+		if handler, ok := u.handlers[*event.SubscriptionId]; ok {
+			handlers = append(handlers, handler)
+		}
+	} else {
+		for _, handler := range u.handlers {
+			handlers = append(handlers, handler)
+		}
+	}
+	u.mu.Unlock()
+	for _, handler := range handlers {
+		handler(event.Event)
+	}
+}
@@ -0,0 +1,135 @@
+package binance_connector
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+type websocketAPIUserDataTestSuite struct {
+	suite.Suite
+	params chan map[string]interface{}
+}
+
+func TestWebsocketAPIUserData(t *testing.T) {
+	suite.Run(t, new(websocketAPIUserDataTestSuite))
+}
+
+// newClient connects a websocket API client to a server accepting user data subscriptions with ids from 3,
+// and sending the events of every subscription after its response
+func (s *websocketAPIUserDataTestSuite) newClient(events ...string) *WebsocketAPIClient {
+	s.params = make(chan map[string]interface{}, 4)
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		next := 3
+		for {
+			var request struct {
+				ID     string                 `json:"id"`
+				Method string                 `json:"method"`
+				Params map[string]interface{} `json:"params"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			s.params <- request.Params
+			var messages []string
+			switch request.Method {
+			case "userDataStream.subscribe.signature":
+				if request.Params["apiKey"] == "rejectedAPIKey" {
+					messages = append(messages, `{"id":"`+request.ID+`","status":400,"error":{"code":-2015,"msg":"Invalid API-key, IP, or permissions for action."}}`)
+					break
+				}
+				subscription := strconv.Itoa(next)
+				next++
+				messages = append(messages, `{"id":"`+request.ID+`","status":200,"result":{"subscriptionId":`+subscription+`}}`)
+				for _, event := range events {
+					messages = append(messages, `{"subscriptionId":`+subscription+`,"event":`+event+`}`)
+				}
+			case "userDataStream.unsubscribe":
+				messages = append(messages, `{"id":"`+request.ID+`","status":200,"result":{}}`)
+				// a late event of the subscription is dropped
+				for _, event := range events {
+					messages = append(messages, `{"subscriptionId":3,"event":`+event+`}`)
+				}
+			}
+			for _, message := range messages {
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+					return
+				}
+			}
+		}
+	})
+	s.T().Cleanup(server.Close)
+
+	client := NewWebsocketAPIClient("dummyAPIKey", "dummySecretKey", url)
+	s.Require().NoError(client.Connect())
+	s.T().Cleanup(func() { client.Close() })
+	return client
+}
+
+func (s *websocketAPIUserDataTestSuite) nextEvent(events chan *WsUserDataEvent) *WsUserDataEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		s.FailNow("no user data event received")
+	}
+	return nil
+}
+
+func (s *websocketAPIUserDataTestSuite) TestSubscribe() {
+	client := s.newClient(
+		`{"e":"balanceUpdate","E":1573200697110,"a":"BTC","d":"100.00000000","T":1573200697068}`,
+		`{"e":"executionReport","E":1499405658658,"s":"ETHBTC","c":"mUvoqJxFIILMdfAW5iGSOW","S":"BUY","o":"LIMIT","X":"NEW","i":4293153}`,
+	)
+	events := make(chan *WsUserDataEvent, 8)
+	errs := make(chan error, 8)
+	subscriptionId, err := client.SubscribeUserData(context.Background(), func(event *WsUserDataEvent) { events <- event }, func(err error) { errs <- err })
+	s.Require().NoError(err)
+	s.Equal(3, subscriptionId)
+
+	params := <-s.params
+	s.Equal("dummyAPIKey", params["apiKey"])
+	s.NotEmpty(params["timestamp"])
+	s.NotEmpty(params["signature"])
+
+	event := s.nextEvent(events)
+	s.Equal(UserDataEventTypeBalanceUpdate, event.Event)
+	s.Equal("BTC", event.BalanceUpdate.Asset)
+	event = s.nextEvent(events)
+	s.Equal(UserDataEventTypeExecutionReport, event.Event)
+	s.Equal("ETHBTC", event.OrderUpdate.Symbol)
+	s.Equal(int64(4293153), event.OrderUpdate.Id)
+
+	s.Require().NoError(client.UnsubscribeUserData(context.Background(), subscriptionId))
+	s.Equal(map[string]interface{}{"subscriptionId": float64(3)}, <-s.params)
+
+	// the connection still serves requests, and no event of the stopped subscription is dispatched
+	_, err = client.SubscribeUserData(context.Background(), func(event *WsUserDataEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	s.Empty(events)
+	s.Empty(errs)
+}
+
+func (s *websocketAPIUserDataTestSuite) TestSubscribeError() {
+	client := s.newClient()
+	client.APIKey = "rejectedAPIKey"
+	_, err := client.SubscribeUserData(context.Background(), func(event *WsUserDataEvent) {}, func(err error) {})
+	s.Require().True(handlers.IsAPIError(err))
+	s.Equal(int64(-2015), err.(*handlers.APIError).Code)
+	s.Empty(client.userData.handlers)
+	s.Empty(client.userData.pending)
+	s.Empty(client.userData.unsubscribing)
+}
+
+func (s *websocketAPIUserDataTestSuite) TestSubscribeWithoutCredentials() {
+	client := s.newClient()
+	client.APISecret = ""
+	_, err := client.SubscribeUserData(context.Background(), func(event *WsUserDataEvent) {}, func(err error) {})
+	s.Error(err)
+	s.Empty(s.params)
+}
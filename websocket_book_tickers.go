@@ -0,0 +1,103 @@
+package binance_connector
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+)
+
+// WsStreamGroupError is passed to the error handler of a helper opening several connections,
+// Symbols are the symbols of the connection that failed
+type WsStreamGroupError struct {
+	Symbols []string
+	Err     error
+}
+
+func (e *WsStreamGroupError) Error() string {
+	return fmt.Sprintf("stream connection of %s: %v", strings.Join(e.Symbols, ","), e.Err)
+}
+
+func (e *WsStreamGroupError) Unwrap() error {
+	return e.Err
+}
+
+// combinedEndpoint return the combined stream endpoint of the client, whether it is combined or not
+func (c *WebsocketStreamClient) combinedEndpoint() string {
+	if c.IsCombined {
+		return c.Endpoint
+	}
+	return strings.TrimSuffix(c.Endpoint, "/ws") + "/stream?streams="
+}
+
+// SubscribeBookTickers serve the bookTicker streams of symbols on combined connections of at most
+// WebsocketMaxStreamsPerConnection streams each, like a WsStreamPool, with the streams in the
+// connection URL rather than subscribed one by one. Errors are reported as a *WsStreamGroupError with the
+// symbols of the affected connection. doneCh is closed once every connection ended, stopCh stops them all.
+func (c *WebsocketStreamClient) SubscribeBookTickers(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
+	if len(symbols) == 0 {
+		return nil, nil, fmt.Errorf("no symbols")
+	}
+	size := WebsocketMaxStreamsPerConnection
+	if size <= 0 {
+		size = len(symbols)
+	}
+	wsHandler := func(message []byte) {
+		event := new(WsCombinedBookTickerEvent)
+		if err := json.Unmarshal(message, event); err != nil {
+			errHandler.report(err)
+			return
+		}
+		if event.Data != nil {
+			handler(event.Data)
+		}
+	}
+
+	var stops []chan struct{}
+	var wg sync.WaitGroup
+	for start := 0; start < len(symbols); start += size {
+		group := append([]string(nil), symbols[start:min(start+size, len(symbols))]...)
+		streams := make([]string, 0, len(group))
+		for _, symbol := range group {
+			streams = append(streams, strings.ToLower(symbol)+"@bookTicker")
+		}
+		groupErrHandler := func(err error) {
+			errHandler.report(&WsStreamGroupError{Symbols: group, Err: err})
+		}
+		done, stop, err := c.serve(newWsConfig(c.combinedEndpoint()+strings.Join(streams, "/")), wsHandler, groupErrHandler)
+		if err != nil {
+			for _, stop := range stops {
+				stop <- struct{}{}
+			}
+			wg.Wait()
+			return nil, nil, &WsStreamGroupError{Symbols: group, Err: err}
+		}
+		stops = append(stops, stop)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-done
+		}()
+	}
+
+	doneCh = make(chan struct{})
+	stopCh = make(chan struct{}, 1)
+	go func() {
+		wg.Wait()
+		close(doneCh)
+	}()
+	go func() {
+		select {
+		case <-stopCh:
+			for _, stop := range stops {
+				stop <- struct{}{}
+			}
+		case <-doneCh:
+		}
+	}()
+	return doneCh, stopCh, nil
+}
@@ -0,0 +1,131 @@
+package binance_connector
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type bookTickersTestSuite struct {
+	suite.Suite
+	server  *httptest.Server
+	url     string
+	mu      sync.Mutex
+	queries []string
+}
+
+func TestSubscribeBookTickers(t *testing.T) {
+	suite.Run(t, new(bookTickersTestSuite))
+}
+
+// SetupTest starts a server sending one bookTicker event per stream of the connection URL,
+// connections with ethusdt are closed with a policy violation after the events
+func (s *bookTickersTestSuite) SetupTest() {
+	s.queries = nil
+	upgrader := websocket.Upgrader{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		streams := r.URL.Query().Get("streams")
+		s.mu.Lock()
+		s.queries = append(s.queries, r.URL.Path+"?"+streams)
+		s.mu.Unlock()
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, stream := range strings.Split(streams, "/") {
+			symbol := strings.ToUpper(strings.TrimSuffix(stream, "@bookTicker"))
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"`+stream+`","data":{"u":1,"s":"`+symbol+`","b":"1.0","B":"2.0","a":"1.1","A":"3.0"}}`))
+		}
+		if strings.Contains(streams, "ethusdt") {
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "closed"), time.Now().Add(time.Second))
+		}
+		conn.ReadMessage()
+	}))
+	s.url = "ws" + strings.TrimPrefix(s.server.URL, "http")
+
+	orig := WebsocketMaxStreamsPerConnection
+	s.T().Cleanup(func() { WebsocketMaxStreamsPerConnection = orig })
+	WebsocketMaxStreamsPerConnection = 2
+}
+
+func (s *bookTickersTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *bookTickersTestSuite) TestSplitConnections() {
+	events := make(chan string, 10)
+	c := NewWebsocketStreamClient(false, s.url)
+	doneCh, stopCh, err := c.SubscribeBookTickers([]string{"BTCUSDT", "BNBUSDT", "XRPUSDT"}, func(event *WsBookTickerEvent) {
+		events <- event.Symbol
+	}, func(err error) { s.Fail(err.Error()) })
+	s.Require().NoError(err)
+
+	var symbols []string
+	for i := 0; i < 3; i++ {
+		select {
+		case symbol := <-events:
+			symbols = append(symbols, symbol)
+		case <-time.After(5 * time.Second):
+			s.FailNow("event not received")
+		}
+	}
+	sort.Strings(symbols)
+	s.Equal([]string{"BNBUSDT", "BTCUSDT", "XRPUSDT"}, symbols)
+	s.Len(c.States(), 2)
+
+	stopCh <- struct{}{}
+	select {
+	case <-doneCh:
+	case <-time.After(5 * time.Second):
+		s.FailNow("connections not stopped")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sort.Strings(s.queries)
+	s.Equal([]string{"/stream?btcusdt@bookTicker/bnbusdt@bookTicker", "/stream?xrpusdt@bookTicker"}, s.queries)
+}
+
+func (s *bookTickersTestSuite) TestConnectionErrorCarriesSymbols() {
+	errs := make(chan error, 10)
+	c := NewWebsocketStreamClient(true, s.url)
+	doneCh, stopCh, err := c.SubscribeBookTickers([]string{"BTCUSDT", "BNBUSDT", "ETHUSDT"}, func(event *WsBookTickerEvent) {}, func(err error) {
+		errs <- err
+	})
+	s.Require().NoError(err)
+	defer func() {
+		stopCh <- struct{}{}
+		<-doneCh
+	}()
+
+	select {
+	case err := <-errs:
+		var groupErr *WsStreamGroupError
+		s.Require().True(errors.As(err, &groupErr))
+		s.Equal([]string{"ETHUSDT"}, groupErr.Symbols)
+		var closeErr *WsCloseError
+		s.Require().ErrorAs(err, &closeErr)
+		s.Equal(websocket.ClosePolicyViolation, closeErr.CloseCode)
+	case <-time.After(5 * time.Second):
+		s.FailNow("error not reported")
+	}
+}
+
+func (s *bookTickersTestSuite) TestDialError() {
+	c := NewWebsocketStreamClient(false, "ws://127.0.0.1:1")
+	_, _, err := c.SubscribeBookTickers([]string{"BTCUSDT"}, func(event *WsBookTickerEvent) {}, func(err error) {})
+	var groupErr *WsStreamGroupError
+	s.Require().ErrorAs(err, &groupErr)
+	s.Equal([]string{"BTCUSDT"}, groupErr.Symbols)
+
+	_, _, err = c.SubscribeBookTickers(nil, func(event *WsBookTickerEvent) {}, func(err error) {})
+	s.Error(err)
+}
@@ -0,0 +1,62 @@
+package binance_connector
+
+import (
+	"bytes"
+	"sync"
+)
+
+var (
+	// WebsocketReuseReadBuffers reads stream messages into buffers taken from a pool instead of
+	// allocating every message, which reduces the garbage of large messages such as !ticker@arr.
+	// The message passed to a WsHandler, or to a raw message handler, is then only valid until the
+	// handler returns: copy it before keeping it or handing it to another goroutine. The typed
+	// handlers of the library decode the message before returning and are not affected.
+	WebsocketReuseReadBuffers = false
+)
+
+// readBufferPool holds the buffers of the stream messages when WebsocketReuseReadBuffers is set
+var readBufferPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// messageReader read the messages of one connection with the settings of the time it was dialed
+type messageReader struct {
+	pooled        bool
+	maxCap        int
+	recoverPanics bool
+}
+
+func newMessageReader() messageReader {
+	return messageReader{
+		pooled:        WebsocketReuseReadBuffers,
+		maxCap:        int(2 * WebsocketReadLimit),
+		recoverPanics: WebsocketRecoverHandlerPanics,
+	}
+}
+
+// read return the next message of c and the pooled buffer holding it, nil when buffers are not reused.
+// The buffer is given back with release once the message is no longer used.
+func (r messageReader) read(c *wsConn) ([]byte, *bytes.Buffer, error) {
+	if !r.pooled {
+		_, message, err := c.ReadMessage()
+		return message, nil, err
+	}
+	buf := readBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	_, reader, err := c.NextReader()
+	if err == nil {
+		_, err = buf.ReadFrom(reader)
+	}
+	if err != nil {
+		r.release(buf)
+		return nil, nil, err
+	}
+	return buf.Bytes(), buf, nil
+}
+
+// release give buf back to the pool, unless it grew past twice the read limit
+// after a message bigger than the usual ones
+func (r messageReader) release(buf *bytes.Buffer) {
+	if buf == nil || buf.Cap() > r.maxCap {
+		return
+	}
+	readBufferPool.Put(buf)
+}
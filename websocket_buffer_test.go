@@ -0,0 +1,134 @@
+package binance_connector
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsBufferTestSuite struct {
+	suite.Suite
+}
+
+func TestWsBuffer(t *testing.T) {
+	suite.Run(t, new(wsBufferTestSuite))
+}
+
+func (s *wsBufferTestSuite) SetupTest() {
+	WebsocketReuseReadBuffers = true
+}
+
+func (s *wsBufferTestSuite) TearDownTest() {
+	WebsocketReuseReadBuffers = false
+}
+
+// tickerArrMessage return a !ticker@arr like message of n tickers
+func tickerArrMessage(n int) []byte {
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(`{"e":"24hrTicker","E":1672515782136,"s":"SYM` + strconv.Itoa(i) + `","p":"0.0015","P":"250.00","w":"0.0018","c":"0.0025","Q":"10","o":"0.0010","h":"0.0025","l":"0.0010","v":"10000","q":"18"}`)
+	}
+	b.WriteByte(']')
+	return b.Bytes()
+}
+
+func (s *wsBufferTestSuite) TestMessagesOfDifferentSizes() {
+	messages := [][]byte{tickerArrMessage(100), []byte(`[]`), tickerArrMessage(3), tickerArrMessage(200)}
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		for _, message := range messages {
+			conn.WriteMessage(websocket.TextMessage, message)
+		}
+		conn.ReadMessage()
+	})
+	defer server.Close()
+
+	received := make(chan []byte, len(messages))
+	doneCh, stopCh, err := wsServe(newWsConfig(url), func(message []byte) {
+		// the message is only valid during the call
+		received <- append([]byte(nil), message...)
+	}, func(err error) {})
+	s.Require().NoError(err)
+	defer func() {
+		close(stopCh)
+		<-doneCh
+	}()
+
+	for _, expected := range messages {
+		select {
+		case message := <-received:
+			s.Equal(string(expected), string(message))
+		case <-time.After(5 * time.Second):
+			s.FailNow("message not received")
+		}
+	}
+}
+
+func (s *wsBufferTestSuite) TestReadLimit() {
+	limit := WebsocketReadLimit
+	WebsocketReadLimit = 1024
+	defer func() { WebsocketReadLimit = limit }()
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		conn.WriteMessage(websocket.TextMessage, tickerArrMessage(100))
+		conn.ReadMessage()
+	})
+	defer server.Close()
+
+	errCh := make(chan error, 1)
+	doneCh, _, err := wsServe(newWsConfig(url), func(message []byte) {
+		s.Fail("message over the read limit delivered")
+	}, func(err error) { errCh <- err })
+	s.Require().NoError(err)
+	<-doneCh
+	s.ErrorIs(<-errCh, websocket.ErrReadLimit)
+}
+
+func benchmarkWsRead(b *testing.B, reuse bool) {
+	message := tickerArrMessage(1500)
+	WebsocketReuseReadBuffers = reuse
+	defer func() { WebsocketReuseReadBuffers = false }()
+	limit := WebsocketReadLimit
+	WebsocketReadLimit = int64(2 * len(message))
+	defer func() { WebsocketReadLimit = limit }()
+
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		for i := 0; i < b.N; i++ {
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		}
+		conn.ReadMessage()
+	})
+	defer server.Close()
+
+	received := make(chan struct{}, 1)
+	count := 0
+	b.SetBytes(int64(len(message)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	doneCh, stopCh, err := wsServe(newWsConfig(url), func(message []byte) {
+		count++
+		if count == b.N {
+			received <- struct{}{}
+		}
+	}, func(err error) {})
+	if err != nil {
+		b.Fatal(err)
+	}
+	<-received
+	b.StopTimer()
+	close(stopCh)
+	<-doneCh
+}
+
+func BenchmarkWsRead(b *testing.B) {
+	b.Run("ReadMessage", func(b *testing.B) { benchmarkWsRead(b, false) })
+	b.Run("ReuseReadBuffers", func(b *testing.B) { benchmarkWsRead(b, true) })
+}
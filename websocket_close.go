@@ -0,0 +1,58 @@
+package binance_connector
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrWsPongTimeout is passed to the error handler when keepalive closed a connection that did not
+// answer pings within the pong timeout
+var ErrWsPongTimeout = errors.New("websocket pong timeout")
+
+// ErrWsReadTimeout is passed to the error handler when no message was read from a connection within
+// WebsocketReadTimeout
+var ErrWsReadTimeout = errors.New("websocket read timeout")
+
+// WsCloseError is passed to the error handler when the server closed the connection, or when the
+// connection dropped without a close frame (CloseCode 1006).
+type WsCloseError struct {
+	CloseCode int
+	CloseText string
+	Err       error
+}
+
+func (e *WsCloseError) Error() string {
+	if e.CloseText != "" {
+		return fmt.Sprintf("websocket closed with code %d: %s", e.CloseCode, e.CloseText)
+	}
+	return fmt.Sprintf("websocket closed with code %d", e.CloseCode)
+}
+
+func (e *WsCloseError) Unwrap() error {
+	return e.Err
+}
+
+// Reconnectable return false for close codes a new connection would get again, e.g. 1008 policy
+// violation, and true for the others, e.g. 1006 when the network dropped the connection
+func (e *WsCloseError) Reconnectable() bool {
+	switch e.CloseCode {
+	case websocket.CloseProtocolError,
+		websocket.CloseUnsupportedData,
+		websocket.CloseInvalidFramePayloadData,
+		websocket.ClosePolicyViolation,
+		websocket.CloseMessageTooBig:
+		return false
+	}
+	return true
+}
+
+// wrapWsCloseError return a WsCloseError for the close error of gorilla/websocket, err otherwise
+func wrapWsCloseError(err error) error {
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		return &WsCloseError{CloseCode: closeErr.Code, CloseText: closeErr.Text, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,169 @@
+package binance_connector
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsCloseTestSuite struct {
+	suite.Suite
+}
+
+func TestWsClose(t *testing.T) {
+	suite.Run(t, new(wsCloseTestSuite))
+}
+
+// serveError return the error passed to the error handler of a connection to a server running serve
+func (s *wsCloseTestSuite) serveError(serve func(conn *websocket.Conn)) error {
+	server, url := newWsTestServer(serve)
+	defer server.Close()
+	errCh := make(chan error, 1)
+	doneCh, _, err := wsServe(newWsConfig(url), func(message []byte) {}, func(err error) { errCh <- err })
+	s.Require().NoError(err)
+	select {
+	case <-doneCh:
+	case <-time.After(5 * time.Second):
+		s.FailNow("connection not closed")
+	}
+	return <-errCh
+}
+
+func (s *wsCloseTestSuite) TestPolicyViolation() {
+	err := s.serveError(func(conn *websocket.Conn) {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "too many streams"), time.Now().Add(time.Second))
+		conn.ReadMessage()
+	})
+	var closeErr *WsCloseError
+	s.Require().ErrorAs(err, &closeErr)
+	s.Equal(websocket.ClosePolicyViolation, closeErr.CloseCode)
+	s.Equal("too many streams", closeErr.CloseText)
+	s.False(closeErr.Reconnectable())
+	s.EqualError(err, "websocket closed with code 1008: too many streams")
+
+	var gorillaErr *websocket.CloseError
+	s.True(errors.As(err, &gorillaErr), "the gorilla error is wrapped")
+}
+
+func (s *wsCloseTestSuite) TestAbnormalClosure() {
+	err := s.serveError(func(conn *websocket.Conn) {
+		// drop the connection without a close frame
+		conn.UnderlyingConn().Close()
+	})
+	var closeErr *WsCloseError
+	s.Require().ErrorAs(err, &closeErr)
+	s.Equal(websocket.CloseAbnormalClosure, closeErr.CloseCode)
+	s.True(closeErr.Reconnectable())
+}
+
+func (s *wsCloseTestSuite) TestOtherErrors() {
+	err := errors.New("read failed")
+	s.Equal(err, wrapWsCloseError(err))
+}
+
+func (s *wsCloseTestSuite) TestPongTimeout() {
+	keepalive, interval, pong := WebsocketKeepalive, WebsocketPingInterval, WebsocketPongTimeout
+	defer func() {
+		WebsocketKeepalive, WebsocketPingInterval, WebsocketPongTimeout = keepalive, interval, pong
+	}()
+	WebsocketKeepalive, WebsocketPingInterval, WebsocketPongTimeout = true, 20*time.Millisecond, 50*time.Millisecond
+
+	err := s.serveError(func(conn *websocket.Conn) {
+		conn.SetPingHandler(func(string) error { return nil })
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	s.ErrorIs(err, ErrWsPongTimeout)
+}
+
+func (s *wsCloseTestSuite) TestReadTimeout() {
+	keepalive, interval, readTimeout := WebsocketKeepalive, WebsocketPingInterval, WebsocketReadTimeout
+	defer func() {
+		WebsocketKeepalive, WebsocketPingInterval, WebsocketReadTimeout = keepalive, interval, readTimeout
+	}()
+	WebsocketKeepalive, WebsocketPingInterval, WebsocketReadTimeout = true, 10*time.Millisecond, 100*time.Millisecond
+
+	started := time.Now()
+	err := s.serveError(func(conn *websocket.Conn) {
+		// a few messages within the timeout, then only pongs
+		for i := 0; i < 5; i++ {
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"trade"}`))
+			time.Sleep(50 * time.Millisecond)
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	s.ErrorIs(err, ErrWsReadTimeout)
+	s.GreaterOrEqual(time.Since(started), 300*time.Millisecond, "the deadline is refreshed by each message")
+}
+
+func (s *wsCloseTestSuite) TestClientKeepalive() {
+	keepalive := WebsocketKeepalive
+	defer func() { WebsocketKeepalive = keepalive }()
+	WebsocketKeepalive = false
+
+	// the client enables keepalive with its own timeout while the package default is off
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		conn.SetPingHandler(func(string) error { return nil })
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	defer server.Close()
+	errCh := make(chan error, 1)
+	client := NewWebsocketStreamClient(false, url).SetKeepalive(true, 50*time.Millisecond)
+	doneCh, _, err := client.WsAggTradeServe("BTCUSDT", func(event *WsAggTradeEvent) {}, func(err error) { errCh <- err })
+	s.Require().NoError(err)
+	select {
+	case <-doneCh:
+	case <-time.After(5 * time.Second):
+		s.FailNow("connection not closed")
+	}
+	s.ErrorIs(<-errCh, ErrWsPongTimeout)
+
+	// and disables it while the package default is on
+	WebsocketKeepalive = true
+	pings := make(chan struct{}, 10)
+	server, url = newWsTestServer(func(conn *websocket.Conn) {
+		conn.SetPingHandler(func(string) error {
+			pings <- struct{}{}
+			return nil
+		})
+		conn.ReadMessage()
+	})
+	defer server.Close()
+	client = NewWebsocketStreamClient(false, url).SetKeepalive(false, 0)
+	doneCh, stopCh, err := client.WsAggTradeServe("BTCUSDT", func(event *WsAggTradeEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	time.Sleep(50 * time.Millisecond)
+	close(stopCh)
+	<-doneCh
+	s.Empty(pings)
+}
+
+func (s *wsCloseTestSuite) TestKeepAliveConfigOverride() {
+	keepalive := WebsocketKeepalive
+	defer func() { WebsocketKeepalive = keepalive }()
+	WebsocketKeepalive = true
+
+	cfg := newWsConfig("wss://stream.binance.com:9443/ws/btcusdt@trade")
+	keepAliveCfg, ok := cfg.keepAliveConfig()
+	s.True(ok)
+	s.Equal(newKeepAliveConfig(WebsocketTimeout), keepAliveCfg)
+
+	NewWebsocketStreamClient(false).SetKeepalive(true, 10*time.Second).applyKeepAlive(cfg)
+	keepAliveCfg, ok = cfg.keepAliveConfig()
+	s.True(ok)
+	s.Equal(keepAliveConfig{pingInterval: 10 * time.Second, pingWriteTimeout: WebsocketPingWriteTimeout, pongTimeout: 10 * time.Second}, keepAliveCfg)
+}
@@ -0,0 +1,55 @@
+package binance_connector
+
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WsConnectHandler handle a stream connection once it is established, before its first message is read.
+// stream is the connection endpoint relative to the client endpoint, e.g. btcusdt@depth.
+type WsConnectHandler func(stream string, conn *WsConn)
+
+// WsConn gives advanced users access to a stream connection, e.g. to read its addresses, set socket
+// options or send custom control frames. The write methods hold the write lock the library shares with
+// keepalive; gorilla/websocket supports only one concurrent writer, so never write through Conn directly.
+type WsConn struct {
+	conn *wsConn
+}
+
+// Conn return the underlying connection. It is unsafe with respect to the library: reading from it, closing
+// it, writing to it or replacing its ping, pong or close handlers races with the library read loop and
+// keepalive. Only use it for what the WsConn methods do not cover, such as UnderlyingConn or LocalAddr.
+func (c *WsConn) Conn() *websocket.Conn {
+	return c.conn.Conn
+}
+
+// WriteMessage write a data frame while holding the library write lock
+func (c *WsConn) WriteMessage(messageType int, data []byte) error {
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// WriteControl write a control frame while holding the library write lock
+func (c *WsConn) WriteControl(messageType int, data []byte, deadline time.Time) error {
+	return c.conn.WriteControl(messageType, data, deadline)
+}
+
+// OnConnect set the handler called once per connection of the client, multiplexers and reconnections
+// included. It is called synchronously before the connection is read, a slow handler delays the first message.
+// This is an advanced hook, see WsConn for what is safe to do with the connection.
+func (c *WebsocketStreamClient) OnConnect(handler WsConnectHandler) *WebsocketStreamClient {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.onConnect = handler
+	return c
+}
+
+// connected report the connection to endpoint to the connect handler
+func (c *WebsocketStreamClient) connected(endpoint string, conn *wsConn) {
+	c.stateMu.Lock()
+	handler := c.onConnect
+	c.stateMu.Unlock()
+	if handler != nil {
+		handler(c.streamName(endpoint), &WsConn{conn: conn})
+	}
+}
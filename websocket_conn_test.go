@@ -0,0 +1,61 @@
+package binance_connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsConnectTestSuite struct {
+	suite.Suite
+}
+
+func TestWsConnect(t *testing.T) {
+	suite.Run(t, new(wsConnectTestSuite))
+}
+
+func (s *wsConnectTestSuite) TestOnConnect() {
+	received := make(chan string, 4)
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		received <- string(message)
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"trade","s":"BTCUSDT","p":"1"}`))
+	})
+	defer server.Close()
+
+	type connected struct {
+		stream string
+		local  string
+	}
+	connects := make(chan connected, 4)
+	client := NewWebsocketStreamClient(false, url).OnConnect(func(stream string, conn *WsConn) {
+		connects <- connected{stream, conn.Conn().LocalAddr().String()}
+		s.NoError(conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)))
+		s.NoError(conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+	})
+
+	for i := 0; i < 2; i++ {
+		doneCh, _, err := client.WsTradeServe("BTCUSDT", func(event *WsTradeEvent) {}, func(err error) {})
+		s.Require().NoError(err)
+		c := <-connects
+		s.Equal("btcusdt@trade", c.stream)
+		s.NotEmpty(c.local)
+		s.Equal("hello", <-received)
+		<-doneCh
+	}
+
+	m := NewWebsocketStreamClient(true, url).OnConnect(func(stream string, conn *WsConn) {
+		connects <- connected{stream: stream}
+		conn.WriteMessage(websocket.TextMessage, []byte("hello"))
+	}).NewStreamMultiplexer(func(err error) {})
+	doneCh, _, err := m.Register("btcusdt@trade", func(message []byte) {}).Start()
+	s.Require().NoError(err)
+	s.Equal("btcusdt@trade", (<-connects).stream)
+	s.Equal("hello", <-received)
+	<-doneCh
+}
@@ -0,0 +1,94 @@
+package binance_connector
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/luciano-personal-org/binance-connector/handlers"
+)
+
+// WsStreamError is passed to the error handler for an error frame of the stream API that no pending
+// request waits for, e.g. the answer to a malformed SUBSCRIBE sent through WsConn. The error frames are
+// never delivered to the stream handlers as market data.
+type WsStreamError struct {
+	// ID is the id of the request as sent, empty when Binance could not read it
+	ID  string
+	Err *handlers.APIError
+}
+
+func (e *WsStreamError) Error() string {
+	if e.ID == "" {
+		return fmt.Sprintf("stream request: %v", e.Err)
+	}
+	return fmt.Sprintf("stream request %s: %v", e.ID, e.Err)
+}
+
+func (e *WsStreamError) Unwrap() error {
+	return e.Err
+}
+
+// wsStreamErrorBody is the error of a stream API response
+type wsStreamErrorBody struct {
+	Code    int64  `json:"code"`
+	Message string `json:"msg"`
+}
+
+func (e *wsStreamErrorBody) apiError() *handlers.APIError {
+	return &handlers.APIError{Code: e.Code, Message: e.Message}
+}
+
+// wsControlFrame is a combined stream message, or the response to a request of the stream API such as
+// {"result":null,"id":1} or {"error":{"code":2,"msg":"Invalid request"},"id":1}
+type wsControlFrame struct {
+	Stream string             `json:"stream"`
+	ID     json.RawMessage    `json:"id"`
+	Error  *wsStreamErrorBody `json:"error"`
+}
+
+// wsStreamFramePrefix starts every combined stream message sent by Binance
+var wsStreamFramePrefix = []byte(`{"stream"`)
+
+// parseControlFrame return the response to a stream API request carried by message, false for a stream message.
+// The stream messages are recognized by their prefix and not decoded.
+func parseControlFrame(message []byte) (*wsControlFrame, bool) {
+	if bytes.HasPrefix(message, wsStreamFramePrefix) {
+		return nil, false
+	}
+	var frame wsControlFrame
+	if err := json.Unmarshal(message, &frame); err != nil || frame.Stream != "" {
+		return nil, false
+	}
+	if len(frame.ID) == 0 && frame.Error == nil {
+		return nil, false
+	}
+	return &frame, true
+}
+
+// requestID return the id of the request as sent, empty when it is null
+func (f *wsControlFrame) requestID() string {
+	id := string(f.ID)
+	if id == "null" {
+		return ""
+	}
+	return strings.Trim(id, `"`)
+}
+
+// withControlFrames wrap the handler of a combined connection to keep the responses to stream API requests
+// away from it: the errors are reported to errHandler and the results dropped. Other connections are unchanged.
+func withControlFrames(endpoint string, handler WsHandler, errHandler ErrHandler) WsHandler {
+	if !strings.Contains(endpoint, "?streams=") {
+		return handler
+	}
+	return func(message []byte) {
+		frame, ok := parseControlFrame(message)
+		if !ok {
+			handler(message)
+			return
+		}
+		if frame.Error != nil {
+			errHandler.report(&WsStreamError{ID: frame.requestID(), Err: frame.Error.apiError()})
+		}
+	}
+}
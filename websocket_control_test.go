@@ -0,0 +1,114 @@
+package binance_connector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsControlTestSuite struct {
+	suite.Suite
+}
+
+func TestWsControlFrames(t *testing.T) {
+	suite.Run(t, new(wsControlTestSuite))
+}
+
+// serveErrorFrames injects the responses to stream API requests between two aggTrade messages
+func serveErrorFrames(conn *websocket.Conn) {
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"btcusdt@aggTrade","data":{"e":"aggTrade","s":"BTCUSDT","a":1}}`))
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"error":{"code":2,"msg":"Invalid request: unknown variant"},"id":7}`))
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"result":null,"id":8}`))
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"error":{"code":3,"msg":"Invalid JSON: expected value at line 1 column 1"},"id":null}`))
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"btcusdt@aggTrade","data":{"e":"aggTrade","s":"BTCUSDT","a":2}}`))
+	conn.ReadMessage()
+}
+
+func (s *wsControlTestSuite) TestCombinedServe() {
+	server, url := newWsTestServer(serveErrorFrames)
+	defer server.Close()
+
+	var raw []string
+	client := NewWebsocketStreamClient(true, url).OnRawMessage(func(stream string, data []byte) { raw = append(raw, stream) })
+	events := make(chan *WsAggTradeEvent, 10)
+	errs := make(chan error, 10)
+	doneCh, stopCh, err := client.WsCombinedAggTradeServe([]string{"BTCUSDT"}, func(event *WsAggTradeEvent) {
+		events <- event
+	}, func(err error) { errs <- err })
+	s.Require().NoError(err)
+
+	s.Equal(int64(1), s.receiveEvent(events).AggTradeID)
+	s.Equal(int64(2), s.receiveEvent(events).AggTradeID, "the control frames are not delivered as data")
+	close(stopCh)
+	<-doneCh
+
+	s.Require().Len(errs, 2)
+	var streamErr *WsStreamError
+	s.Require().ErrorAs(<-errs, &streamErr)
+	s.Equal("7", streamErr.ID)
+	s.Equal(&handlers.APIError{Code: 2, Message: "Invalid request: unknown variant"}, streamErr.Err)
+	s.EqualError(streamErr, "stream request 7: <APIError> code=2, msg=Invalid request: unknown variant")
+	var apiErr *handlers.APIError
+	s.Require().ErrorAs(<-errs, &apiErr)
+	s.Equal(int64(3), apiErr.Code)
+	s.Len(raw, 5, "the raw message handler still receives every frame")
+}
+
+func (s *wsControlTestSuite) receiveEvent(events chan *WsAggTradeEvent) *WsAggTradeEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		s.FailNow("timed out waiting for an event")
+	}
+	return nil
+}
+
+func (s *wsControlTestSuite) TestMultiplexer() {
+	server, url := newWsTestServer(serveErrorFrames)
+	defer server.Close()
+
+	messages := make(chan string, 10)
+	errs := make(chan error, 10)
+	m := NewWebsocketStreamClient(true, url).NewStreamMultiplexer(func(err error) { errs <- err })
+	m.Register("btcusdt@aggTrade", func(message []byte) { messages <- string(message) })
+	doneCh, stopCh, err := m.Start()
+	s.Require().NoError(err)
+
+	for _, expected := range []string{`"a":1`, `"a":2`} {
+		select {
+		case message := <-messages:
+			s.Contains(message, expected)
+		case <-time.After(5 * time.Second):
+			s.FailNow("timed out waiting for a message")
+		}
+	}
+	close(stopCh)
+	<-doneCh
+
+	// the requests 7 and 8 are not pending, only the errors are reported
+	s.Require().Len(errs, 2)
+	var streamErr *WsStreamError
+	s.Require().ErrorAs(<-errs, &streamErr)
+	s.Equal("7", streamErr.ID)
+	s.Require().ErrorAs(<-errs, &streamErr)
+	s.Empty(streamErr.ID)
+	s.Equal(int64(3), streamErr.Err.Code)
+
+	s.ErrorIs(m.Subscribe(context.Background(), "ethusdt@aggTrade", func([]byte) {}), ErrMultiplexerNotConnected)
+}
+
+func (s *wsControlTestSuite) TestParseControlFrame() {
+	_, ok := parseControlFrame([]byte(`{"stream":"btcusdt@trade","data":{"id":1}}`))
+	s.False(ok)
+	_, ok = parseControlFrame([]byte(`{"data":{"e":"trade"},"stream":"btcusdt@trade"}`))
+	s.False(ok, "a stream message in another field order")
+	frame, ok := parseControlFrame([]byte(`{"result":null,"id":"my-request"}`))
+	s.True(ok)
+	s.Equal("my-request", frame.requestID())
+	s.Nil(frame.Error)
+}
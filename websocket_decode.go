@@ -0,0 +1,105 @@
+package binance_connector
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+)
+
+// The pooled stream variants decode every message into an event taken from a sync.Pool and
+// hand it back once the handler returned. They avoid an allocation per message on high rate
+// streams, at the cost that the event is only valid during the handler call: copy the event,
+// not the pointer, to keep it.
+
+var (
+	aggTradeEventPool = sync.Pool{New: func() interface{} { return new(WsAggTradeEvent) }}
+	bookTickerPool    = sync.Pool{New: func() interface{} { return new(WsBookTickerEvent) }}
+)
+
+// wsPooledCombinedFrame is a combined stream message whose data is decoded into a pooled event
+type wsPooledCombinedFrame struct {
+	Stream string      `json:"stream"`
+	Data   interface{} `json:"data"`
+}
+
+// WsAggTradePooledServe is similar to WsAggTradeServe, but event is reused once handler returned
+func (c *WebsocketStreamClient) WsAggTradePooledServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
+	endpoint := fmt.Sprintf("%s/%s@aggTrade", c.Endpoint, strings.ToLower(symbol))
+	return c.serve(newWsConfig(endpoint), newPooledAggTradeHandler(false, handler, errHandler), errHandler)
+}
+
+// WsCombinedAggTradePooledServe is similar to WsCombinedAggTradeServe, but event is reused once handler returned
+func (c *WebsocketStreamClient) WsCombinedAggTradePooledServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
+	streams := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		streams = append(streams, fmt.Sprintf("%s@aggTrade", strings.ToLower(symbol)))
+	}
+	endpoint := c.Endpoint + strings.Join(streams, "/")
+	return c.serve(newWsConfig(endpoint), newPooledAggTradeHandler(true, handler, errHandler), errHandler)
+}
+
+// WsBookTickerPooledServe is similar to WsBookTickerServe, but event is reused once handler returned
+func (c *WebsocketStreamClient) WsBookTickerPooledServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
+	endpoint := fmt.Sprintf("%s/%s@bookTicker", c.Endpoint, strings.ToLower(symbol))
+	return c.serve(newWsConfig(endpoint), newPooledBookTickerHandler(false, handler, errHandler), errHandler)
+}
+
+// WsCombinedBookTickerPooledServe is similar to WsCombinedBookTickerServe, but event is reused once handler returned
+func (c *WebsocketStreamClient) WsCombinedBookTickerPooledServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
+	streams := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		streams = append(streams, fmt.Sprintf("%s@bookTicker", strings.ToLower(symbol)))
+	}
+	endpoint := c.Endpoint + strings.Join(streams, "/")
+	return c.serve(newWsConfig(endpoint), newPooledBookTickerHandler(true, handler, errHandler), errHandler)
+}
+
+func newPooledAggTradeHandler(combined bool, handler WsAggTradeHandler, errHandler ErrHandler) WsHandler {
+	return func(message []byte) {
+		event := aggTradeEventPool.Get().(*WsAggTradeEvent)
+		defer aggTradeEventPool.Put(event)
+		// fields absent from the message must not keep the value of the previous one
+		*event = WsAggTradeEvent{}
+		if err := decodePooled(combined, message, event); err != nil {
+			errHandler.report(err)
+			return
+		}
+		handler(event)
+	}
+}
+
+func newPooledBookTickerHandler(combined bool, handler WsBookTickerHandler, errHandler ErrHandler) WsHandler {
+	return func(message []byte) {
+		event := bookTickerPool.Get().(*WsBookTickerEvent)
+		defer bookTickerPool.Put(event)
+		*event = WsBookTickerEvent{}
+		if err := decodePooled(combined, message, event); err != nil {
+			errHandler.report(err)
+			return
+		}
+		handler(event)
+	}
+}
+
+// decodePooled decode message into event, unwrapping the data of a combined stream message
+func decodePooled(combined bool, message []byte, event interface{}) error {
+	if !combined {
+		return json.Unmarshal(message, event)
+	}
+	frame := wsPooledCombinedFrame{Data: event}
+	return json.Unmarshal(message, &frame)
+}
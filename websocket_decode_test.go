@@ -0,0 +1,165 @@
+package binance_connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	aggTradeMessage           = []byte(`{"e":"aggTrade","E":1672515782136,"s":"BNBBTC","a":12345,"p":"0.001","q":"100","f":100,"l":105,"T":1672515782136,"m":true,"M":true}`)
+	combinedAggTradeMessage   = []byte(`{"stream":"bnbbtc@aggTrade","data":{"e":"aggTrade","E":1672515782136,"s":"BNBBTC","a":12345,"p":"0.001","q":"100","f":100,"l":105,"T":1672515782136,"m":true,"M":true}}`)
+	bookTickerMessage         = []byte(`{"u":400900217,"s":"BNBUSDT","b":"25.35190000","B":"31.21000000","a":"25.36520000","A":"40.66000000"}`)
+	combinedBookTickerMessage = []byte(`{"stream":"bnbusdt@bookTicker","data":{"u":400900217,"s":"BNBUSDT","b":"25.35190000","B":"31.21000000","a":"25.36520000","A":"40.66000000"}}`)
+)
+
+type wsDecodeTestSuite struct {
+	suite.Suite
+}
+
+func TestWsDecode(t *testing.T) {
+	suite.Run(t, new(wsDecodeTestSuite))
+}
+
+// captureWsHandler return the raw handler a Serve method passes to wsServe, and the endpoint
+func captureWsHandler(serve func(c *WebsocketStreamClient)) (WsHandler, string) {
+	var handler WsHandler
+	var endpoint string
+	orig := wsServe
+	wsServe = func(cfg *WsConfig, h WsHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+		handler, endpoint = h, cfg.Endpoint
+		doneCh = make(chan struct{})
+		close(doneCh)
+		return doneCh, make(chan struct{}, 1), nil
+	}
+	defer func() { wsServe = orig }()
+	serve(NewWebsocketStreamClient(false, "wss://stream.testnet.binance.vision"))
+	return handler, endpoint
+}
+
+func (s *wsDecodeTestSuite) TestAggTradePooled() {
+	var events []WsAggTradeEvent
+	handler, endpoint := captureWsHandler(func(c *WebsocketStreamClient) {
+		c.WsAggTradePooledServe("BNBBTC", func(event *WsAggTradeEvent) {
+			events = append(events, *event)
+		}, func(err error) { s.Fail(err.Error()) })
+	})
+	s.Equal("wss://stream.testnet.binance.vision/ws/bnbbtc@aggTrade", endpoint)
+
+	handler(aggTradeMessage)
+	// a reused event must not keep the fields of the previous message
+	handler([]byte(`{"e":"aggTrade","s":"ETHBTC","a":12346}`))
+	s.Require().Len(events, 2)
+	s.Equal(WsAggTradeEvent{
+		Event: "aggTrade", Time: 1672515782136, Symbol: "BNBBTC", AggTradeID: 12345, Price: "0.001", Quantity: "100",
+		FirstBreakdownTradeID: 100, LastBreakdownTradeID: 105, TradeTime: 1672515782136, IsBuyerMaker: true, Placeholder: true,
+	}, events[0])
+	s.Equal(WsAggTradeEvent{Event: "aggTrade", Symbol: "ETHBTC", AggTradeID: 12346}, events[1])
+}
+
+func (s *wsDecodeTestSuite) TestCombinedAggTradePooled() {
+	var event WsAggTradeEvent
+	handler, _ := captureWsHandler(func(c *WebsocketStreamClient) {
+		c.WsCombinedAggTradePooledServe([]string{"BNBBTC", "ETHBTC"}, func(e *WsAggTradeEvent) {
+			event = *e
+		}, func(err error) { s.Fail(err.Error()) })
+	})
+	handler(combinedAggTradeMessage)
+	s.Equal("BNBBTC", event.Symbol)
+	s.Equal(int64(12345), event.AggTradeID)
+	s.Equal("0.001", event.Price)
+}
+
+func (s *wsDecodeTestSuite) TestBookTickerPooled() {
+	var events []WsBookTickerEvent
+	handler, _ := captureWsHandler(func(c *WebsocketStreamClient) {
+		c.WsBookTickerPooledServe("BNBUSDT", func(event *WsBookTickerEvent) {
+			events = append(events, *event)
+		}, func(err error) { s.Fail(err.Error()) })
+	})
+	handler(bookTickerMessage)
+	handler([]byte(`{"u":400900218,"s":"BNBUSDT"}`))
+	s.Require().Len(events, 2)
+	s.Equal(WsBookTickerEvent{UpdateID: 400900217, Symbol: "BNBUSDT", BestBidPrice: "25.35190000", BestBidQty: "31.21000000", BestAskPrice: "25.36520000", BestAskQty: "40.66000000"}, events[0])
+	s.Equal(WsBookTickerEvent{UpdateID: 400900218, Symbol: "BNBUSDT"}, events[1])
+}
+
+func (s *wsDecodeTestSuite) TestCombinedBookTickerPooled() {
+	var event WsBookTickerEvent
+	handler, _ := captureWsHandler(func(c *WebsocketStreamClient) {
+		c.WsCombinedBookTickerPooledServe([]string{"BNBUSDT"}, func(e *WsBookTickerEvent) {
+			event = *e
+		}, func(err error) { s.Fail(err.Error()) })
+	})
+	handler(combinedBookTickerMessage)
+	s.Equal("BNBUSDT", event.Symbol)
+	s.Equal("25.36520000", event.BestAskPrice)
+}
+
+func (s *wsDecodeTestSuite) TestPooledDecodeError() {
+	var errs []error
+	handler, _ := captureWsHandler(func(c *WebsocketStreamClient) {
+		c.WsBookTickerPooledServe("BNBUSDT", func(e *WsBookTickerEvent) {
+			s.Fail("handler called on an invalid message")
+		}, func(err error) { errs = append(errs, err) })
+	})
+	handler([]byte(`{"u":"not a number"}`))
+	s.Len(errs, 1)
+}
+
+func benchmarkWsHandler(b *testing.B, message []byte, serve func(c *WebsocketStreamClient)) {
+	handler, _ := captureWsHandler(serve)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		handler(message)
+	}
+}
+
+func BenchmarkAggTrade(b *testing.B) {
+	errHandler := func(err error) { b.Fatal(err) }
+	b.Run("Serve", func(b *testing.B) {
+		benchmarkWsHandler(b, aggTradeMessage, func(c *WebsocketStreamClient) {
+			c.WsAggTradeServe("BNBBTC", func(event *WsAggTradeEvent) {}, errHandler)
+		})
+	})
+	b.Run("PooledServe", func(b *testing.B) {
+		benchmarkWsHandler(b, aggTradeMessage, func(c *WebsocketStreamClient) {
+			c.WsAggTradePooledServe("BNBBTC", func(event *WsAggTradeEvent) {}, errHandler)
+		})
+	})
+	b.Run("CombinedServe", func(b *testing.B) {
+		benchmarkWsHandler(b, combinedAggTradeMessage, func(c *WebsocketStreamClient) {
+			c.WsCombinedAggTradeServe([]string{"BNBBTC"}, func(event *WsAggTradeEvent) {}, errHandler)
+		})
+	})
+	b.Run("CombinedPooledServe", func(b *testing.B) {
+		benchmarkWsHandler(b, combinedAggTradeMessage, func(c *WebsocketStreamClient) {
+			c.WsCombinedAggTradePooledServe([]string{"BNBBTC"}, func(event *WsAggTradeEvent) {}, errHandler)
+		})
+	})
+}
+
+func BenchmarkBookTicker(b *testing.B) {
+	errHandler := func(err error) { b.Fatal(err) }
+	b.Run("Serve", func(b *testing.B) {
+		benchmarkWsHandler(b, bookTickerMessage, func(c *WebsocketStreamClient) {
+			c.WsBookTickerServe("BNBUSDT", func(event *WsBookTickerEvent) {}, errHandler)
+		})
+	})
+	b.Run("PooledServe", func(b *testing.B) {
+		benchmarkWsHandler(b, bookTickerMessage, func(c *WebsocketStreamClient) {
+			c.WsBookTickerPooledServe("BNBUSDT", func(event *WsBookTickerEvent) {}, errHandler)
+		})
+	})
+	b.Run("CombinedServe", func(b *testing.B) {
+		benchmarkWsHandler(b, combinedBookTickerMessage, func(c *WebsocketStreamClient) {
+			c.WsCombinedBookTickerServe([]string{"BNBUSDT"}, func(event *WsBookTickerEvent) {}, errHandler)
+		})
+	})
+	b.Run("CombinedPooledServe", func(b *testing.B) {
+		benchmarkWsHandler(b, combinedBookTickerMessage, func(c *WebsocketStreamClient) {
+			c.WsCombinedBookTickerPooledServe([]string{"BNBUSDT"}, func(event *WsBookTickerEvent) {}, errHandler)
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package binance_connector
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidDepthStream is returned for a depth stream Binance does not offer
+var ErrInvalidDepthStream = errors.New("invalid depth stream")
+
+// PartialDepthLevels are the level counts of the partial book depth streams
+var PartialDepthLevels = []int{5, 10, 20}
+
+// DepthStreamName return the name of a depth stream of symbol, e.g. btcusdt@depth10@100ms.
+// levels 0 is the diff depth stream, 5, 10 and 20 are the partial book depth streams.
+// speed is the update speed, 0 or time.Second for the default 1000ms, or 100*time.Millisecond.
+func DepthStreamName(symbol string, levels int, speed time.Duration) (string, error) {
+	if symbol == "" {
+		return "", fmt.Errorf("%w: empty symbol", ErrInvalidDepthStream)
+	}
+	name := strings.ToLower(symbol) + "@depth"
+	if levels != 0 {
+		if err := validateDepthLevels(strconv.Itoa(levels)); err != nil {
+			return "", err
+		}
+		name += strconv.Itoa(levels)
+	}
+	switch speed {
+	case 0, time.Second:
+	case 100 * time.Millisecond:
+		name += "@100ms"
+	default:
+		return "", fmt.Errorf("%w: update speed %s, supported speeds are 1000ms and 100ms", ErrInvalidDepthStream, speed)
+	}
+	return name, nil
+}
+
+// validateDepthLevels return ErrInvalidDepthStream when levels is not a partial book depth level count
+func validateDepthLevels(levels string) error {
+	for _, supported := range PartialDepthLevels {
+		if levels == strconv.Itoa(supported) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q levels, supported levels are 5, 10 and 20", ErrInvalidDepthStream, levels)
+}
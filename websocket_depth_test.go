@@ -0,0 +1,66 @@
+package binance_connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type wsDepthStreamTestSuite struct {
+	suite.Suite
+}
+
+func TestWsDepthStream(t *testing.T) {
+	suite.Run(t, new(wsDepthStreamTestSuite))
+}
+
+func (s *wsDepthStreamTestSuite) TestDepthStreamName() {
+	for _, tc := range []struct {
+		levels   int
+		speed    time.Duration
+		expected string
+	}{
+		{0, 0, "btcusdt@depth"},
+		{0, time.Second, "btcusdt@depth"},
+		{0, 100 * time.Millisecond, "btcusdt@depth@100ms"},
+		{5, 0, "btcusdt@depth5"},
+		{10, time.Second, "btcusdt@depth10"},
+		{20, 100 * time.Millisecond, "btcusdt@depth20@100ms"},
+	} {
+		name, err := DepthStreamName("BTCUSDT", tc.levels, tc.speed)
+		s.NoError(err)
+		s.Equal(tc.expected, name)
+	}
+}
+
+func (s *wsDepthStreamTestSuite) TestInvalidDepthStream() {
+	for _, tc := range []struct {
+		symbol string
+		levels int
+		speed  time.Duration
+	}{
+		{"BTCUSDT", 15, 0},
+		{"BTCUSDT", -5, 0},
+		{"BTCUSDT", 0, 250 * time.Millisecond},
+		{"BTCUSDT", 5, 500 * time.Millisecond},
+		{"", 5, 0},
+	} {
+		_, err := DepthStreamName(tc.symbol, tc.levels, tc.speed)
+		s.ErrorIs(err, ErrInvalidDepthStream, "%+v", tc)
+	}
+}
+
+func (s *wsDepthStreamTestSuite) TestPartialDepthServeRejectsLevels() {
+	c := NewWebsocketStreamClient(false, "ws://127.0.0.1:1")
+	handler := func(event *WsPartialDepthEvent) {}
+	errHandler := func(err error) {}
+
+	_, _, err := c.WsPartialDepthServe("BTCUSDT", "15", handler, errHandler)
+	s.ErrorIs(err, ErrInvalidDepthStream)
+	_, _, err = c.WsPartialDepthServe100Ms("BTCUSDT", "", handler, errHandler)
+	s.ErrorIs(err, ErrInvalidDepthStream)
+	_, _, err = c.WsCombinedPartialDepthServe(map[string]string{"BTCUSDT": "5", "ETHUSDT": "50"}, handler, errHandler)
+	s.ErrorIs(err, ErrInvalidDepthStream)
+	s.Empty(c.States(), "no connection is dialed")
+}
@@ -0,0 +1,173 @@
+package binance_connector
+
+import (
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// WebsocketFanOutQueueSize is the number of messages a fan-out consumer queues when Add is given no size
+	WebsocketFanOutQueueSize = 256
+)
+
+// WsBackpressurePolicy define what a fan-out consumer does with a message when its queue is full
+type WsBackpressurePolicy int
+
+const (
+	// WsBackpressureBlock waits for room in the queue. Nothing is lost, but a slow consumer holds back
+	// the delivery to the consumers added after it and the read of the connection.
+	WsBackpressureBlock WsBackpressurePolicy = iota
+	// WsBackpressureDropNewest drops the message received, the consumer keeps its queued messages
+	WsBackpressureDropNewest
+	// WsBackpressureDropOldest drops the oldest queued message to make room, the consumer stays current
+	WsBackpressureDropOldest
+)
+
+// WsFanOut deliver the messages of one stream to several independent consumers, e.g. a persister, a strategy
+// and a monitor, over a single connection. Handle is passed as the handler of the stream, typed or raw:
+//
+//	fan := NewWsFanOut[*WsAggTradeEvent](errHandler)
+//	fan.Add(persist, 4096, WsBackpressureBlock)
+//	fan.Add(strategy, 16, WsBackpressureDropOldest)
+//	client.WsAggTradeServe("btcusdt", fan.Handle, errHandler)
+//
+// Each consumer has its own queue and goroutine, so a slow consumer does not stall the others unless its
+// policy is WsBackpressureBlock. Every consumer receives the messages in the order of the stream, minus the ones
+// its policy dropped; there is no ordering across consumers, one can be several messages ahead of another.
+// Consumers share the typed events and must not modify them. Raw []byte messages are copied once per message,
+// so they outlive the read buffer when WebsocketReuseReadBuffers is set.
+type WsFanOut[T any] struct {
+	errHandler ErrHandler
+
+	mu        sync.RWMutex
+	consumers []*WsFanOutConsumer[T]
+	wg        sync.WaitGroup
+}
+
+// WsFanOutConsumer is one consumer of a WsFanOut
+type WsFanOutConsumer[T any] struct {
+	fan     *WsFanOut[T]
+	handler func(T)
+	policy  WsBackpressurePolicy
+	queue   chan T
+	dropped atomic.Int64
+	removed bool
+}
+
+// NewWsFanOut create a fan-out without consumers. errHandler receives the panics of the consumers as a
+// HandlerPanicError when WebsocketRecoverHandlerPanics is set.
+func NewWsFanOut[T any](errHandler ErrHandler) *WsFanOut[T] {
+	return &WsFanOut[T]{errHandler: errHandler}
+}
+
+// Add start delivering the next messages to handler, through a queue of queueSize messages
+// (WebsocketFanOutQueueSize when not positive) applying policy once full
+func (f *WsFanOut[T]) Add(handler func(T), queueSize int, policy WsBackpressurePolicy) *WsFanOutConsumer[T] {
+	if queueSize <= 0 {
+		queueSize = WebsocketFanOutQueueSize
+	}
+	consumer := &WsFanOutConsumer[T]{fan: f, handler: handler, policy: policy, queue: make(chan T, queueSize)}
+	recoverPanics := WebsocketRecoverHandlerPanics
+	f.mu.Lock()
+	f.consumers = append(f.consumers, consumer)
+	f.wg.Add(1)
+	f.mu.Unlock()
+	go func() {
+		defer f.wg.Done()
+		for message := range consumer.queue {
+			consumer.handle(message, recoverPanics)
+		}
+	}()
+	return consumer
+}
+
+// Handle deliver message to every consumer, it is the handler to serve the stream with
+func (f *WsFanOut[T]) Handle(message T) {
+	if raw, ok := any(message).([]byte); ok {
+		message = any(append([]byte(nil), raw...)).(T)
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, consumer := range f.consumers {
+		consumer.push(message)
+	}
+}
+
+// Close remove every consumer and wait for them to handle their queued messages
+func (f *WsFanOut[T]) Close() {
+	f.mu.Lock()
+	for _, consumer := range f.consumers {
+		consumer.removed = true
+		close(consumer.queue)
+	}
+	f.consumers = nil
+	f.mu.Unlock()
+	f.wg.Wait()
+}
+
+// Remove stop delivering messages to the consumer, the messages already queued are still handled
+func (c *WsFanOutConsumer[T]) Remove() {
+	f := c.fan
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c.removed {
+		return
+	}
+	c.removed = true
+	close(c.queue)
+	for i, consumer := range f.consumers {
+		if consumer == c {
+			f.consumers = append(f.consumers[:i:i], f.consumers[i+1:]...)
+			break
+		}
+	}
+}
+
+// Dropped return the number of messages the backpressure policy dropped for the consumer
+func (c *WsFanOutConsumer[T]) Dropped() int64 {
+	return c.dropped.Load()
+}
+
+// Pending return the number of messages queued for the consumer
+func (c *WsFanOutConsumer[T]) Pending() int {
+	return len(c.queue)
+}
+
+// push queue message according to the policy of the consumer, the fan-out read lock must be held
+func (c *WsFanOutConsumer[T]) push(message T) {
+	switch c.policy {
+	case WsBackpressureDropNewest:
+		select {
+		case c.queue <- message:
+		default:
+			c.dropped.Add(1)
+		}
+	case WsBackpressureDropOldest:
+		for {
+			select {
+			case c.queue <- message:
+				return
+			default:
+			}
+			select {
+			case <-c.queue:
+				c.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		c.queue <- message
+	}
+}
+
+func (c *WsFanOutConsumer[T]) handle(message T, recoverPanics bool) {
+	if recoverPanics {
+		defer func() {
+			if v := recover(); v != nil {
+				c.fan.errHandler.report(&HandlerPanicError{Value: v, Stack: debug.Stack()})
+			}
+		}()
+	}
+	c.handler(message)
+}
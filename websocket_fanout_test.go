@@ -0,0 +1,114 @@
+package binance_connector
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type wsFanOutTestSuite struct {
+	suite.Suite
+}
+
+func TestWsFanOut(t *testing.T) {
+	suite.Run(t, new(wsFanOutTestSuite))
+}
+
+func (s *wsFanOutTestSuite) TestWsFanOutBlock() {
+	fan := NewWsFanOut[*WsAggTradeEvent](func(err error) {})
+	var mu sync.Mutex
+	var persisted, traded []int64
+	release := make(chan struct{})
+	persister := fan.Add(func(event *WsAggTradeEvent) {
+		<-release
+		mu.Lock()
+		persisted = append(persisted, event.AggTradeID)
+		mu.Unlock()
+	}, 100, WsBackpressureBlock)
+	strategy := fan.Add(func(event *WsAggTradeEvent) {
+		mu.Lock()
+		traded = append(traded, event.AggTradeID)
+		mu.Unlock()
+	}, 10, WsBackpressureDropNewest)
+
+	var handler WsAggTradeHandler = fan.Handle
+	for i := int64(1); i <= 5; i++ {
+		handler(&WsAggTradeEvent{AggTradeID: i})
+	}
+	// the stalled persister does not hold back the strategy
+	s.Require().Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(traded) == 5
+	}, time.Second, time.Millisecond)
+	s.Equal([]int64{1, 2, 3, 4, 5}, traded)
+	s.Empty(persisted)
+
+	close(release)
+	fan.Close()
+	s.Equal([]int64{1, 2, 3, 4, 5}, persisted)
+	s.Zero(persister.Dropped())
+	s.Zero(strategy.Dropped())
+}
+
+func (s *wsFanOutTestSuite) TestWsFanOutPolicies() {
+	fan := NewWsFanOut[[]byte](func(err error) {})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	received := map[string][]string{}
+	consumer := func(name string) func([]byte) {
+		return func(message []byte) {
+			<-release
+			mu.Lock()
+			received[name] = append(received[name], string(message))
+			mu.Unlock()
+		}
+	}
+	newest := fan.Add(consumer("newest"), 2, WsBackpressureDropNewest)
+	oldest := fan.Add(consumer("oldest"), 2, WsBackpressureDropOldest)
+
+	buf := []byte("m1")
+	fan.Handle(buf)
+	// the first message is taken by each consumer goroutine, then blocks on release
+	s.Require().Eventually(func() bool { return newest.Pending() == 0 && oldest.Pending() == 0 }, time.Second, time.Millisecond)
+	copy(buf, "xx") // raw messages are copied, the read buffer can be reused
+	for _, m := range []string{"m2", "m3", "m4", "m5"} {
+		fan.Handle([]byte(m))
+	}
+	s.Equal(int64(2), newest.Dropped())
+	s.Equal(int64(2), oldest.Dropped())
+
+	close(release)
+	fan.Close()
+	s.Equal([]string{"m1", "m2", "m3"}, received["newest"])
+	s.Equal([]string{"m1", "m4", "m5"}, received["oldest"])
+}
+
+func (s *wsFanOutTestSuite) TestWsFanOutRemoveAndPanic() {
+	errCh := make(chan error, 1)
+	fan := NewWsFanOut[int](func(err error) { errCh <- err })
+	var mu sync.Mutex
+	var kept []int
+	fan.Add(func(v int) {
+		if v == 2 {
+			panic("boom")
+		}
+		mu.Lock()
+		kept = append(kept, v)
+		mu.Unlock()
+	}, 0, WsBackpressureBlock)
+	removed := fan.Add(func(v int) {}, 0, WsBackpressureBlock)
+
+	fan.Handle(1)
+	removed.Remove()
+	removed.Remove()
+	fan.Handle(2)
+	fan.Handle(3)
+	fan.Close()
+
+	s.Equal([]int{1, 3}, kept)
+	err := <-errCh
+	s.ErrorIs(err, ErrHandlerPanic)
+}
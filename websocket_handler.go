@@ -0,0 +1,26 @@
+package binance_connector
+
+import (
+	"errors"
+	"log"
+)
+
+var (
+	// WebsocketDefaultErrHandler receives the errors of the streams served with a nil ErrHandler, it logs them
+	// with the standard logger by default. Set it to nil to ignore them silently.
+	WebsocketDefaultErrHandler ErrHandler = func(err error) {
+		log.Printf("websocket error: %v", err)
+	}
+)
+
+// ErrNilHandler is returned when a stream is served or subscribed with a nil message handler
+var ErrNilHandler = errors.New("websocket handler is nil")
+
+// report pass err to h, or to WebsocketDefaultErrHandler when h is nil
+func (h ErrHandler) report(err error) {
+	if h != nil {
+		h(err)
+	} else if WebsocketDefaultErrHandler != nil {
+		WebsocketDefaultErrHandler(err)
+	}
+}
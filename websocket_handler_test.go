@@ -0,0 +1,62 @@
+package binance_connector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestWsHandler(t *testing.T) {
+	suite.Run(t, new(wsHandlerTestSuite))
+}
+
+func (s *wsHandlerTestSuite) TestNilErrHandler() {
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		conn.WriteMessage(websocket.TextMessage, []byte("not json"))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"aggTrade","s":"BTCUSDT","a":1}`))
+		conn.ReadMessage()
+	})
+	defer server.Close()
+
+	errs := make(chan error, 1)
+	orig := WebsocketDefaultErrHandler
+	WebsocketDefaultErrHandler = func(err error) { errs <- err }
+	defer func() { WebsocketDefaultErrHandler = orig }()
+
+	events := make(chan *WsAggTradeEvent, 1)
+	doneCh, stopCh, err := NewWebsocketStreamClient(false, url).WsAggTradeServe("BTCUSDT", func(event *WsAggTradeEvent) {
+		events <- event
+	}, nil)
+	s.Require().NoError(err)
+	s.Error(<-errs)
+	s.Equal(int64(1), (<-events).AggTradeID)
+	stopCh <- struct{}{}
+	<-doneCh
+}
+
+func (s *wsHandlerTestSuite) TestNilErrHandlerSilenced() {
+	orig := WebsocketDefaultErrHandler
+	WebsocketDefaultErrHandler = nil
+	defer func() { WebsocketDefaultErrHandler = orig }()
+	s.NotPanics(func() { ErrHandler(nil).report(ErrWsPongTimeout) })
+}
+
+func (s *wsHandlerTestSuite) TestNilHandler() {
+	client := NewWebsocketStreamClient(false, "ws://127.0.0.1:1")
+	_, _, err := client.WsAggTradeServe("BTCUSDT", nil, nil)
+	s.ErrorIs(err, ErrNilHandler)
+	_, _, err = client.WsDepthServe("BTCUSDT", nil, nil)
+	s.ErrorIs(err, ErrNilHandler)
+	_, _, err = client.WsPartialDepthServe("BTCUSDT", "5", nil, nil)
+	s.ErrorIs(err, ErrNilHandler)
+	_, err = client.NewStreamPool(nil).Acquire(context.Background(), "btcusdt@trade", nil)
+	s.ErrorIs(err, ErrNilHandler)
+	_, err = client.NewUserDataStream(nil, nil, nil).Start(context.Background())
+	s.ErrorIs(err, ErrNilHandler)
+}
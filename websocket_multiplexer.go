@@ -0,0 +1,443 @@
+package binance_connector
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// ErrMultiplexerNotConnected is returned when a subscription change is sent before Start or after the connection ended
+var ErrMultiplexerNotConnected = errors.New("stream multiplexer not connected")
+
+var (
+	// WebsocketStreamMessageInterval is the minimum delay between two SUBSCRIBE or UNSUBSCRIBE messages
+	// sent on one stream connection, Binance accepts 5 incoming messages per second pings included
+	WebsocketStreamMessageInterval = time.Millisecond * 250
+)
+
+// WsStreamMultiplexer opens one combined stream connection and routes each message to the handler
+// registered for its stream. Streams can be added and removed while connected, with the
+// SUBSCRIBE and UNSUBSCRIBE methods of the stream API.
+type WsStreamMultiplexer struct {
+	client     *WebsocketStreamClient
+	endpoint   string
+	errHandler ErrHandler
+
+	mu       sync.Mutex
+	handlers map[string]WsHandler
+	lastSeen map[string]time.Time
+	pending  map[int64]chan error
+	nextID   int64
+	conn     *wsConn
+	stopped  bool
+	nextSend time.Time
+	// reconnects is set by a pool dialing the connection again when it ends, it is then reported RECONNECTING
+	// instead of CLOSED
+	reconnects bool
+
+	// the activity of the current connection, for Stats
+	starts      int
+	connectedAt time.Time
+	endedAt     time.Time
+	messages    atomic.Int64
+	bytes       atomic.Int64
+	lastMessage atomic.Int64
+}
+
+// WsConnStats is a snapshot of the activity of a stream connection
+type WsConnStats struct {
+	State   WsConnState
+	Streams int
+	// ConnectedAt is when the current connection, or the last one, was established
+	ConnectedAt time.Time
+	// Messages and Bytes count the frames received on the connection and their size
+	Messages int64
+	Bytes    int64
+	// MessagesPerSecond and BytesPerSecond are averages over the life of the connection, the difference of
+	// two snapshots gives a recent rate
+	MessagesPerSecond float64
+	BytesPerSecond    float64
+	// LastMessage is when the last frame was received, zero before the first one
+	LastMessage time.Time
+	// Reconnects is the number of times the multiplexer was started again after its first connection
+	Reconnects int
+}
+
+// wsStreamFrame is either a combined stream message or the response to a subscription request
+type wsStreamFrame struct {
+	Stream string             `json:"stream"`
+	Data   json.RawMessage    `json:"data"`
+	ID     *int64             `json:"id"`
+	Error  *wsStreamErrorBody `json:"error"`
+}
+
+// NewStreamMultiplexer create a multiplexer using the client base URL, errHandler receives read errors
+func (c *WebsocketStreamClient) NewStreamMultiplexer(errHandler ErrHandler) *WsStreamMultiplexer {
+	return &WsStreamMultiplexer{
+		client:     c,
+		endpoint:   c.combinedEndpoint(),
+		errHandler: errHandler,
+		handlers:   make(map[string]WsHandler),
+		lastSeen:   make(map[string]time.Time),
+		pending:    make(map[int64]chan error),
+	}
+}
+
+// Register add a stream and its handler, streams registered before Start are part of the connection URL
+func (m *WsStreamMultiplexer) Register(stream string, handler WsHandler) *WsStreamMultiplexer {
+	stream = m.client.normalizeStream(stream)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[stream] = handler
+	return m
+}
+
+// Start connect with every registered stream, the connection is closed when stopCh is closed or written to
+func (m *WsStreamMultiplexer) Start() (doneCh, stopCh chan struct{}, err error) {
+	return m.connect(m.Streams())
+}
+
+// connect open a connection with streams in its URL, the other registered streams are not delivered until
+// subscribed
+func (m *WsStreamMultiplexer) connect(streams []string) (doneCh, stopCh chan struct{}, err error) {
+	endpoint := m.endpoint + strings.Join(streams, "/")
+	if len(streams) == 0 {
+		endpoint = strings.TrimSuffix(m.endpoint, "?streams=")
+	}
+	// a connection dialed again by a pool stays RECONNECTING until it is connected
+	reconnecting := m.client.stateOf(m) == WsConnStateReconnecting
+	if !reconnecting {
+		m.client.setState(m, endpoint, WsConnStateConnecting)
+	}
+	cfg := &WsConfig{Endpoint: endpoint, TLSConfig: m.client.getTLSConfig(), EnableCompression: m.client.EnableCompression}
+	m.client.applyKeepAlive(cfg)
+	c, err := dialWs(cfg)
+	if err != nil {
+		if !reconnecting {
+			m.client.setState(m, endpoint, WsConnStateClosed)
+		}
+		return nil, nil, err
+	}
+	m.client.setState(m, endpoint, WsConnStateConnected)
+	m.mu.Lock()
+	m.conn = c
+	m.stopped = false
+	m.starts++
+	m.connectedAt = time.Now()
+	m.endedAt = time.Time{}
+	m.messages.Store(0)
+	m.bytes.Store(0)
+	m.lastMessage.Store(0)
+	m.mu.Unlock()
+	if keepAliveCfg, ok := cfg.keepAliveConfig(); ok {
+		keepAlive(c, keepAliveCfg)
+	}
+	m.client.connected(endpoint, c)
+
+	doneCh = make(chan struct{})
+	stopCh = make(chan struct{}, 1)
+	reader := newMessageReader()
+	go func() {
+		defer close(doneCh)
+		ended := WsConnStateClosed
+		defer func() { m.client.setState(m, endpoint, ended) }()
+		for {
+			message, buf, err := reader.read(c)
+			if err != nil {
+				m.mu.Lock()
+				stopped := m.stopped
+				if !stopped && m.reconnects {
+					ended = WsConnStateReconnecting
+				}
+				m.conn = nil
+				m.endedAt = time.Now()
+				for id, respCh := range m.pending {
+					resolveStreamRequest(respCh, ErrMultiplexerNotConnected)
+					delete(m.pending, id)
+				}
+				m.mu.Unlock()
+				if !stopped {
+					m.errHandler.report(c.readError(err))
+				}
+				return
+			}
+			m.messages.Add(1)
+			m.bytes.Add(int64(len(message)))
+			m.lastMessage.Store(time.Now().UnixNano())
+			reader.handle(m.dispatch, message, m.errHandler)
+			reader.release(buf)
+		}
+	}()
+	go func() {
+		select {
+		case <-stopCh:
+			m.mu.Lock()
+			m.stopped = true
+			m.mu.Unlock()
+			c.Close()
+		case <-doneCh:
+		}
+	}()
+	return doneCh, stopCh, nil
+}
+
+// setReconnects set whether the connection is dialed again when it ends
+func (m *WsStreamMultiplexer) setReconnects(reconnects bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reconnects = reconnects
+}
+
+// Subscribe add a stream to a running connection and wait for the server to acknowledge it
+func (m *WsStreamMultiplexer) Subscribe(ctx context.Context, stream string, handler WsHandler) error {
+	if handler == nil {
+		return ErrNilHandler
+	}
+	return m.subscribe(ctx, map[string]WsHandler{m.client.normalizeStream(stream): handler})
+}
+
+// subscribe add the streams of handlers to a running connection in one SUBSCRIBE, none is registered when it fails
+func (m *WsStreamMultiplexer) subscribe(ctx context.Context, handlers map[string]WsHandler) error {
+	streams := make([]string, 0, len(handlers))
+	m.mu.Lock()
+	for stream, handler := range handlers {
+		m.handlers[stream] = handler
+		streams = append(streams, stream)
+	}
+	m.mu.Unlock()
+	sort.Strings(streams)
+	if err := m.request(ctx, "SUBSCRIBE", streams...); err != nil {
+		m.forget(streams...)
+		return err
+	}
+	return nil
+}
+
+// Unsubscribe remove a stream from a running connection, its handler is not called anymore
+func (m *WsStreamMultiplexer) Unsubscribe(ctx context.Context, stream string) error {
+	return m.unsubscribe(ctx, m.client.normalizeStream(stream))
+}
+
+// unsubscribe remove streams from a running connection in one UNSUBSCRIBE
+func (m *WsStreamMultiplexer) unsubscribe(ctx context.Context, streams ...string) error {
+	m.forget(streams...)
+	return m.request(ctx, "UNSUBSCRIBE", streams...)
+}
+
+// forget remove the handler of streams without unsubscribing them
+func (m *WsStreamMultiplexer) forget(streams ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, stream := range streams {
+		delete(m.handlers, stream)
+		delete(m.lastSeen, stream)
+	}
+}
+
+// resubscribe subscribe streams on a new connection and return the error of each one that failed.
+// They are sent in one SUBSCRIBE, and one by one when it fails to find the streams rejected. The connection
+// ending is returned as ErrMultiplexerNotConnected instead.
+func (m *WsStreamMultiplexer) resubscribe(streams []string) (map[string]error, error) {
+	failed := make(map[string]error)
+	if len(streams) == 0 {
+		return failed, nil
+	}
+	err := m.requestWithTimeout("SUBSCRIBE", streams...)
+	if err == nil || errors.Is(err, ErrMultiplexerNotConnected) {
+		return failed, err
+	}
+	if len(streams) == 1 {
+		failed[streams[0]] = err
+		return failed, nil
+	}
+	for _, stream := range streams {
+		err := m.requestWithTimeout("SUBSCRIBE", stream)
+		if errors.Is(err, ErrMultiplexerNotConnected) {
+			return failed, err
+		}
+		if err != nil {
+			failed[stream] = err
+		}
+	}
+	return failed, nil
+}
+
+// requestWithTimeout send a request waiting WebsocketResubscribeTimeout at most for its response
+func (m *WsStreamMultiplexer) requestWithTimeout(method string, streams ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), WebsocketResubscribeTimeout)
+	defer cancel()
+	return m.request(ctx, method, streams...)
+}
+
+func (m *WsStreamMultiplexer) handler(stream string) WsHandler {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.handlers[stream]
+}
+
+// Streams return the registered streams, sorted
+func (m *WsStreamMultiplexer) Streams() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	streams := make([]string, 0, len(m.handlers))
+	for stream := range m.handlers {
+		streams = append(streams, stream)
+	}
+	sort.Strings(streams)
+	return streams
+}
+
+// LastMessage return when the last message of stream was received.
+// A registered stream that never delivered data, e.g. an inactive symbol, returns false.
+func (m *WsStreamMultiplexer) LastMessage(stream string) (time.Time, bool) {
+	stream = m.client.normalizeStream(stream)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.lastSeen[stream]
+	return t, ok
+}
+
+// Stats return a snapshot of the activity of the connection, it is cheap and safe to call from any goroutine
+func (m *WsStreamMultiplexer) Stats() WsConnStats {
+	m.mu.Lock()
+	stats := WsConnStats{State: WsConnStateClosed, Streams: len(m.handlers), ConnectedAt: m.connectedAt}
+	if m.conn != nil {
+		stats.State = WsConnStateConnected
+	}
+	if m.starts > 1 {
+		stats.Reconnects = m.starts - 1
+	}
+	end := m.endedAt
+	m.mu.Unlock()
+	if stats.State == WsConnStateClosed && m.client.stateOf(m) == WsConnStateReconnecting {
+		stats.State = WsConnStateReconnecting
+	}
+	stats.Messages = m.messages.Load()
+	stats.Bytes = m.bytes.Load()
+	if last := m.lastMessage.Load(); last > 0 {
+		stats.LastMessage = time.Unix(0, last)
+	}
+	if stats.ConnectedAt.IsZero() {
+		return stats
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if elapsed := end.Sub(stats.ConnectedAt).Seconds(); elapsed > 0 {
+		stats.MessagesPerSecond = float64(stats.Messages) / elapsed
+		stats.BytesPerSecond = float64(stats.Bytes) / elapsed
+	}
+	return stats
+}
+
+func (m *WsStreamMultiplexer) request(ctx context.Context, method string, streams ...string) error {
+	m.mu.Lock()
+	c := m.conn
+	if c == nil {
+		m.mu.Unlock()
+		return ErrMultiplexerNotConnected
+	}
+	m.nextID++
+	id := m.nextID
+	respCh := make(chan error, 1)
+	m.pending[id] = respCh
+	now := time.Now()
+	sendAt := m.nextSend
+	if sendAt.Before(now) {
+		sendAt = now
+	}
+	m.nextSend = sendAt.Add(WebsocketStreamMessageInterval)
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.pending, id)
+		m.mu.Unlock()
+	}()
+	if delay := time.Until(sendAt); delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	err := c.WriteJSON(map[string]interface{}{
+		"method": method,
+		"params": streams,
+		"id":     id,
+	})
+	if err != nil {
+		return err
+	}
+	select {
+	case err := <-respCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *WsStreamMultiplexer) dispatch(message []byte) {
+	var frame wsStreamFrame
+	err := json.Unmarshal(message, &frame)
+	if raw := m.client.rawMessageHandler(); raw != nil {
+		raw(frame.Stream, message)
+	}
+	if err != nil {
+		m.errHandler.report(err)
+		return
+	}
+	// an error frame without id answers a request Binance could not read
+	if frame.ID != nil || frame.Error != nil {
+		if frame.Error != nil {
+			err = frame.Error.apiError()
+		}
+		var respCh chan error
+		ok := false
+		if frame.ID != nil {
+			m.mu.Lock()
+			respCh, ok = m.pending[*frame.ID]
+			m.mu.Unlock()
+		}
+		if ok {
+			resolveStreamRequest(respCh, err)
+		} else if frame.Error != nil {
+			// the request gave up waiting, or was not sent by the multiplexer
+			streamErr := &WsStreamError{Err: frame.Error.apiError()}
+			if frame.ID != nil {
+				streamErr.ID = strconv.FormatInt(*frame.ID, 10)
+			}
+			m.errHandler.report(streamErr)
+		}
+		return
+	}
+	m.mu.Lock()
+	handler, ok := m.handlers[frame.Stream]
+	empty := len(frame.Data) == 0 || bytes.Equal(frame.Data, []byte("null"))
+	if ok && !empty {
+		m.lastSeen[frame.Stream] = time.Now()
+	}
+	m.mu.Unlock()
+	// messages of streams no longer registered and frames without data are dropped
+	if !ok || empty {
+		return
+	}
+	handler(frame.Data)
+}
+
+func resolveStreamRequest(respCh chan error, err error) {
+	select {
+	case respCh <- err:
+	default:
+	}
+}
@@ -0,0 +1,105 @@
+package binance_connector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+type multiplexerTestSuite struct {
+	suite.Suite
+}
+
+func TestStreamMultiplexer(t *testing.T) {
+	suite.Run(t, new(multiplexerTestSuite))
+}
+
+// serveStreams sends one message for btcusdt@trade, answers SUBSCRIBE requests
+// and sends one message on each newly subscribed stream
+func serveStreams(query chan<- string) func(conn *websocket.Conn) {
+	return func(conn *websocket.Conn) {
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"btcusdt@trade","data":{"s":"BTCUSDT"}}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"ethusdt@trade","data":{"s":"ETHUSDT"}}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"btcusdt@trade","data":null}`))
+		for {
+			var request struct {
+				Method string   `json:"method"`
+				Params []string `json:"params"`
+				ID     int64    `json:"id"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			query <- request.Method + " " + request.Params[0]
+			if request.Params[0] == "invalid" {
+				conn.WriteJSON(map[string]interface{}{"id": request.ID, "error": map[string]interface{}{"code": 2, "msg": "Invalid request"}})
+				continue
+			}
+			conn.WriteJSON(map[string]interface{}{"id": request.ID, "result": nil})
+			if request.Method == "SUBSCRIBE" {
+				conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"`+request.Params[0]+`","data":{"s":"BNBUSDT"}}`))
+			}
+		}
+	}
+}
+
+func (s *multiplexerTestSuite) TestRouteAndSubscribe() {
+	requests := make(chan string, 10)
+	server, url := newWsTestServer(serveStreams(requests))
+	defer server.Close()
+
+	btc := make(chan string, 10)
+	bnb := make(chan string, 10)
+	m := NewWebsocketStreamClient(true, url).NewStreamMultiplexer(func(err error) {})
+	m.Register("btcusdt@trade", func(message []byte) { btc <- string(message) })
+	s.Equal(url+"/stream?streams=", m.endpoint)
+
+	doneCh, stopCh, err := m.Start()
+	s.Require().NoError(err)
+
+	s.Equal(`{"s":"BTCUSDT"}`, s.receive(btc))
+	_, ok := m.LastMessage("btcusdt@trade")
+	s.True(ok)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.Require().NoError(m.Subscribe(ctx, "bnbusdt@trade", func(message []byte) { bnb <- string(message) }))
+	s.Equal("SUBSCRIBE bnbusdt@trade", s.receive(requests))
+	s.Equal(`{"s":"BNBUSDT"}`, s.receive(bnb))
+	s.Equal([]string{"bnbusdt@trade", "btcusdt@trade"}, m.Streams())
+
+	err = m.Subscribe(ctx, "invalid", func(message []byte) {})
+	s.Equal(&handlers.APIError{Code: 2, Message: "Invalid request"}, err)
+	s.Equal("SUBSCRIBE invalid", s.receive(requests))
+	s.Equal([]string{"bnbusdt@trade", "btcusdt@trade"}, m.Streams())
+
+	s.Require().NoError(m.Unsubscribe(ctx, "btcusdt@trade"))
+	s.Equal("UNSUBSCRIBE btcusdt@trade", s.receive(requests))
+	s.Equal([]string{"bnbusdt@trade"}, m.Streams())
+
+	// the unregistered stream and the frame without data never reached a handler
+	s.Len(btc, 0)
+
+	close(stopCh)
+	<-doneCh
+	s.ErrorIs(m.Subscribe(ctx, "xrpusdt@trade", func(message []byte) {}), ErrMultiplexerNotConnected)
+}
+
+func (s *multiplexerTestSuite) TestEndpointFromRawClient() {
+	m := NewWebsocketStreamClient(false, "wss://stream.binance.com:9443").NewStreamMultiplexer(func(err error) {})
+	s.Equal("wss://stream.binance.com:9443/stream?streams=", m.endpoint)
+}
+
+func (s *multiplexerTestSuite) receive(ch <-chan string) string {
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		s.FailNow("timed out waiting for a message")
+		return ""
+	}
+}
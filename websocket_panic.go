@@ -0,0 +1,51 @@
+package binance_connector
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+var (
+	// WebsocketRecoverHandlerPanics recovers the panics of stream handlers: the panic is reported to the
+	// ErrHandler as a HandlerPanicError and the next messages are still delivered. When false a panic
+	// propagates and ends the process, like in any goroutine.
+	WebsocketRecoverHandlerPanics = true
+)
+
+// ErrHandlerPanic is matched by errors.Is on a HandlerPanicError
+var ErrHandlerPanic = errors.New("websocket handler panicked")
+
+// HandlerPanicError is reported to the ErrHandler when a stream handler panicked on a message
+type HandlerPanicError struct {
+	// Value is the value passed to panic
+	Value interface{}
+	Stack []byte
+}
+
+func (e *HandlerPanicError) Error() string {
+	return fmt.Sprintf("%v: %v\n%s", ErrHandlerPanic, e.Value, e.Stack)
+}
+
+// Is return true for ErrHandlerPanic
+func (e *HandlerPanicError) Is(target error) bool {
+	return target == ErrHandlerPanic
+}
+
+// Unwrap return the value passed to panic when it is an error
+func (e *HandlerPanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// handle call handler with message, recovering a panic when WebsocketRecoverHandlerPanics was set at dial time
+func (r messageReader) handle(handler WsHandler, message []byte, errHandler ErrHandler) {
+	if r.recoverPanics {
+		defer func() {
+			if v := recover(); v != nil {
+				errHandler.report(&HandlerPanicError{Value: v, Stack: debug.Stack()})
+			}
+		}()
+	}
+	handler(message)
+}
@@ -0,0 +1,90 @@
+package binance_connector
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsPanicTestSuite struct {
+	suite.Suite
+}
+
+func TestWsHandlerPanic(t *testing.T) {
+	suite.Run(t, new(wsPanicTestSuite))
+}
+
+func (s *wsPanicTestSuite) TestStreamKeepsDelivering() {
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		for _, message := range []string{"first", "panic", "third"} {
+			conn.WriteMessage(websocket.TextMessage, []byte(message))
+		}
+		conn.ReadMessage()
+	})
+	defer server.Close()
+
+	var mu sync.Mutex
+	var messages []string
+	var errs []error
+	got := make(chan struct{})
+	doneCh, stopCh, err := wsServe(newWsConfig(url), func(message []byte) {
+		if string(message) == "panic" {
+			panic(errors.New("bad message"))
+		}
+		mu.Lock()
+		messages = append(messages, string(message))
+		if len(messages) == 2 {
+			close(got)
+		}
+		mu.Unlock()
+	}, func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	})
+	s.Require().NoError(err)
+	<-got
+	stopCh <- struct{}{}
+	<-doneCh
+
+	s.Equal([]string{"first", "third"}, messages)
+	s.Require().Len(errs, 1)
+	s.ErrorIs(errs[0], ErrHandlerPanic)
+	var panicErr *HandlerPanicError
+	s.Require().ErrorAs(errs[0], &panicErr)
+	s.EqualError(panicErr.Unwrap(), "bad message")
+	s.Contains(string(panicErr.Stack), "websocket_panic_test.go")
+}
+
+func (s *wsPanicTestSuite) TestMultiplexerKeepsDelivering() {
+	server, url := newWsTestServer(serveStreams(make(chan string, 10)))
+	defer server.Close()
+
+	errs := make(chan error, 1)
+	eth := make(chan string, 1)
+	m := NewWebsocketStreamClient(true, url).NewStreamMultiplexer(func(err error) { errs <- err })
+	m.Register("btcusdt@trade", func(message []byte) { panic("boom") })
+	m.Register("ethusdt@trade", func(message []byte) { eth <- string(message) })
+	doneCh, stopCh, err := m.Start()
+	s.Require().NoError(err)
+	defer func() {
+		stopCh <- struct{}{}
+		<-doneCh
+	}()
+
+	s.ErrorIs(<-errs, ErrHandlerPanic)
+	s.Equal(`{"s":"ETHUSDT"}`, <-eth)
+}
+
+func (s *wsPanicTestSuite) TestPropagate() {
+	orig := WebsocketRecoverHandlerPanics
+	WebsocketRecoverHandlerPanics = false
+	defer func() { WebsocketRecoverHandlerPanics = orig }()
+	reader := newMessageReader()
+	s.PanicsWithValue("boom", func() {
+		reader.handle(func(message []byte) { panic("boom") }, nil, func(err error) { s.Fail("panic reported") })
+	})
+}
@@ -0,0 +1,613 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// WebsocketMaxStreamsPerConnection is the number of streams a pooled connection carries at most,
+	// Binance accepts 1024 streams on a single connection
+	WebsocketMaxStreamsPerConnection = 1024
+	// WebsocketResubscribeTimeout is how long a SUBSCRIBE sent after a pool reconnection waits for its response
+	WebsocketResubscribeTimeout = time.Second * 10
+)
+
+// WsStreamPool spreads stream subscriptions over the minimum number of combined connections.
+// A connection is opened when every other one is full, and connections are merged back when
+// unsubscribing leaves enough room elsewhere. Streams are reference counted: several consumers
+// can subscribe to the same stream, it is only unsubscribed once the last one released it.
+type WsStreamPool struct {
+	client     *WebsocketStreamClient
+	errHandler ErrHandler
+
+	// reconnectBackoff, when set, reconnects the dropped connections, see Reconnect
+	reconnectBackoff *Backoff
+	onResubscribe    func(result *WsResubscribeResult)
+
+	// opMu serializes the changes of the subscriptions, it is held across their SUBSCRIBE and UNSUBSCRIBE requests
+	opMu sync.Mutex
+
+	// mu guards conns, streams and dropped, and the flags of the connections. It is never held while a request
+	// is sent, so the reconnections and Stats do not wait on a subscription change.
+	mu      sync.Mutex
+	conns   []*pooledStreamConn
+	streams map[string]*pooledStreamConn
+	dropped int
+
+	// consumersMu guards consumers only and is never held while a message is sent, so handlers
+	// can be called while opMu waits on a subscription request
+	consumersMu    sync.Mutex
+	consumers      map[string][]poolConsumer
+	nextConsumerID int64
+}
+
+type poolConsumer struct {
+	id      int64
+	handler WsHandler
+}
+
+// WsStreamSubscription is the reference of one consumer to a pooled stream
+type WsStreamSubscription struct {
+	pool     *WsStreamPool
+	stream   string
+	id       int64
+	released atomic.Bool
+}
+
+type pooledStreamConn struct {
+	m       *WsStreamMultiplexer
+	stopCh  chan struct{}
+	closing bool
+	// reconnecting is set from the end of the connection until its streams are subscribed again, no stream is
+	// added to or moved from it meanwhile
+	reconnecting bool
+}
+
+// WsResubscribeResult define the outcome of subscribing again the streams of a reconnected pool connection
+type WsResubscribeResult struct {
+	// Resubscribed are the streams delivered again, sorted
+	Resubscribed []string
+	// Failed holds the error of each stream that could not be subscribed again, e.g. a handlers.APIError.
+	// These streams and their consumers were removed from the pool.
+	Failed map[string]error
+	// Attempts is the number of connections dialed until one succeeded
+	Attempts int
+}
+
+// Err return a *WsResubscribeError naming the failed streams, nil when every stream was subscribed again
+func (r *WsResubscribeResult) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	return &WsResubscribeError{Failed: r.Failed}
+}
+
+// WsResubscribeError is reported to the error handler of a pool when streams of a reconnected connection
+// could not be subscribed again
+type WsResubscribeError struct {
+	Failed map[string]error
+}
+
+func (e *WsResubscribeError) Error() string {
+	streams := make([]string, 0, len(e.Failed))
+	for stream := range e.Failed {
+		streams = append(streams, stream)
+	}
+	sort.Strings(streams)
+	for i, stream := range streams {
+		streams[i] = fmt.Sprintf("%s: %v", stream, e.Failed[stream])
+	}
+	return fmt.Sprintf("resubscribe failed for %d streams: %s", len(streams), strings.Join(streams, "; "))
+}
+
+// WsStreamPoolStats define the connections of a pool and how many streams each carries
+type WsStreamPoolStats struct {
+	Connections          int
+	Streams              int
+	StreamsPerConnection []int
+	// Conns is the activity of each connection, in the order of StreamsPerConnection
+	Conns []WsConnStats
+	// Dropped is the number of connections that ended without being closed by the pool, e.g. closed by the
+	// server; their streams were removed from the pool
+	Dropped int
+}
+
+// NewStreamPool create a pool of combined connections, errHandler receives the errors of every connection
+func (c *WebsocketStreamClient) NewStreamPool(errHandler ErrHandler) *WsStreamPool {
+	return &WsStreamPool{
+		client:     c,
+		errHandler: errHandler,
+		streams:    make(map[string]*pooledStreamConn),
+		consumers:  make(map[string][]poolConsumer),
+	}
+}
+
+// Reconnect make the pool reconnect a connection ending without being closed by the pool, e.g. closed by
+// the server, and subscribe its streams again so their consumers keep receiving messages. Failed connections
+// are retried after the delays of backoff. By default such a connection is dropped with its streams.
+// Messages sent while disconnected are lost.
+//
+// A connection dialed again is reported RECONNECTING to the OnStateChange handler of the client until it is
+// connected.
+func (p *WsStreamPool) Reconnect(backoff Backoff) *WsStreamPool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reconnectBackoff = &backoff
+	for _, conn := range p.conns {
+		conn.m.setReconnects(true)
+	}
+	return p
+}
+
+// OnResubscribe set the handler called once the streams of a reconnected connection were subscribed again,
+// with the streams that failed. The failures are also reported to the error handler as a *WsResubscribeError.
+func (p *WsStreamPool) OnResubscribe(handler func(result *WsResubscribeResult)) *WsStreamPool {
+	p.onResubscribe = handler
+	return p
+}
+
+// Subscribe add handler as a consumer of stream, like Acquire. The consumer stays until Unsubscribe removes the stream.
+func (p *WsStreamPool) Subscribe(ctx context.Context, stream string, handler WsHandler) error {
+	_, err := p.Acquire(ctx, stream, handler)
+	return err
+}
+
+// Acquire add handler as a consumer of stream. The first consumer subscribes the stream, on the first
+// connection with room or on a new one; the next ones share it and every message goes to each handler.
+func (p *WsStreamPool) Acquire(ctx context.Context, stream string, handler WsHandler) (*WsStreamSubscription, error) {
+	if handler == nil {
+		return nil, ErrNilHandler
+	}
+	stream = p.client.normalizeStream(stream)
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+	id, first := p.addConsumer(stream, handler)
+	if first {
+		if err := p.subscribe(ctx, stream); err != nil {
+			p.removeConsumer(stream, id)
+			return nil, err
+		}
+	}
+	return &WsStreamSubscription{pool: p, stream: stream, id: id}, nil
+}
+
+// Unsubscribe remove stream and all its consumers from the pool, and merge the least used connection
+// into the others when they have room
+func (p *WsStreamPool) Unsubscribe(ctx context.Context, stream string) error {
+	stream = p.client.normalizeStream(stream)
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+	p.consumersMu.Lock()
+	delete(p.consumers, stream)
+	p.consumersMu.Unlock()
+	return p.unsubscribe(ctx, stream)
+}
+
+// Subscriptions return the number of consumers of each stream
+func (p *WsStreamPool) Subscriptions() map[string]int {
+	p.consumersMu.Lock()
+	defer p.consumersMu.Unlock()
+	counts := make(map[string]int, len(p.consumers))
+	for stream, consumers := range p.consumers {
+		counts[stream] = len(consumers)
+	}
+	return counts
+}
+
+// Stream return the subscribed stream
+func (s *WsStreamSubscription) Stream() string {
+	return s.stream
+}
+
+// Unsubscribe release the subscription, the stream is unsubscribed when it was the last consumer.
+// Releasing a subscription twice or after its connection ended does nothing.
+func (s *WsStreamSubscription) Unsubscribe(ctx context.Context) error {
+	if s.released.Swap(true) {
+		return nil
+	}
+	p := s.pool
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+	if !p.removeConsumer(s.stream, s.id) {
+		return nil
+	}
+	return p.unsubscribe(ctx, s.stream)
+}
+
+// subscribe route stream to its consumers, p.opMu must be held
+func (p *WsStreamPool) subscribe(ctx context.Context, stream string) error {
+	handler := p.fanOut(stream)
+	p.mu.Lock()
+	conn := p.connWithRoom()
+	p.mu.Unlock()
+	if conn != nil {
+		if err := conn.m.Subscribe(ctx, stream, handler); err != nil {
+			return err
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if !p.has(conn) {
+			// the connection was dropped meanwhile, with its streams
+			return ErrMultiplexerNotConnected
+		}
+		p.streams[stream] = conn
+		return nil
+	}
+	m := p.client.NewStreamMultiplexer(p.errHandler).Register(stream, handler)
+	p.mu.Lock()
+	m.setReconnects(p.reconnectBackoff != nil)
+	p.mu.Unlock()
+	doneCh, stopCh, err := m.Start()
+	if err != nil {
+		return err
+	}
+	conn = &pooledStreamConn{m: m, stopCh: stopCh}
+	p.mu.Lock()
+	p.conns = append(p.conns, conn)
+	p.streams[stream] = conn
+	p.mu.Unlock()
+	go p.watch(conn, doneCh)
+	return nil
+}
+
+// unsubscribe remove stream from its connection, p.opMu must be held
+func (p *WsStreamPool) unsubscribe(ctx context.Context, stream string) error {
+	p.mu.Lock()
+	conn, ok := p.streams[stream]
+	if !ok {
+		p.mu.Unlock()
+		return nil
+	}
+	delete(p.streams, stream)
+	if len(conn.m.Streams()) == 1 {
+		p.close(conn)
+		p.mu.Unlock()
+		return nil
+	}
+	p.mu.Unlock()
+	if err := conn.m.Unsubscribe(ctx, stream); err != nil {
+		p.mu.Lock()
+		reconnecting := conn.reconnecting
+		p.mu.Unlock()
+		// the stream is not subscribed again by the reconnection
+		if reconnecting && errors.Is(err, ErrMultiplexerNotConnected) {
+			return nil
+		}
+		return err
+	}
+	return p.rebalance(ctx)
+}
+
+// addConsumer register handler for stream and return its id, first is true when stream had no consumer
+func (p *WsStreamPool) addConsumer(stream string, handler WsHandler) (id int64, first bool) {
+	p.consumersMu.Lock()
+	defer p.consumersMu.Unlock()
+	p.nextConsumerID++
+	first = len(p.consumers[stream]) == 0
+	p.consumers[stream] = append(p.consumers[stream], poolConsumer{id: p.nextConsumerID, handler: handler})
+	return p.nextConsumerID, first
+}
+
+// removeConsumer remove a consumer of stream, last is true when it was the last one
+func (p *WsStreamPool) removeConsumer(stream string, id int64) (last bool) {
+	p.consumersMu.Lock()
+	defer p.consumersMu.Unlock()
+	consumers := p.consumers[stream]
+	// a new slice is built so fanOut can keep reading the previous one without the lock
+	kept := make([]poolConsumer, 0, len(consumers))
+	for _, consumer := range consumers {
+		if consumer.id != id {
+			kept = append(kept, consumer)
+		}
+	}
+	if len(kept) == len(consumers) {
+		return false
+	}
+	if len(kept) == 0 {
+		delete(p.consumers, stream)
+		return true
+	}
+	p.consumers[stream] = kept
+	return false
+}
+
+// fanOut return the handler delivering the messages of stream to each of its consumers
+func (p *WsStreamPool) fanOut(stream string) WsHandler {
+	return func(message []byte) {
+		p.consumersMu.Lock()
+		consumers := p.consumers[stream]
+		p.consumersMu.Unlock()
+		for _, consumer := range consumers {
+			consumer.handler(message)
+		}
+	}
+}
+
+// Stats return the number of connections and streams of the pool, and the activity of each connection to
+// spot a stalled or overloaded one. It is cheap and safe to call from any goroutine: the counts are a snapshot
+// taken under mu, which no subscription request holds, and the connections are read after it is released, so
+// Stats does not wait on a SUBSCRIBE the server is slow to answer.
+func (p *WsStreamPool) Stats() WsStreamPoolStats {
+	p.mu.Lock()
+	conns := append([]*pooledStreamConn(nil), p.conns...)
+	stats := WsStreamPoolStats{
+		Connections:          len(conns),
+		Streams:              len(p.streams),
+		StreamsPerConnection: make([]int, 0, len(conns)),
+		Conns:                make([]WsConnStats, 0, len(conns)),
+		Dropped:              p.dropped,
+	}
+	p.mu.Unlock()
+	for _, conn := range conns {
+		connStats := conn.m.Stats()
+		stats.StreamsPerConnection = append(stats.StreamsPerConnection, connStats.Streams)
+		stats.Conns = append(stats.Conns, connStats)
+	}
+	return stats
+}
+
+// Close stop every connection of the pool
+func (p *WsStreamPool) Close() {
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for len(p.conns) > 0 {
+		p.close(p.conns[0])
+	}
+	p.streams = make(map[string]*pooledStreamConn)
+	p.consumersMu.Lock()
+	p.consumers = make(map[string][]poolConsumer)
+	p.consumersMu.Unlock()
+}
+
+// rebalance move the streams of the least used connection to the others when they can take all of them, p.opMu
+// must be held. The streams going to a connection are sent in one SUBSCRIBE, before they leave the old one, so a
+// message may be delivered twice. When a SUBSCRIBE fails the streams already moved are unsubscribed from their new
+// connection and all of them stay on the old one.
+func (p *WsStreamPool) rebalance(ctx context.Context) error {
+	p.mu.Lock()
+	src, moves := p.planRebalance()
+	p.mu.Unlock()
+	if src == nil {
+		return nil
+	}
+
+	for i, move := range moves {
+		handlers := make(map[string]WsHandler, len(move.streams))
+		for _, stream := range move.streams {
+			handlers[stream] = src.m.handler(stream)
+		}
+		if err := move.dst.m.subscribe(ctx, handlers); err != nil {
+			for _, done := range moves[:i] {
+				p.leave(done.dst, done.streams)
+			}
+			return err
+		}
+	}
+
+	p.mu.Lock()
+	// the streams removed with src meanwhile, e.g. dropped, leave their new connection as well
+	var gone []streamMove
+	var moved []string
+	keep := false
+	for _, move := range moves {
+		if !p.has(move.dst) {
+			// dropped meanwhile, its streams stay on src
+			keep = true
+			continue
+		}
+		var left []string
+		for _, stream := range move.streams {
+			if p.streams[stream] == src {
+				p.streams[stream] = move.dst
+				moved = append(moved, stream)
+			} else {
+				left = append(left, stream)
+			}
+		}
+		if len(left) > 0 {
+			gone = append(gone, streamMove{dst: move.dst, streams: left})
+		}
+	}
+	if keep {
+		gone = append(gone, streamMove{dst: src, streams: moved})
+	} else if p.has(src) {
+		p.close(src)
+	}
+	p.mu.Unlock()
+	for _, move := range gone {
+		if len(move.streams) > 0 {
+			p.leave(move.dst, move.streams)
+		}
+	}
+	return nil
+}
+
+// streamMove define streams moved to the connection dst
+type streamMove struct {
+	dst     *pooledStreamConn
+	streams []string
+}
+
+// planRebalance return the least used connection and where its streams go, a nil connection when the others
+// cannot take all of them, p.mu must be held
+func (p *WsStreamPool) planRebalance() (*pooledStreamConn, []streamMove) {
+	var src *pooledStreamConn
+	for _, conn := range p.conns {
+		if !conn.reconnecting && (src == nil || len(conn.m.Streams()) < len(src.m.Streams())) {
+			src = conn
+		}
+	}
+	if src == nil {
+		return nil, nil
+	}
+	var moves []streamMove
+	streams := src.m.Streams()
+	for _, conn := range p.conns {
+		if conn == src || conn.reconnecting || len(streams) == 0 {
+			continue
+		}
+		if room := min(WebsocketMaxStreamsPerConnection-len(conn.m.Streams()), len(streams)); room > 0 {
+			moves = append(moves, streamMove{dst: conn, streams: streams[:room]})
+			streams = streams[room:]
+		}
+	}
+	if len(streams) > 0 {
+		return nil, nil
+	}
+	return src, moves
+}
+
+// leave unsubscribe streams from conn, the errors are reported to the error handler of the pool
+func (p *WsStreamPool) leave(conn *pooledStreamConn, streams []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), WebsocketResubscribeTimeout)
+	defer cancel()
+	if err := conn.m.unsubscribe(ctx, streams...); err != nil && !errors.Is(err, ErrMultiplexerNotConnected) {
+		p.errHandler.report(err)
+	}
+}
+
+// connWithRoom return the first connected connection that can take one more stream, p.mu must be held
+func (p *WsStreamPool) connWithRoom() *pooledStreamConn {
+	for _, conn := range p.conns {
+		if !conn.reconnecting && len(conn.m.Streams()) < WebsocketMaxStreamsPerConnection {
+			return conn
+		}
+	}
+	return nil
+}
+
+// has return true when conn is in the pool, p.mu must be held
+func (p *WsStreamPool) has(conn *pooledStreamConn) bool {
+	for _, c := range p.conns {
+		if c == conn {
+			return true
+		}
+	}
+	return false
+}
+
+// close stop conn and remove it from the pool, p.mu must be held
+func (p *WsStreamPool) close(conn *pooledStreamConn) {
+	conn.closing = true
+	p.remove(conn)
+	close(conn.stopCh)
+}
+
+// watch reconnect conn each time it ends, until the pool closes or drops it
+func (p *WsStreamPool) watch(conn *pooledStreamConn, doneCh chan struct{}) {
+	for doneCh != nil {
+		<-doneCh
+		p.mu.Lock()
+		if conn.closing {
+			p.mu.Unlock()
+			return
+		}
+		if p.reconnectBackoff == nil {
+			p.drop(conn)
+			p.mu.Unlock()
+			return
+		}
+		conn.reconnecting = true
+		p.mu.Unlock()
+		doneCh = p.reconnect(conn)
+	}
+}
+
+// reconnect dial conn again and subscribe its streams, the new connection is returned to be watched, nil once
+// the pool closed conn
+func (p *WsStreamPool) reconnect(conn *pooledStreamConn) chan struct{} {
+	result := &WsResubscribeResult{}
+	var doneCh, stopCh chan struct{}
+	for {
+		var err error
+		result.Attempts++
+		doneCh, stopCh, err = conn.m.connect(nil)
+		p.mu.Lock()
+		if conn.closing {
+			p.mu.Unlock()
+			if err == nil {
+				close(stopCh)
+			} else {
+				conn.m.client.setState(conn.m, "", WsConnStateClosed)
+			}
+			return nil
+		}
+		if err == nil {
+			conn.stopCh = stopCh
+			p.mu.Unlock()
+			break
+		}
+		p.mu.Unlock()
+		p.errHandler.report(err)
+		time.Sleep(p.reconnectBackoff.Delay(result.Attempts - 1))
+	}
+
+	streams := conn.m.Streams()
+	failed, err := conn.m.resubscribe(streams)
+	if err != nil {
+		// the new connection ended as well, it is reconnected again
+		return doneCh
+	}
+	p.mu.Lock()
+	conn.reconnecting = false
+	p.consumersMu.Lock()
+	for stream := range failed {
+		if p.streams[stream] == conn {
+			delete(p.streams, stream)
+			delete(p.consumers, stream)
+		}
+		conn.m.forget(stream)
+	}
+	p.consumersMu.Unlock()
+	for _, stream := range streams {
+		// a stream unsubscribed meanwhile is not delivered anymore
+		if _, ok := failed[stream]; !ok && p.streams[stream] == conn {
+			result.Resubscribed = append(result.Resubscribed, stream)
+		}
+	}
+	result.Failed = failed
+	if len(conn.m.Streams()) == 0 {
+		p.close(conn)
+	}
+	p.mu.Unlock()
+
+	if err := result.Err(); err != nil {
+		p.errHandler.report(err)
+	}
+	if p.onResubscribe != nil {
+		p.onResubscribe(result)
+	}
+	return doneCh
+}
+
+// drop forget a connection that ended, its streams are not delivered anymore, p.mu must be held
+func (p *WsStreamPool) drop(conn *pooledStreamConn) {
+	p.dropped++
+	p.remove(conn)
+	p.consumersMu.Lock()
+	defer p.consumersMu.Unlock()
+	for stream, c := range p.streams {
+		if c == conn {
+			delete(p.streams, stream)
+			delete(p.consumers, stream)
+		}
+	}
+}
+
+func (p *WsStreamPool) remove(conn *pooledStreamConn) {
+	for i, c := range p.conns {
+		if c == conn {
+			p.conns = append(p.conns[:i], p.conns[i+1:]...)
+			return
+		}
+	}
+}
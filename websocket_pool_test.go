@@ -0,0 +1,469 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/luciano-personal-org/binance-connector/handlers"
+	"github.com/stretchr/testify/suite"
+)
+
+type streamPoolTestSuite struct {
+	suite.Suite
+	origMaxStreams int
+	origInterval   time.Duration
+}
+
+func TestStreamPool(t *testing.T) {
+	suite.Run(t, new(streamPoolTestSuite))
+}
+
+func (s *streamPoolTestSuite) SetupTest() {
+	s.origMaxStreams = WebsocketMaxStreamsPerConnection
+	s.origInterval = WebsocketStreamMessageInterval
+	WebsocketMaxStreamsPerConnection = 2
+	WebsocketStreamMessageInterval = 0
+}
+
+func (s *streamPoolTestSuite) TearDownTest() {
+	WebsocketMaxStreamsPerConnection = s.origMaxStreams
+	WebsocketStreamMessageInterval = s.origInterval
+}
+
+func (s *streamPoolTestSuite) TestPackAndRebalance() {
+	var open atomic.Int32
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		open.Add(1)
+		defer open.Add(-1)
+		for {
+			var request struct {
+				ID int64 `json:"id"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			conn.WriteJSON(map[string]interface{}{"id": request.ID, "result": nil})
+		}
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool := NewWebsocketStreamClient(true, url).NewStreamPool(func(err error) {})
+	for _, stream := range []string{"a@trade", "b@trade", "c@trade", "d@trade", "e@trade"} {
+		s.Require().NoError(pool.Subscribe(ctx, stream, func(message []byte) {}))
+	}
+	s.Equal(WsStreamPoolStats{Connections: 3, Streams: 5, StreamsPerConnection: []int{2, 2, 1}}, s.counts(pool.Stats()))
+	s.Eventually(func() bool { return open.Load() == 3 }, time.Second, 10*time.Millisecond)
+
+	// d@trade is moved next to e@trade and its connection closed
+	s.Require().NoError(pool.Unsubscribe(ctx, "c@trade"))
+	s.Equal(WsStreamPoolStats{Connections: 2, Streams: 4, StreamsPerConnection: []int{2, 2}}, s.counts(pool.Stats()))
+	s.Eventually(func() bool { return open.Load() == 2 }, time.Second, 10*time.Millisecond)
+
+	s.Require().NoError(pool.Unsubscribe(ctx, "a@trade"))
+	s.Require().NoError(pool.Unsubscribe(ctx, "b@trade"))
+	s.Equal(WsStreamPoolStats{Connections: 1, Streams: 2, StreamsPerConnection: []int{2}}, s.counts(pool.Stats()))
+
+	pool.Close()
+	s.Equal(WsStreamPoolStats{StreamsPerConnection: []int{}}, s.counts(pool.Stats()))
+	s.Eventually(func() bool { return open.Load() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+// serveSharedStreams answers subscription requests and, after a SUBSCRIBE, sends one message on a@trade
+// and one on the new stream
+func serveSharedStreams(requests chan<- string) func(conn *websocket.Conn) {
+	return func(conn *websocket.Conn) {
+		for {
+			var request struct {
+				Method string   `json:"method"`
+				Params []string `json:"params"`
+				ID     int64    `json:"id"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			requests <- request.Method + " " + request.Params[0]
+			conn.WriteJSON(map[string]interface{}{"id": request.ID, "result": nil})
+			if request.Method == "SUBSCRIBE" {
+				conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"a@trade","data":{"s":"A"}}`))
+				conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"`+request.Params[0]+`","data":{"s":"B"}}`))
+			}
+		}
+	}
+}
+
+func (s *streamPoolTestSuite) TestSharedSubscriptions() {
+	WebsocketMaxStreamsPerConnection = 10
+	requests := make(chan string, 10)
+	server, url := newWsTestServer(serveSharedStreams(requests))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool := NewWebsocketStreamClient(true, url).NewStreamPool(func(err error) {})
+	defer pool.Close()
+
+	first := make(chan string, 10)
+	second := make(chan string, 10)
+	sub1, err := pool.Acquire(ctx, "a@trade", func(message []byte) { first <- string(message) })
+	s.Require().NoError(err)
+	sub2, err := pool.Acquire(ctx, "a@trade", func(message []byte) { second <- string(message) })
+	s.Require().NoError(err)
+	s.Equal("a@trade", sub2.Stream())
+	s.Equal(map[string]int{"a@trade": 2}, pool.Subscriptions())
+	s.Equal(WsStreamPoolStats{Connections: 1, Streams: 1, StreamsPerConnection: []int{1}}, s.counts(pool.Stats()))
+
+	s.Require().NoError(pool.Subscribe(ctx, "b@trade", func(message []byte) {}))
+	s.Equal("SUBSCRIBE b@trade", <-requests)
+	s.Equal(`{"s":"A"}`, <-first)
+	s.Equal(`{"s":"A"}`, <-second)
+
+	// the feed of the second consumer survives the first one leaving
+	s.Require().NoError(sub1.Unsubscribe(ctx))
+	s.Require().NoError(sub1.Unsubscribe(ctx))
+	s.Equal(map[string]int{"a@trade": 1, "b@trade": 1}, pool.Subscriptions())
+	s.Require().NoError(pool.Subscribe(ctx, "c@trade", func(message []byte) {}))
+	s.Equal("SUBSCRIBE c@trade", <-requests)
+	s.Equal(`{"s":"A"}`, <-second)
+	s.Empty(first)
+
+	s.Require().NoError(sub2.Unsubscribe(ctx))
+	s.Equal("UNSUBSCRIBE a@trade", <-requests)
+	s.Equal(map[string]int{"b@trade": 1, "c@trade": 1}, pool.Subscriptions())
+}
+
+func (s *streamPoolTestSuite) TestConcurrentSharedSubscriptions() {
+	requests := make(chan string, 100)
+	server, url := newWsTestServer(serveSharedStreams(requests))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool := NewWebsocketStreamClient(true, url).NewStreamPool(func(err error) {})
+	defer pool.Close()
+	keep, err := pool.Acquire(ctx, "a@trade", func(message []byte) {})
+	s.Require().NoError(err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sub, err := pool.Acquire(ctx, "a@trade", func(message []byte) {})
+			if s.NoError(err) {
+				s.NoError(sub.Unsubscribe(ctx))
+			}
+		}()
+	}
+	wg.Wait()
+	s.Equal(map[string]int{"a@trade": 1}, pool.Subscriptions())
+	s.Empty(requests, "the stream stays subscribed while a consumer is left")
+	s.Require().NoError(keep.Unsubscribe(ctx))
+	s.Empty(pool.Subscriptions())
+	s.Equal(WsStreamPoolStats{StreamsPerConnection: []int{}}, s.counts(pool.Stats()))
+}
+
+// counts return the stats without the activity of the connections, after checking there is one per connection
+func (s *streamPoolTestSuite) counts(stats WsStreamPoolStats) WsStreamPoolStats {
+	s.Len(stats.Conns, stats.Connections)
+	stats.Conns = nil
+	return stats
+}
+
+func (s *streamPoolTestSuite) TestStats() {
+	WebsocketMaxStreamsPerConnection = 10
+	requests := make(chan string, 10)
+	server, url := newWsTestServer(serveSharedStreams(requests))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool := NewWebsocketStreamClient(true, url).NewStreamPool(func(err error) {})
+	defer pool.Close()
+	received := make(chan string, 10)
+	s.Require().NoError(pool.Subscribe(ctx, "a@trade", func(message []byte) { received <- string(message) }))
+	s.Require().NoError(pool.Subscribe(ctx, "b@trade", func(message []byte) {}))
+	<-received
+	// a@trade is part of the connection URL, b@trade is subscribed: its response and the two messages
+	s.Eventually(func() bool { return pool.Stats().Conns[0].Messages == 3 }, time.Second, 10*time.Millisecond)
+
+	stats := pool.Stats()
+	s.Require().Len(stats.Conns, 1)
+	conn := stats.Conns[0]
+	s.Equal(WsConnStateConnected, conn.State)
+	s.Equal(2, conn.Streams)
+	s.Equal(int64(len(`{"id":1,"result":null}`+"\n")+len(`{"stream":"a@trade","data":{"s":"A"}}`)+
+		len(`{"stream":"b@trade","data":{"s":"B"}}`)), conn.Bytes)
+	s.Positive(conn.MessagesPerSecond)
+	s.Positive(conn.BytesPerSecond)
+	s.WithinDuration(time.Now(), conn.LastMessage, time.Second)
+	s.False(conn.LastMessage.Before(conn.ConnectedAt))
+	s.Zero(conn.Reconnects)
+	s.Zero(stats.Dropped)
+}
+
+func (s *streamPoolTestSuite) TestStatsDropped() {
+	// the server closes the connection of the first stream, part of its URL, once connected
+	server, url := newWsTestServer(func(conn *websocket.Conn) { time.Sleep(50 * time.Millisecond) })
+	defer server.Close()
+
+	pool := NewWebsocketStreamClient(true, url).NewStreamPool(func(err error) {})
+	defer pool.Close()
+	s.Require().NoError(pool.Subscribe(context.Background(), "a@trade", func(message []byte) {}))
+	s.Eventually(func() bool { return pool.Stats().Dropped == 1 }, 5*time.Second, 10*time.Millisecond)
+	s.Equal(WsStreamPoolStats{StreamsPerConnection: []int{}, Dropped: 1}, s.counts(pool.Stats()))
+}
+
+func (s *streamPoolTestSuite) TestStatsDuringSubscription() {
+	// the server never answers the SUBSCRIBE of b@trade
+	received := make(chan struct{}, 1)
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+			received <- struct{}{}
+		}
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool := NewWebsocketStreamClient(true, url).NewStreamPool(func(err error) {})
+	defer pool.Close()
+	s.Require().NoError(pool.Subscribe(ctx, "a@trade", func(message []byte) {}))
+	subscribed := make(chan error, 1)
+	go func() { subscribed <- pool.Subscribe(ctx, "b@trade", func(message []byte) {}) }()
+	<-received
+
+	statsCh := make(chan WsStreamPoolStats, 1)
+	go func() { statsCh <- pool.Stats() }()
+	select {
+	case stats := <-statsCh:
+		s.Equal(1, stats.Connections)
+	case <-time.After(time.Second):
+		s.Fail("Stats waited on the pending SUBSCRIBE")
+	}
+	cancel()
+	s.ErrorIs(<-subscribed, context.Canceled)
+}
+
+func (s *streamPoolTestSuite) TestMultiplexerReconnects() {
+	server, url := newWsTestServer(func(conn *websocket.Conn) { conn.ReadMessage() })
+	defer server.Close()
+
+	m := NewWebsocketStreamClient(true, url).NewStreamMultiplexer(func(err error) {})
+	s.Equal(WsConnStats{State: WsConnStateClosed}, m.Stats())
+	for i := 0; i < 2; i++ {
+		doneCh, stopCh, err := m.Start()
+		s.Require().NoError(err)
+		s.Equal(WsConnStateConnected, m.Stats().State)
+		close(stopCh)
+		<-doneCh
+	}
+	stats := m.Stats()
+	s.Equal(WsConnStateClosed, stats.State)
+	s.Equal(1, stats.Reconnects)
+	s.Zero(stats.MessagesPerSecond)
+}
+
+// serveResubscriptions answers subscription requests, rejecting c@trade once reconnected, and sends one message
+// with the connection number on each stream subscribed. The connections are passed to conns.
+func serveResubscriptions(conns chan<- *websocket.Conn, requests chan<- []string) func(conn *websocket.Conn) {
+	var count atomic.Int32
+	return func(conn *websocket.Conn) {
+		n := count.Add(1)
+		conns <- conn
+		for {
+			var request struct {
+				Params []string `json:"params"`
+				ID     int64    `json:"id"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			if n > 1 {
+				requests <- request.Params
+			}
+			if n > 1 && slices.Contains(request.Params, "c@trade") {
+				conn.WriteJSON(map[string]interface{}{"id": request.ID, "error": map[string]interface{}{"code": 2, "msg": "Invalid request"}})
+				continue
+			}
+			conn.WriteJSON(map[string]interface{}{"id": request.ID, "result": nil})
+			for _, stream := range request.Params {
+				conn.WriteJSON(map[string]interface{}{"stream": stream, "data": map[string]interface{}{"conn": n}})
+			}
+		}
+	}
+}
+
+func (s *streamPoolTestSuite) TestReconnectResubscribe() {
+	WebsocketMaxStreamsPerConnection = 10
+	conns := make(chan *websocket.Conn, 10)
+	requests := make(chan []string, 10)
+	server, url := newWsTestServer(serveResubscriptions(conns, requests))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	errs := make(chan error, 10)
+	results := make(chan *WsResubscribeResult, 1)
+	pool := NewWebsocketStreamClient(true, url).NewStreamPool(func(err error) { errs <- err }).
+		Reconnect(Backoff{Base: 10 * time.Millisecond}).
+		OnResubscribe(func(result *WsResubscribeResult) { results <- result })
+	defer pool.Close()
+	received := make(chan string, 10)
+	for _, stream := range []string{"a@trade", "b@trade", "c@trade"} {
+		s.Require().NoError(pool.Subscribe(ctx, stream, func(message []byte) { received <- string(message) }))
+	}
+
+	// the server side of the first connection is closed without a close frame
+	(<-conns).UnderlyingConn().Close()
+	var result *WsResubscribeResult
+	select {
+	case result = <-results:
+	case <-time.After(5 * time.Second):
+		s.FailNow("timed out waiting for the resubscription")
+	}
+	s.Equal([]string{"a@trade", "b@trade"}, result.Resubscribed)
+	s.Equal(1, result.Attempts)
+	s.Require().Len(result.Failed, 1)
+	var apiErr *handlers.APIError
+	s.Require().ErrorAs(result.Failed["c@trade"], &apiErr)
+	s.Equal(int64(2), apiErr.Code)
+	s.EqualError(result.Err(), "resubscribe failed for 1 streams: c@trade: <APIError> code=2, msg=Invalid request")
+
+	// one SUBSCRIBE for every stream, then one per stream to find the failing one
+	s.Equal([]string{"a@trade", "b@trade", "c@trade"}, <-requests)
+	s.Equal([]string{"a@trade"}, <-requests)
+	s.Equal([]string{"b@trade"}, <-requests)
+	s.Equal([]string{"c@trade"}, <-requests)
+
+	var messages []string
+	s.Eventually(func() bool {
+		select {
+		case message := <-received:
+			messages = append(messages, message)
+		default:
+		}
+		return slices.Contains(messages, `{"conn":2}`)
+	}, time.Second, time.Millisecond, "the consumers receive the messages of the new connection")
+
+	s.Equal(map[string]int{"a@trade": 1, "b@trade": 1}, pool.Subscriptions())
+	stats := pool.Stats()
+	s.Equal(WsStreamPoolStats{Connections: 1, Streams: 2, StreamsPerConnection: []int{2}}, s.counts(stats))
+	s.Equal(1, stats.Conns[0].Reconnects)
+
+	var resubscribeErr *WsResubscribeError
+	s.Eventually(func() bool {
+		select {
+		case err := <-errs:
+			return errors.As(err, &resubscribeErr)
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond, "the failed streams are reported to the error handler")
+	s.Contains(resubscribeErr.Failed, "c@trade")
+
+	// a stream subscribed after the reconnection goes on the new connection
+	s.Require().NoError(pool.Subscribe(ctx, "d@trade", func(message []byte) {}))
+	s.Equal(WsStreamPoolStats{Connections: 1, Streams: 3, StreamsPerConnection: []int{3}}, s.counts(pool.Stats()))
+}
+
+// serveRebalance answers subscription requests, rejecting those with a stream for which reject is true, and
+// passes each request as "METHOD stream,stream" to requests
+func serveRebalance(requests chan<- string, reject func(stream string) bool) func(conn *websocket.Conn) {
+	return func(conn *websocket.Conn) {
+		for {
+			var request struct {
+				Method string   `json:"method"`
+				Params []string `json:"params"`
+				ID     int64    `json:"id"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			requests <- request.Method + " " + strings.Join(request.Params, ",")
+			if reject != nil && slices.ContainsFunc(request.Params, reject) {
+				conn.WriteJSON(map[string]interface{}{"id": request.ID, "error": map[string]interface{}{"code": 2, "msg": "Invalid request"}})
+				continue
+			}
+			conn.WriteJSON(map[string]interface{}{"id": request.ID, "result": nil})
+		}
+	}
+}
+
+// drain return the requests received so far
+func (s *streamPoolTestSuite) drain(requests chan string) []string {
+	var received []string
+	for {
+		select {
+		case request := <-requests:
+			received = append(received, request)
+		case <-time.After(50 * time.Millisecond):
+			return received
+		}
+	}
+}
+
+func (s *streamPoolTestSuite) TestRebalanceInOneRequest() {
+	WebsocketMaxStreamsPerConnection = 4
+	requests := make(chan string, 100)
+	server, url := newWsTestServer(serveRebalance(requests, nil))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool := NewWebsocketStreamClient(true, url).NewStreamPool(func(err error) {})
+	defer pool.Close()
+	for _, stream := range []string{"a@trade", "b@trade", "c@trade", "d@trade", "e@trade", "f@trade"} {
+		s.Require().NoError(pool.Subscribe(ctx, stream, func(message []byte) {}))
+	}
+	s.Require().NoError(pool.Unsubscribe(ctx, "a@trade"))
+	s.drain(requests)
+
+	s.Require().NoError(pool.Unsubscribe(ctx, "b@trade"))
+	s.Equal([]string{"UNSUBSCRIBE b@trade", "SUBSCRIBE c@trade,d@trade"}, s.drain(requests))
+	s.Equal(WsStreamPoolStats{Connections: 1, Streams: 4, StreamsPerConnection: []int{4}}, s.counts(pool.Stats()))
+}
+
+func (s *streamPoolTestSuite) TestRebalanceUndoneOnFailure() {
+	WebsocketMaxStreamsPerConnection = 3
+	requests := make(chan string, 100)
+	var rejecting atomic.Bool
+	server, url := newWsTestServer(serveRebalance(requests, func(stream string) bool {
+		return rejecting.Load() && stream == "c@trade"
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool := NewWebsocketStreamClient(true, url).NewStreamPool(func(err error) {})
+	defer pool.Close()
+	for _, stream := range []string{"a@trade", "b@trade", "c@trade", "d@trade", "e@trade", "f@trade", "g@trade", "h@trade"} {
+		s.Require().NoError(pool.Subscribe(ctx, stream, func(message []byte) {}))
+	}
+	s.Require().NoError(pool.Unsubscribe(ctx, "e@trade"))
+	s.drain(requests)
+	rejecting.Store(true)
+
+	// b@trade moves next to d@trade, c@trade is rejected next to g@trade: b@trade leaves again
+	var apiErr *handlers.APIError
+	s.Require().ErrorAs(pool.Unsubscribe(ctx, "a@trade"), &apiErr)
+	s.Equal([]string{"UNSUBSCRIBE a@trade", "SUBSCRIBE b@trade", "SUBSCRIBE c@trade", "UNSUBSCRIBE b@trade"}, s.drain(requests))
+	s.Equal(WsStreamPoolStats{Connections: 3, Streams: 6, StreamsPerConnection: []int{2, 2, 2}}, s.counts(pool.Stats()))
+
+	// the streams are still on their connection and can be unsubscribed
+	rejecting.Store(false)
+	s.Require().NoError(pool.Unsubscribe(ctx, "b@trade"))
+	s.Equal([]string{"UNSUBSCRIBE b@trade", "SUBSCRIBE c@trade"}, s.drain(requests))
+	s.Equal(WsStreamPoolStats{Connections: 2, Streams: 5, StreamsPerConnection: []int{3, 2}}, s.counts(pool.Stats()))
+}
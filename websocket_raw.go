@@ -0,0 +1,58 @@
+package binance_connector
+
+import (
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// WsRawMessageHandler handle an inbound frame before it is decoded.
+// stream is the stream of the frame on combined connections, and the connection streams otherwise,
+// e.g. btcusdt@depth. data is shared with the typed handler and must not be modified.
+type WsRawMessageHandler func(stream string, data []byte)
+
+// wsStreamName is the stream field of a combined stream message
+type wsStreamName struct {
+	Stream string `json:"stream"`
+}
+
+// OnRawMessage set the handler called with every frame received by the client connections,
+// multiplexers included, before the frame is passed to the typed handler. It is called
+// synchronously, a slow handler delays the delivery of messages.
+func (c *WebsocketStreamClient) OnRawMessage(handler WsRawMessageHandler) *WebsocketStreamClient {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.onRawMessage = handler
+	return c
+}
+
+func (c *WebsocketStreamClient) rawMessageHandler() WsRawMessageHandler {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.onRawMessage
+}
+
+// withRawMessage wrap handler to report every frame of the connection to endpoint to the raw message handler
+func (c *WebsocketStreamClient) withRawMessage(endpoint string, handler WsHandler) WsHandler {
+	stream := c.streamName(endpoint)
+	combined := strings.Contains(endpoint, "?streams=")
+	return func(message []byte) {
+		if raw := c.rawMessageHandler(); raw != nil {
+			if combined {
+				raw(combinedStreamName(message, stream), message)
+			} else {
+				raw(stream, message)
+			}
+		}
+		handler(message)
+	}
+}
+
+// combinedStreamName return the stream field of a combined stream message, fallback when it has none
+func combinedStreamName(message []byte, fallback string) string {
+	var frame wsStreamName
+	if err := json.Unmarshal(message, &frame); err != nil || frame.Stream == "" {
+		return fallback
+	}
+	return frame.Stream
+}
@@ -0,0 +1,104 @@
+package binance_connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type wsRawMessageTestSuite struct {
+	suite.Suite
+}
+
+func TestWsRawMessage(t *testing.T) {
+	suite.Run(t, new(wsRawMessageTestSuite))
+}
+
+type rawFrame struct {
+	stream string
+	data   string
+}
+
+func (s *wsRawMessageTestSuite) TestSingleStream() {
+	var frames []rawFrame
+	var events []*WsBookTickerEvent
+	handler, _ := captureWsHandler(func(c *WebsocketStreamClient) {
+		c.OnRawMessage(func(stream string, data []byte) {
+			frames = append(frames, rawFrame{stream, string(data)})
+		})
+		c.WsBookTickerServe("BNBUSDT", func(event *WsBookTickerEvent) {
+			events = append(events, event)
+		}, func(err error) { s.Fail(err.Error()) })
+	})
+	handler(bookTickerMessage)
+	s.Equal([]rawFrame{{"bnbusdt@bookTicker", string(bookTickerMessage)}}, frames)
+	s.Require().Len(events, 1, "the typed handler still receives the message")
+	s.Equal("BNBUSDT", events[0].Symbol)
+}
+
+func (s *wsRawMessageTestSuite) TestCombinedStream() {
+	var frames []rawFrame
+	handler, _ := captureWsHandler(func(c *WebsocketStreamClient) {
+		c.IsCombined = true
+		c.Endpoint += "/stream?streams="
+		c.OnRawMessage(func(stream string, data []byte) {
+			frames = append(frames, rawFrame{stream, string(data)})
+		})
+		c.WsCombinedBookTickerServe([]string{"BNBUSDT", "BTCUSDT"}, func(event *WsBookTickerEvent) {}, func(err error) {})
+	})
+	handler(combinedBookTickerMessage)
+	handler([]byte(`not json`))
+	s.Equal([]rawFrame{
+		{"bnbusdt@bookTicker", string(combinedBookTickerMessage)},
+		// frames without a stream field are reported with the connection streams
+		{"bnbusdt@bookTicker/btcusdt@bookTicker", "not json"},
+	}, frames)
+}
+
+func (s *wsRawMessageTestSuite) TestNilHandler() {
+	var events int
+	handler, _ := captureWsHandler(func(c *WebsocketStreamClient) {
+		c.OnRawMessage(nil)
+		c.WsBookTickerServe("BNBUSDT", func(event *WsBookTickerEvent) { events++ }, func(err error) { s.Fail(err.Error()) })
+	})
+	handler(bookTickerMessage)
+	s.Equal(1, events)
+}
+
+func (s *wsRawMessageTestSuite) TestMultiplexer() {
+	requests := make(chan string, 10)
+	server, url := newWsTestServer(serveStreams(requests))
+	defer server.Close()
+
+	raw := make(chan rawFrame, 10)
+	btc := make(chan string, 10)
+	c := NewWebsocketStreamClient(true, url).OnRawMessage(func(stream string, data []byte) {
+		raw <- rawFrame{stream, string(data)}
+	})
+	m := c.NewStreamMultiplexer(func(err error) {})
+	m.Register("btcusdt@trade", func(message []byte) { btc <- string(message) })
+	_, stopCh, err := m.Start()
+	s.Require().NoError(err)
+	defer close(stopCh)
+
+	// frames of unregistered streams are reported too
+	for _, expected := range []rawFrame{
+		{"btcusdt@trade", `{"stream":"btcusdt@trade","data":{"s":"BTCUSDT"}}`},
+		{"ethusdt@trade", `{"stream":"ethusdt@trade","data":{"s":"ETHUSDT"}}`},
+		{"btcusdt@trade", `{"stream":"btcusdt@trade","data":null}`},
+	} {
+		select {
+		case frame := <-raw:
+			s.Equal(expected, frame)
+		case <-time.After(5 * time.Second):
+			s.FailNow("no raw message")
+		}
+	}
+	select {
+	case message := <-btc:
+		s.Equal(`{"s":"BTCUSDT"}`, message)
+	case <-time.After(5 * time.Second):
+		s.FailNow("no message")
+	}
+}
@@ -40,13 +40,38 @@ const (
 	UserDataEventTypeBalanceUpdate           UserDataEventType = "balanceUpdate"
 	UserDataEventTypeExecutionReport         UserDataEventType = "executionReport"
 	UserDataEventTypeListStatus              UserDataEventType = "ListStatus"
+	UserDataEventTypeListenKeyExpired        UserDataEventType = "listenKeyExpired"
 )
 
 var (
-	// WebsocketTimeout is an interval for sending ping/pong messages if WebsocketKeepalive is enabled
+	// WebsocketTimeout is an interval for sending ping/pong messages if WebsocketKeepalive is enabled.
+	// It is the fallback for WebsocketPingInterval and WebsocketPongTimeout when those are left at zero.
 	WebsocketTimeout = time.Second * 60
-	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
+	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability, for the stream
+	// clients that did not call SetKeepalive
 	WebsocketKeepalive = false
+	// WebsocketPingInterval controls how often a ping frame is sent.
+	// Zero means WebsocketTimeout is used.
+	WebsocketPingInterval time.Duration
+	// WebsocketPingWriteTimeout is the write deadline applied to each ping frame, on both the
+	// stream and the websocket API connections. It only bounds how long sending one ping may block,
+	// it says nothing about the pong.
+	WebsocketPingWriteTimeout = time.Second * 10
+	// WebsocketPongTimeout is how long the connection may go without receiving a pong
+	// before keepalive gives up on it. Zero means WebsocketTimeout is used.
+	WebsocketPongTimeout time.Duration
+	// WebsocketReadLimit is the maximum size in bytes of a stream message.
+	// Fragmented messages are assembled before delivery and the limit applies to the whole message.
+	WebsocketReadLimit int64 = 655350
+	// WebsocketReadTimeout bounds the wait for the next data frame on the stream and the websocket API
+	// connections, the deadline being refreshed after each message read. Unlike keepalive, pongs do not
+	// refresh it, so a connection that only answers pings fails with ErrWsReadTimeout: set it above the
+	// longest quiet period of the stream, e.g. a user data stream without activity. Zero, the default,
+	// disables it.
+	WebsocketReadTimeout time.Duration
+	// WebsocketWriteTimeout is the write deadline applied to each data frame sent on the stream and the
+	// websocket API connections, e.g. a subscription or a request. Zero, the default, disables it.
+	WebsocketWriteTimeout time.Duration
 )
 
 // WsPartialDepthEvent define websocket partial depth book event
@@ -62,23 +87,35 @@ type WsPartialDepthHandler func(event *WsPartialDepthEvent)
 
 // WsPartialDepthServe serve websocket partial depth handler with a symbol, using 1sec updates
 func (c *WebsocketStreamClient) WsPartialDepthServe(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
+	if err := validateDepthLevels(levels); err != nil {
+		return nil, nil, err
+	}
 	endpoint := fmt.Sprintf("%s/%s@depth%s", c.Endpoint, strings.ToLower(symbol), levels)
-	return wsPartialDepthServe(endpoint, symbol, handler, errHandler)
+	return c.wsPartialDepthServe(endpoint, symbol, handler, errHandler)
 }
 
 // WsPartialDepthServe100Ms serve websocket partial depth handler with a symbol, using 100msec updates
 func (c *WebsocketStreamClient) WsPartialDepthServe100Ms(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
+	if err := validateDepthLevels(levels); err != nil {
+		return nil, nil, err
+	}
 	endpoint := fmt.Sprintf("%s/%s@depth%s@100ms", c.Endpoint, strings.ToLower(symbol), levels)
-	return wsPartialDepthServe(endpoint, symbol, handler, errHandler)
+	return c.wsPartialDepthServe(endpoint, symbol, handler, errHandler)
 }
 
 // WsPartialDepthServe serve websocket partial depth handler with a symbol
-func wsPartialDepthServe(endpoint string, symbol string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+func (c *WebsocketStreamClient) wsPartialDepthServe(endpoint string, symbol string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		event := new(WsPartialDepthEvent)
@@ -104,13 +141,19 @@ func wsPartialDepthServe(endpoint string, symbol string, handler WsPartialDepthH
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsCombinedPartialDepthServe is similar to WsPartialDepthServe, but it for multiple symbols
 func (c *WebsocketStreamClient) WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := c.Endpoint
 	for s, l := range symbolLevels {
+		if err := validateDepthLevels(l); err != nil {
+			return nil, nil, err
+		}
 		endpoint += fmt.Sprintf("%s@depth%s", strings.ToLower(s), l) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
@@ -118,7 +161,7 @@ func (c *WebsocketStreamClient) WsCombinedPartialDepthServe(symbolLevels map[str
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		event := new(WsPartialDepthEvent)
@@ -148,7 +191,7 @@ func (c *WebsocketStreamClient) WsCombinedPartialDepthServe(symbolLevels map[str
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsDepthHandler handle websocket depth event
@@ -156,23 +199,29 @@ type WsDepthHandler func(event *WsDepthEvent)
 
 // WsDepthServe serve websocket depth handler with a symbol, using 1sec updates
 func (c *WebsocketStreamClient) WsDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := fmt.Sprintf("%s/%s@depth", c.Endpoint, strings.ToLower(symbol))
-	return wsDepthServe(endpoint, handler, errHandler)
+	return c.wsDepthServe(endpoint, handler, errHandler)
 }
 
 // WsDepthServe100Ms serve websocket depth handler with a symbol, using 100msec updates
 func (c *WebsocketStreamClient) WsDepthServe100Ms(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := fmt.Sprintf("%s/%s@depth@100ms", c.Endpoint, strings.ToLower(symbol))
-	return wsDepthServe(endpoint, handler, errHandler)
+	return c.wsDepthServe(endpoint, handler, errHandler)
 }
 
 // WsDepthServe serve websocket depth handler with an arbitrary endpoint address
-func wsDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+func (c *WebsocketStreamClient) wsDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		event := new(WsDepthEvent)
@@ -201,7 +250,7 @@ func wsDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsDepthEvent define websocket depth event
@@ -217,29 +266,35 @@ type WsDepthEvent struct {
 
 // WsCombinedDepthServe is similar to WsDepthServe, but it for multiple symbols
 func (c *WebsocketStreamClient) WsCombinedDepthServe(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := c.Endpoint
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@depth", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return wsCombinedDepthServe(endpoint, handler, errHandler)
+	return c.wsCombinedDepthServe(endpoint, handler, errHandler)
 }
 
 func (c *WebsocketStreamClient) WsCombinedDepthServe100Ms(symbols []string, handler WsDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := c.Endpoint
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@depth@100ms", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return wsCombinedDepthServe(endpoint, handler, errHandler)
+	return c.wsCombinedDepthServe(endpoint, handler, errHandler)
 }
 
-func wsCombinedDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+func (c *WebsocketStreamClient) wsCombinedDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		event := new(WsDepthEvent)
@@ -271,7 +326,7 @@ func wsCombinedDepthServe(endpoint string, handler WsDepthHandler, errHandler Er
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsKlineHandler handle websocket kline event
@@ -279,6 +334,9 @@ type WsKlineHandler func(event *WsKlineEvent)
 
 // WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
 func (c *WebsocketStreamClient) WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := c.Endpoint
 	for symbol, interval := range symbolIntervalPair {
 		endpoint += fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval) + "/"
@@ -288,7 +346,7 @@ func (c *WebsocketStreamClient) WsCombinedKlineServe(symbolIntervalPair map[stri
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 
@@ -302,30 +360,33 @@ func (c *WebsocketStreamClient) WsCombinedKlineServe(symbolIntervalPair map[stri
 		event := new(WsKlineEvent)
 		err = json.Unmarshal(jsonData, event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		event.Symbol = strings.ToUpper(symbol)
 
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
 func (c *WebsocketStreamClient) WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := fmt.Sprintf("%s/%s@kline_%s", c.Endpoint, strings.ToLower(symbol), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsKlineEvent)
 		err := json.Unmarshal(message, event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsKlineEvent define websocket kline event
@@ -361,22 +422,28 @@ type WsAggTradeHandler func(event *WsAggTradeEvent)
 
 // WsAggTradeServe serve websocket aggregate handler with a symbol
 func (c *WebsocketStreamClient) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := fmt.Sprintf("%s/%s@aggTrade", c.Endpoint, strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsAggTradeEvent)
 		err := json.Unmarshal(message, event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsCombinedAggTradeServe is similar to WsAggTradeServe, but it handles multiple symbolx
 func (c *WebsocketStreamClient) WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := c.Endpoint
 	for s := range symbols {
 		endpoint += fmt.Sprintf("%s@aggTrade", strings.ToLower(symbols[s])) + "/"
@@ -386,7 +453,7 @@ func (c *WebsocketStreamClient) WsCombinedAggTradeServe(symbols []string, handle
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 
@@ -400,7 +467,7 @@ func (c *WebsocketStreamClient) WsCombinedAggTradeServe(symbols []string, handle
 		event := new(WsAggTradeEvent)
 		err = json.Unmarshal(jsonData, event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 
@@ -408,7 +475,7 @@ func (c *WebsocketStreamClient) WsCombinedAggTradeServe(symbols []string, handle
 
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsAggTradeEvent define websocket aggregate trade event
@@ -432,21 +499,27 @@ type WsCombinedTradeHandler func(event *WsCombinedTradeEvent)
 
 // WsTradeServe serve websocket handler with a symbol
 func (c *WebsocketStreamClient) WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := fmt.Sprintf("%s/%s@trade", c.Endpoint, strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsTradeEvent)
 		err := json.Unmarshal(message, event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 func (c *WebsocketStreamClient) WsCombinedTradeServe(symbols []string, handler WsCombinedTradeHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := c.Endpoint
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@trade/", strings.ToLower(s))
@@ -457,12 +530,12 @@ func (c *WebsocketStreamClient) WsCombinedTradeServe(symbols []string, handler W
 		event := new(WsCombinedTradeEvent)
 		err := json.Unmarshal(message, event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsTradeEvent define websocket trade event
@@ -576,12 +649,51 @@ type WsUserDataHandler func(event *WsUserDataEvent)
 
 // WsUserDataServe serve user data handler with listen key
 func (c *WebsocketStreamClient) WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := fmt.Sprintf("%s/%s", c.Endpoint, listenKey)
 	cfg := newWsConfig(endpoint)
-	wsHandler := func(message []byte) {
+	return c.serve(cfg, newUserDataWsHandler(handler, errHandler), errHandler)
+}
+
+// WsMarginUserDataServe serve cross margin user data handler with a listen key of CreateMarginUserStreamService
+func (c *WebsocketStreamClient) WsMarginUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
+	return c.WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// WsIsolatedMarginUserDataEvent define an isolated margin user data event and the symbol of its account
+type WsIsolatedMarginUserDataEvent struct {
+	Symbol string
+	WsUserDataEvent
+}
+
+// WsIsolatedMarginUserDataHandler handle WsIsolatedMarginUserDataEvent
+type WsIsolatedMarginUserDataHandler func(event *WsIsolatedMarginUserDataEvent)
+
+// WsIsolatedMarginUserDataServe serve isolated margin user data handler with the listen key of symbol,
+// created by CreateIsolatedMarginUserStreamService
+func (c *WebsocketStreamClient) WsIsolatedMarginUserDataServe(symbol string, listenKey string, handler WsIsolatedMarginUserDataHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
+	if symbol == "" {
+		return nil, nil, ErrUserStreamSymbolRequired
+	}
+	return c.WsUserDataServe(listenKey, func(event *WsUserDataEvent) {
+		handler(&WsIsolatedMarginUserDataEvent{Symbol: symbol, WsUserDataEvent: *event})
+	}, errHandler)
+}
+
+// newUserDataWsHandler decode user data messages, shared by the spot and margin user data streams
+func newUserDataWsHandler(handler WsUserDataHandler, errHandler ErrHandler) WsHandler {
+	return func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 
@@ -589,7 +701,7 @@ func (c *WebsocketStreamClient) WsUserDataServe(listenKey string, handler WsUser
 
 		err = json.Unmarshal(message, event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 
@@ -597,19 +709,19 @@ func (c *WebsocketStreamClient) WsUserDataServe(listenKey string, handler WsUser
 		case UserDataEventTypeOutboundAccountPosition:
 			err = json.Unmarshal(message, &event.AccountUpdate)
 			if err != nil {
-				errHandler(err)
+				errHandler.report(err)
 				return
 			}
 		case UserDataEventTypeBalanceUpdate:
 			err = json.Unmarshal(message, &event.BalanceUpdate)
 			if err != nil {
-				errHandler(err)
+				errHandler.report(err)
 				return
 			}
 		case UserDataEventTypeExecutionReport:
 			err = json.Unmarshal(message, &event.OrderUpdate)
 			if err != nil {
-				errHandler(err)
+				errHandler.report(err)
 				return
 			}
 			// Unmarshal has case sensitive problem
@@ -621,14 +733,13 @@ func (c *WebsocketStreamClient) WsUserDataServe(listenKey string, handler WsUser
 		case UserDataEventTypeListStatus:
 			err = json.Unmarshal(message, &event.OCOUpdate)
 			if err != nil {
-				errHandler(err)
+				errHandler.report(err)
 				return
 			}
 		}
 
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
 }
 
 // WsMarketTickersStatHandler handle websocket that push single market statistics for 24hr
@@ -636,6 +747,9 @@ type WsMarketTickersStatHandler func(event *WsMarketTickerStatEvent)
 
 // WsCombinedMarketTickersStatServe is similar to WsMarketTickersStatServe, but it handles multiple symbols
 func (c *WebsocketStreamClient) WsCombinedMarketTickersStatServe(symbols []string, handler WsMarketTickersStatHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := c.Endpoint
 	for s := range symbols {
 		endpoint += fmt.Sprintf("%s@ticker", strings.ToLower(symbols[s])) + "/"
@@ -646,7 +760,7 @@ func (c *WebsocketStreamClient) WsCombinedMarketTickersStatServe(symbols []strin
 	wsHandler := func(message []byte) {
 		j, err := newJSON(message)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 
@@ -660,7 +774,7 @@ func (c *WebsocketStreamClient) WsCombinedMarketTickersStatServe(symbols []strin
 		event := new(WsMarketTickerStatEvent)
 		err = json.Unmarshal(jsonData, event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 
@@ -668,23 +782,26 @@ func (c *WebsocketStreamClient) WsCombinedMarketTickersStatServe(symbols []strin
 
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsMarketTickersStatServe serve websocket that push 24hr statistics for single market every second
 func (c *WebsocketStreamClient) WsMarketTickersStatServe(symbol string, handler WsMarketTickersStatHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := fmt.Sprintf("%s/%s@ticker", c.Endpoint, strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsMarketTickerStatEvent
 		err := json.Unmarshal(message, &event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		handler(&event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsAllMarketTickersStatHandler handle websocket that push all markets statistics for 24hr
@@ -692,18 +809,21 @@ type WsAllMarketTickersStatHandler func(event WsAllMarketTickersStatEvent)
 
 // WsAllMarketTickersStatServe serve websocket that push 24hr statistics for all market every second
 func (c *WebsocketStreamClient) WsAllMarketTickersStatServe(handler WsAllMarketTickersStatHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := fmt.Sprintf("%s/!ticker@arr", c.Endpoint)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketTickersStatEvent
 		err := json.Unmarshal(message, &event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsAllMarketTickersStatEvent define array of websocket market statistics events
@@ -741,18 +861,21 @@ type WsAllMarketMiniTickersStatServeHandler func(event WsAllMarketMiniTickersSta
 
 // WsAllMarketMiniTickersStatServe serve websocket that push mini version of 24hr statistics for all market every second
 func (c *WebsocketStreamClient) WsAllMarketMiniTickersStatServe(handler WsAllMarketMiniTickersStatServeHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := fmt.Sprintf("%s/!miniTicker@arr", c.Endpoint)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketMiniTickersStatEvent
 		err := json.Unmarshal(message, &event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsAllMarketMiniTickersStatEvent define array of websocket market mini-ticker statistics events
@@ -763,18 +886,21 @@ type WsMarketMiniTickersStatHandler func(event WsMarketMiniTickerStatEvent)
 
 // WsMarketMiniTickersStatServe serve websocket that push mini version of 24hr statistics for single market every second
 func (c *WebsocketStreamClient) WsMarketMiniTickersStatServe(symbol string, handler WsMarketMiniTickersStatHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := fmt.Sprintf("%s/%s@miniTicker", c.Endpoint, strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsMarketMiniTickerStatEvent
 		err := json.Unmarshal(message, &event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsMarketMiniTickerStatEvent define array of websocket market mini-ticker statistics events
@@ -813,22 +939,28 @@ type WsBookTickerHandler func(event *WsBookTickerEvent)
 
 // WsBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for a specified symbol.
 func (c *WebsocketStreamClient) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := fmt.Sprintf("%s/%s@bookTicker", c.Endpoint, strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
 		err := json.Unmarshal(message, &event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
 
 // WsCombinedBookTickerServe is similar to WsBookTickerServe, but it is for multiple symbols
 func (c *WebsocketStreamClient) WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if handler == nil {
+		return nil, nil, ErrNilHandler
+	}
 	endpoint := c.Endpoint
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
@@ -839,10 +971,10 @@ func (c *WebsocketStreamClient) WsCombinedBookTickerServe(symbols []string, hand
 		event := new(WsCombinedBookTickerEvent)
 		err := json.Unmarshal(message, event)
 		if err != nil {
-			errHandler(err)
+			errHandler.report(err)
 			return
 		}
 		handler(event.Data)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.serve(cfg, wsHandler, errHandler)
 }
@@ -157,6 +157,45 @@ func (s *websocketTestSuite) TestWsUserDataServeAccountUpdate() {
 	s.testWsUserDataServe(data, expectedEvent)
 }
 
+func (s *websocketTestSuite) TestWsIsolatedMarginUserDataServe() {
+	websocketStreamClient := NewWebsocketStreamClient(false, "wss://stream.testnet.binance.vision")
+
+	data := []byte(`{
+	   "e":"balanceUpdate",
+	   "E":1573200697110,
+	   "a":"BTC",
+	   "d":"100.00000000",
+	   "T":1573200697068
+	}`)
+	s.mockWsServe(data, nil)
+	defer s.assertWsServe()
+
+	var event *WsIsolatedMarginUserDataEvent
+	doneC, stopC, err := websocketStreamClient.WsIsolatedMarginUserDataServe("BTCUSDT", "listenKey", func(e *WsIsolatedMarginUserDataEvent) {
+		event = e
+	}, func(err error) {
+		s.r().NoError(err)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+
+	s.r().NotNil(event)
+	s.r().Equal("BTCUSDT", event.Symbol)
+	s.r().Equal(UserDataEventTypeBalanceUpdate, event.Event)
+	s.r().Equal(int64(1573200697068), event.TransactionTime)
+	s.assertBalanceUpdate(&WsBalanceUpdate{Asset: "BTC", Change: "100.00000000"}, &event.BalanceUpdate)
+}
+
+func (s *websocketTestSuite) TestWsIsolatedMarginUserDataServeRequiresSymbol() {
+	websocketStreamClient := NewWebsocketStreamClient(false, "wss://stream.testnet.binance.vision")
+	s.mockWsServe(nil, nil)
+	defer s.assertWsServe(0)
+
+	_, _, err := websocketStreamClient.WsIsolatedMarginUserDataServe("", "listenKey", func(e *WsIsolatedMarginUserDataEvent) {}, func(err error) {})
+	s.r().ErrorIs(err, ErrUserStreamSymbolRequired)
+}
+
 func (s *websocketTestSuite) TestWsTradeServe() {
 	websocketStreamClient := NewWebsocketStreamClient(false, "wss://stream.testnet.binance.vision")
 
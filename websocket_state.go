@@ -0,0 +1,165 @@
+package binance_connector
+
+import (
+	"strconv"
+	"strings"
+)
+
+// WsConnState define the state of a stream connection
+type WsConnState int
+
+const (
+	// WsConnStateClosed is the state of a stream that is not connected, it is the state of unknown streams
+	WsConnStateClosed WsConnState = iota
+	// WsConnStateConnecting is the state of a stream while its connection is dialed
+	WsConnStateConnecting
+	// WsConnStateConnected is the state of a stream receiving messages
+	WsConnStateConnected
+	// WsConnStateReconnecting is the state of a stream that lost its connection and dials a new one
+	WsConnStateReconnecting
+)
+
+// String return the name of the state
+func (s WsConnState) String() string {
+	switch s {
+	case WsConnStateClosed:
+		return "CLOSED"
+	case WsConnStateConnecting:
+		return "CONNECTING"
+	case WsConnStateConnected:
+		return "CONNECTED"
+	case WsConnStateReconnecting:
+		return "RECONNECTING"
+	}
+	return "UNKNOWN"
+}
+
+// WsStateChangeHandler handle a state transition of a stream connection.
+// stream is the connection endpoint relative to the client endpoint, e.g. btcusdt@depth. A connection keeps its
+// name until it is closed, through its reconnections; when another connection of the client already reports
+// under that name, a suffix tells them apart, e.g. btcusdt@depth#2.
+type WsStateChangeHandler func(stream string, oldState, newState WsConnState)
+
+// OnStateChange set the handler called on every state transition of the client connections.
+// It is called synchronously, a slow handler delays the connection it reports on.
+func (c *WebsocketStreamClient) OnStateChange(handler WsStateChangeHandler) *WebsocketStreamClient {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.onStateChange = handler
+	return c
+}
+
+// State return the state of the connection serving stream
+func (c *WebsocketStreamClient) State(stream string) WsConnState {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.states[stream]
+}
+
+// States return the state of every connection of the client that is not closed
+func (c *WebsocketStreamClient) States() map[string]WsConnState {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	states := make(map[string]WsConnState, len(c.states))
+	for stream, state := range c.states {
+		states[stream] = state
+	}
+	return states
+}
+
+// setState record the state of the connection of owner, dialed to endpoint, and report the transition
+func (c *WebsocketStreamClient) setState(owner any, endpoint string, state WsConnState) {
+	c.stateMu.Lock()
+	stream, ok := c.stateNames[owner]
+	if !ok {
+		if state == WsConnStateClosed {
+			c.stateMu.Unlock()
+			return
+		}
+		stream = c.uniqueStateName(c.streamName(endpoint))
+		if c.stateNames == nil {
+			c.stateNames = make(map[any]string)
+		}
+		c.stateNames[owner] = stream
+	}
+	old := c.states[stream]
+	if old == state {
+		c.stateMu.Unlock()
+		return
+	}
+	if state == WsConnStateClosed {
+		delete(c.states, stream)
+		delete(c.stateNames, owner)
+	} else {
+		if c.states == nil {
+			c.states = make(map[string]WsConnState)
+		}
+		c.states[stream] = state
+	}
+	handler := c.onStateChange
+	c.stateMu.Unlock()
+	if handler != nil {
+		handler(stream, old, state)
+	}
+}
+
+// stateOf return the state of the connection of owner
+func (c *WebsocketStreamClient) stateOf(owner any) WsConnState {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	stream, ok := c.stateNames[owner]
+	if !ok {
+		return WsConnStateClosed
+	}
+	return c.states[stream]
+}
+
+// uniqueStateName return stream, or stream#2, stream#3... when another connection reports under it,
+// c.stateMu must be held
+func (c *WebsocketStreamClient) uniqueStateName(stream string) string {
+	name := stream
+	for i := 2; ; i++ {
+		if _, ok := c.states[name]; !ok {
+			return name
+		}
+		name = stream + "#" + strconv.Itoa(i)
+	}
+}
+
+// streamName return the streams of endpoint, e.g. btcusdt@depth for wss://stream.binance.com:9443/ws/btcusdt@depth
+func (c *WebsocketStreamClient) streamName(endpoint string) string {
+	if strings.HasPrefix(endpoint, c.Endpoint) {
+		return strings.TrimPrefix(strings.TrimPrefix(endpoint, c.Endpoint), "/")
+	}
+	// combined connections opened by a raw stream client, as the multiplexer does
+	if i := strings.Index(endpoint, "?streams="); i >= 0 {
+		return endpoint[i+len("?streams="):]
+	}
+	return endpoint
+}
+
+// serve connect cfg through wsServe with the client TLS configuration, track the state of the connection
+// and report its frames to the raw message handler
+func (c *WebsocketStreamClient) serve(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneCh, stopCh chan struct{}, err error) {
+	if cfg.TLSConfig == nil {
+		cfg.TLSConfig = c.getTLSConfig()
+	}
+	if c.EnableCompression {
+		cfg.EnableCompression = true
+	}
+	cfg.onConnect = func(conn *wsConn) { c.connected(cfg.Endpoint, conn) }
+	c.applyKeepAlive(cfg)
+	handler = c.withRawMessage(cfg.Endpoint, withControlFrames(cfg.Endpoint, handler, errHandler))
+	c.setState(cfg, cfg.Endpoint, WsConnStateConnecting)
+	doneCh, stopCh, err = wsServe(cfg, handler, errHandler)
+	if err != nil {
+		c.setState(cfg, cfg.Endpoint, WsConnStateClosed)
+		return nil, nil, err
+	}
+	c.setState(cfg, cfg.Endpoint, WsConnStateConnected)
+	go func() {
+		<-doneCh
+		c.setState(cfg, cfg.Endpoint, WsConnStateClosed)
+	}()
+	return doneCh, stopCh, nil
+}
@@ -0,0 +1,153 @@
+package binance_connector
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsStateTestSuite struct {
+	suite.Suite
+}
+
+func TestWsState(t *testing.T) {
+	suite.Run(t, new(wsStateTestSuite))
+}
+
+func (s *wsStateTestSuite) recordTransitions(client *WebsocketStreamClient) chan string {
+	transitions := make(chan string, 10)
+	client.OnStateChange(func(stream string, oldState, newState WsConnState) {
+		transitions <- fmt.Sprintf("%s %s>%s", stream, oldState, newState)
+	})
+	return transitions
+}
+
+func (s *wsStateTestSuite) nextTransition(transitions chan string) string {
+	select {
+	case t := <-transitions:
+		return t
+	case <-time.After(time.Second):
+		return "timeout"
+	}
+}
+
+func (s *wsStateTestSuite) TestServeReportsTransitions() {
+	serverDone := make(chan struct{})
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"trade","s":"BTCUSDT"}`))
+		<-serverDone
+	})
+	defer server.Close()
+	defer close(serverDone)
+
+	client := NewWebsocketStreamClient(false, url)
+	transitions := s.recordTransitions(client)
+	received := make(chan struct{}, 1)
+	doneCh, stopCh, err := client.WsTradeServe("BTCUSDT", func(event *WsTradeEvent) {
+		received <- struct{}{}
+	}, func(err error) {})
+	s.Require().NoError(err)
+
+	s.Equal("btcusdt@trade CLOSED>CONNECTING", s.nextTransition(transitions))
+	s.Equal("btcusdt@trade CONNECTING>CONNECTED", s.nextTransition(transitions))
+	<-received
+	s.Equal(WsConnStateConnected, client.State("btcusdt@trade"))
+	s.Equal(map[string]WsConnState{"btcusdt@trade": WsConnStateConnected}, client.States())
+
+	stopCh <- struct{}{}
+	<-doneCh
+	s.Equal("btcusdt@trade CONNECTED>CLOSED", s.nextTransition(transitions))
+	s.Equal(WsConnStateClosed, client.State("btcusdt@trade"))
+	s.Empty(client.States())
+}
+
+func (s *wsStateTestSuite) TestDialFailureReportsClosed() {
+	server, url := newWsTestServer(func(conn *websocket.Conn) {})
+	server.Close()
+
+	client := NewWebsocketStreamClient(true, url)
+	transitions := s.recordTransitions(client)
+	_, _, err := client.WsCombinedTradeServe([]string{"BTCUSDT", "ETHUSDT"}, func(event *WsCombinedTradeEvent) {}, func(err error) {})
+	s.Require().Error(err)
+
+	s.Equal("btcusdt@trade/ethusdt@trade CLOSED>CONNECTING", s.nextTransition(transitions))
+	s.Equal("btcusdt@trade/ethusdt@trade CONNECTING>CLOSED", s.nextTransition(transitions))
+	s.Equal(WsConnStateClosed, client.State("btcusdt@trade/ethusdt@trade"))
+}
+
+func (s *wsStateTestSuite) TestMultiplexerReportsTransitions() {
+	requests := make(chan string, 10)
+	server, url := newWsTestServer(serveStreams(requests))
+	defer server.Close()
+
+	client := NewWebsocketStreamClient(false, url)
+	transitions := s.recordTransitions(client)
+	m := client.NewStreamMultiplexer(func(err error) {})
+	m.Register("btcusdt@trade", func(message []byte) {})
+	doneCh, stopCh, err := m.Start()
+	s.Require().NoError(err)
+
+	s.Equal("btcusdt@trade CLOSED>CONNECTING", s.nextTransition(transitions))
+	s.Equal("btcusdt@trade CONNECTING>CONNECTED", s.nextTransition(transitions))
+	close(stopCh)
+	<-doneCh
+	s.Equal("btcusdt@trade CONNECTED>CLOSED", s.nextTransition(transitions))
+}
+
+func (s *wsStateTestSuite) TestPoolReportsReconnecting() {
+	origMaxStreams := WebsocketMaxStreamsPerConnection
+	WebsocketMaxStreamsPerConnection = 1
+	defer func() { WebsocketMaxStreamsPerConnection = origMaxStreams }()
+	conns := make(chan *websocket.Conn, 10)
+	server, url := newWsTestServer(serveResubscriptions(conns, make(chan []string, 10)))
+	defer server.Close()
+
+	client := NewWebsocketStreamClient(true, url)
+	transitions := s.recordTransitions(client)
+	pool := client.NewStreamPool(func(err error) {}).Reconnect(Backoff{Base: 10 * time.Millisecond})
+	s.Require().NoError(pool.Subscribe(newContext(), "a@trade", func(message []byte) {}))
+	s.Require().NoError(pool.Subscribe(newContext(), "b@trade", func(message []byte) {}))
+	for i := 0; i < 4; i++ {
+		s.nextTransition(transitions)
+	}
+	<-conns
+	(<-conns).UnderlyingConn().Close()
+
+	// the connection keeps the name of its streams, though it is dialed again without them
+	s.Equal("b@trade CONNECTED>RECONNECTING", s.nextTransition(transitions))
+	s.Equal("b@trade RECONNECTING>CONNECTED", s.nextTransition(transitions))
+	s.Equal(map[string]WsConnState{"a@trade": WsConnStateConnected, "b@trade": WsConnStateConnected}, client.States())
+
+	pool.Close()
+	s.ElementsMatch([]string{"a@trade CONNECTED>CLOSED", "b@trade CONNECTED>CLOSED"},
+		[]string{s.nextTransition(transitions), s.nextTransition(transitions)})
+	s.Empty(client.States())
+}
+
+func (s *wsStateTestSuite) TestSameStreamsOnTwoConnections() {
+	server, url := newWsTestServer(serveStreams(make(chan string, 10)))
+	defer server.Close()
+
+	client := NewWebsocketStreamClient(false, url)
+	var stops []chan struct{}
+	for i := 0; i < 2; i++ {
+		_, stopCh, err := client.NewStreamMultiplexer(func(err error) {}).Register("btcusdt@trade", func(message []byte) {}).Start()
+		s.Require().NoError(err)
+		stops = append(stops, stopCh)
+	}
+	s.Equal(map[string]WsConnState{"btcusdt@trade": WsConnStateConnected, "btcusdt@trade#2": WsConnStateConnected}, client.States())
+	close(stops[0])
+	s.Eventually(func() bool { return len(client.States()) == 1 }, time.Second, 10*time.Millisecond)
+	s.Equal(WsConnStateConnected, client.State("btcusdt@trade#2"), "the other connection keeps its name")
+	close(stops[1])
+	s.Eventually(func() bool { return len(client.States()) == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func (s *wsStateTestSuite) TestStateString() {
+	s.Equal("CONNECTING", WsConnStateConnecting.String())
+	s.Equal("RECONNECTING", WsConnStateReconnecting.String())
+	s.Equal("UNKNOWN", WsConnState(42).String())
+}
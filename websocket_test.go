@@ -0,0 +1,351 @@
+package binance_connector
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsConnTestSuite struct {
+	suite.Suite
+}
+
+func TestWsConn(t *testing.T) {
+	suite.Run(t, new(wsConnTestSuite))
+}
+
+// newWsTestServer starts a websocket server that hands every accepted
+// connection to serve and returns the ws:// URL to dial
+func newWsTestServer(serve func(conn *websocket.Conn)) (*httptest.Server, string) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		serve(conn)
+	}))
+	return server, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// newWssTestServer is similar to newWsTestServer, but serves TLS and returns the wss:// URL
+func newWssTestServer(serve func(conn *websocket.Conn)) (*httptest.Server, string) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		serve(conn)
+	}))
+	return server, "wss" + strings.TrimPrefix(server.URL, "https")
+}
+
+// newCompressedWsTestServer is similar to newWsTestServer, but accepts permessage-deflate and
+// sends the extensions offered by each client on offered
+func newCompressedWsTestServer(offered chan<- string, serve func(conn *websocket.Conn)) (*httptest.Server, string) {
+	upgrader := websocket.Upgrader{EnableCompression: true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offered <- r.Header.Get("Sec-WebSocket-Extensions")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		serve(conn)
+	}))
+	return server, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func (s *wsConnTestSuite) TestConcurrentControlAndDataWrites() {
+	const writers = 8
+	const messages = 50
+
+	received := make(chan string, writers*messages)
+	server, url := newWsTestServer(func(conn *websocket.Conn) {
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			received <- string(message)
+		}
+	})
+	defer server.Close()
+
+	raw, _, err := websocket.DefaultDialer.Dial(url, nil)
+	s.Require().NoError(err)
+	c := newWsConn(raw)
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < messages; j++ {
+				s.NoError(c.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(time.Second)))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < messages; j++ {
+				s.NoError(c.WriteMessage(websocket.TextMessage, []byte(`{"method":"SUBSCRIBE"}`)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < writers*messages; i++ {
+		select {
+		case message := <-received:
+			s.Equal(`{"method":"SUBSCRIBE"}`, message)
+		case <-time.After(5 * time.Second):
+			s.FailNow("timed out waiting for data frames", "received %d of %d", i, writers*messages)
+		}
+	}
+}
+
+func (s *wsConnTestSuite) TestKeepAliveConfigDefaults() {
+	cfg := newKeepAliveConfig(time.Minute)
+	s.Equal(time.Minute, cfg.pingInterval)
+	s.Equal(WebsocketPingWriteTimeout, cfg.pingWriteTimeout)
+	s.Equal(time.Minute, cfg.pongTimeout)
+}
+
+func (s *wsConnTestSuite) TestKeepAliveConfigOverrides() {
+	origInterval, origPong := WebsocketPingInterval, WebsocketPongTimeout
+	defer func() {
+		WebsocketPingInterval, WebsocketPongTimeout = origInterval, origPong
+	}()
+	WebsocketPingInterval = 5 * time.Second
+	WebsocketPongTimeout = 2 * time.Minute
+
+	cfg := newKeepAliveConfig(time.Minute)
+	s.Equal(5*time.Second, cfg.pingInterval)
+	s.Equal(2*time.Minute, cfg.pongTimeout)
+}
+
+// serveFragmented streams message through a single message writer in chunks,
+// gorilla emits a continuation frame each time its write buffer (4096 bytes) fills,
+// then closes the connection
+func serveFragmented(message []byte, chunkSize int) func(conn *websocket.Conn) {
+	return func(conn *websocket.Conn) {
+		w, err := conn.NextWriter(websocket.TextMessage)
+		if err != nil {
+			return
+		}
+		for len(message) > 0 {
+			n := min(chunkSize, len(message))
+			if _, err := w.Write(message[:n]); err != nil {
+				return
+			}
+			message = message[n:]
+		}
+		w.Close()
+	}
+}
+
+func (s *wsConnTestSuite) TestFragmentedMessageDeliveredWhole() {
+	message := []byte(strings.Repeat("x", 20000))
+	server, url := newWsTestServer(serveFragmented(message, 1000))
+	defer server.Close()
+
+	received := make(chan []byte, 1)
+	doneCh, _, err := wsServe(newWsConfig(url), func(message []byte) {
+		received <- message
+	}, func(err error) {})
+	s.Require().NoError(err)
+
+	select {
+	case got := <-received:
+		s.Equal(message, got)
+	case <-time.After(5 * time.Second):
+		s.FailNow("timed out waiting for the fragmented message")
+	}
+	<-doneCh
+}
+
+func (s *wsConnTestSuite) TestReadLimitAppliesAcrossFragments() {
+	origLimit := WebsocketReadLimit
+	defer func() { WebsocketReadLimit = origLimit }()
+	WebsocketReadLimit = 10000
+
+	server, url := newWsTestServer(serveFragmented([]byte(strings.Repeat("x", 20000)), 1000))
+	defer server.Close()
+
+	errs := make(chan error, 1)
+	doneCh, _, err := wsServe(newWsConfig(url), func(message []byte) {
+		s.Fail("message over the read limit must not be delivered")
+	}, func(err error) {
+		errs <- err
+	})
+	s.Require().NoError(err)
+
+	select {
+	case err := <-errs:
+		s.ErrorIs(err, websocket.ErrReadLimit)
+	case <-time.After(5 * time.Second):
+		s.FailNow("timed out waiting for the read limit error")
+	}
+	<-doneCh
+}
+
+// serveTicks writes messages until the client goes away
+func serveTicks(conn *websocket.Conn) {
+	for {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"trade"}`)); err != nil {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (s *wsConnTestSuite) TestRapidStartStop() {
+	server, url := newWsTestServer(serveTicks)
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			doneCh, stopCh, err := wsServe(newWsConfig(url), func(message []byte) {}, func(err error) {})
+			if !s.NoError(err) {
+				return
+			}
+			time.Sleep(time.Duration(i%5) * time.Millisecond)
+			switch i % 3 {
+			case 0:
+				stopCh <- struct{}{}
+			case 1:
+				close(stopCh)
+			case 2:
+				// a stop racing with a close, the late one must not block or panic
+				stopCh <- struct{}{}
+				<-doneCh
+				close(stopCh)
+			}
+			select {
+			case <-doneCh:
+			case <-time.After(5 * time.Second):
+				s.Fail("connection not stopped")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (s *wsConnTestSuite) TestStopAfterServerClose() {
+	server, url := newWsTestServer(func(conn *websocket.Conn) {})
+	defer server.Close()
+
+	errs := make(chan error, 1)
+	doneCh, stopCh, err := wsServe(newWsConfig(url), func(message []byte) {}, func(err error) {
+		errs <- err
+	})
+	s.Require().NoError(err)
+	<-doneCh
+	s.Error(<-errs)
+
+	// stopping a connection that already ended neither blocks nor panics
+	stopCh <- struct{}{}
+	close(stopCh)
+}
+
+func (s *wsConnTestSuite) TestNoHandlerCallAfterDone() {
+	server, url := newWsTestServer(serveTicks)
+	defer server.Close()
+
+	var mu sync.Mutex
+	done := false
+	calls := make(chan struct{}, 1)
+	doneCh, stopCh, err := wsServe(newWsConfig(url), func(message []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		s.False(done, "handler called after doneCh closed")
+		select {
+		case calls <- struct{}{}:
+		default:
+		}
+	}, func(err error) {
+		s.Fail("no error expected after a requested stop", err.Error())
+	})
+	s.Require().NoError(err)
+	<-calls
+	close(stopCh)
+	<-doneCh
+	mu.Lock()
+	done = true
+	mu.Unlock()
+	time.Sleep(20 * time.Millisecond)
+}
+
+func (s *wsConnTestSuite) TestStreamTLSConfig() {
+	server, url := newWssTestServer(func(conn *websocket.Conn) {
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"trade","s":"BTCUSDT"}`))
+	})
+	defer server.Close()
+
+	client := NewWebsocketStreamClient(false, url)
+	_, _, err := client.WsTradeServe("BTCUSDT", func(event *WsTradeEvent) {}, func(err error) {})
+	var unknownAuthority x509.UnknownAuthorityError
+	s.Require().ErrorAs(err, &unknownAuthority)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	client.SetTLSConfig(&tls.Config{RootCAs: roots})
+	received := make(chan string, 1)
+	doneCh, _, err := client.WsTradeServe("BTCUSDT", func(event *WsTradeEvent) {
+		received <- event.Symbol
+	}, func(err error) {})
+	s.Require().NoError(err)
+	s.Equal("BTCUSDT", <-received)
+	<-doneCh
+
+	m := client.NewStreamMultiplexer(func(err error) {})
+	doneCh, _, err = m.Register("btcusdt@trade", func(message []byte) {}).Start()
+	s.Require().NoError(err)
+	<-doneCh
+}
+
+func (s *wsConnTestSuite) TestStreamCompression() {
+	large := `{"e":"trade","s":"BTCUSDT","p":"` + strings.Repeat("1", 64*1024) + `"}`
+	offered := make(chan string, 4)
+	server, url := newCompressedWsTestServer(offered, func(conn *websocket.Conn) {
+		conn.WriteMessage(websocket.TextMessage, []byte(large))
+	})
+	defer server.Close()
+
+	client := NewWebsocketStreamClient(false, url)
+	doneCh, _, err := client.WsTradeServe("BTCUSDT", func(event *WsTradeEvent) {}, func(err error) {})
+	s.Require().NoError(err)
+	<-doneCh
+	s.Empty(<-offered, "compression is off by default")
+
+	client.EnableCompression = true
+	received := make(chan string, 1)
+	doneCh, _, err = client.WsTradeServe("BTCUSDT", func(event *WsTradeEvent) {
+		received <- event.Price
+	}, func(err error) {})
+	s.Require().NoError(err)
+	s.Contains(<-offered, "permessage-deflate")
+	s.Len(<-received, 64*1024)
+	<-doneCh
+
+	m := client.NewStreamMultiplexer(func(err error) {})
+	doneCh, _, err = m.Register("btcusdt@trade", func(message []byte) {}).Start()
+	s.Require().NoError(err)
+	s.Contains(<-offered, "permessage-deflate")
+	<-doneCh
+}
@@ -0,0 +1,218 @@
+package binance_connector
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/goccy/go-json"
+)
+
+// DefaultRequestWeight is the weight of an endpoint missing from EndpointWeights
+const DefaultRequestWeight = 1
+
+// WeightTier define the weight of a request while a parameter is at most Max, Max 0 means no upper bound
+type WeightTier struct {
+	Max    int
+	Weight int
+}
+
+// EndpointWeight define how the request weight of an endpoint is computed from its parameters.
+// Rules are checked in field order, the first one that applies gives the weight.
+type EndpointWeight struct {
+	// LimitTiers give the weight by the limit parameter, DefaultLimit is used when it is not set
+	LimitTiers   []WeightTier
+	DefaultLimit int
+	// SymbolsTiers give the weight by the number of symbols in the symbols parameter
+	SymbolsTiers []WeightTier
+	// PerSymbol is the weight of each symbol in the symbols parameter, capped at MaxWeight
+	PerSymbol int
+	MaxWeight int
+	// ParamWeights give the weight when one of the parameters is set, e.g. symbol or orderId
+	ParamWeights map[string]int
+	// Weight applies when no other rule does
+	Weight int
+}
+
+// EndpointWeights is the request weight registry keyed by "METHOD /path".
+// Entries can be replaced when Binance changes its costs.
+var EndpointWeights = map[string]EndpointWeight{
+	// Market Endpoints
+	"GET /api/v3/ping":         {Weight: 1},
+	"GET /api/v3/time":         {Weight: 1},
+	"GET /api/v3/exchangeInfo": {Weight: 20},
+	"GET /api/v3/depth": {
+		LimitTiers:   []WeightTier{{Max: 100, Weight: 5}, {Max: 500, Weight: 25}, {Max: 1000, Weight: 50}, {Weight: 250}},
+		DefaultLimit: 100,
+	},
+	"GET /api/v3/trades":           {Weight: 25},
+	"GET /api/v3/historicalTrades": {Weight: 25},
+	"GET /api/v3/aggTrades":        {Weight: 2},
+	"GET /api/v3/klines":           {Weight: 2},
+	"GET /api/v3/uiKlines":         {Weight: 2},
+	"GET /api/v3/avgPrice":         {Weight: 2},
+	"GET /api/v3/ticker/24hr": {
+		SymbolsTiers: []WeightTier{{Max: 20, Weight: 2}, {Max: 100, Weight: 40}, {Weight: 80}},
+		ParamWeights: map[string]int{"symbol": 2},
+		Weight:       80,
+	},
+	"GET /api/v3/ticker/price": {
+		ParamWeights: map[string]int{"symbol": 2},
+		Weight:       4,
+	},
+	"GET /api/v3/ticker/bookTicker": {
+		ParamWeights: map[string]int{"symbol": 2},
+		Weight:       4,
+	},
+	"GET /api/v3/ticker": {
+		PerSymbol: 4,
+		MaxWeight: 200,
+		Weight:    4,
+	},
+
+	// Account Endpoints
+	"POST /api/v3/order/test":           {Weight: 1},
+	"POST /api/v3/order":                {Weight: 1},
+	"GET /api/v3/order":                 {Weight: 4},
+	"DELETE /api/v3/order":              {Weight: 1},
+	"POST /api/v3/order/cancelReplace":  {Weight: 1},
+	"DELETE /api/v3/openOrders":         {Weight: 1},
+	"GET /api/v3/openOrders":            {ParamWeights: map[string]int{"symbol": 6}, Weight: 80},
+	"GET /api/v3/allOrders":             {Weight: 20},
+	"POST /api/v3/order/oco":            {Weight: 1},
+	"DELETE /api/v3/orderList":          {Weight: 1},
+	"GET /api/v3/orderList":             {Weight: 4},
+	"GET /api/v3/allOrderList":          {Weight: 20},
+	"GET /api/v3/openOrderList":         {Weight: 6},
+	"GET /api/v3/account":               {Weight: 20},
+	"GET /api/v3/myTrades":              {ParamWeights: map[string]int{"orderId": 5}, Weight: 20},
+	"GET /api/v3/rateLimit/order":       {Weight: 40},
+	"GET /api/v3/myPreventedMatches":    {ParamWeights: map[string]int{"preventedMatchId": 2}, Weight: 20},
+	"POST /api/v3/userDataStream":       {Weight: 2},
+	"PUT /api/v3/userDataStream":        {Weight: 2},
+	"DELETE /api/v3/userDataStream":     {Weight: 2},
+	"GET " + convertOrderStatusEndpoint: {Weight: 100},
+}
+
+// RequestWeight return the weight of a request to endpoint with the given parameters
+func RequestWeight(method, endpoint string, params url.Values) int {
+	w, ok := EndpointWeights[method+" "+endpoint]
+	if !ok {
+		return DefaultRequestWeight
+	}
+	return w.weight(params)
+}
+
+func (w EndpointWeight) weight(params url.Values) int {
+	if len(w.LimitTiers) > 0 {
+		limit := w.DefaultLimit
+		if v, err := strconv.Atoi(params.Get("limit")); err == nil {
+			limit = v
+		}
+		return tierWeight(w.LimitTiers, limit)
+	}
+	if symbols := params.Get("symbols"); symbols != "" && (len(w.SymbolsTiers) > 0 || w.PerSymbol > 0) {
+		var list []string
+		if err := json.Unmarshal([]byte(symbols), &list); err == nil {
+			if len(w.SymbolsTiers) > 0 {
+				return tierWeight(w.SymbolsTiers, len(list))
+			}
+			return min(w.PerSymbol*len(list), w.MaxWeight)
+		}
+	}
+	for param, weight := range w.ParamWeights {
+		if params.Has(param) {
+			return weight
+		}
+	}
+	return w.Weight
+}
+
+func tierWeight(tiers []WeightTier, n int) int {
+	for _, tier := range tiers {
+		if tier.Max == 0 || n <= tier.Max {
+			return tier.Weight
+		}
+	}
+	return tiers[len(tiers)-1].Weight
+}
+
+// usedWeightHeader reports the weight used by the IP over the current minute, on every /api response
+const usedWeightHeader = "X-Mbx-Used-Weight-1m"
+
+// LastUsedWeight return the request weight used over the current minute as reported by the last response
+// carrying the X-MBX-USED-WEIGHT-1M header, zero before one is received. It is shared with the clones of the
+// client, as the weight is counted by IP, and safe for concurrent use.
+func (c *Client) LastUsedWeight() int64 {
+	if c.usedWeight == nil {
+		return 0
+	}
+	return c.usedWeight.Load()
+}
+
+// recordUsedWeight keep the used weight reported by header, the responses without it leave it unchanged
+func (c *Client) recordUsedWeight(header http.Header) {
+	if c.usedWeight == nil {
+		return
+	}
+	if weight, err := strconv.ParseInt(header.Get(usedWeightHeader), 10, 64); err == nil {
+		c.usedWeight.Store(weight)
+	}
+}
+
+// weight return the weight of the request, query and form parameters included
+func (r *request) weight() int {
+	params := url.Values{}
+	for k, v := range r.query {
+		params[k] = v
+	}
+	for k, v := range r.form {
+		params[k] = v
+	}
+	return RequestWeight(r.method, r.endpoint, params)
+}
+
+// Weight return the request weight of the service with the parameters set so far
+func (s *OrderBook) Weight() int {
+	r := &request{method: http.MethodGet, endpoint: "/api/v3/depth"}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	return r.weight()
+}
+
+// Weight return the request weight of the service with the parameters set so far
+func (s *Ticker24hr) Weight() int {
+	return tickerSymbolsWeight("/api/v3/ticker/24hr", s.symbol, s.symbols)
+}
+
+// Weight return the request weight of the service with the parameters set so far
+func (s *TickerPrice) Weight() int {
+	return tickerSymbolsWeight("/api/v3/ticker/price", s.symbol, s.symbols)
+}
+
+// Weight return the request weight of the service with the parameters set so far
+func (s *TickerBookTicker) Weight() int {
+	return tickerSymbolsWeight("/api/v3/ticker/bookTicker", s.symbol, s.symbols)
+}
+
+// Weight return the request weight of the service with the parameters set so far
+func (s *GetOpenOrdersService) Weight() int {
+	r := &request{method: http.MethodGet, endpoint: "/api/v3/openOrders"}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	return r.weight()
+}
+
+func tickerSymbolsWeight(endpoint string, symbol *string, symbols *[]string) int {
+	r := &request{method: http.MethodGet, endpoint: endpoint}
+	if symbol != nil {
+		r.setParam("symbol", *symbol)
+	}
+	if symbols != nil {
+		s, _ := json.Marshal(symbols)
+		r.setParam("symbols", string(s))
+	}
+	return r.weight()
+}
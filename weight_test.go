@@ -0,0 +1,102 @@
+package binance_connector
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type weightTestSuite struct {
+	baseTestSuite
+}
+
+func TestWeight(t *testing.T) {
+	suite.Run(t, new(weightTestSuite))
+}
+
+func (s *weightTestSuite) TestDepthWeightByLimit() {
+	s.Equal(5, s.client.NewOrderBookService().Symbol("BTCUSDT").Weight())
+	s.Equal(5, s.client.NewOrderBookService().Symbol("BTCUSDT").Limit(100).Weight())
+	s.Equal(25, s.client.NewOrderBookService().Symbol("BTCUSDT").Limit(500).Weight())
+	s.Equal(50, s.client.NewOrderBookService().Symbol("BTCUSDT").Limit(1000).Weight())
+	s.Equal(250, s.client.NewOrderBookService().Symbol("BTCUSDT").Limit(5000).Weight())
+}
+
+func (s *weightTestSuite) TestTickerWeightBySymbols() {
+	s.Equal(80, s.client.NewTicker24hrService().Weight())
+	s.Equal(2, s.client.NewTicker24hrService().Symbol("BTCUSDT").Weight())
+	s.Equal(2, s.client.NewTicker24hrService().Symbols([]string{"BTCUSDT", "BNBUSDT"}).Weight())
+	s.Equal(40, s.client.NewTicker24hrService().Symbols(make([]string, 21)).Weight())
+	s.Equal(80, s.client.NewTicker24hrService().Symbols(make([]string, 101)).Weight())
+
+	s.Equal(2, s.client.NewTickerPriceService().Symbol("BTCUSDT").Weight())
+	s.Equal(4, s.client.NewTickerPriceService().Symbols([]string{"BTCUSDT", "BNBUSDT"}).Weight())
+	s.Equal(4, s.client.NewTickerBookTickerService().Weight())
+
+	s.Equal(12, RequestWeight(http.MethodGet, "/api/v3/ticker", url.Values{"symbols": {`["BTCUSDT","BNBUSDT","ETHUSDT"]`}}))
+	s.Equal(200, RequestWeight(http.MethodGet, "/api/v3/ticker", url.Values{"symbols": {`["A","B","C","D","E","F","G","H","I","J","K","L","M","N","O","P","Q","R","S","T","U","V","W","X","Y","Z","AA","AB","AC","AD","AE","AF","AG","AH","AI","AJ","AK","AL","AM","AN","AO","AP","AQ","AR","AS","AT","AU","AV","AW","AX","AY"]`}}))
+}
+
+func (s *weightTestSuite) TestParamWeights() {
+	s.Equal(80, s.client.NewGetOpenOrdersService().Weight())
+	s.Equal(6, s.client.NewGetOpenOrdersService().Symbol("BTCUSDT").Weight())
+	s.Equal(5, RequestWeight(http.MethodGet, "/api/v3/myTrades", url.Values{"symbol": {"BTCUSDT"}, "orderId": {"1"}}))
+	s.Equal(20, RequestWeight(http.MethodGet, "/api/v3/myTrades", url.Values{"symbol": {"BTCUSDT"}}))
+}
+
+func (s *weightTestSuite) TestRegistryOverride() {
+	key := "GET /api/v3/exchangeInfo"
+	orig := EndpointWeights[key]
+	defer func() { EndpointWeights[key] = orig }()
+
+	s.Equal(20, RequestWeight(http.MethodGet, "/api/v3/exchangeInfo", nil))
+	EndpointWeights[key] = EndpointWeight{Weight: 10}
+	s.Equal(10, RequestWeight(http.MethodGet, "/api/v3/exchangeInfo", nil))
+	s.Equal(DefaultRequestWeight, RequestWeight(http.MethodGet, "/sapi/v1/unknown", nil))
+}
+
+func (s *weightTestSuite) TestLastUsedWeight() {
+	c := s.client.Client
+	s.Zero(c.LastUsedWeight())
+
+	var mu sync.Mutex
+	weight := 0
+	c.do = func(req *http.Request) (*http.Response, error) {
+		res := newHTTPResponse([]byte(`{"serverTime":1499827319559}`), http.StatusOK)
+		res.Header = http.Header{}
+		mu.Lock()
+		defer mu.Unlock()
+		if req.URL.Path == "/api/v3/time" {
+			weight++
+			res.Header.Set("X-MBX-USED-WEIGHT-1M", strconv.Itoa(weight))
+		}
+		return res, nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.NewServerTimeService().Do(newContext())
+			s.NoError(err)
+			s.Positive(c.LastUsedWeight())
+		}()
+	}
+	wg.Wait()
+	s.Equal(int64(10), c.LastUsedWeight())
+
+	// a response without the header keeps the last weight
+	err := c.NewPingService().Do(newContext())
+	s.Require().NoError(err)
+	s.Equal(int64(10), c.LastUsedWeight())
+
+	// the weight is counted by IP, a clone shares it
+	clone := c.Clone()
+	_, err = clone.NewServerTimeService().Do(newContext())
+	s.Require().NoError(err)
+	s.Equal(int64(11), c.LastUsedWeight())
+}
@@ -0,0 +1,92 @@
+package binance_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrWithdrawAddressRejected is matched by errors.Is on a WithdrawAddressError
+var ErrWithdrawAddressRejected = errors.New("withdraw address rejected")
+
+// Checks of a WithdrawAddressError
+const (
+	WithdrawCheckCoin    = "coin"
+	WithdrawCheckNetwork = "network"
+	WithdrawCheckAddress = "address"
+	WithdrawCheckMemo    = "memo"
+)
+
+// WithdrawAddressError is returned when a withdrawal fails a check against the coin configuration
+// of GET /sapi/v1/capital/config/getall. Check is the failed check, e.g. WithdrawCheckAddress.
+type WithdrawAddressError struct {
+	Coin    string
+	Network string
+	Address string
+	Check   string
+	Reason  string
+}
+
+func (e *WithdrawAddressError) Error() string {
+	return fmt.Sprintf("withdraw of %s on network %s to %s rejected by the %s check: %s", e.Coin, e.Network, e.Address, e.Check, e.Reason)
+}
+
+// Is return true for ErrWithdrawAddressRejected
+func (e *WithdrawAddressError) Is(target error) bool {
+	return target == ErrWithdrawAddressRejected
+}
+
+// ValidateWithdrawAddress check that network accepts withdrawals of coin and that address and
+// addressTag match its addressRegex and memoRegex. The default network of the coin is checked
+// when network is empty, and addressTag may be empty for networks without memo.
+func (c *Client) ValidateWithdrawAddress(ctx context.Context, coin, network, address, addressTag string) error {
+	coins, err := c.NewGetAllCoinsInfoService().Do(ctx)
+	if err != nil {
+		return err
+	}
+	reject := func(network, check, reason string) error {
+		return &WithdrawAddressError{Coin: coin, Network: network, Address: address, Check: check, Reason: reason}
+	}
+	for _, info := range coins {
+		if info.Coin != coin {
+			continue
+		}
+		for _, n := range info.NetworkList {
+			if n.Network != network && (network != "" || !n.IsDefault) {
+				continue
+			}
+			if !n.WithdrawEnable {
+				return reject(n.Network, WithdrawCheckNetwork, "withdrawals are disabled on the network")
+			}
+			if !matchCoinRegex(n.AddressRegex, address) {
+				return reject(n.Network, WithdrawCheckAddress, fmt.Sprintf("address does not match %s", n.AddressRegex))
+			}
+			if addressTag == "" && n.SameAddress {
+				return reject(n.Network, WithdrawCheckMemo, "the network requires a memo")
+			}
+			if addressTag != "" && !matchCoinRegex(n.MemoRegex, addressTag) {
+				return reject(n.Network, WithdrawCheckMemo, fmt.Sprintf("memo does not match %s", n.MemoRegex))
+			}
+			return nil
+		}
+		if network == "" {
+			return reject(network, WithdrawCheckNetwork, "the coin has no default network")
+		}
+		return reject(network, WithdrawCheckNetwork, "unknown network for the coin")
+	}
+	return reject(network, WithdrawCheckCoin, "unknown coin")
+}
+
+// matchCoinRegex return true when value matches pattern, or when pattern is empty or not a
+// valid Go regular expression, so that a pattern that cannot be checked does not block withdrawals
+func matchCoinRegex(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return true
+	}
+	return re.MatchString(value)
+}
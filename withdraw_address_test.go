@@ -0,0 +1,125 @@
+package binance_connector
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var coinsConfigData = []byte(`[
+	{
+		"coin": "BNB",
+		"networkList": [
+			{
+				"network": "BNB",
+				"coin": "BNB",
+				"isDefault": false,
+				"withdrawEnable": true,
+				"addressRegex": "^(bnb1)[0-9a-z]{38}$",
+				"memoRegex": "^[0-9A-Za-z\\-_]{1,120}$",
+				"sameAddress": true
+			},
+			{
+				"network": "BSC",
+				"coin": "BNB",
+				"isDefault": true,
+				"withdrawEnable": true,
+				"addressRegex": "^(0x)[0-9A-Fa-f]{40}$",
+				"memoRegex": "",
+				"sameAddress": false
+			},
+			{
+				"network": "ETH",
+				"coin": "BNB",
+				"isDefault": false,
+				"withdrawEnable": false,
+				"addressRegex": "^(0x)[0-9A-Fa-f]{40}$"
+			}
+		]
+	}
+]`)
+
+const (
+	bscAddress = "0x8894e0a0c962cb723c1976a4421c95949be2d4e3"
+	bnbAddress = "bnb136ns6lfw4zs5hg4n85vdthaad7hq5m4gtkgf23"
+)
+
+type withdrawAddressTestSuite struct {
+	suite.Suite
+	client    *Client
+	withdrawn int
+}
+
+func TestWithdrawAddress(t *testing.T) {
+	suite.Run(t, new(withdrawAddressTestSuite))
+}
+
+func (s *withdrawAddressTestSuite) SetupTest() {
+	s.client = NewClient("dummyAPIKey", "dummySecretKey", "https://dummyapi.com")
+	s.withdrawn = 0
+	s.client.do = func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case allCoinsInfoEndpoint:
+			return newHTTPResponse(coinsConfigData, http.StatusOK), nil
+		case withdrawEndpoint:
+			s.withdrawn++
+			return newHTTPResponse([]byte(`{"id":"7213fea8e94b4a5593d507237e5a555b"}`), http.StatusOK), nil
+		}
+		s.FailNow("unexpected request " + req.URL.Path)
+		return nil, nil
+	}
+}
+
+func (s *withdrawAddressTestSuite) check(network, address, addressTag string) *WithdrawAddressError {
+	err := s.client.ValidateWithdrawAddress(newContext(), "BNB", network, address, addressTag)
+	if err == nil {
+		return nil
+	}
+	s.Require().ErrorIs(err, ErrWithdrawAddressRejected)
+	return err.(*WithdrawAddressError)
+}
+
+func (s *withdrawAddressTestSuite) TestValidAddresses() {
+	s.Nil(s.check("BSC", bscAddress, ""))
+	s.Nil(s.check("", bscAddress, ""), "the default network is checked")
+	s.Nil(s.check("BNB", bnbAddress, "12345"))
+}
+
+func (s *withdrawAddressTestSuite) TestRejectedAddresses() {
+	err := s.check("BSC", bnbAddress, "")
+	s.Require().NotNil(err)
+	s.Equal(WithdrawCheckAddress, err.Check)
+	s.Equal("BSC", err.Network)
+	s.Contains(err.Error(), "rejected by the address check")
+
+	s.Equal(WithdrawCheckAddress, s.check("", bnbAddress, "").Check)
+	s.Equal(WithdrawCheckMemo, s.check("BNB", bnbAddress, "").Check)
+	s.Equal(WithdrawCheckMemo, s.check("BNB", bnbAddress, "not a memo!").Check)
+	s.Equal(WithdrawCheckNetwork, s.check("ETH", bscAddress, "").Check)
+	s.Equal(WithdrawCheckNetwork, s.check("TRX", bscAddress, "").Check)
+
+	err = s.client.ValidateWithdrawAddress(newContext(), "XYZ", "", bscAddress, "").(*WithdrawAddressError)
+	s.Equal(WithdrawCheckCoin, err.Check)
+}
+
+func (s *withdrawAddressTestSuite) TestWithdraw() {
+	_, err := s.client.NewWithdrawService().Coin("BNB").Network("BNB").Address(bnbAddress).Amount(1).Do(newContext())
+	s.ErrorIs(err, ErrWithdrawAddressRejected)
+	s.Equal(0, s.withdrawn, "a rejected withdrawal is not sent")
+
+	res, err := s.client.NewWithdrawService().Coin("BNB").Network("BNB").Address(bnbAddress).AddressTag("12345").Amount(1).Do(newContext())
+	s.Require().NoError(err)
+	s.Equal("7213fea8e94b4a5593d507237e5a555b", res.Id)
+
+	_, err = s.client.NewWithdrawService().Coin("BNB").Network("BNB").Address(bnbAddress).Amount(1).
+		SkipAddressValidation(true).Do(newContext())
+	s.NoError(err)
+	s.Equal(2, s.withdrawn)
+}
+
+func (s *withdrawAddressTestSuite) TestInvalidPattern() {
+	s.True(matchCoinRegex("", "anything"))
+	s.True(matchCoinRegex("(?<=a)b", "anything"), "patterns Go cannot compile are not checked")
+	s.False(matchCoinRegex("^[0-9]+$", "abc"))
+}